@@ -0,0 +1,342 @@
+package audit
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/core/requestctx"
+)
+
+// Struct tag controlling how a field is audited:
+//
+//	Password string `audit:"redact"` // changes are recorded, values are not
+//	Cache    string `audit:"-"`      // never recorded
+const (
+	tagName   = "audit"
+	tagIgnore = "-"
+	tagRedact = "redact"
+)
+
+// redactedValue replaces the values of redacted fields in recorded events.
+var redactedValue = json.RawMessage(`"[REDACTED]"`)
+
+// beforeSnapshotKey stores the rows loaded before an update or delete in the statement settings.
+const beforeSnapshotKey = "audit:before_snapshot"
+
+// Register installs the audit callbacks on db. From then on every create, update
+// and delete of a model with a single primary key, including soft deletes and
+// restores, writes an Event in the same transaction as the change. A failure to
+// record the event fails (and rolls back) the change itself.
+//
+// Updates and deletes load the affected rows before and after the statement, so
+// they cost two extra queries. The actor and request ID of events are taken from
+// the statement context (see package requestctx).
+func Register(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().After("gorm:create").Before("gorm:after_create").
+		Register("audit:after_create", afterCreate); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("audit:before_update", loadBefore); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:update").Before("gorm:after_update").
+		Register("audit:after_update", afterChange); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("audit:before_delete", loadBefore); err != nil {
+		return err
+	}
+	return cb.Delete().After("gorm:delete").Before("gorm:after_delete").
+		Register("audit:after_delete", afterChange)
+}
+
+// snapshot is the audited state of one row.
+type snapshot struct {
+	id     string                     // Primary key rendered as the event EntityID
+	key    interface{}                // Primary key value, used to reload the row
+	values map[string]json.RawMessage // Column name -> JSON encoded value
+}
+
+// Exempt is implemented by models whose changes are never recorded, such as
+// Event itself and other bookkeeping tables.
+type Exempt interface {
+	AuditExempt()
+}
+
+var exemptType = reflect.TypeOf((*Exempt)(nil)).Elem()
+
+// auditable reports whether the statement should be recorded.
+func auditable(db *gorm.DB) bool {
+	stmt := db.Statement
+	return db.Error == nil && !db.DryRun &&
+		stmt.Schema != nil && stmt.Schema.PrioritizedPrimaryField != nil &&
+		!reflect.PointerTo(stmt.Schema.ModelType).Implements(exemptType)
+}
+
+// afterCreate records the rows inserted by a create statement.
+func afterCreate(db *gorm.DB) {
+	if !auditable(db) || db.Statement.RowsAffected == 0 {
+		return
+	}
+
+	var events []Event
+	eachModel(db.Statement.ReflectValue, func(rv reflect.Value) {
+		s := takeSnapshot(db, rv)
+		events = append(events, newEvent(db, s.id, ActionCreate, nil, render(db.Statement.Schema, s.values, nil)))
+	})
+	record(db, events)
+}
+
+// loadBefore stores the rows an update or delete is about to change.
+func loadBefore(db *gorm.DB) {
+	if !auditable(db) {
+		return
+	}
+
+	var exprs []clause.Expression
+	if c, ok := db.Statement.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok {
+			exprs = append(exprs, where.Exprs...)
+		}
+	}
+	// GORM adds the primary key of the model to the WHERE clause later on
+	var keys []interface{}
+	eachModel(db.Statement.ReflectValue, func(rv reflect.Value) {
+		if key, zero := db.Statement.Schema.PrioritizedPrimaryField.ValueOf(db.Statement.Context, rv); !zero {
+			keys = append(keys, key)
+		}
+	})
+	if len(keys) > 0 {
+		exprs = append(exprs, primaryKeyIn(db, keys))
+	}
+
+	rows, err := loadRows(db, exprs)
+	if err != nil {
+		_ = db.AddError(fmt.Errorf("audit: failed to load %s rows: %w", db.Statement.Schema.Name, err))
+		return
+	}
+	db.Statement.Settings.Store(beforeSnapshotKey, rows)
+}
+
+// afterChange compares the rows stored by loadBefore with their current state
+// and records one event per changed row.
+func afterChange(db *gorm.DB) {
+	stored, ok := db.Statement.Settings.LoadAndDelete(beforeSnapshotKey)
+	if !ok || !auditable(db) || db.Statement.RowsAffected == 0 {
+		return
+	}
+	before := stored.([]snapshot)
+	if len(before) == 0 {
+		return
+	}
+
+	keys := make([]interface{}, len(before))
+	for i, s := range before {
+		keys[i] = s.key
+	}
+	rows, err := loadRows(db, []clause.Expression{primaryKeyIn(db, keys)})
+	if err != nil {
+		_ = db.AddError(fmt.Errorf("audit: failed to reload %s rows: %w", db.Statement.Schema.Name, err))
+		return
+	}
+	after := make(map[string]snapshot, len(rows))
+	for _, s := range rows {
+		after[s.id] = s
+	}
+
+	sch := db.Statement.Schema
+	var events []Event
+	for _, old := range before {
+		current, exists := after[old.id]
+		if !exists {
+			events = append(events, newEvent(db, old.id, ActionDelete, render(sch, old.values, nil), nil))
+			continue
+		}
+
+		var changed []string
+		for column, value := range current.values {
+			if !bytes.Equal(old.values[column], value) {
+				changed = append(changed, column)
+			}
+		}
+		if len(changed) == 0 {
+			continue
+		}
+		events = append(events, newEvent(db, old.id, classify(sch, old, current),
+			render(sch, old.values, changed), render(sch, current.values, changed)))
+	}
+	record(db, events)
+}
+
+// classify tells soft deletes and restores apart from plain updates.
+func classify(sch *schema.Schema, before, after snapshot) Action {
+	for _, field := range sch.Fields {
+		if field.FieldType != reflect.TypeOf(gorm.DeletedAt{}) {
+			continue
+		}
+		wasDeleted := !bytes.Equal(before.values[field.DBName], []byte("null"))
+		isDeleted := !bytes.Equal(after.values[field.DBName], []byte("null"))
+		switch {
+		case !wasDeleted && isDeleted:
+			return ActionDelete
+		case wasDeleted && !isDeleted:
+			return ActionRestore
+		}
+	}
+	return ActionUpdate
+}
+
+// loadRows loads the rows matching exprs, including soft-deleted ones, in the
+// connection (and transaction) of the running statement.
+func loadRows(db *gorm.DB, exprs []clause.Expression) ([]snapshot, error) {
+	tx := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Unscoped().Table(db.Statement.Table)
+	if len(exprs) > 0 {
+		tx = tx.Clauses(clause.Where{Exprs: exprs})
+	}
+
+	dest := reflect.New(reflect.SliceOf(db.Statement.Schema.ModelType))
+	if err := tx.Find(dest.Interface()).Error; err != nil {
+		return nil, err
+	}
+
+	rows := make([]snapshot, 0, dest.Elem().Len())
+	eachModel(dest.Elem(), func(rv reflect.Value) {
+		rows = append(rows, takeSnapshot(db, rv))
+	})
+	return rows, nil
+}
+
+// primaryKeyIn builds a "primary key IN (keys)" condition.
+func primaryKeyIn(db *gorm.DB, keys []interface{}) clause.Expression {
+	return clause.IN{
+		Column: clause.Column{Table: clause.CurrentTable, Name: db.Statement.Schema.PrioritizedPrimaryField.DBName},
+		Values: keys,
+	}
+}
+
+// eachModel calls fn for every model struct held by rv, a struct or a slice of
+// structs or struct pointers. Other values, such as maps, are ignored.
+func eachModel(rv reflect.Value, fn func(reflect.Value)) {
+	rv = reflect.Indirect(rv)
+	switch rv.Kind() {
+	case reflect.Struct:
+		fn(rv)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if elem := reflect.Indirect(rv.Index(i)); elem.Kind() == reflect.Struct {
+				fn(elem)
+			}
+		}
+	}
+}
+
+// takeSnapshot encodes the audited columns of the model value rv.
+func takeSnapshot(db *gorm.DB, rv reflect.Value) snapshot {
+	ctx := db.Statement.Context
+	sch := db.Statement.Schema
+
+	key, _ := sch.PrioritizedPrimaryField.ValueOf(ctx, rv)
+	s := snapshot{
+		id:     fmt.Sprint(indirect(key)),
+		key:    key,
+		values: make(map[string]json.RawMessage, len(sch.Fields)),
+	}
+	for _, field := range sch.Fields {
+		if field.DBName == "" || field.Tag.Get(tagName) == tagIgnore {
+			continue
+		}
+		value, _ := field.ValueOf(ctx, rv)
+		s.values[field.DBName] = encodeValue(value)
+	}
+	return s
+}
+
+// encodeValue JSON-encodes a column value the way it is written to the database.
+func encodeValue(value interface{}) json.RawMessage {
+	value = indirect(value)
+	if valuer, ok := value.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			v = err.Error()
+		}
+		value = v
+	}
+	if b, ok := value.([]byte); ok {
+		value = string(b)
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		encoded, _ = json.Marshal(fmt.Sprint(value))
+	}
+	return encoded
+}
+
+// indirect dereferences pointers, returning nil for nil pointers.
+func indirect(value interface{}) interface{} {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return nil
+	}
+	return rv.Interface()
+}
+
+// render builds the JSON object recorded for the given columns (all columns
+// when nil), hiding the values of redacted fields.
+func render(sch *schema.Schema, values map[string]json.RawMessage, columns []string) JSONMap {
+	if columns == nil {
+		for column := range values {
+			columns = append(columns, column)
+		}
+	}
+
+	out := make(JSONMap, len(columns))
+	for _, column := range columns {
+		out[column] = values[column]
+		if field := sch.LookUpField(column); field != nil && field.Tag.Get(tagName) == tagRedact {
+			out[column] = redactedValue
+		}
+	}
+	return out
+}
+
+// newEvent creates an event attributed to the actor and request of the statement context.
+func newEvent(db *gorm.DB, entityID string, action Action, before, after JSONMap) Event {
+	ctx := db.Statement.Context
+	return Event{
+		BaseEntity: entity.BaseEntity{ID: uuid.New(), Version: 1},
+		EntityType: db.Statement.Schema.Name,
+		EntityID:   entityID,
+		Action:     action,
+		Before:     before,
+		After:      after,
+		Actor:      requestctx.Actor(ctx),
+		RequestID:  requestctx.RequestID(ctx),
+	}
+}
+
+// record writes events in the connection of the running statement.
+func record(db *gorm.DB, events []Event) {
+	if len(events) == 0 {
+		return
+	}
+	if err := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Create(&events).Error; err != nil {
+		_ = db.AddError(fmt.Errorf("audit: failed to record %s events: %w", db.Statement.Schema.Name, err))
+	}
+}
@@ -0,0 +1,86 @@
+// Package audit records an audit trail of entity changes. Register installs
+// GORM callbacks that write an Event for every create, update and delete, and
+// Store reads the trail back.
+package audit
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+)
+
+// Action is the kind of change recorded by an Event.
+type Action string
+
+const (
+	ActionCreate  Action = "create"
+	ActionUpdate  Action = "update"
+	ActionDelete  Action = "delete"  // Soft or hard delete
+	ActionRestore Action = "restore" // Soft-deleted row made visible again
+)
+
+// Event is one recorded change of one entity. Before and After hold the changed
+// columns only, keyed by column name: Before is empty for creates and After is
+// empty for hard deletes.
+type Event struct {
+	entity.BaseEntity
+	EntityType string  `json:"entity_type" gorm:"size:100;not null;index:idx_audit_events_entity,priority:1"`
+	EntityID   string  `json:"entity_id" gorm:"size:100;not null;index:idx_audit_events_entity,priority:2"`
+	Action     Action  `json:"action" gorm:"size:20;not null"`
+	Before     JSONMap `json:"before,omitempty" gorm:"type:jsonb"`
+	After      JSONMap `json:"after,omitempty" gorm:"type:jsonb"`
+	Actor      string  `json:"actor" gorm:"size:255;index"`      // JWT subject of the caller, empty when anonymous
+	RequestID  string  `json:"request_id" gorm:"size:100;index"` // Request the change was made in
+}
+
+// TableName overrides the default table name.
+func (Event) TableName() string {
+	return "audit_events"
+}
+
+// AuditExempt implements Exempt: recording an event is not itself audited.
+func (Event) AuditExempt() {}
+
+// FilterableColumns returns the columns that may be used in filters.
+func (e Event) FilterableColumns() []string {
+	return append(e.BaseEntity.FilterableColumns(), "entity_type", "entity_id", "action", "actor", "request_id")
+}
+
+// SortableColumns returns the columns that may be used for sorting.
+func (e Event) SortableColumns() []string {
+	return e.BaseEntity.SortableColumns()
+}
+
+// JSONMap is a JSON object stored in a jsonb column.
+type JSONMap map[string]interface{}
+
+// Value implements driver.Valuer.
+func (m JSONMap) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner.
+func (m *JSONMap) Scan(value interface{}) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("audit: cannot scan %T into JSONMap", value)
+	}
+	return json.Unmarshal(b, m)
+}
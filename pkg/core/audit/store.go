@@ -0,0 +1,39 @@
+package audit
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"golang-microservices-boilerplate/pkg/core/repository"
+	"golang-microservices-boilerplate/pkg/core/types"
+)
+
+// Reader lists recorded audit events.
+type Reader interface {
+	// List returns the events of the given entity, newest first unless opts say
+	// otherwise. An empty entityType or entityID matches any value.
+	List(ctx context.Context, entityType, entityID string, opts types.FilterOptions) (*types.PaginationResult[Event], error)
+}
+
+// Store reads audit events from the audit_events table.
+type Store struct {
+	repo *repository.GormBaseRepository[Event]
+}
+
+// NewStore creates a Store on db.
+func NewStore(db *gorm.DB) *Store {
+	return &Store{repo: repository.NewGormBaseRepository[Event](db)}
+}
+
+// List implements Reader.
+func (s *Store) List(ctx context.Context, entityType, entityID string, opts types.FilterOptions) (*types.PaginationResult[Event], error) {
+	filter := make(map[string]interface{})
+	if entityType != "" {
+		filter["entity_type"] = entityType
+	}
+	if entityID != "" {
+		filter["entity_id"] = entityID
+	}
+	return s.repo.FindWithFilter(ctx, filter, opts)
+}
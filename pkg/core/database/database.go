@@ -1,11 +1,13 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"golang-microservices-boilerplate/pkg/utils"
 	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"gorm.io/driver/postgres"
@@ -15,17 +17,22 @@ import (
 
 // DBConfig contains all the database configuration options
 type DBConfig struct {
-	URI          string
-	Host         string
-	Port         int
-	Username     string
-	Password     string
-	Database     string
-	SSLMode      string
-	MaxIdleConns int
-	MaxOpenConns int
-	MaxLifetime  time.Duration
-	LogLevel     logger.LogLevel
+	URI            string
+	Host           string
+	Port           int
+	Username       string
+	Password       string
+	Database       string
+	SSLMode        string
+	MaxIdleConns   int
+	MaxOpenConns   int
+	MaxLifetime    time.Duration
+	LogLevel       logger.LogLevel
+	MigrateOnStart bool // Apply pending migrations at boot instead of only via the migrate subcommand
+	SeedOnStart    bool // Upsert reference data at boot instead of only via the seed subcommand
+
+	ReplicaURIs           []string      // Read replicas for FindAll/FindByID/Count; empty reads from the primary
+	ReplicaHealthInterval time.Duration // How often replicas are pinged; unhealthy ones are skipped
 }
 
 // DefaultDBConfig returns a default database configuration using environment variables
@@ -35,6 +42,20 @@ func DefaultDBConfig() DBConfig {
 	maxOpenConns, _ := strconv.Atoi(utils.GetEnv("DB_MAX_OPEN_CONNS", "100"))
 	maxLifetime, _ := strconv.Atoi(utils.GetEnv("DB_MAX_LIFETIME", "60"))
 
+	migrateOnStart, _ := strconv.ParseBool(utils.GetEnv("DB_MIGRATE_ON_START", "true"))
+	seedOnStart, _ := strconv.ParseBool(utils.GetEnv("DB_SEED_ON_START", "true"))
+
+	var replicaURIs []string
+	for _, uri := range strings.Split(utils.GetEnv("DB_REPLICA_URIS", ""), ",") {
+		if uri = strings.TrimSpace(uri); uri != "" {
+			replicaURIs = append(replicaURIs, uri)
+		}
+	}
+	replicaHealthInterval, err := time.ParseDuration(utils.GetEnv("DB_REPLICA_HEALTH_INTERVAL", "5s"))
+	if err != nil || replicaHealthInterval <= 0 {
+		replicaHealthInterval = 5 * time.Second
+	}
+
 	logLevelStr := utils.GetEnv("DB_LOG_LEVEL", "info")
 	var logLevel logger.LogLevel
 	switch logLevelStr {
@@ -49,24 +70,30 @@ func DefaultDBConfig() DBConfig {
 	}
 
 	return DBConfig{
-		URI:          utils.GetEnv("DB_URI", ""),
-		Host:         utils.GetEnv("DB_HOST", "localhost"),
-		Port:         port,
-		Username:     utils.GetEnv("DB_USER", "postgres"),
-		Password:     utils.GetEnv("DB_PASSWORD", "postgres"),
-		Database:     utils.GetEnv("DB_NAME", "microservices"),
-		SSLMode:      utils.GetEnv("DB_SSL_MODE", "disable"),
-		MaxIdleConns: maxIdleConns,
-		MaxOpenConns: maxOpenConns,
-		MaxLifetime:  time.Duration(maxLifetime) * time.Minute,
-		LogLevel:     logLevel,
+		URI:            utils.GetEnv("DB_URI", ""),
+		Host:           utils.GetEnv("DB_HOST", "localhost"),
+		Port:           port,
+		Username:       utils.GetEnv("DB_USER", "postgres"),
+		Password:       utils.GetEnv("DB_PASSWORD", "postgres"),
+		Database:       utils.GetEnv("DB_NAME", "microservices"),
+		SSLMode:        utils.GetEnv("DB_SSL_MODE", "disable"),
+		MaxIdleConns:   maxIdleConns,
+		MaxOpenConns:   maxOpenConns,
+		MaxLifetime:    time.Duration(maxLifetime) * time.Minute,
+		LogLevel:       logLevel,
+		MigrateOnStart: migrateOnStart,
+		SeedOnStart:    seedOnStart,
+
+		ReplicaURIs:           replicaURIs,
+		ReplicaHealthInterval: replicaHealthInterval,
 	}
 }
 
 // DatabaseConnection represents a database connection manager
 type DatabaseConnection struct {
-	DB     *gorm.DB
-	Config DBConfig
+	DB       *gorm.DB
+	Config   DBConfig
+	Replicas *Replicas // Nil without configured replicas
 }
 
 // NewDatabaseConnection creates a new database connection using the provided configuration
@@ -85,9 +112,10 @@ func NewDatabaseConnection(config DBConfig) (*DatabaseConnection, error) {
 	)
 
 	// Open connection to the database
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+	gormConfig := &gorm.Config{
 		Logger: gormLogger,
-	})
+	}
+	db, err := gorm.Open(postgres.Open(dsn), gormConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -102,9 +130,30 @@ func NewDatabaseConnection(config DBConfig) (*DatabaseConnection, error) {
 	sqlDB.SetMaxOpenConns(config.MaxOpenConns)
 	sqlDB.SetConnMaxLifetime(config.MaxLifetime)
 
+	// Record statements in the trace of the request running them
+	if err := db.Use(tracingPlugin{role: "primary"}); err != nil {
+		_ = sqlDB.Close()
+		return nil, fmt.Errorf("failed to register tracing: %w", err)
+	}
+
+	// Route repository reads to the replicas, if any
+	var replicas *Replicas
+	if len(config.ReplicaURIs) > 0 {
+		if replicas, err = openReplicas(config, gormConfig); err != nil {
+			_ = sqlDB.Close()
+			return nil, err
+		}
+		if err := db.Use(replicas); err != nil {
+			_ = replicas.close()
+			_ = sqlDB.Close()
+			return nil, fmt.Errorf("failed to register replicas: %w", err)
+		}
+	}
+
 	return &DatabaseConnection{
-		DB:     db,
-		Config: config,
+		DB:       db,
+		Config:   config,
+		Replicas: replicas,
 	}, nil
 }
 
@@ -114,8 +163,11 @@ func Connect() (*DatabaseConnection, error) {
 	return NewDatabaseConnection(config)
 }
 
-// Close closes the database connection
+// Close closes the database connection and the replica connections
 func (dc *DatabaseConnection) Close() error {
+	if dc.Replicas != nil {
+		_ = dc.Replicas.close()
+	}
 	sqlDB, err := dc.DB.DB()
 	if err != nil {
 		return fmt.Errorf("failed to get database instance: %w", err)
@@ -123,18 +175,14 @@ func (dc *DatabaseConnection) Close() error {
 	return sqlDB.Close()
 }
 
-// MigrateModels runs database migrations for the provided models
-func (dc *DatabaseConnection) MigrateModels(models ...interface{}) error {
-	return dc.DB.AutoMigrate(models...)
-}
-
-// Ping checks if the database connection is still alive
-func (dc *DatabaseConnection) Ping() error {
+// Ping checks if the primary database connection is still alive. It is the
+// health check of services backed by the database (see coreGrpc.HealthChecker).
+func (dc *DatabaseConnection) Ping(ctx context.Context) error {
 	sqlDB, err := dc.DB.DB()
 	if err != nil {
 		return fmt.Errorf("failed to get database instance: %w", err)
 	}
-	return sqlDB.Ping()
+	return sqlDB.PingContext(ctx)
 }
 
 // Transaction executes a function within a database transaction
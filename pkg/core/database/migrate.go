@@ -0,0 +1,304 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// migrationLockKey is the pg_advisory_xact_lock key serializing migration runs
+// of all services sharing a database.
+const migrationLockKey int64 = 7_236_501_441
+
+// migrationFilePattern matches <version>_<name>.up.sql and <version>_<name>.down.sql.
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Source is a set of versioned SQL migrations, usually embedded in the binary of
+// the service owning the tables.
+type Source struct {
+	Name string // Recorded in schema_migrations; must be unique per database
+	FS   fs.FS  // Files named <version>_<name>.up.sql and <version>_<name>.down.sql
+}
+
+// Migration is one versioned schema change.
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string // Empty when the migration cannot be reverted
+	Checksum string // SHA-256 of Up, used to detect edits after a migration was applied
+}
+
+// Migrations reads the migrations of the source, ordered by version.
+func (s Source) Migrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(s.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations of %s: %w", s.Name, err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		match := migrationFilePattern.FindStringSubmatch(e.Name())
+		if match == nil {
+			continue
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := fs.ReadFile(s.FS, e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s of %s: %w", e.Name(), s.Name, err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d of %s is used by both %q and %q", version, s.Name, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+			sum := sha256.Sum256(content)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s of %s has no up file", m.Version, m.Name, s.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// MigrationStatus describes a migration and whether it is applied.
+type MigrationStatus struct {
+	Source    string
+	Version   int64
+	Name      string
+	AppliedAt *time.Time // Nil while pending
+	Modified  bool       // The up file changed after the migration was applied
+	Missing   bool       // Applied, but no longer part of the source
+}
+
+// appliedMigration is a row of schema_migrations.
+type appliedMigration struct {
+	Source    string
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Migrator applies the migrations of a service and of the shared sources it
+// depends on, recording them in the schema_migrations table.
+type Migrator struct {
+	db     *gorm.DB
+	source Source
+	shared []Source
+}
+
+// NewMigrator creates a Migrator for the migrations of source. Shared sources,
+// such as the core audit and outbox tables, are applied before source but never
+// reverted by Down since other services depend on them.
+func NewMigrator(db *gorm.DB, source Source, shared ...Source) *Migrator {
+	return &Migrator{db: db, source: source, shared: shared}
+}
+
+// sources returns all sources in the order they are applied.
+func (m *Migrator) sources() []Source {
+	return append(append([]Source(nil), m.shared...), m.source)
+}
+
+// Up applies all pending migrations and returns them. The run is a single
+// transaction holding an advisory lock, so concurrent pods wait for each other
+// and a failing migration leaves the schema untouched. The transaction-scoped
+// lock also works through transaction poolers.
+func (m *Migrator) Up(ctx context.Context) ([]MigrationStatus, error) {
+	var applied []MigrationStatus
+	err := m.locked(ctx, func(tx *gorm.DB) error {
+		for _, source := range m.sources() {
+			migrations, err := source.Migrations()
+			if err != nil {
+				return err
+			}
+			done, err := appliedMigrations(tx, source.Name)
+			if err != nil {
+				return err
+			}
+
+			for _, mig := range migrations {
+				if prev, ok := done[mig.Version]; ok {
+					if prev.Checksum != mig.Checksum {
+						return fmt.Errorf("migration %d_%s of %s was modified after it was applied", mig.Version, mig.Name, source.Name)
+					}
+					continue
+				}
+				if err := execMigration(ctx, tx, mig.Up); err != nil {
+					return fmt.Errorf("migration %d_%s of %s failed: %w", mig.Version, mig.Name, source.Name, err)
+				}
+				now := time.Now()
+				if err := tx.Exec(
+					"INSERT INTO schema_migrations (source, version, name, checksum, applied_at) VALUES (?, ?, ?, ?, ?)",
+					source.Name, mig.Version, mig.Name, mig.Checksum, now,
+				).Error; err != nil {
+					return fmt.Errorf("failed to record migration %d_%s of %s: %w", mig.Version, mig.Name, source.Name, err)
+				}
+				applied = append(applied, MigrationStatus{Source: source.Name, Version: mig.Version, Name: mig.Name, AppliedAt: &now})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+// Down reverts the last steps applied migrations of the service's own source
+// and returns them, newest first. Like Up it runs in one locked transaction.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]MigrationStatus, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("down needs at least one step, got %d", steps)
+	}
+	migrations, err := m.source.Migrations()
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int64]Migration, len(migrations))
+	for _, mig := range migrations {
+		byVersion[mig.Version] = mig
+	}
+
+	var reverted []MigrationStatus
+	err = m.locked(ctx, func(tx *gorm.DB) error {
+		var rows []appliedMigration
+		if err := tx.Raw(
+			"SELECT source, version, name, checksum, applied_at FROM schema_migrations WHERE source = ? ORDER BY version DESC LIMIT ?",
+			m.source.Name, steps,
+		).Scan(&rows).Error; err != nil {
+			return fmt.Errorf("failed to read applied migrations: %w", err)
+		}
+
+		for _, row := range rows {
+			mig, ok := byVersion[row.Version]
+			if !ok {
+				return fmt.Errorf("applied migration %d_%s of %s is not part of this binary", row.Version, row.Name, row.Source)
+			}
+			if mig.Down == "" {
+				return fmt.Errorf("migration %d_%s of %s has no down file", mig.Version, mig.Name, row.Source)
+			}
+			if err := execMigration(ctx, tx, mig.Down); err != nil {
+				return fmt.Errorf("reverting migration %d_%s of %s failed: %w", mig.Version, mig.Name, row.Source, err)
+			}
+			if err := tx.Exec("DELETE FROM schema_migrations WHERE source = ? AND version = ?", row.Source, row.Version).Error; err != nil {
+				return fmt.Errorf("failed to unrecord migration %d_%s of %s: %w", mig.Version, mig.Name, row.Source, err)
+			}
+			reverted = append(reverted, MigrationStatus{Source: row.Source, Version: mig.Version, Name: mig.Name})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reverted, nil
+}
+
+// Status lists the migrations of all sources with their state, including
+// applied migrations that are no longer part of a source.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	db := m.db.WithContext(ctx)
+	tableExists := db.Migrator().HasTable("schema_migrations")
+
+	var statuses []MigrationStatus
+	for _, source := range m.sources() {
+		migrations, err := source.Migrations()
+		if err != nil {
+			return nil, err
+		}
+		done := map[int64]appliedMigration{}
+		if tableExists {
+			if done, err = appliedMigrations(db, source.Name); err != nil {
+				return nil, err
+			}
+		}
+
+		var sourceStatuses []MigrationStatus
+		for _, mig := range migrations {
+			status := MigrationStatus{Source: source.Name, Version: mig.Version, Name: mig.Name}
+			if prev, ok := done[mig.Version]; ok {
+				status.AppliedAt = &prev.AppliedAt
+				status.Modified = prev.Checksum != mig.Checksum
+				delete(done, mig.Version)
+			}
+			sourceStatuses = append(sourceStatuses, status)
+		}
+		for _, prev := range done {
+			sourceStatuses = append(sourceStatuses, MigrationStatus{
+				Source: source.Name, Version: prev.Version, Name: prev.Name, AppliedAt: &prev.AppliedAt, Missing: true,
+			})
+		}
+		sort.Slice(sourceStatuses, func(i, j int) bool { return sourceStatuses[i].Version < sourceStatuses[j].Version })
+		statuses = append(statuses, sourceStatuses...)
+	}
+	return statuses, nil
+}
+
+// locked runs fn in a transaction holding the migration lock, creating the
+// schema_migrations table first if needed.
+func (m *Migrator) locked(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockKey).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		if err := tx.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+	source varchar(100) NOT NULL,
+	version bigint NOT NULL,
+	name varchar(255) NOT NULL,
+	checksum char(64) NOT NULL,
+	applied_at timestamptz NOT NULL DEFAULT now(),
+	PRIMARY KEY (source, version)
+)`).Error; err != nil {
+			return fmt.Errorf("failed to create schema_migrations: %w", err)
+		}
+		return fn(tx)
+	})
+}
+
+// appliedMigrations returns the applied migrations of source by version.
+func appliedMigrations(db *gorm.DB, source string) (map[int64]appliedMigration, error) {
+	var rows []appliedMigration
+	if err := db.Raw(
+		"SELECT source, version, name, checksum, applied_at FROM schema_migrations WHERE source = ?", source,
+	).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations of %s: %w", source, err)
+	}
+	done := make(map[int64]appliedMigration, len(rows))
+	for _, row := range rows {
+		done[row.Version] = row
+	}
+	return done, nil
+}
+
+// execMigration runs the statements of a migration file as is. Without
+// arguments the driver uses the simple query protocol, so a file may hold
+// several statements.
+func execMigration(ctx context.Context, tx *gorm.DB, sql string) error {
+	_, err := tx.Statement.ConnPool.ExecContext(ctx, sql)
+	return err
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// MigrateUsage describes the arguments of RunMigrateCommand.
+const MigrateUsage = "usage: migrate up | down [steps] | status"
+
+// RunMigrateCommand implements the "migrate" subcommand of the service
+// binaries: args are the arguments after "migrate", output goes to out.
+func RunMigrateCommand(ctx context.Context, m *Migrator, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(MigrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Fprintln(out, "No pending migrations")
+		}
+		for _, s := range applied {
+			fmt.Fprintf(out, "Applied %s %d_%s\n", s.Source, s.Version, s.Name)
+		}
+		return nil
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid number of steps %q; %s", args[1], MigrateUsage)
+			}
+			steps = n
+		}
+		reverted, err := m.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		if len(reverted) == 0 {
+			fmt.Fprintln(out, "No applied migrations to revert")
+		}
+		for _, s := range reverted {
+			fmt.Fprintf(out, "Reverted %s %d_%s\n", s.Source, s.Version, s.Name)
+		}
+		return nil
+
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SOURCE\tVERSION\tNAME\tSTATUS")
+		for _, s := range statuses {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", s.Source, s.Version, s.Name, describeStatus(s))
+		}
+		return w.Flush()
+
+	default:
+		return fmt.Errorf("unknown migrate command %q; %s", args[0], MigrateUsage)
+	}
+}
+
+// describeStatus renders the state of a migration for the status table.
+func describeStatus(s MigrationStatus) string {
+	switch {
+	case s.AppliedAt == nil:
+		return "pending"
+	case s.Missing:
+		return "applied " + s.AppliedAt.Format(time.RFC3339) + ", missing from binary"
+	case s.Modified:
+		return "applied " + s.AppliedAt.Format(time.RFC3339) + ", modified since"
+	default:
+		return "applied " + s.AppliedAt.Format(time.RFC3339)
+	}
+}
@@ -0,0 +1,193 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// replicasPluginName is the name Replicas is registered under on the primary *gorm.DB.
+const replicasPluginName = "core:replicas"
+
+// defaultReplicaHealthInterval is used when DBConfig.ReplicaHealthInterval is not positive.
+const defaultReplicaHealthInterval = 5 * time.Second
+
+// primaryKey is the context key forcing reads to the primary.
+type primaryKey struct{}
+
+// WithPrimary returns a copy of ctx whose reads go to the primary instead of a
+// replica, e.g. to read back a row right after writing it.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// UsesPrimary reports whether ctx forces reads to the primary.
+func UsesPrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryKey{}).(bool)
+	return primary
+}
+
+// ReadConn returns the handle read-only repository queries should run on: a
+// healthy replica of db when one is configured, otherwise the same handle as
+// Conn. Reads stay on the primary inside a transaction, when ctx was marked
+// with WithPrimary, and when no replica is healthy.
+func ReadConn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if _, ok := TxFromContext(ctx); ok || UsesPrimary(ctx) {
+		return Conn(ctx, db)
+	}
+	if _, inTx := db.Statement.ConnPool.(gorm.TxCommitter); inTx {
+		return db.WithContext(ctx)
+	}
+	if replicas, ok := db.Config.Plugins[replicasPluginName].(*Replicas); ok {
+		if replica := replicas.pick(); replica != nil {
+			return replica.WithContext(ctx)
+		}
+	}
+	return db.WithContext(ctx)
+}
+
+// replica is one read replica and its last known health.
+type replica struct {
+	db      *gorm.DB
+	healthy atomic.Bool
+}
+
+// Replicas routes reads to read replicas in round-robin order, skipping
+// replicas whose last health check failed. It is a GORM plugin registered on
+// the primary, so every handle derived from the primary can find it.
+type Replicas struct {
+	replicas []*replica
+	next     atomic.Uint64
+	stop     chan struct{}
+}
+
+// openReplicas connects to the replica URIs with the settings of the primary
+// and starts checking their health every config.ReplicaHealthInterval, or
+// defaultReplicaHealthInterval when it is not positive. Unreachable replicas do
+// not fail startup; they are skipped until healthy.
+func openReplicas(config DBConfig, gormConfig *gorm.Config) (*Replicas, error) {
+	interval := config.ReplicaHealthInterval
+	if interval <= 0 {
+		interval = defaultReplicaHealthInterval
+	}
+	r := &Replicas{stop: make(chan struct{})}
+	for i, uri := range config.ReplicaURIs {
+		db, err := gorm.Open(postgres.Open(uri), &gorm.Config{Logger: gormConfig.Logger, DisableAutomaticPing: true})
+		if err != nil {
+			_ = r.close()
+			return nil, fmt.Errorf("failed to open replica %d: %w", i, err)
+		}
+		sqlDB, err := db.DB()
+		if err != nil {
+			_ = r.close()
+			return nil, fmt.Errorf("failed to get replica %d instance: %w", i, err)
+		}
+		sqlDB.SetMaxIdleConns(config.MaxIdleConns)
+		sqlDB.SetMaxOpenConns(config.MaxOpenConns)
+		sqlDB.SetConnMaxLifetime(config.MaxLifetime)
+		r.replicas = append(r.replicas, &replica{db: db})
+		if err := db.Use(tracingPlugin{role: "replica"}); err != nil {
+			_ = r.close()
+			return nil, fmt.Errorf("failed to register tracing on replica %d: %w", i, err)
+		}
+	}
+
+	r.checkHealth(interval)
+	go r.run(interval)
+	return r, nil
+}
+
+// Name implements gorm.Plugin.
+func (r *Replicas) Name() string {
+	return replicasPluginName
+}
+
+// Initialize implements gorm.Plugin. Routing happens in ReadConn, so there is
+// nothing to install on the primary.
+func (r *Replicas) Initialize(*gorm.DB) error {
+	return nil
+}
+
+// Healthy returns the number of replicas that passed their last health check.
+func (r *Replicas) Healthy() int {
+	n := 0
+	for _, rep := range r.replicas {
+		if rep.healthy.Load() {
+			n++
+		}
+	}
+	return n
+}
+
+// pick returns the next healthy replica, or nil when none is healthy.
+func (r *Replicas) pick() *gorm.DB {
+	n := uint64(len(r.replicas))
+	for i := uint64(0); i < n; i++ {
+		rep := r.replicas[(r.next.Add(1)-1)%n]
+		if rep.healthy.Load() {
+			return rep.db
+		}
+	}
+	return nil
+}
+
+// run re-checks replica health every interval until close.
+func (r *Replicas) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.checkHealth(interval)
+		}
+	}
+}
+
+// checkHealth pings all replicas concurrently, allowing them one interval, so
+// a check is over before the next one starts however many replicas hang.
+func (r *Replicas) checkHealth(interval time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), interval)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, rep := range r.replicas {
+		wg.Add(1)
+		go func(rep *replica) {
+			defer wg.Done()
+			healthy := false
+			if sqlDB, err := rep.db.DB(); err == nil {
+				healthy = sqlDB.PingContext(ctx) == nil
+			}
+			rep.healthy.Store(healthy)
+		}(rep)
+	}
+	wg.Wait()
+}
+
+// close stops the health checks and closes the replica connections.
+func (r *Replicas) close() error {
+	select {
+	case <-r.stop:
+	default:
+		close(r.stop)
+	}
+
+	var firstErr error
+	for _, rep := range r.replicas {
+		sqlDB, err := rep.db.DB()
+		if err == nil {
+			err = sqlDB.Close()
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
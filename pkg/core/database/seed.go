@@ -0,0 +1,276 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// seedLockKey is the pg_advisory_xact_lock key serializing seed runs of all
+// services sharing a database.
+const seedLockKey int64 = 7_236_501_442
+
+// seedFilePattern matches <order>_<name>.yaml and <order>_<name>.yml.
+var seedFilePattern = regexp.MustCompile(`^\d+_[a-z0-9_]+\.ya?ml$`)
+
+// identifierPattern matches the table and column names seed files may use.
+var identifierPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// SeedSource is a set of declarative seed files holding the reference data of
+// a service, usually embedded in its binary.
+type SeedSource struct {
+	Name string // Shown in seed output
+	FS   fs.FS  // Files named <order>_<name>.yaml, applied by file name
+}
+
+// SeedFile is the reference data of one table:
+//
+//	table: staff_roles
+//	key: [name]
+//	rows:
+//	  - name: Doctor
+//	    description: Physician who can be booked for appointments
+//
+// Rows are upserted by the key columns. Columns a row leaves out keep their
+// current value, or their default when the row is inserted.
+type SeedFile struct {
+	Name  string                   `yaml:"-"` // File name within the source
+	Table string                   `yaml:"table"`
+	Key   []string                 `yaml:"key"` // Columns of a unique constraint identifying a row
+	Rows  []map[string]interface{} `yaml:"rows"`
+}
+
+// Files reads and validates the seed files of the source, ordered by name.
+func (s SeedSource) Files() ([]SeedFile, error) {
+	entries, err := fs.ReadDir(s.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seeds of %s: %w", s.Name, err)
+	}
+
+	var files []SeedFile
+	for _, e := range entries {
+		if e.IsDir() || !seedFilePattern.MatchString(e.Name()) {
+			continue
+		}
+		content, err := fs.ReadFile(s.FS, e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read seed %s of %s: %w", e.Name(), s.Name, err)
+		}
+		var f SeedFile
+		if err := yaml.Unmarshal(content, &f); err != nil {
+			return nil, fmt.Errorf("failed to parse seed %s of %s: %w", e.Name(), s.Name, err)
+		}
+		f.Name = e.Name()
+		if err := f.validate(); err != nil {
+			return nil, fmt.Errorf("invalid seed %s of %s: %w", e.Name(), s.Name, err)
+		}
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+	return files, nil
+}
+
+// validate checks the identifiers of the file and that every row has its key.
+func (f SeedFile) validate() error {
+	if !identifierPattern.MatchString(f.Table) {
+		return fmt.Errorf("invalid table name %q", f.Table)
+	}
+	if len(f.Key) == 0 {
+		return fmt.Errorf("table %s needs at least one key column", f.Table)
+	}
+	for _, col := range f.Key {
+		if !identifierPattern.MatchString(col) {
+			return fmt.Errorf("invalid key column %q", col)
+		}
+	}
+	for i, row := range f.Rows {
+		for col := range row {
+			if !identifierPattern.MatchString(col) {
+				return fmt.Errorf("row %d: invalid column %q", i+1, col)
+			}
+		}
+		for _, col := range f.Key {
+			if row[col] == nil {
+				return fmt.Errorf("row %d: key column %s is missing", i+1, col)
+			}
+		}
+	}
+	return nil
+}
+
+// Decode stores the rows in out, a pointer to a slice of models, matching
+// columns to fields by their GORM column names. Tests use it to load the same
+// reference data into in-memory repositories:
+//
+//	var roles []entity.StaffRole
+//	err := file.Decode(&roles)
+func (f SeedFile) Decode(out interface{}) error {
+	slice := reflect.ValueOf(out)
+	if slice.Kind() != reflect.Pointer || slice.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("decode needs a pointer to a slice, got %T", out)
+	}
+	slice = slice.Elem()
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	modelSchema, err := schema.Parse(reflect.New(structType).Interface(), &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", structType.Name(), err)
+	}
+
+	ctx := context.Background()
+	for i, row := range f.Rows {
+		model := reflect.New(structType)
+		for col, value := range row {
+			field := modelSchema.LookUpField(col)
+			if field == nil {
+				return fmt.Errorf("%s row %d: %s has no column %s", f.Name, i+1, structType.Name(), col)
+			}
+			if err := field.Set(ctx, model.Elem(), value); err != nil {
+				return fmt.Errorf("%s row %d: failed to set %s: %w", f.Name, i+1, col, err)
+			}
+		}
+		if elemType.Kind() == reflect.Pointer {
+			slice.Set(reflect.Append(slice, model))
+		} else {
+			slice.Set(reflect.Append(slice, model.Elem()))
+		}
+	}
+	return nil
+}
+
+// SeedResult reports how many rows of a seed file were inserted or changed.
+type SeedResult struct {
+	Source  string
+	File    string
+	Table   string
+	Rows    int   // Rows in the file
+	Changed int64 // Rows inserted or updated; unchanged rows are not written
+}
+
+// Seeder upserts the reference data of seed sources.
+type Seeder struct {
+	db      *gorm.DB
+	sources []SeedSource
+}
+
+// NewSeeder creates a Seeder applying the sources in order.
+func NewSeeder(db *gorm.DB, sources ...SeedSource) *Seeder {
+	return &Seeder{db: db, sources: sources}
+}
+
+// Seed upserts every row of every seed file and reports what changed. Running
+// it again is a no-op unless the files changed. Like migrations, a run is a
+// single transaction holding an advisory lock, so pods starting at the same
+// time wait for each other and a failing row leaves the data untouched.
+func (s *Seeder) Seed(ctx context.Context) ([]SeedResult, error) {
+	if len(s.sources) == 0 {
+		return nil, nil
+	}
+	var results []SeedResult
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", seedLockKey).Error; err != nil {
+			return fmt.Errorf("failed to acquire seed lock: %w", err)
+		}
+		for _, source := range s.sources {
+			files, err := source.Files()
+			if err != nil {
+				return err
+			}
+			for _, f := range files {
+				result := SeedResult{Source: source.Name, File: f.Name, Table: f.Table, Rows: len(f.Rows)}
+				for i, row := range f.Rows {
+					changed, err := upsertRow(tx, f, row)
+					if err != nil {
+						return fmt.Errorf("seed %s of %s row %d: %w", f.Name, source.Name, i+1, err)
+					}
+					result.Changed += changed
+				}
+				results = append(results, result)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// upsertRow inserts row or updates the columns it sets, skipping the write when
+// nothing differs, and returns the number of rows written.
+func upsertRow(tx *gorm.DB, f SeedFile, row map[string]interface{}) (int64, error) {
+	columns := make([]string, 0, len(row))
+	for col := range row {
+		columns = append(columns, col)
+	}
+	sort.Strings(columns)
+
+	isKey := make(map[string]bool, len(f.Key))
+	for _, col := range f.Key {
+		isKey[col] = true
+	}
+	quoted := make([]string, len(columns))
+	placeholders := make([]string, len(columns))
+	values := make([]interface{}, len(columns))
+	var updates, current, excluded []string
+	for i, col := range columns {
+		quoted[i] = `"` + col + `"`
+		placeholders[i] = "?"
+		value, err := seedValue(row[col])
+		if err != nil {
+			return 0, fmt.Errorf("column %s: %w", col, err)
+		}
+		values[i] = value
+		if !isKey[col] {
+			updates = append(updates, fmt.Sprintf(`"%s" = EXCLUDED."%s"`, col, col))
+			current = append(current, fmt.Sprintf(`"%s"."%s"`, f.Table, col))
+			excluded = append(excluded, fmt.Sprintf(`EXCLUDED."%s"`, col))
+		}
+	}
+	keys := make([]string, len(f.Key))
+	for i, col := range f.Key {
+		keys[i] = `"` + col + `"`
+	}
+
+	sql := fmt.Sprintf(`INSERT INTO "%s" (%s) VALUES (%s) ON CONFLICT (%s) `,
+		f.Table, strings.Join(quoted, ", "), strings.Join(placeholders, ", "), strings.Join(keys, ", "))
+	if len(updates) == 0 {
+		sql += "DO NOTHING"
+	} else {
+		sql += fmt.Sprintf("DO UPDATE SET %s WHERE (%s) IS DISTINCT FROM (%s)",
+			strings.Join(updates, ", "), strings.Join(current, ", "), strings.Join(excluded, ", "))
+	}
+	result := tx.Exec(sql, values...)
+	return result.RowsAffected, result.Error
+}
+
+// seedValue converts a decoded YAML value to a query argument. Lists and
+// mappings are stored as JSON, e.g. for jsonb columns.
+func seedValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case nil, string, bool, int, int64, uint64, float64, time.Time:
+		return v, nil
+	case []interface{}, map[string]interface{}:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	default:
+		return nil, fmt.Errorf("unsupported value %v of type %T", v, v)
+	}
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// SeedUsage describes the arguments of RunSeedCommand.
+const SeedUsage = "usage: seed [apply] | list"
+
+// RunSeedCommand implements the "seed" subcommand of the service binaries:
+// args are the arguments after "seed", output goes to out. Without arguments
+// it applies the seeds.
+func RunSeedCommand(ctx context.Context, s *Seeder, args []string, out io.Writer) error {
+	command := "apply"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	switch command {
+	case "apply":
+		results, err := s.Seed(ctx)
+		if err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			fmt.Fprintln(out, "No seed files")
+		}
+		for _, r := range results {
+			fmt.Fprintf(out, "Seeded %s %s: %d of %d rows changed\n", r.Source, r.Table, r.Changed, r.Rows)
+		}
+		return nil
+
+	case "list":
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SOURCE\tFILE\tTABLE\tROWS")
+		for _, source := range s.sources {
+			files, err := source.Files()
+			if err != nil {
+				return err
+			}
+			for _, f := range files {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", source.Name, f.Name, f.Table, len(f.Rows))
+			}
+		}
+		return w.Flush()
+
+	default:
+		return fmt.Errorf("unknown seed command %q; %s", command, SeedUsage)
+	}
+}
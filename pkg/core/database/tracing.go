@@ -0,0 +1,116 @@
+package database
+
+import (
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+
+	"golang-microservices-boilerplate/pkg/core/tracing"
+)
+
+const (
+	tracingPluginName = "tracing"
+	tracingSpanKey    = "tracing:span" // Statement instance key of the span in progress
+)
+
+// tracingPlugin records a client span for each statement run within a traced
+// request, named after its operation and table, e.g. "SELECT users". Statements
+// outside a request, such as migrations, are not traced. Only the SQL with its
+// placeholders is recorded, never the bound values.
+type tracingPlugin struct {
+	role string // "primary" or "replica", to tell where reads went
+}
+
+// Name implements gorm.Plugin.
+func (p tracingPlugin) Name() string {
+	return tracingPluginName
+}
+
+// Initialize implements gorm.Plugin by wrapping the built-in callbacks.
+func (p tracingPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("tracing:before_create", p.before); err != nil {
+		return err
+	}
+	if err := callbacks.Create().After("gorm:create").Register("tracing:after_create", p.after); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("tracing:before_query", p.before); err != nil {
+		return err
+	}
+	if err := callbacks.Query().After("gorm:query").Register("tracing:after_query", p.after); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("tracing:before_update", p.before); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("tracing:after_update", p.after); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("tracing:before_delete", p.before); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().After("gorm:delete").Register("tracing:after_delete", p.after); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("tracing:before_row", p.before); err != nil {
+		return err
+	}
+	if err := callbacks.Row().After("gorm:row").Register("tracing:after_row", p.after); err != nil {
+		return err
+	}
+	if err := callbacks.Raw().Before("gorm:raw").Register("tracing:before_raw", p.before); err != nil {
+		return err
+	}
+	return callbacks.Raw().After("gorm:raw").Register("tracing:after_raw", p.after)
+}
+
+// before starts the span of a statement when its context carries a span.
+func (p tracingPlugin) before(db *gorm.DB) {
+	ctx := db.Statement.Context
+	if ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+		return
+	}
+	_, span := tracing.Tracer().Start(ctx, "db", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.role", p.role),
+		),
+	)
+	db.InstanceSet(tracingSpanKey, span)
+}
+
+// after names the span of a statement from its SQL, records the outcome and ends it.
+func (p tracingPlugin) after(db *gorm.DB) {
+	value, ok := db.InstanceGet(tracingSpanKey)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	defer span.End()
+
+	statement := db.Statement.SQL.String()
+	operation, _, _ := strings.Cut(strings.TrimSpace(statement), " ")
+	operation = strings.ToUpper(operation)
+	name := operation
+	if db.Statement.Table != "" {
+		name += " " + db.Statement.Table
+	}
+	if name != "" {
+		span.SetName(name)
+	}
+	span.SetAttributes(
+		attribute.String("db.statement", statement),
+		attribute.String("db.operation", operation),
+		attribute.String("db.sql.table", db.Statement.Table),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// UnitOfWork runs work spanning several repositories atomically.
+type UnitOfWork interface {
+	// Do runs fn in a transaction that is committed when fn returns nil and
+	// rolled back otherwise. Repositories called with the ctx passed to fn take
+	// part in the transaction. When ctx already carries a transaction, fn runs
+	// in a savepoint of it.
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// GormUnitOfWork implements UnitOfWork by putting the *gorm.DB transaction into
+// the context, where repositories pick it up through Conn.
+type GormUnitOfWork struct {
+	db *gorm.DB
+}
+
+// NewUnitOfWork creates a UnitOfWork on db.
+func NewUnitOfWork(db *gorm.DB) *GormUnitOfWork {
+	return &GormUnitOfWork{db: db}
+}
+
+// Do implements UnitOfWork. Functions registered with AfterCommit run once the
+// outermost unit of work has committed.
+func (u *GormUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	hooks, nested := ctx.Value(afterCommitKey{}).(*afterCommitHooks)
+	if !nested {
+		hooks = &afterCommitHooks{}
+		ctx = context.WithValue(ctx, afterCommitKey{}, hooks)
+	}
+	err := Conn(ctx, u.db).Transaction(func(tx *gorm.DB) error {
+		return fn(WithTx(ctx, tx))
+	})
+	if err == nil && !nested {
+		hooks.run()
+	}
+	return err
+}
+
+// afterCommitKey is the context key of the hooks of the outermost unit of work.
+type afterCommitKey struct{}
+
+// afterCommitHooks collects the functions to run after a unit of work commits.
+type afterCommitHooks struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+// run runs the collected functions in registration order.
+func (h *afterCommitHooks) run() {
+	h.mu.Lock()
+	fns := h.fns
+	h.fns = nil
+	h.mu.Unlock()
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// AfterCommit runs fn once the unit of work carried by ctx has committed, or
+// right away outside of one. fn is dropped when the unit of work rolls back;
+// functions registered inside a savepoint that rolls back still run when the
+// outer unit of work commits, so they should be safe to run spuriously (e.g.
+// cache invalidation).
+func AfterCommit(ctx context.Context, fn func()) {
+	hooks, ok := ctx.Value(afterCommitKey{}).(*afterCommitHooks)
+	if !ok {
+		fn()
+		return
+	}
+	hooks.mu.Lock()
+	hooks.fns = append(hooks.fns, fn)
+	hooks.mu.Unlock()
+}
+
+// txKey is the context key of the current transaction.
+type txKey struct{}
+
+// WithTx returns a copy of ctx carrying tx as the current transaction.
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFromContext returns the transaction carried by ctx, if any.
+func TxFromContext(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return tx, ok
+}
+
+// Conn returns the handle repositories should run statements on: the
+// transaction carried by ctx, or db outside of a unit of work. Either way the
+// handle is bound to ctx.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
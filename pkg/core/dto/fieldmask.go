@@ -0,0 +1,124 @@
+package dto
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Masker is implemented by update DTOs carrying a field mask. The base use case
+// applies only the masked fields of such DTOs (see ApplyMask) and every field of
+// other DTOs (see MapToEntity). A nil mask also applies every field, an empty
+// one none.
+type Masker interface {
+	FieldMask() []string
+}
+
+// MaskError reports a field mask path that cannot be applied.
+type MaskError struct {
+	Path   string
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *MaskError) Error() string {
+	return fmt.Sprintf("update mask path '%s' %s", e.Path, e.Reason)
+}
+
+// ApplyMask copies the fields of from named by paths to the struct `to` points
+// to. Unlike MapToEntity, a masked field is copied even when it holds its zero
+// value (a nil pointer clears the destination), which is how clients clear a
+// field. Unmasked fields are left untouched.
+//
+// Paths name top-level fields of from by their JSON name, which for generated
+// proto messages is the proto field name (e.g. "phone_number"). The destination
+// field is matched by Go name like in MapToEntity; fields promoted from embedded
+// structs such as entity.BaseEntity (ID, Version, ...) cannot be masked.
+// Clearing a destination field tagged `gorm:"not null"` is rejected. Sources
+// with an AsTime method (e.g. *timestamppb.Timestamp) are copied to time.Time
+// fields. Nothing is copied when any path is invalid; the error is a *MaskError.
+func ApplyMask(from interface{}, to interface{}, paths []string) error {
+	fromValue := reflect.ValueOf(from)
+	toValuePtr := reflect.ValueOf(to)
+
+	if toValuePtr.Kind() != reflect.Ptr || toValuePtr.IsNil() || toValuePtr.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("destination must be a non-nil pointer to a struct")
+	}
+	toValue := toValuePtr.Elem()
+	if fromValue.Kind() == reflect.Ptr {
+		if fromValue.IsNil() {
+			return fmt.Errorf("source must not be nil")
+		}
+		fromValue = fromValue.Elem()
+	}
+	if fromValue.Kind() != reflect.Struct {
+		return fmt.Errorf("source must be a struct or a pointer to a struct")
+	}
+
+	// Resolve every path before touching the destination
+	type maskedField struct {
+		name     string
+		from, to reflect.Value
+	}
+	fields := make([]maskedField, 0, len(paths))
+	for _, path := range paths {
+		fromField, ok := fieldByJSONName(fromValue.Type(), path)
+		if !ok {
+			return &MaskError{Path: path, Reason: "does not name an updatable field"}
+		}
+		toField, ok := toValue.Type().FieldByName(fromField.Name)
+		if !ok || len(toField.Index) != 1 || !toField.IsExported() {
+			return &MaskError{Path: path, Reason: "does not name an updatable field"}
+		}
+
+		fromFieldValue := fromValue.FieldByIndex(fromField.Index)
+		if fromFieldValue.IsZero() && isNotNull(toField) {
+			return &MaskError{Path: path, Reason: "is required and cannot be cleared"}
+		}
+		fields = append(fields, maskedField{name: fromField.Name, from: fromFieldValue, to: toValue.FieldByIndex(toField.Index)})
+	}
+
+	for _, f := range fields {
+		if f.from.Kind() == reflect.Ptr && f.from.IsNil() {
+			f.to.Set(reflect.Zero(f.to.Type()))
+			continue
+		}
+		if t, ok := f.from.Interface().(interface{ AsTime() time.Time }); ok && f.to.Type() == reflect.TypeOf(time.Time{}) {
+			f.to.Set(reflect.ValueOf(t.AsTime()))
+			continue
+		}
+		if err := mapField(f.name, f.from, f.to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldByJSONName finds the exported top-level field of t whose JSON name is name.
+func fieldByJSONName(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+		if jsonName != "-" && jsonName == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// isNotNull reports whether a field is tagged `gorm:"not null"`.
+func isNotNull(field reflect.StructField) bool {
+	for _, setting := range strings.Split(field.Tag.Get("gorm"), ";") {
+		if strings.EqualFold(strings.TrimSpace(setting), "not null") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package dto
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+)
+
+// patientUpdate is shaped like a generated update request.
+type patientUpdate struct {
+	ID          string                 `json:"id,omitempty"`
+	Version     int64                  `json:"version,omitempty"`
+	FirstName   string                 `json:"first_name,omitempty"`
+	PhoneNumber string                 `json:"phone_number,omitempty"`
+	Address     *string                `json:"address,omitempty"`
+	DateOfBirth *timestamppb.Timestamp `json:"date_of_birth,omitempty"`
+}
+
+type maskedPatient struct {
+	entity.BaseEntity
+	FirstName   string `gorm:"not null"`
+	PhoneNumber string
+	Address     *string
+	DateOfBirth time.Time
+}
+
+func newMaskedPatient() maskedPatient {
+	address := "12 Main St"
+	return maskedPatient{
+		BaseEntity:  entity.BaseEntity{Version: 3},
+		FirstName:   "Ada",
+		PhoneNumber: "555-0100",
+		Address:     &address,
+		DateOfBirth: time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestApplyMask(t *testing.T) {
+	birth := time.Date(1985, 6, 7, 0, 0, 0, 0, time.UTC)
+	newAddress := "1 High St"
+	cases := []struct {
+		name  string
+		from  patientUpdate
+		paths []string
+		want  func(p *maskedPatient) // Applied to newMaskedPatient for the expected result
+	}{
+		{"clears fields", patientUpdate{}, []string{"phone_number", "address"}, func(p *maskedPatient) {
+			p.PhoneNumber, p.Address = "", nil
+		}},
+		{"sets masked fields only", patientUpdate{FirstName: "Grace", Address: &newAddress}, []string{"address"}, func(p *maskedPatient) {
+			p.Address = &newAddress
+		}},
+		{"converts timestamps", patientUpdate{DateOfBirth: timestamppb.New(birth)}, []string{"date_of_birth"}, func(p *maskedPatient) {
+			p.DateOfBirth = birth
+		}},
+		{"clears timestamps", patientUpdate{}, []string{"date_of_birth"}, func(p *maskedPatient) {
+			p.DateOfBirth = time.Time{}
+		}},
+		{"empty mask", patientUpdate{FirstName: "Grace"}, []string{}, func(p *maskedPatient) {}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, want := newMaskedPatient(), newMaskedPatient()
+			tc.want(&want)
+			if err := ApplyMask(&tc.from, &got, tc.paths); err != nil {
+				t.Fatalf("ApplyMask failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ApplyMask = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestApplyMaskRejects(t *testing.T) {
+	cases := []struct {
+		name  string
+		from  patientUpdate
+		paths []string
+	}{
+		{"clearing a not null field", patientUpdate{PhoneNumber: "555-0199"}, []string{"phone_number", "first_name"}},
+		{"unknown path", patientUpdate{PhoneNumber: "555-0199"}, []string{"phone_number", "nickname"}},
+		{"Go field name", patientUpdate{PhoneNumber: "555-0199"}, []string{"PhoneNumber"}},
+		{"embedded ID", patientUpdate{ID: "6f1c1a3e-0000-0000-0000-000000000000"}, []string{"id"}},
+		{"embedded version", patientUpdate{Version: 9}, []string{"version"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := newMaskedPatient()
+			err := ApplyMask(&tc.from, &got, tc.paths)
+			var maskErr *MaskError
+			if !errors.As(err, &maskErr) {
+				t.Fatalf("ApplyMask error = %v, want a *MaskError", err)
+			}
+			if want := newMaskedPatient(); !reflect.DeepEqual(got, want) {
+				t.Errorf("destination changed to %+v despite the error", got)
+			}
+		})
+	}
+}
@@ -47,93 +47,102 @@ func MapToEntity(from interface{}, to interface{}) error {
 		toFieldValue := toValue.FieldByName(fromFieldName)
 
 		if toFieldValue.IsValid() && toFieldValue.CanSet() {
-			isFromPtr := fromFieldValue.Kind() == reflect.Ptr
-			isToPtr := toFieldValue.Kind() == reflect.Ptr
-
-			// Case 1: Direct assignment possible (types match exactly)
-			if fromFieldValue.Type().AssignableTo(toFieldValue.Type()) {
-				toFieldValue.Set(fromFieldValue)
-				continue
+			if err := mapField(fromFieldName, fromFieldValue, toFieldValue); err != nil {
+				return err
 			}
+		}
+	}
 
-			// Case 2: Destination is Ptr, Source is not Ptr
-			if isToPtr && !isFromPtr {
-				// Check if Source type is assignable to Dest Elem type
-				if fromFieldValue.Type().AssignableTo(toFieldValue.Type().Elem()) {
-					newPtr := reflect.New(toFieldValue.Type().Elem()) // Create new pointer of destination element type
-					newPtr.Elem().Set(fromFieldValue)                 // Set pointer's element value
-					toFieldValue.Set(newPtr)                          // Assign the new pointer
-					continue
-				}
-			}
+	return nil
+}
 
-			// Case 3: Source is Ptr, Destination is not Ptr
-			if isFromPtr && !isToPtr {
-				if !fromFieldValue.IsNil() {
-					fromElemValue := fromFieldValue.Elem() // Dereference source pointer
-					// Check if Source Elem type is assignable/convertible to Dest type
-					if fromElemValue.Type().AssignableTo(toFieldValue.Type()) {
-						toFieldValue.Set(fromElemValue)
-						continue
-					} else if fromElemValue.Type().ConvertibleTo(toFieldValue.Type()) {
-						toFieldValue.Set(fromElemValue.Convert(toFieldValue.Type()))
-						continue
-					}
-				}
-				// If fromFieldValue is Nil, do nothing for non-ptr destination
-				continue
-			}
+// mapField copies one field for MapToEntity, converting between compatible types.
+func mapField(fromFieldName string, fromFieldValue, toFieldValue reflect.Value) error {
+	isFromPtr := fromFieldValue.Kind() == reflect.Ptr
+	isToPtr := toFieldValue.Kind() == reflect.Ptr
 
-			// Case 4: Basic type conversion (neither is pointer, types differ)
-			if !isFromPtr && !isToPtr {
-				if fromFieldValue.Type().ConvertibleTo(toFieldValue.Type()) {
-					toFieldValue.Set(fromFieldValue.Convert(toFieldValue.Type()))
-					continue
-				}
-			}
+	// Case 1: Direct assignment possible (types match exactly)
+	if fromFieldValue.Type().AssignableTo(toFieldValue.Type()) {
+		toFieldValue.Set(fromFieldValue)
+		return nil
+	}
 
-			// Case 5: Nested Structs (Recursive Call)
-			if fromFieldValue.Kind() == reflect.Struct && toFieldValue.Kind() == reflect.Struct {
-				// Ensure destination field is addressable for the recursive call
-				if toFieldValue.CanAddr() {
-					if err := MapToEntity(fromFieldValue.Interface(), toFieldValue.Addr().Interface()); err != nil {
-						// Potentially log or wrap this error, returning prevents further mapping
-						return fmt.Errorf("error mapping nested struct field '%s': %w", fromFieldName, err)
-					}
-					continue
-				}
-			} else if fromFieldValue.Kind() == reflect.Ptr && fromFieldValue.Elem().Kind() == reflect.Struct &&
-				toFieldValue.Kind() == reflect.Ptr && toFieldValue.Type().Elem().Kind() == reflect.Struct {
-				// Handle pointer to struct -> pointer to struct
-				if !fromFieldValue.IsNil() {
-					if toFieldValue.IsNil() {
-						// If destination is nil, create a new struct instance for it
-						newStructPtr := reflect.New(toFieldValue.Type().Elem())
-						toFieldValue.Set(newStructPtr)
-					}
-					if err := MapToEntity(fromFieldValue.Interface(), toFieldValue.Interface()); err != nil {
-						return fmt.Errorf("error mapping nested pointer to struct field '%s': %w", fromFieldName, err)
-					}
-				} else {
-					// If source is nil, set destination to nil
-					toFieldValue.Set(reflect.Zero(toFieldValue.Type()))
-				}
-				continue
+	// Case 2: Destination is Ptr, Source is not Ptr
+	if isToPtr && !isFromPtr {
+		// Check if Source type is assignable to Dest Elem type
+		if fromFieldValue.Type().AssignableTo(toFieldValue.Type().Elem()) {
+			newPtr := reflect.New(toFieldValue.Type().Elem()) // Create new pointer of destination element type
+			newPtr.Elem().Set(fromFieldValue)                 // Set pointer's element value
+			toFieldValue.Set(newPtr)                          // Assign the new pointer
+			return nil
+		}
+	}
+
+	// Case 3: Source is Ptr, Destination is not Ptr
+	if isFromPtr && !isToPtr {
+		if !fromFieldValue.IsNil() {
+			fromElemValue := fromFieldValue.Elem() // Dereference source pointer
+			// Check if Source Elem type is assignable/convertible to Dest type
+			if fromElemValue.Type().AssignableTo(toFieldValue.Type()) {
+				toFieldValue.Set(fromElemValue)
+				return nil
+			} else if fromElemValue.Type().ConvertibleTo(toFieldValue.Type()) {
+				toFieldValue.Set(fromElemValue.Convert(toFieldValue.Type()))
+				return nil
 			}
+		}
+		// If fromFieldValue is Nil, do nothing for non-ptr destination
+		return nil
+	}
 
-			// Case 6: Explicit time.Time check (often handled by AssignableTo, but good for clarity)
-			if _, ok := fromFieldValue.Interface().(time.Time); ok {
-				if fromFieldValue.Type().AssignableTo(toFieldValue.Type()) {
-					toFieldValue.Set(fromFieldValue)
-					continue
-				}
+	// Case 4: Basic type conversion (neither is pointer, types differ)
+	if !isFromPtr && !isToPtr {
+		if fromFieldValue.Type().ConvertibleTo(toFieldValue.Type()) {
+			toFieldValue.Set(fromFieldValue.Convert(toFieldValue.Type()))
+			return nil
+		}
+	}
+
+	// Case 5: Nested Structs (Recursive Call)
+	if fromFieldValue.Kind() == reflect.Struct && toFieldValue.Kind() == reflect.Struct {
+		// Ensure destination field is addressable for the recursive call
+		if toFieldValue.CanAddr() {
+			if err := MapToEntity(fromFieldValue.Interface(), toFieldValue.Addr().Interface()); err != nil {
+				// Potentially log or wrap this error, returning prevents further mapping
+				return fmt.Errorf("error mapping nested struct field '%s': %w", fromFieldName, err)
 			}
+			return nil
+		}
+	} else if fromFieldValue.Kind() == reflect.Ptr && fromFieldValue.Elem().Kind() == reflect.Struct &&
+		toFieldValue.Kind() == reflect.Ptr && toFieldValue.Type().Elem().Kind() == reflect.Struct {
+		// Handle pointer to struct -> pointer to struct
+		if !fromFieldValue.IsNil() {
+			if toFieldValue.IsNil() {
+				// If destination is nil, create a new struct instance for it
+				newStructPtr := reflect.New(toFieldValue.Type().Elem())
+				toFieldValue.Set(newStructPtr)
+			}
+			if err := MapToEntity(fromFieldValue.Interface(), toFieldValue.Interface()); err != nil {
+				return fmt.Errorf("error mapping nested pointer to struct field '%s': %w", fromFieldName, err)
+			}
+		} else {
+			// If source is nil, set destination to nil
+			toFieldValue.Set(reflect.Zero(toFieldValue.Type()))
+		}
+		return nil
+	}
 
-			// Add more specific type handling if needed (e.g., custom type conversions not covered by ConvertibleTo)
-			// Example: if fromFieldValue.Type() == reflect.TypeOf(MyCustomType{}) { ... }
+	// Case 6: Explicit time.Time check (often handled by AssignableTo, but good for clarity)
+	if _, ok := fromFieldValue.Interface().(time.Time); ok {
+		if fromFieldValue.Type().AssignableTo(toFieldValue.Type()) {
+			toFieldValue.Set(fromFieldValue)
+			return nil
 		}
 	}
 
+	// Add more specific type handling if needed (e.g., custom type conversions not covered by ConvertibleTo)
+	// Example: if fromFieldValue.Type() == reflect.TypeOf(MyCustomType{}) { ... }
+
 	return nil
 }
 
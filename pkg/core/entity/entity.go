@@ -16,12 +16,36 @@ type Entity interface {
 	GetDeletedAt() *time.Time
 }
 
+// Queryable defines the columns of an entity that clients may use in
+// FilterOptions.Filters and FilterOptions.SortBy. BaseEntity implements it with
+// its own columns only; entities override it to expose additional columns.
+type Queryable interface {
+	FilterableColumns() []string
+	SortableColumns() []string
+}
+
+// Searchable is implemented by entities supporting full-text search. Their
+// table needs two generated columns built from SearchColumns: search_vector
+// (a 'simple' tsvector) and search_text (lower-cased text with a pg_trgm index).
+type Searchable interface {
+	SearchColumns() []string
+}
+
+// Versioned is implemented by pointers to entities embedding BaseEntity.
+// Repositories use it for optimistic locking: an update only succeeds when the
+// stored version still equals GetVersion(), and then increments it.
+type Versioned interface {
+	GetVersion() int64
+	SetVersion(version int64)
+}
+
 // BaseEntity struct to be embedded in other structs
 type BaseEntity struct {
-	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;"`
-	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty" gorm:"index"`
+	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;"`
+	CreatedAt time.Time      `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"autoUpdateTime"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"` // Soft delete marker; GORM excludes these rows unless Unscoped
+	Version   int64          `json:"version" gorm:"not null;default:1"` // Optimistic locking version
 }
 
 // GetID returns the entity ID
@@ -50,7 +74,31 @@ func (base BaseEntity) GetUpdatedAt() time.Time {
 
 // GetDeletedAt returns the deletion timestamp
 func (base BaseEntity) GetDeletedAt() *time.Time {
-	return base.DeletedAt
+	if !base.DeletedAt.Valid {
+		return nil
+	}
+	deletedAt := base.DeletedAt.Time
+	return &deletedAt
+}
+
+// GetVersion returns the optimistic locking version
+func (base BaseEntity) GetVersion() int64 {
+	return base.Version
+}
+
+// SetVersion sets the optimistic locking version
+func (base *BaseEntity) SetVersion(version int64) {
+	base.Version = version
+}
+
+// FilterableColumns returns the base columns that may be used in filters
+func (base BaseEntity) FilterableColumns() []string {
+	return []string{"id", "created_at", "updated_at", "deleted_at"}
+}
+
+// SortableColumns returns the base columns that may be used for sorting
+func (base BaseEntity) SortableColumns() []string {
+	return []string{"id", "created_at", "updated_at"}
 }
 
 // BeforeCreate hook to set the ID before creating a new record
@@ -58,6 +106,9 @@ func (base *BaseEntity) BeforeCreate(tx *gorm.DB) (err error) {
 	if base.ID == uuid.Nil {
 		base.ID = uuid.New()
 	}
+	if base.Version == 0 {
+		base.Version = 1
+	}
 	return nil
 }
 
@@ -72,7 +123,7 @@ func (base *BaseEntity) BeforeUpdate(tx *gorm.DB) (err error) {
 
 // IsDeleted checks if the entity has been soft deleted
 func (base *BaseEntity) IsDeleted() bool {
-	return base.DeletedAt != nil
+	return base.DeletedAt.Valid
 }
 
 // Clone creates a copy of a BaseEntity for safe modification
@@ -82,6 +133,7 @@ func (base *BaseEntity) Clone() BaseEntity {
 		CreatedAt: base.CreatedAt,
 		UpdatedAt: base.UpdatedAt,
 		DeletedAt: base.DeletedAt,
+		Version:   base.Version,
 	}
 }
 
@@ -91,4 +143,5 @@ type BaseEntityDTO struct {
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	Version   int64      `json:"version"`
 }
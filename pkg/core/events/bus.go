@@ -0,0 +1,57 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Publisher delivers outbox messages to subscribers. Delivery is at least once:
+// a message whose Publish failed is retried by the Dispatcher.
+type Publisher interface {
+	Publish(ctx context.Context, msg *Message) error
+}
+
+// Handler processes a delivered message. Handlers must be idempotent since a
+// message may be delivered more than once.
+type Handler func(ctx context.Context, msg *Message) error
+
+// AllEvents subscribes a handler to every event name.
+const AllEvents = "*"
+
+// Bus is the in-process Publisher: it calls the handlers subscribed to the
+// message name synchronously. It is also the delivery target of PostgresListener.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+// NewBus creates an empty Bus.
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe registers handler for messages named name, or for all messages when
+// name is AllEvents.
+func (b *Bus) Subscribe(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish implements Publisher. Every matching handler is called, even when an
+// earlier one fails; the returned error joins all handler errors.
+func (b *Bus) Publish(ctx context.Context, msg *Message) error {
+	b.mu.RLock()
+	handlers := append(append([]Handler(nil), b.handlers[msg.Name]...), b.handlers[AllEvents]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("handler for %s failed: %w", msg.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
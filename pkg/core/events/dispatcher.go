@@ -0,0 +1,161 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// Publisher kinds selectable with EVENTS_PUBLISHER.
+const (
+	PublisherInProcess = "inprocess"
+	PublisherPostgres  = "postgres"
+)
+
+// Config contains configuration for publishing domain events.
+type Config struct {
+	Publisher    string        // PublisherInProcess or PublisherPostgres
+	Channel      string        // LISTEN/NOTIFY channel of the postgres publisher
+	ListenURI    string        // Direct database URI for LISTEN; defaults to the service DB URI
+	PollInterval time.Duration // How often the dispatcher looks for pending messages
+	BatchSize    int           // Maximum number of messages published per transaction
+	MaxAttempts  int           // Attempts after which a message is no longer retried
+}
+
+// DefaultConfig provides sensible defaults, overridable through environment variables.
+func DefaultConfig() Config {
+	pollInterval, err := time.ParseDuration(utils.GetEnv("OUTBOX_POLL_INTERVAL", "1s"))
+	if err != nil || pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+	batchSize, _ := strconv.Atoi(utils.GetEnv("OUTBOX_BATCH_SIZE", "100"))
+	maxAttempts, _ := strconv.Atoi(utils.GetEnv("OUTBOX_MAX_ATTEMPTS", "10"))
+
+	return Config{
+		Publisher:    utils.GetEnv("EVENTS_PUBLISHER", PublisherInProcess),
+		Channel:      utils.GetEnv("EVENTS_CHANNEL", "domain_events"),
+		ListenURI:    utils.GetEnv("EVENTS_LISTEN_URI", ""),
+		PollInterval: pollInterval,
+		BatchSize:    max(batchSize, 1),
+		MaxAttempts:  max(maxAttempts, 1),
+	}
+}
+
+// Dispatcher publishes pending outbox messages in the order they occurred.
+type Dispatcher struct {
+	db        *gorm.DB
+	publisher Publisher
+	logger    logger.Logger
+	config    Config
+}
+
+// NewDispatcher creates a Dispatcher for the outbox of db.
+func NewDispatcher(db *gorm.DB, publisher Publisher, logger logger.Logger, config Config) *Dispatcher {
+	return &Dispatcher{db: db, publisher: publisher, logger: logger, config: config}
+}
+
+// Run dispatches pending messages every PollInterval until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for ctx.Err() == nil {
+			published, err := d.DispatchPending(ctx)
+			if err != nil {
+				d.logger.Error("Failed to dispatch outbox messages", "error", err)
+			}
+			if err != nil || published < d.config.BatchSize {
+				break
+			}
+		}
+	}
+}
+
+// DispatchPending publishes one batch of pending messages and returns how many
+// were published. Rows are locked with SKIP LOCKED so several replicas can run
+// dispatchers side by side. The batch stops at the first failed message to keep
+// the order; the failure is recorded and the message retried on the next run
+// until MaxAttempts is reached.
+func (d *Dispatcher) DispatchPending(ctx context.Context) (int, error) {
+	published := 0
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var messages []*Message
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("published_at IS NULL AND attempts < ?", d.config.MaxAttempts).
+			Order("occurred_at").Limit(d.config.BatchSize).
+			Find(&messages).Error
+		if err != nil {
+			return err
+		}
+
+		for _, msg := range messages {
+			if err := d.publish(ctx, tx, msg); err != nil {
+				d.logger.Warn("Failed to publish event", "event", msg.Name, "id", msg.ID, "attempt", msg.Attempts+1, "error", err)
+				return tx.Model(msg).UpdateColumns(map[string]interface{}{
+					"attempts":   gorm.Expr("attempts + 1"),
+					"last_error": err.Error(),
+				}).Error
+			}
+			if err := tx.Model(msg).UpdateColumns(map[string]interface{}{
+				"attempts":     gorm.Expr("attempts + 1"),
+				"published_at": time.Now(),
+			}).Error; err != nil {
+				return fmt.Errorf("failed to mark message %s as published: %w", msg.ID, err)
+			}
+			published++
+		}
+		return nil
+	})
+	return published, err
+}
+
+// TxPublisher is implemented by publishers writing to the outbox database. The
+// Dispatcher publishes through the transaction marking the message published,
+// so nothing is sent when that transaction rolls back.
+type TxPublisher interface {
+	PublishTx(ctx context.Context, tx *gorm.DB, msg *Message) error
+}
+
+// publish publishes msg, through tx when the publisher is a TxPublisher.
+func (d *Dispatcher) publish(ctx context.Context, tx *gorm.DB, msg *Message) error {
+	if publisher, ok := d.publisher.(TxPublisher); ok {
+		return publisher.PublishTx(ctx, tx, msg)
+	}
+	return d.publisher.Publish(ctx, msg)
+}
+
+// Start runs a Dispatcher for the outbox of db until ctx is cancelled. With the
+// postgres publisher it also runs a PostgresListener delivering the events of all
+// services to bus; otherwise bus itself is the publisher.
+func Start(ctx context.Context, db *gorm.DB, dbURI string, bus *Bus, logger logger.Logger, config Config) error {
+	var publisher Publisher
+	switch config.Publisher {
+	case PublisherInProcess:
+		publisher = bus
+	case PublisherPostgres:
+		publisher = NewPostgresPublisher(db, config.Channel)
+		listenURI := config.ListenURI
+		if listenURI == "" {
+			listenURI = dbURI
+		}
+		go NewPostgresListener(listenURI, config.Channel, bus, logger).Run(ctx)
+	default:
+		return fmt.Errorf("unknown events publisher %q", config.Publisher)
+	}
+
+	go NewDispatcher(db, publisher, logger, config).Run(ctx)
+	return nil
+}
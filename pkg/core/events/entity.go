@@ -0,0 +1,51 @@
+package events
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/schema"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+)
+
+// Changes reported by EntityEvent.
+const (
+	EntityCreated  = "created"
+	EntityUpdated  = "updated"
+	EntityDeleted  = "deleted"
+	EntityRestored = "restored"
+)
+
+// EntityEvent is the generic event usecase.BaseUseCaseImpl records for every
+// entity it writes, named "<entity>.<change>", e.g. "user.created". Entity is
+// set for creates and updates; deletes and restores only carry ID.
+type EntityEvent[T entity.Entity] struct {
+	Change string
+	ID     uuid.UUID
+	Entity *T
+}
+
+// EventName implements Event.
+func (e EntityEvent[T]) EventName() string {
+	var zero T
+	name := schema.NamingStrategy{}.ColumnName("", reflect.TypeOf(zero).Name())
+	return name + "." + e.Change
+}
+
+// AggregateID implements Event.
+func (e EntityEvent[T]) AggregateID() string {
+	if e.Entity != nil {
+		return (*e.Entity).GetID().String()
+	}
+	return e.ID.String()
+}
+
+// MarshalJSON encodes the event as {"id": ..., "entity": ...}.
+func (e EntityEvent[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		ID     string `json:"id"`
+		Entity *T     `json:"entity,omitempty"`
+	}{ID: e.AggregateID(), Entity: e.Entity})
+}
@@ -0,0 +1,63 @@
+// Package events implements domain events delivered through a transactional
+// outbox.
+//
+// Use cases Record events in the context they pass to a repository write.
+// Register installs GORM callbacks that store the recorded events as outbox
+// Messages in the same transaction as that write, so an event exists if and only
+// if its change was committed. A Dispatcher then publishes pending messages
+// through a Publisher: the in-process Bus, or PostgresPublisher which fans them
+// out to other services with LISTEN/NOTIFY.
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// Event is a typed domain event, such as AppointmentScheduled.
+type Event interface {
+	// EventName identifies the kind of event, e.g. "appointment.scheduled".
+	EventName() string
+	// AggregateID is the ID of the entity the event is about.
+	AggregateID() string
+}
+
+type contextKey struct{}
+
+// recorded holds the events recorded in one context. parent links to the
+// events recorded in the enclosing context.
+type recorded struct {
+	mu     sync.Mutex
+	events []Event
+	parent *recorded
+}
+
+// Record returns a copy of ctx carrying evts. They are written to the outbox by
+// the next create, update or delete that runs with the returned context (or a
+// context derived from it) and changes at least one row, within the transaction
+// of that statement.
+//
+// Events are encoded when they are written, so an event may reference an entity
+// whose ID is only assigned by the write itself.
+func Record(ctx context.Context, evts ...Event) context.Context {
+	parent, _ := ctx.Value(contextKey{}).(*recorded)
+	return context.WithValue(ctx, contextKey{}, &recorded{events: evts, parent: parent})
+}
+
+// take removes and returns the events recorded in ctx that were not written yet.
+func take(ctx context.Context) []Event {
+	var chain []*recorded
+	for r, _ := ctx.Value(contextKey{}).(*recorded); r != nil; r = r.parent {
+		chain = append(chain, r)
+	}
+
+	var evts []Event
+	for i := len(chain) - 1; i >= 0; i-- { // Oldest first
+		r := chain[i]
+		r.mu.Lock()
+		evts = append(evts, r.events...)
+		r.events = nil
+		r.mu.Unlock()
+	}
+	return evts
+}
@@ -0,0 +1,138 @@
+package events
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"golang-microservices-boilerplate/pkg/core/requestctx"
+)
+
+// Message is an event stored in the outbox_messages table. Its JSON encoding is
+// the envelope delivered to subscribers.
+type Message struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	Name        string     `json:"name" gorm:"size:100;not null;index"`
+	AggregateID string     `json:"aggregate_id" gorm:"size:100;not null;index"`
+	Payload     JSON       `json:"payload" gorm:"type:jsonb;not null"`
+	RequestID   string     `json:"request_id,omitempty" gorm:"size:100"`
+	OccurredAt  time.Time  `json:"occurred_at" gorm:"not null;index"`
+	PublishedAt *time.Time `json:"-" gorm:"index"`              // Nil until the dispatcher published the message
+	Attempts    int        `json:"-" gorm:"not null;default:0"` // Number of publish attempts
+	LastError   string     `json:"-" gorm:"type:text"`          // Error of the last failed attempt
+}
+
+// TableName overrides the default table name.
+func (Message) TableName() string {
+	return "outbox_messages"
+}
+
+// AuditExempt excludes outbox bookkeeping from the audit trail (see audit.Exempt).
+func (Message) AuditExempt() {}
+
+// Decode unmarshals the payload of the message into v.
+func (m *Message) Decode(v interface{}) error {
+	return json.Unmarshal(m.Payload, v)
+}
+
+// NewMessage encodes evt as an outbox message.
+func NewMessage(ctx context.Context, evt Event) (*Message, error) {
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event %s: %w", evt.EventName(), err)
+	}
+	return &Message{
+		ID:          uuid.New(),
+		Name:        evt.EventName(),
+		AggregateID: evt.AggregateID(),
+		Payload:     payload,
+		RequestID:   requestctx.RequestID(ctx),
+		OccurredAt:  time.Now(),
+	}, nil
+}
+
+// JSON is a raw JSON document stored in a jsonb column.
+type JSON []byte
+
+// Value implements driver.Valuer.
+func (j JSON) Value() (driver.Value, error) {
+	if j == nil {
+		return nil, nil
+	}
+	return string(j), nil
+}
+
+// Scan implements sql.Scanner.
+func (j *JSON) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*j = nil
+	case []byte:
+		*j = append(JSON(nil), v...)
+	case string:
+		*j = JSON(v)
+	default:
+		return fmt.Errorf("events: cannot scan %T into JSON", value)
+	}
+	return nil
+}
+
+// MarshalJSON embeds the document as is.
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if j == nil {
+		return []byte("null"), nil
+	}
+	return j, nil
+}
+
+// UnmarshalJSON keeps a copy of the raw document.
+func (j *JSON) UnmarshalJSON(data []byte) error {
+	*j = append(JSON(nil), data...)
+	return nil
+}
+
+// Register installs the GORM callbacks that write recorded events to the outbox
+// after every create, update and delete that changed at least one row.
+func Register(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().After("gorm:create").Before("gorm:after_create").
+		Register("events:outbox_create", writeOutbox); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:update").Before("gorm:after_update").
+		Register("events:outbox_update", writeOutbox); err != nil {
+		return err
+	}
+	return cb.Delete().After("gorm:delete").Before("gorm:after_delete").
+		Register("events:outbox_delete", writeOutbox)
+}
+
+// writeOutbox stores the events recorded in the statement context.
+func writeOutbox(db *gorm.DB) {
+	if db.Error != nil || db.DryRun || db.Statement.RowsAffected == 0 || db.Statement.Table == (Message{}).TableName() {
+		return
+	}
+	ctx := db.Statement.Context
+	evts := take(ctx)
+	if len(evts) == 0 {
+		return
+	}
+
+	messages := make([]*Message, 0, len(evts))
+	for _, evt := range evts {
+		msg, err := NewMessage(ctx, evt)
+		if err != nil {
+			_ = db.AddError(err)
+			return
+		}
+		messages = append(messages, msg)
+	}
+	if err := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Create(&messages).Error; err != nil {
+		_ = db.AddError(fmt.Errorf("failed to write %d events to the outbox: %w", len(messages), err))
+	}
+}
@@ -0,0 +1,116 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+)
+
+// maxNotifyPayload is the largest payload Postgres accepts in NOTIFY.
+const maxNotifyPayload = 8000
+
+// PostgresPublisher publishes messages with pg_notify on a channel shared by all
+// services of the database. Listeners connected to the channel receive every
+// message; those that are offline miss it.
+type PostgresPublisher struct {
+	db      *gorm.DB
+	channel string
+}
+
+// NewPostgresPublisher creates a PostgresPublisher notifying channel through db.
+func NewPostgresPublisher(db *gorm.DB, channel string) *PostgresPublisher {
+	return &PostgresPublisher{db: db, channel: channel}
+}
+
+// Publish implements Publisher.
+func (p *PostgresPublisher) Publish(ctx context.Context, msg *Message) error {
+	return p.PublishTx(ctx, p.db, msg)
+}
+
+// PublishTx implements TxPublisher: the notification is only delivered when tx
+// commits, as Postgres holds NOTIFY back until then.
+func (p *PostgresPublisher) PublishTx(ctx context.Context, tx *gorm.DB, msg *Message) error {
+	envelope, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode message %s: %w", msg.ID, err)
+	}
+	if len(envelope) >= maxNotifyPayload {
+		return fmt.Errorf("message %s is %d bytes, more than NOTIFY accepts", msg.ID, len(envelope))
+	}
+	return tx.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", p.channel, string(envelope)).Error
+}
+
+// PostgresListener LISTENs on a channel and hands every notification to a
+// Publisher, usually the service's Bus.
+type PostgresListener struct {
+	connString string
+	channel    string
+	target     Publisher
+	logger     logger.Logger
+}
+
+// NewPostgresListener creates a listener on channel. connString must point at
+// the database directly: LISTEN does not work through transaction poolers.
+func NewPostgresListener(connString, channel string, target Publisher, logger logger.Logger) *PostgresListener {
+	return &PostgresListener{connString: connString, channel: channel, target: target, logger: logger}
+}
+
+// Run listens until ctx is cancelled, reconnecting with backoff when the
+// connection is lost.
+func (l *PostgresListener) Run(ctx context.Context) {
+	backoff := time.Second
+	for {
+		connected, err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			backoff = time.Second
+		}
+		l.logger.Warn("Event listener disconnected, reconnecting", "channel", l.channel, "retry_in", backoff, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, 30*time.Second)
+	}
+}
+
+// listen holds one connection and delivers notifications until it fails. It
+// reports whether LISTEN succeeded.
+func (l *PostgresListener) listen(ctx context.Context) (bool, error) {
+	conn, err := pgx.Connect(ctx, l.connString)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{l.channel}.Sanitize()); err != nil {
+		return false, err
+	}
+	l.logger.Info("Listening for events", "channel", l.channel)
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, err
+		}
+
+		var msg Message
+		if err := json.Unmarshal([]byte(notification.Payload), &msg); err != nil {
+			l.logger.Error("Dropping malformed event notification", "channel", l.channel, "error", err)
+			continue
+		}
+		if err := l.target.Publish(ctx, &msg); err != nil {
+			l.logger.Error("Failed to handle event", "event", msg.Name, "id", msg.ID, "error", err)
+		}
+	}
+}
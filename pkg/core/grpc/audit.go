@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"golang-microservices-boilerplate/pkg/core/audit"
+	coreUseCase "golang-microservices-boilerplate/pkg/core/usecase"
+	corepb "golang-microservices-boilerplate/proto/core"
+)
+
+// AuditEventServer implements the ListAuditEvents RPC that every service exposes.
+// Service controllers delegate their ListAuditEvents method to it.
+type AuditEventServer struct {
+	events audit.Reader
+}
+
+// NewAuditEventServer creates an AuditEventServer reading from events.
+func NewAuditEventServer(events audit.Reader) *AuditEventServer {
+	return &AuditEventServer{events: events}
+}
+
+// ListAuditEvents returns a page of the audit trail.
+func (s *AuditEventServer) ListAuditEvents(ctx context.Context, req *corepb.ListAuditEventsRequest) (*corepb.ListAuditEventsResponse, error) {
+	result, err := s.events.List(ctx, req.EntityType, req.EntityId, FilterOptionsFromProto(req.Options))
+	if err != nil {
+		if ucErr := coreUseCase.TranslateRepositoryError(err); ucErr != nil {
+			return nil, ErrorToStatus(ucErr)
+		}
+		return nil, ErrorToStatus(err)
+	}
+
+	events := make([]*corepb.AuditEvent, 0, len(result.Items))
+	for _, event := range result.Items {
+		eventProto, err := AuditEventToProto(event)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to map audit event to proto: %v", err)
+		}
+		events = append(events, eventProto)
+	}
+
+	return &corepb.ListAuditEventsResponse{
+		Events:         events,
+		PaginationInfo: PaginationInfoToProto(result),
+	}, nil
+}
+
+// AuditEventToProto converts an audit.Event into core.AuditEvent.
+func AuditEventToProto(event *audit.Event) (*corepb.AuditEvent, error) {
+	eventProto := &corepb.AuditEvent{
+		Id:         event.ID.String(),
+		EntityType: event.EntityType,
+		EntityId:   event.EntityID,
+		Action:     string(event.Action),
+		Actor:      event.Actor,
+		RequestId:  event.RequestID,
+		CreatedAt:  timestamppb.New(event.CreatedAt),
+	}
+
+	var err error
+	if event.Before != nil {
+		if eventProto.Before, err = structpb.NewStruct(event.Before); err != nil {
+			return nil, err
+		}
+	}
+	if event.After != nil {
+		if eventProto.After, err = structpb.NewStruct(event.After); err != nil {
+			return nil, err
+		}
+	}
+	return eventProto, nil
+}
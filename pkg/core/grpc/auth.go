@@ -0,0 +1,143 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// healthMethodPrefix is the prefix of the gRPC health checking methods, which
+// are always public so probes work without a token.
+const healthMethodPrefix = "/grpc.health.v1.Health/"
+
+// Claims are the claims of an access token, in the format issued by
+// middleware.GenerateToken: custom claims such as "role" live under "data".
+type Claims struct {
+	Data map[string]interface{} `json:"data,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Role returns the "role" custom claim, or "" when it is missing.
+func (c *Claims) Role() string {
+	role, _ := c.Data["role"].(string)
+	return role
+}
+
+type claimsContextKey struct{}
+
+// ContextWithClaims returns a copy of ctx carrying the claims of the caller.
+func ContextWithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsContextKey{}, claims)
+}
+
+// ClaimsFromContext returns the claims of the authenticated caller. It is
+// false for public methods called without a token and when auth is disabled.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey{}).(*Claims)
+	return claims, ok
+}
+
+// AuthPolicy declares who may call the methods of a server, by full method
+// name such as user.UserService_DeleteMany_FullMethodName. Methods listed in
+// neither Public nor Roles need a valid access token with any role.
+type AuthPolicy struct {
+	Public []string            // Callable without a token, e.g. Login and Refresh
+	Roles  map[string][]string // Methods restricted to callers with one of the roles
+}
+
+// authorize checks the caller of method and returns ctx with its claims.
+func (p *AuthPolicy) authorize(ctx context.Context, method, secret string) (context.Context, error) {
+	public := strings.HasPrefix(method, healthMethodPrefix)
+	for _, m := range p.Public {
+		public = public || m == method
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	authorization := firstMetadataValue(md, "authorization")
+	if authorization == "" {
+		if public {
+			return ctx, nil
+		}
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	claims, err := parseAccessToken(authorization, secret)
+	if err != nil {
+		if public {
+			return ctx, nil // A stale token must not lock a user out of Login
+		}
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ctx = ContextWithClaims(ctx, claims)
+	if public {
+		return ctx, nil
+	}
+
+	roles, restricted := p.Roles[method]
+	if !restricted {
+		return ctx, nil
+	}
+	for _, role := range roles {
+		if claims.Role() == role {
+			return ctx, nil
+		}
+	}
+	return nil, status.Errorf(codes.PermissionDenied, "role %q may not call %s", claims.Role(), method)
+}
+
+// AuthUnaryServerInterceptor rejects calls the policy does not allow with
+// Unauthenticated or PermissionDenied, and stores the claims of valid HS256
+// access tokens signed with accessTokenSecret in the call context (see
+// ClaimsFromContext). With an empty secret no token is valid, so only public
+// methods can be called.
+func AuthUnaryServerInterceptor(accessTokenSecret string, policy *AuthPolicy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := policy.authorize(ctx, info.FullMethod, accessTokenSecret)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamServerInterceptor is the streaming counterpart of AuthUnaryServerInterceptor.
+func AuthStreamServerInterceptor(accessTokenSecret string, policy *AuthPolicy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := policy.authorize(stream.Context(), info.FullMethod, accessTokenSecret)
+		if err != nil {
+			return err
+		}
+		wrapped := grpc_middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = ctx
+		return handler(srv, wrapped)
+	}
+}
+
+// parseAccessToken validates a "Bearer <jwt>" authorization value.
+func parseAccessToken(authorization, secret string) (*Claims, error) {
+	if secret == "" {
+		return nil, errors.New("access tokens cannot be verified")
+	}
+	scheme, token, ok := strings.Cut(authorization, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return nil, errors.New("authorization must be a bearer token")
+	}
+
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(strings.TrimSpace(token), claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return nil, errors.New("access token expired")
+	}
+	if err != nil {
+		return nil, errors.New("invalid access token")
+	}
+	return claims, nil
+}
@@ -0,0 +1,148 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testSecret       = "test-secret"
+	testPublicMethod = "/test.Service/Login"
+	testAdminMethod  = "/test.Service/Delete"
+)
+
+var testPolicy = &AuthPolicy{
+	Public: []string{testPublicMethod},
+	Roles:  map[string][]string{testAdminMethod: {"admin", "manager"}},
+}
+
+// signToken returns an access token for role expiring after ttl, signed with
+// method and secret.
+func signToken(t *testing.T, method jwt.SigningMethod, secret, role string, ttl time.Duration) string {
+	t.Helper()
+	claims := Claims{
+		Data:             map[string]interface{}{"role": role},
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl))},
+	}
+	token, err := jwt.NewWithClaims(method, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return token
+}
+
+// withToken returns the context of a call sending token as bearer token.
+func withToken(token string) context.Context {
+	ctx := context.Background()
+	if token == "" {
+		return ctx
+	}
+	return metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestAuthorize(t *testing.T) {
+	valid := signToken(t, jwt.SigningMethodHS256, testSecret, "officer", time.Hour)
+	admin := signToken(t, jwt.SigningMethodHS256, testSecret, "admin", time.Hour)
+	expired := signToken(t, jwt.SigningMethodHS256, testSecret, "admin", -time.Minute)
+	hs384 := signToken(t, jwt.SigningMethodHS384, testSecret, "admin", time.Hour)
+	none, err := jwt.NewWithClaims(jwt.SigningMethodNone, Claims{Data: map[string]interface{}{"role": "admin"}}).
+		SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	otherSecret := signToken(t, jwt.SigningMethodHS256, "other-secret", "admin", time.Hour)
+
+	cases := []struct {
+		name     string
+		method   string
+		token    string
+		wantCode codes.Code
+		wantRole string // Role of the claims in the returned context, "" for none
+	}{
+		{"public without token", testPublicMethod, "", codes.OK, ""},
+		{"public with stale token", testPublicMethod, expired, codes.OK, ""},
+		{"public with valid token", testPublicMethod, valid, codes.OK, "officer"},
+		{"health without token", healthMethodPrefix + "Check", "", codes.OK, ""},
+		{"missing token", testMethod, "", codes.Unauthenticated, ""},
+		{"any role", testMethod, valid, codes.OK, "officer"},
+		{"allowed role", testAdminMethod, admin, codes.OK, "admin"},
+		{"wrong role", testAdminMethod, valid, codes.PermissionDenied, ""},
+		{"expired token", testMethod, expired, codes.Unauthenticated, ""},
+		{"HS384 token", testMethod, hs384, codes.Unauthenticated, ""},
+		{"unsigned token", testMethod, none, codes.Unauthenticated, ""},
+		{"token of another secret", testMethod, otherSecret, codes.Unauthenticated, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, err := testPolicy.authorize(withToken(tc.token), tc.method, testSecret)
+			if code := status.Code(err); code != tc.wantCode {
+				t.Fatalf("authorize error = %v, want %s", err, tc.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			claims, ok := ClaimsFromContext(ctx)
+			if tc.wantRole == "" {
+				if ok {
+					t.Errorf("context carries claims of role %q, want none", claims.Role())
+				}
+			} else if !ok || claims.Role() != tc.wantRole {
+				t.Errorf("context claims = %v, %v, want role %q", claims, ok, tc.wantRole)
+			}
+		})
+	}
+
+	// Expiry is reported as such
+	_, err = testPolicy.authorize(withToken(expired), testMethod, testSecret)
+	if got := status.Convert(err).Message(); got != "access token expired" {
+		t.Errorf("expired token message = %q", got)
+	}
+	// Without a secret no token is valid
+	if _, err := testPolicy.authorize(withToken(admin), testMethod, ""); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("authorize without secret = %v, want Unauthenticated", err)
+	}
+}
+
+// contextStream is a server stream that only carries a context.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context { return s.ctx }
+
+func TestAuthStreamServerInterceptor(t *testing.T) {
+	interceptor := AuthStreamServerInterceptor(testSecret, testPolicy)
+	info := &grpc.StreamServerInfo{FullMethod: testAdminMethod, IsServerStream: true}
+	var role string
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		if claims, ok := ClaimsFromContext(stream.Context()); ok {
+			role = claims.Role()
+		}
+		return nil
+	}
+
+	manager := signToken(t, jwt.SigningMethodHS256, testSecret, "manager", time.Hour)
+	if err := interceptor(nil, &contextStream{ctx: withToken(manager)}, info, handler); err != nil {
+		t.Fatalf("stream of an allowed role failed: %v", err)
+	}
+	if role != "manager" {
+		t.Errorf("handler saw role %q, want manager", role)
+	}
+
+	officer := signToken(t, jwt.SigningMethodHS256, testSecret, "officer", time.Hour)
+	err := interceptor(nil, &contextStream{ctx: withToken(officer)}, info, func(interface{}, grpc.ServerStream) error {
+		t.Error("handler ran for a denied role")
+		return nil
+	})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("stream of a wrong role = %v, want PermissionDenied", err)
+	}
+}
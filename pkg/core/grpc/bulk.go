@@ -0,0 +1,35 @@
+package grpc
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc/status"
+
+	"golang-microservices-boilerplate/pkg/core/usecase"
+	corepb "golang-microservices-boilerplate/proto/core"
+)
+
+// BulkItemResultToProto converts the outcome of one bulk item into
+// core.BulkItemResult. A failed item gets the code (as its google.rpc.Code
+// name), message and reason the error would have been returned with by
+// ErrorToStatus.
+func BulkItemResultToProto(index int, id uuid.UUID, err error) *corepb.BulkItemResult {
+	result := &corepb.BulkItemResult{Index: int32(index), Code: code.Code_OK.String()}
+	if id != uuid.Nil {
+		result.Id = id.String()
+	}
+	if err == nil {
+		return result
+	}
+
+	st := status.Convert(ErrorToStatus(err))
+	result.Code = code.Code(st.Code()).String()
+	result.Message = st.Message()
+	var ucErr *usecase.UseCaseError
+	if errors.As(err, &ucErr) {
+		result.Reason = ucErr.ReasonCode()
+	}
+	return result
+}
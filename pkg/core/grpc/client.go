@@ -19,7 +19,12 @@ type GrpcClientConfig struct {
 	DialTimeout            time.Duration
 	KeepAlive              time.Duration
 	KeepAliveTimeout       time.Duration
-	AllowInsecureTransport bool // Should be false in production
+	AllowInsecureTransport bool       // Plaintext when TLS is nil; should be false in production
+	TLS                    *TLSConfig // Dials with TLS, presenting CertFile for mutual TLS when set
+
+	// Resilience bounds, retries and sheds the unary calls of the client (see
+	// ResilienceUnaryClientInterceptor); nil makes every call a single attempt.
+	Resilience *ResilienceConfig
 }
 
 // DefaultGrpcClientConfig provides sensible defaults for gRPC client configuration
@@ -32,6 +37,8 @@ func DefaultGrpcClientConfig(serviceName string, serviceHost string, servicePort
 		KeepAlive:              30 * time.Second,
 		KeepAliveTimeout:       10 * time.Second,
 		AllowInsecureTransport: true, // Defaulting to true for easier local dev/testing
+		TLS:                    TLSConfigFromEnv(),
+		Resilience:             DefaultResilienceConfig(),
 	}
 }
 
@@ -44,33 +51,53 @@ type BaseGrpcClient struct {
 
 // NewBaseGrpcClient creates a new gRPC client connection
 func NewBaseGrpcClient(logger logger.Logger, config *GrpcClientConfig) (*BaseGrpcClient, error) {
+	// ServicePort may be left 0 when ServiceHost is a host:port address
+	addr := config.ServiceHost
+	if config.ServicePort != 0 {
+		addr = fmt.Sprintf("%s:%d", config.ServiceHost, config.ServicePort)
+	}
+
 	dialOptions := []grpc.DialOption{
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
 			Time:                config.KeepAlive,
 			Timeout:             config.KeepAliveTimeout,
 			PermitWithoutStream: true,
 		}),
-		// Add interceptors if needed (e.g., logging, tracing)
-		// grpc.WithUnaryInterceptor(...),
+		// Calls carry the request ID and credentials of the call being served
+		grpc.WithChainUnaryInterceptor(
+			RequestContextUnaryClientInterceptor(),
+			TracingUnaryClientInterceptor(),
+			ObservabilityUnaryClientInterceptor(logger.Named("grpc.client")),
+		),
+		grpc.WithChainStreamInterceptor(
+			RequestContextStreamClientInterceptor(),
+			TracingStreamClientInterceptor(),
+			ObservabilityStreamClientInterceptor(logger.Named("grpc.client")),
+		),
+	}
+	// Attempts are made inside the observed call, which logs the final outcome
+	if config.Resilience != nil {
+		dialOptions = append(dialOptions, grpc.WithChainUnaryInterceptor(
+			ResilienceUnaryClientInterceptor(addr, config.Resilience, logger.Named("grpc.resilience")),
+		))
 	}
 
-	// Handle transport security
-	if config.AllowInsecureTransport {
+	// Handle transport security; TLS wins over plaintext when configured
+	switch {
+	case config.TLS != nil:
+		creds, err := NewClientCredentials(config.TLS, logger.Named("grpc.tls"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS credentials for %s: %w", config.ServiceName, err)
+		}
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(creds))
+	case config.AllowInsecureTransport:
 		dialOptions = append(dialOptions, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	} else {
-		// TODO: Implement secure credentials loading for production
-		// For example, using TLS:
-		// creds, err := credentials.NewClientTLSFromFile("path/to/ca.crt", "service.domain.com")
-		// if err != nil {
-		// 	return nil, fmt.Errorf("failed to load TLS credentials: %w", err)
-		// }
-		// dialOptions = append(dialOptions, grpc.WithTransportCredentials(creds))
-		return nil, fmt.Errorf("secure transport requested but not implemented (AllowInsecureTransport=false)")
+	default:
+		return nil, fmt.Errorf("secure transport requested but no TLS configuration given for %s", config.ServiceName)
 	}
 
 	// Connect to the server
-	addr := fmt.Sprintf("%s:%d", config.ServiceHost, config.ServicePort)
-	logger.Info("Connecting to gRPC service", "service", config.ServiceName, "address", addr)
+	logger.Info("Connecting to gRPC service", "service", config.ServiceName, "address", addr, "tls", config.TLS != nil)
 
 	// Dial the server (Note: Context with timeout is often used with grpc.WithBlock())
 	// ctx, cancel := context.WithTimeout(context.Background(), config.DialTimeout)
@@ -0,0 +1,128 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"golang-microservices-boilerplate/pkg/core/usecase"
+)
+
+// ErrorDomain is the google.rpc.ErrorInfo domain attached to errors returned by the services.
+var ErrorDomain = "golang-microservices-boilerplate"
+
+// useCaseErrorCodes maps use case error types to gRPC status codes.
+var useCaseErrorCodes = map[usecase.UseCaseErrorType]codes.Code{
+	usecase.ErrNotFound:      codes.NotFound,
+	usecase.ErrInvalidInput:  codes.InvalidArgument,
+	usecase.ErrUnauthorized:  codes.Unauthenticated,
+	usecase.ErrForbidden:     codes.PermissionDenied,
+	usecase.ErrConflict:      codes.Aborted, // Stale version or conflicting state; client should reload and retry
+	usecase.ErrAlreadyExists: codes.AlreadyExists,
+	usecase.ErrInternal:      codes.Internal,
+}
+
+// ErrorToStatus converts an error returned by a use case into a gRPC status error.
+//
+// *usecase.UseCaseError values keep their message and carry a google.rpc.ErrorInfo
+// detail (reason, domain and metadata) plus a google.rpc.BadRequest detail when
+// field violations are present. Errors that already are gRPC statuses are returned
+// unchanged, context errors map to DeadlineExceeded/Canceled and anything else
+// becomes Internal with a fixed message: the cause is logged by the server
+// observability interceptor, never sent to the client.
+func ErrorToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var ucErr *usecase.UseCaseError
+	if errors.As(err, &ucErr) {
+		code, ok := useCaseErrorCodes[ucErr.Type]
+		if !ok {
+			return &internalError{cause: err}
+		}
+		return withDetails(status.New(code, ucErr.Message), ucErr).Err()
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	if errors.Is(err, context.Canceled) {
+		return status.Error(codes.Canceled, err.Error())
+	}
+	return &internalError{cause: err}
+}
+
+// internalMessage is all a client learns of an unexpected error.
+const internalMessage = "an unexpected error occurred"
+
+// internalError is an unexpected error on its way to the client. It converts
+// to an Internal status with a fixed message and, once
+// ObservabilityUnaryServerInterceptor has set it, the request ID in the
+// ErrorInfo metadata, so the logged cause can be found from what the client
+// reports.
+type internalError struct {
+	cause     error
+	requestID string
+}
+
+func (e *internalError) Error() string { return internalMessage }
+
+func (e *internalError) Unwrap() error { return e.cause }
+
+// GRPCStatus is used by the gRPC server (through status.FromError) to send the error.
+func (e *internalError) GRPCStatus() *status.Status {
+	info := &errdetails.ErrorInfo{
+		Reason: strings.ToUpper(string(usecase.ErrInternal)),
+		Domain: ErrorDomain,
+	}
+	if e.requestID != "" {
+		info.Metadata = map[string]string{"request_id": e.requestID}
+	}
+	st := status.New(codes.Internal, internalMessage)
+	if detailed, err := st.WithDetails(info); err == nil {
+		return detailed
+	}
+	return st
+}
+
+// withRequestID sets requestID on err if it is an unexpected error.
+func withRequestID(err error, requestID string) error {
+	var internal *internalError
+	if requestID == "" || !errors.As(err, &internal) {
+		return err
+	}
+	return &internalError{cause: internal.cause, requestID: requestID}
+}
+
+// withDetails attaches ErrorInfo and BadRequest details describing ucErr to st.
+func withDetails(st *status.Status, ucErr *usecase.UseCaseError) *status.Status {
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason:   ucErr.ReasonCode(),
+		Domain:   ErrorDomain,
+		Metadata: ucErr.Metadata,
+	}}
+	if len(ucErr.Violations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range ucErr.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st // Details are best effort; the code and message are what matter
+	}
+	return detailed
+}
@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/requestctx"
+	"golang-microservices-boilerplate/pkg/core/usecase"
+)
+
+// errorInfo returns the ErrorInfo detail of err, if any.
+func errorInfo(err error) *errdetails.ErrorInfo {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	return nil
+}
+
+func TestErrorToStatus(t *testing.T) {
+	cause := errors.New(`ERROR: relation "patients" does not exist (SQLSTATE 42P01)`)
+	cases := []struct {
+		name       string
+		err        error
+		wantCode   codes.Code
+		wantMsg    string
+		wantReason string
+	}{
+		{"use case error", usecase.NewUseCaseError(usecase.ErrNotFound, "patient not found"), codes.NotFound, "patient not found", "NOT_FOUND"},
+		{"status", status.Error(codes.Unavailable, "down"), codes.Unavailable, "down", ""},
+		{"deadline", context.DeadlineExceeded, codes.DeadlineExceeded, context.DeadlineExceeded.Error(), ""},
+		{"unexpected", cause, codes.Internal, internalMessage, "INTERNAL_ERROR"},
+		{"unknown use case type", usecase.NewUseCaseError("teapot", cause.Error()), codes.Internal, internalMessage, "INTERNAL_ERROR"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ErrorToStatus(tc.err)
+			st := status.Convert(err)
+			if st.Code() != tc.wantCode || st.Message() != tc.wantMsg {
+				t.Errorf("status = %s %q, want %s %q", st.Code(), st.Message(), tc.wantCode, tc.wantMsg)
+			}
+			var reason string
+			if info := errorInfo(err); info != nil {
+				reason = info.Reason
+			}
+			if reason != tc.wantReason {
+				t.Errorf("ErrorInfo reason = %q, want %q", reason, tc.wantReason)
+			}
+			if strings.Contains(err.Error(), "SQLSTATE") {
+				t.Errorf("error %q leaks its cause", err)
+			}
+		})
+	}
+}
+
+func TestObservabilityHidesUnexpectedErrors(t *testing.T) {
+	cause := errors.New("dial tcp 10.0.0.3:5432: connection refused")
+	log := &recordingLogger{}
+	interceptor := ObservabilityUnaryServerInterceptor(log)
+	ctx := requestctx.WithRequestID(context.Background(), "req-42")
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, ErrorToStatus(cause) }
+
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: testMethod}, handler)
+	st := status.Convert(err)
+	if st.Code() != codes.Internal || st.Message() != internalMessage {
+		t.Errorf("status = %s %q, want Internal %q", st.Code(), st.Message(), internalMessage)
+	}
+	if info := errorInfo(err); info == nil || info.Metadata["request_id"] != "req-42" {
+		t.Errorf("ErrorInfo = %v, want the request ID in its metadata", info)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("cause not kept for server-side handling")
+	}
+	if got := log.field("cause"); got != cause.Error() {
+		t.Errorf("logged cause = %v, want %q", got, cause)
+	}
+}
+
+// recordingLogger keeps the fields of the lines logged through it.
+type recordingLogger struct {
+	nopLogger
+	fields []interface{}
+}
+
+func (l *recordingLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.fields = append(l.fields, keysAndValues...)
+}
+
+func (l *recordingLogger) WithContext(context.Context) logger.Logger { return l }
+
+// field returns the last value logged for key.
+func (l *recordingLogger) field(key string) interface{} {
+	var value interface{}
+	for i := 0; i+1 < len(l.fields); i += 2 {
+		if l.fields[i] == key {
+			value = l.fields[i+1]
+		}
+	}
+	return value
+}
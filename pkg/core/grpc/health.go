@@ -0,0 +1,190 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+)
+
+// HealthChecker reports whether a dependency of the server, such as its
+// database or a service it calls, is usable. DatabaseConnection.Ping and
+// ClientHealthCheck are HealthCheckers.
+type HealthChecker func(ctx context.Context) error
+
+// ClientHealthCheck checks a downstream gRPC server through its health
+// service; service is the name to check, "" for the server as a whole.
+func ClientHealthCheck(conn *grpc.ClientConn, service string) HealthChecker {
+	client := healthpb.NewHealthClient(conn)
+	return func(ctx context.Context) error {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			return err
+		}
+		if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("%s is %s", conn.Target(), resp.GetStatus())
+		}
+		return nil
+	}
+}
+
+// healthChecks keeps the grpc.health.v1.Health status of a server up to date
+// with the result of its checkers.
+type healthChecks struct {
+	server   *health.Server
+	interval time.Duration
+	timeout  time.Duration
+	mu       sync.Mutex
+	checkers map[string]HealthChecker
+	optional map[string]HealthChecker // Failures degrade the server without failing it
+	serving  bool
+	degraded bool
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func newHealthChecks(interval, timeout time.Duration) *healthChecks {
+	return &healthChecks{
+		server:   health.NewServer(),
+		interval: interval,
+		timeout:  timeout,
+		checkers: make(map[string]HealthChecker),
+		optional: make(map[string]HealthChecker),
+		serving:  true,
+	}
+}
+
+// AddHealthCheck makes the health status of the server depend on check: while
+// it fails, the server and each of its services report NOT_SERVING. Add checks
+// before calling Start.
+func (s *BaseGrpcServer) AddHealthCheck(name string, check HealthChecker) {
+	s.health.mu.Lock()
+	defer s.health.mu.Unlock()
+	s.health.checkers[name] = check
+}
+
+// AddOptionalHealthCheck adds a check of a dependency the server can do
+// without, such as a service only some of its methods call: while it fails,
+// the server keeps reporting SERVING and logs that it is degraded. Add checks
+// before calling Start.
+func (s *BaseGrpcServer) AddOptionalHealthCheck(name string, check HealthChecker) {
+	s.health.mu.Lock()
+	defer s.health.mu.Unlock()
+	s.health.optional[name] = check
+}
+
+// start runs the checks now and then every interval until shutdown, setting
+// the status of the server ("") and of the given services.
+func (h *healthChecks) start(services []string, logger logger.Logger) {
+	h.stop = make(chan struct{})
+	h.done = make(chan struct{})
+	update := func() {
+		failures, degradations := h.check()
+		serving := len(failures) == 0
+		degraded := len(degradations) > 0
+		status := healthpb.HealthCheckResponse_SERVING
+		if !serving {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		h.server.SetServingStatus("", status)
+		for _, name := range services {
+			h.server.SetServingStatus(name, status)
+		}
+
+		h.mu.Lock()
+		changed := serving != h.serving
+		degradedChanged := degraded != h.degraded
+		h.serving, h.degraded = serving, degraded
+		h.mu.Unlock()
+		switch {
+		case !serving && changed:
+			logger.Warn("Health checks failing, reporting NOT_SERVING", "failures", strings.Join(failures, "; "))
+		case serving && changed:
+			logger.Info("Health checks passing again, reporting SERVING")
+		}
+		switch {
+		case degraded && degradedChanged:
+			logger.Warn("Optional health checks failing, serving degraded", "failures", strings.Join(degradations, "; "))
+		case !degraded && degradedChanged:
+			logger.Info("Optional health checks passing again")
+		}
+	}
+
+	update()
+	go func() {
+		defer close(h.done)
+		ticker := time.NewTicker(h.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-h.stop:
+				return
+			case <-ticker.C:
+				update()
+			}
+		}
+	}()
+}
+
+// check runs every checker concurrently, each within the timeout, and returns
+// the failures of the required and of the optional checks as
+// "<name>: <error>", sorted by name.
+func (h *healthChecks) check() (failures, degradations []string) {
+	h.mu.Lock()
+	checkers := make(map[string]HealthChecker, len(h.checkers)+len(h.optional))
+	optional := make(map[string]bool, len(h.optional))
+	for name, check := range h.checkers {
+		checkers[name] = check
+	}
+	for name, check := range h.optional {
+		checkers[name] = check
+		optional[name] = true
+	}
+	h.mu.Unlock()
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for name, check := range checkers {
+		wg.Add(1)
+		go func(name string, check HealthChecker) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+			defer cancel()
+			if err := check(ctx); err != nil {
+				failure := fmt.Sprintf("%s: %v", name, err)
+				mu.Lock()
+				if optional[name] {
+					degradations = append(degradations, failure)
+				} else {
+					failures = append(failures, failure)
+				}
+				mu.Unlock()
+			}
+		}(name, check)
+	}
+	wg.Wait()
+	sort.Strings(failures)
+	sort.Strings(degradations)
+	return failures, degradations
+}
+
+// shutdown stops the checks and reports NOT_SERVING for good, so clients and
+// probes move away while the server drains.
+func (h *healthChecks) shutdown() {
+	if h.stop != nil {
+		close(h.stop)
+		<-h.done
+		h.stop = nil
+	}
+	h.server.Shutdown()
+}
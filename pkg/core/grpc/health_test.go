@@ -0,0 +1,50 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealthChecksOptional(t *testing.T) {
+	h := newHealthChecks(time.Hour, time.Second)
+	var staffDown, dbDown bool
+	failing := func(down *bool) HealthChecker {
+		return func(ctx context.Context) error {
+			if *down {
+				return errors.New("unreachable")
+			}
+			return nil
+		}
+	}
+	h.checkers["database"] = failing(&dbDown)
+	h.optional["staff-service"] = failing(&staffDown)
+	status := func() healthpb.HealthCheckResponse_ServingStatus {
+		t.Helper()
+		resp, err := h.server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "test.Service"})
+		if err != nil {
+			t.Fatalf("Check failed: %v", err)
+		}
+		return resp.GetStatus()
+	}
+
+	staffDown = true
+	h.start([]string{"test.Service"}, nopLogger{})
+	defer h.shutdown()
+	if got := status(); got != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("status with a failing optional check = %s, want SERVING", got)
+	}
+	failures, degradations := h.check()
+	if len(failures) != 0 || len(degradations) != 1 || degradations[0] != "staff-service: unreachable" {
+		t.Errorf("check() = %q, %q, want only the optional failure", failures, degradations)
+	}
+
+	dbDown = true
+	failures, _ = h.check()
+	if len(failures) != 1 || failures[0] != "database: unreachable" {
+		t.Errorf("check() failures = %q, want the database", failures)
+	}
+}
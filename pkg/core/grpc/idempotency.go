@@ -0,0 +1,152 @@
+package grpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"golang-microservices-boilerplate/pkg/core/idempotency"
+	"golang-microservices-boilerplate/pkg/core/requestctx"
+	"golang-microservices-boilerplate/pkg/core/usecase"
+)
+
+// IdempotencyKeyHeader is the metadata key carrying the idempotency key. The API
+// gateway forwards the Idempotency-Key HTTP header under this key; the
+// x-idempotency-key spelling is accepted as well.
+const IdempotencyKeyHeader = "idempotency-key"
+
+// IdempotencyReplayedHeader is set to "true" on responses replayed for a repeated key.
+const IdempotencyReplayedHeader = "idempotency-replayed"
+
+// ReasonIdempotencyKeyReused is the ErrorInfo reason of requests reusing a key
+// with a different payload.
+const ReasonIdempotencyKeyReused = "IDEMPOTENCY_KEY_REUSED"
+
+// ReasonIdempotencyKeyInProgress is the ErrorInfo reason of requests repeating
+// a key whose first request has not finished yet.
+const ReasonIdempotencyKeyInProgress = "IDEMPOTENCY_KEY_IN_PROGRESS"
+
+// IdempotencyStore runs a request at most once per key and returns the stored
+// response of repeats; *idempotency.Store implements it (see its Do method).
+type IdempotencyStore interface {
+	Do(ctx context.Context, key idempotency.Key, requestHash string, fn func(ctx context.Context) ([]byte, error)) (response []byte, replayed bool, err error)
+}
+
+// IdempotencyUnaryServerInterceptor makes the given methods idempotent for
+// calls carrying an idempotency key: the first call runs and its response is
+// stored, repeated calls with the same key and request get that response back
+// without running the handler again. Reusing a key with a different request
+// fails with InvalidArgument, repeating it while the first call still runs
+// with Aborted. Failed calls are not stored, so they can be retried with the
+// same key. Calls without a key and other methods pass through.
+func IdempotencyUnaryServerInterceptor(store IdempotencyStore, methods ...string) grpc.UnaryServerInterceptor {
+	enabled := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		enabled[m] = true
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !enabled[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		key := firstMetadataValue(md, IdempotencyKeyHeader)
+		if key == "" {
+			key = firstMetadataValue(md, "x-"+IdempotencyKeyHeader)
+		}
+		msg, ok := req.(proto.Message)
+		if key == "" || !ok {
+			return handler(ctx, req)
+		}
+		if len(key) > idempotency.MaxKeyLength {
+			return nil, status.Errorf(codes.InvalidArgument, "idempotency key must be at most %d characters", idempotency.MaxKeyLength)
+		}
+
+		hash, err := requestHash(msg)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to hash request: %v", err)
+		}
+
+		var resp interface{}
+		stored, replayed, err := store.Do(ctx, idempotency.Key{Method: info.FullMethod, Actor: requestctx.Actor(ctx), Key: key}, hash,
+			func(ctx context.Context) ([]byte, error) {
+				out, err := handler(ctx, req)
+				if err != nil {
+					return nil, err
+				}
+				resp = out
+				return marshalResponse(out)
+			})
+		if errors.Is(err, idempotency.ErrKeyReused) {
+			return nil, ErrorToStatus(&usecase.UseCaseError{
+				Type:     usecase.ErrInvalidInput,
+				Message:  err.Error(),
+				Reason:   ReasonIdempotencyKeyReused,
+				Metadata: map[string]string{"idempotency_key": key},
+			})
+		}
+		if errors.Is(err, idempotency.ErrInProgress) {
+			return nil, ErrorToStatus(&usecase.UseCaseError{
+				Type:     usecase.ErrConflict,
+				Message:  err.Error(),
+				Reason:   ReasonIdempotencyKeyInProgress,
+				Metadata: map[string]string{"idempotency_key": key},
+			})
+		}
+		if err != nil {
+			return nil, ErrorToStatus(err)
+		}
+		if !replayed {
+			return resp, nil
+		}
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs(IdempotencyReplayedHeader, "true"))
+		return unmarshalResponse(stored)
+	}
+}
+
+// requestHash fingerprints a request to detect keys reused for other payloads.
+func requestHash(req proto.Message) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// marshalResponse encodes a response as a google.protobuf.Any, so it can be
+// decoded without knowing its type.
+func marshalResponse(resp interface{}) ([]byte, error) {
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("response of type %T is not a proto message", resp)
+	}
+	wrapped, err := anypb.New(msg)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(wrapped)
+}
+
+// unmarshalResponse decodes a response stored by marshalResponse.
+func unmarshalResponse(b []byte) (interface{}, error) {
+	var wrapped anypb.Any
+	if err := proto.Unmarshal(b, &wrapped); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode stored response: %v", err)
+	}
+	msg, err := wrapped.UnmarshalNew()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to decode stored response: %v", err)
+	}
+	return msg, nil
+}
@@ -0,0 +1,176 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"golang-microservices-boilerplate/pkg/core/idempotency"
+)
+
+var _ IdempotencyStore = (*idempotency.Store)(nil)
+
+// memoryIdempotencyStore follows the contract of idempotency.Store.Do in memory;
+// the store itself is tested against Postgres in pkg/core/idempotency.
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[idempotency.Key]*memoryIdempotencyRecord
+}
+
+type memoryIdempotencyRecord struct {
+	requestHash string
+	response    []byte // Nil while in progress
+}
+
+func (s *memoryIdempotencyStore) Do(ctx context.Context, key idempotency.Key, requestHash string, fn func(ctx context.Context) ([]byte, error)) ([]byte, bool, error) {
+	s.mu.Lock()
+	if record, ok := s.records[key]; ok {
+		s.mu.Unlock()
+		switch {
+		case record.requestHash != requestHash:
+			return nil, false, idempotency.ErrKeyReused
+		case record.response == nil:
+			return nil, false, idempotency.ErrInProgress
+		}
+		return record.response, true, nil
+	}
+	record := &memoryIdempotencyRecord{requestHash: requestHash}
+	s.records[key] = record
+	s.mu.Unlock()
+
+	response, err := fn(ctx)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		delete(s.records, key)
+		return nil, false, err
+	}
+	record.response = response
+	return response, false, nil
+}
+
+// headerRecorder is the server transport stream of a test call; it keeps the
+// headers set by the handler.
+type headerRecorder struct {
+	header metadata.MD
+}
+
+func (r *headerRecorder) Method() string { return testMethod }
+
+func (r *headerRecorder) SetHeader(md metadata.MD) error {
+	r.header = metadata.Join(r.header, md)
+	return nil
+}
+
+func (r *headerRecorder) SendHeader(md metadata.MD) error { return r.SetHeader(md) }
+
+func (r *headerRecorder) SetTrailer(metadata.MD) error { return nil }
+
+// idempotentCall returns the context of a call with the given idempotency key
+// and the recorder of its response headers.
+func idempotentCall(key string) (context.Context, *headerRecorder) {
+	recorder := &headerRecorder{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), recorder)
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(IdempotencyKeyHeader, key)), recorder
+}
+
+func TestIdempotencyInterceptor(t *testing.T) {
+	interceptor := IdempotencyUnaryServerInterceptor(&memoryIdempotencyStore{records: map[idempotency.Key]*memoryIdempotencyRecord{}}, testMethod)
+	info := &grpc.UnaryServerInfo{FullMethod: testMethod}
+	var runs int
+	fail := errors.New("handler failed")
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		runs++
+		if req.(*healthpb.HealthCheckRequest).Service == "fail" {
+			return nil, fail
+		}
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+	}
+	call := func(key, service string) (*healthpb.HealthCheckResponse, *headerRecorder, error) {
+		t.Helper()
+		ctx, recorder := idempotentCall(key)
+		resp, err := interceptor(ctx, &healthpb.HealthCheckRequest{Service: service}, info, handler)
+		if err != nil {
+			return nil, recorder, err
+		}
+		return resp.(*healthpb.HealthCheckResponse), recorder, nil
+	}
+
+	// The first call runs; its repeat gets the stored response back
+	if _, recorder, err := call("k1", "a"); err != nil || runs != 1 || recorder.header.Get(IdempotencyReplayedHeader) != nil {
+		t.Fatalf("first call: error %v, %d runs, header %v", err, runs, recorder.header)
+	}
+	resp, recorder, err := call("k1", "a")
+	if err != nil || runs != 1 {
+		t.Fatalf("repeated call: error %v, %d runs, want the stored response", err, runs)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("replayed status = %s, want SERVING", resp.Status)
+	}
+	if got := recorder.header.Get(IdempotencyReplayedHeader); len(got) != 1 || got[0] != "true" {
+		t.Errorf("%s header = %v, want true", IdempotencyReplayedHeader, got)
+	}
+
+	// A reused key with a different payload
+	_, _, err = call("k1", "b")
+	if status.Code(err) != codes.InvalidArgument || errorInfo(err).GetReason() != ReasonIdempotencyKeyReused {
+		t.Errorf("reused key: error = %v, want InvalidArgument %s", err, ReasonIdempotencyKeyReused)
+	}
+
+	// A failed call is not stored, so its key can be retried
+	if _, _, err := call("k2", "fail"); !errors.Is(err, fail) {
+		t.Errorf("failed call: error = %v", err)
+	}
+	if _, _, err := call("k2", "fail"); runs != 3 || err == nil {
+		t.Errorf("retried failed call: error %v after %d runs, want it run again", err, runs)
+	}
+
+	// Calls without a key always run
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), &headerRecorder{})
+	for i := 0; i < 2; i++ {
+		if _, err := interceptor(ctx, &healthpb.HealthCheckRequest{Service: "a"}, info, handler); err != nil {
+			t.Fatalf("call without key failed: %v", err)
+		}
+	}
+	if runs != 5 {
+		t.Errorf("runs = %d after two calls without key, want 5", runs)
+	}
+}
+
+func TestIdempotencyInterceptorInProgress(t *testing.T) {
+	interceptor := IdempotencyUnaryServerInterceptor(&memoryIdempotencyStore{records: map[idempotency.Key]*memoryIdempotencyRecord{}}, testMethod)
+	info := &grpc.UnaryServerInfo{FullMethod: testMethod}
+	started, release := make(chan struct{}), make(chan struct{})
+	slow := func(ctx context.Context, req interface{}) (interface{}, error) {
+		close(started)
+		<-release
+		return &healthpb.HealthCheckResponse{}, nil
+	}
+	done := make(chan error)
+	go func() {
+		ctx, _ := idempotentCall("k1")
+		_, err := interceptor(ctx, &healthpb.HealthCheckRequest{}, info, slow)
+		done <- err
+	}()
+	<-started
+
+	ctx, _ := idempotentCall("k1")
+	_, err := interceptor(ctx, &healthpb.HealthCheckRequest{}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		t.Error("handler ran while the key was in progress")
+		return &healthpb.HealthCheckResponse{}, nil
+	})
+	if status.Code(err) != codes.Aborted || errorInfo(err).GetReason() != ReasonIdempotencyKeyInProgress {
+		t.Errorf("key in progress: error = %v, want Aborted %s", err, ReasonIdempotencyKeyInProgress)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("first call failed: %v", err)
+	}
+}
@@ -0,0 +1,209 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/metrics"
+	"golang-microservices-boilerplate/pkg/core/requestctx"
+)
+
+// ObservabilityUnaryServerInterceptor writes one access log line per call,
+// with the method, status code, latency, peer and request ID, and records the
+// call in the grpc_server_* metrics. Health checks are counted but not logged.
+// The cause of an unexpected error (see ErrorToStatus) is logged, and the
+// request ID added to the error the client gets.
+func ObservabilityUnaryServerInterceptor(log logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		requestID := requestctx.RequestID(ctx)
+		observeCall(ctx, log, serverSide, info.FullMethod, "unary", start, peerAddress(ctx), requestID, err)
+		return resp, withRequestID(err, requestID)
+	}
+}
+
+// ObservabilityStreamServerInterceptor is the streaming counterpart of
+// ObservabilityUnaryServerInterceptor; a stream is observed when it ends.
+func ObservabilityStreamServerInterceptor(log logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		ctx := stream.Context()
+		requestID := requestctx.RequestID(ctx)
+		observeCall(ctx, log, serverSide, info.FullMethod, streamType(info.IsClientStream, info.IsServerStream), start, peerAddress(ctx), requestID, err)
+		return withRequestID(err, requestID)
+	}
+}
+
+// ObservabilityUnaryClientInterceptor logs and records (grpc_client_* metrics)
+// the calls a client makes, like ObservabilityUnaryServerInterceptor does for
+// servers. The request ID is the forwarded x-request-id metadata, if any.
+func ObservabilityUnaryClientInterceptor(log logger.Logger) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var p peer.Peer
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Peer(&p))...)
+		observeCall(ctx, log, clientSide, method, "unary", start, addressOf(&p, cc.Target()), outgoingRequestID(ctx), err)
+		return err
+	}
+}
+
+// ObservabilityStreamClientInterceptor is the streaming counterpart of
+// ObservabilityUnaryClientInterceptor. A stream is observed once RecvMsg
+// reports its end, so callers must read streams to completion.
+func ObservabilityStreamClientInterceptor(log logger.Logger) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		p := &peer.Peer{}
+		start := time.Now()
+		typ := streamType(desc.ClientStreams, desc.ServerStreams)
+		stream, err := streamer(ctx, desc, cc, method, append(opts, grpc.Peer(p))...)
+		if err != nil {
+			observeCall(ctx, log, clientSide, method, typ, start, addressOf(p, cc.Target()), outgoingRequestID(ctx), err)
+			return nil, err
+		}
+		return &observedClientStream{ClientStream: stream, done: func(err error) {
+			observeCall(ctx, log, clientSide, method, typ, start, addressOf(p, cc.Target()), outgoingRequestID(ctx), err)
+		}}, nil
+	}
+}
+
+// observedClientStream reports the end of a client stream once.
+type observedClientStream struct {
+	grpc.ClientStream
+	once sync.Once
+	done func(err error)
+}
+
+func (s *observedClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			s.once.Do(func() { s.done(nil) })
+		} else {
+			s.once.Do(func() { s.done(err) })
+		}
+	}
+	return err
+}
+
+// side tells server and client observations apart.
+type side bool
+
+const (
+	serverSide side = true
+	clientSide side = false
+)
+
+// observeCall records a finished call in the metrics of its side and logs it
+// with the trace and span IDs of ctx.
+func observeCall(ctx context.Context, log logger.Logger, s side, fullMethod, typ string, start time.Time, peerAddr, requestID string, err error) {
+	elapsed := time.Since(start)
+	code := status.Code(err)
+	service, method := splitFullMethod(fullMethod)
+	if s == serverSide {
+		metrics.GrpcServerHandled.WithLabelValues(service, method, typ, code.String()).Inc()
+		metrics.GrpcServerHandlingSeconds.WithLabelValues(service, method, typ).Observe(elapsed.Seconds())
+	} else {
+		metrics.GrpcClientHandled.WithLabelValues(service, method, typ, code.String()).Inc()
+		metrics.GrpcClientHandlingSeconds.WithLabelValues(service, method, typ).Observe(elapsed.Seconds())
+	}
+	if strings.HasPrefix(fullMethod, healthMethodPrefix) {
+		return
+	}
+
+	log = log.WithContext(ctx)
+	msg := "gRPC call handled"
+	if s == clientSide {
+		msg = "gRPC call made"
+	}
+	fields := []interface{}{
+		"grpc.method", fullMethod,
+		"grpc.type", typ,
+		"grpc.code", code.String(),
+		"latency_ms", float64(elapsed.Microseconds()) / 1000,
+		"peer", peerAddr,
+		"request_id", requestID,
+	}
+	var internal *internalError
+	if errors.As(err, &internal) {
+		fields = append(fields, "cause", internal.cause.Error())
+	}
+	switch {
+	case code == codes.OK:
+		log.Info(msg, fields...)
+	case isServerFault(code):
+		log.Error(msg, append(fields, "error", status.Convert(err).Message())...)
+	default:
+		log.Warn(msg, append(fields, "error", status.Convert(err).Message())...)
+	}
+}
+
+// isServerFault reports whether code means the server, not the caller, failed.
+func isServerFault(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unimplemented, codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// splitFullMethod splits "/package.Service/Method" into service and method.
+func splitFullMethod(fullMethod string) (string, string) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return "unknown", "unknown"
+	}
+	return service, method
+}
+
+// streamType names the kind of a call for the grpc_type label.
+func streamType(clientStream, serverStream bool) string {
+	switch {
+	case clientStream && serverStream:
+		return "bidi_stream"
+	case clientStream:
+		return "client_stream"
+	case serverStream:
+		return "server_stream"
+	}
+	return "unary"
+}
+
+// peerAddress returns the address of the caller of a server call.
+func peerAddress(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// addressOf returns the address of the server a client call reached, falling
+// back to the target of the connection when the call never got that far.
+func addressOf(p *peer.Peer, target string) string {
+	if p.Addr != nil {
+		return p.Addr.String()
+	}
+	return target
+}
+
+// outgoingRequestID returns the request ID a client call forwards, or the one
+// of the request being served.
+func outgoingRequestID(ctx context.Context) string {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if requestID := firstMetadataValue(md, RequestIDHeader); requestID != "" {
+		return requestID
+	}
+	return requestctx.RequestID(ctx)
+}
@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/core/types"
+	corepb "golang-microservices-boilerplate/proto/core"
+)
+
+// FilterOptionsFromProto converts the shared core.FilterOptions message into
+// types.FilterOptions. Fields left unset keep their types.DefaultFilterOptions value.
+func FilterOptionsFromProto(in *corepb.FilterOptions) types.FilterOptions {
+	opts := types.DefaultFilterOptions()
+	if in == nil {
+		return opts
+	}
+
+	if in.Limit != nil {
+		opts.Limit = int(*in.Limit)
+	}
+	if in.Offset != nil {
+		opts.Offset = int(*in.Offset)
+	}
+	if in.SortBy != nil {
+		opts.SortBy = *in.SortBy
+	}
+	if in.SortDesc != nil {
+		opts.SortDesc = *in.SortDesc
+	}
+	if in.IncludeDeleted != nil {
+		opts.IncludeDeleted = *in.IncludeDeleted
+	}
+	if in.Cursor != nil {
+		opts.Cursor = *in.Cursor
+	}
+	if in.SkipCount != nil {
+		opts.SkipCount = *in.SkipCount
+	}
+	// structpb.Value.AsInterface yields the float64/string/bool/nil, []interface{}
+	// and map[string]interface{} shapes that types.ParseFilters understands
+	for k, v := range in.Filters {
+		opts.Filters[k] = v.AsInterface()
+	}
+	return opts
+}
+
+// PaginationInfoToProto converts the paging metadata of a result into core.PaginationInfo.
+func PaginationInfoToProto[T entity.Entity](result *types.PaginationResult[T]) *corepb.PaginationInfo {
+	if result == nil {
+		return &corepb.PaginationInfo{}
+	}
+	return &corepb.PaginationInfo{
+		TotalItems: result.TotalItems,
+		Limit:      int32(result.Limit),
+		Offset:     int32(result.Offset),
+		NextCursor: result.NextCursor,
+	}
+}
+
+// SearchPaginationInfoToProto converts the paging metadata of a search result into core.PaginationInfo.
+func SearchPaginationInfoToProto[T entity.Entity](result *types.SearchResult[T]) *corepb.PaginationInfo {
+	if result == nil {
+		return &corepb.PaginationInfo{}
+	}
+	return &corepb.PaginationInfo{
+		TotalItems: result.TotalItems,
+		Limit:      int32(result.Limit),
+		Offset:     int32(result.Offset),
+	}
+}
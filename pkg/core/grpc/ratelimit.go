@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/metrics"
+	"golang-microservices-boilerplate/pkg/core/ratelimit"
+	"golang-microservices-boilerplate/pkg/core/requestctx"
+)
+
+// APIKeyHeader is the metadata key carrying the caller's API key. The API
+// gateway forwards the X-API-Key HTTP header under this key.
+const APIKeyHeader = "x-api-key"
+
+// ReasonRateLimited is the ErrorInfo reason of calls rejected by a rate limit.
+const ReasonRateLimited = "RATE_LIMITED"
+
+// RateLimitUnaryServerInterceptor rejects the calls exceeding the rule of
+// limiter matching their full method with ResourceExhausted, carrying a
+// google.rpc.RetryInfo detail saying when to retry. Callers are identified by
+// the verified actor (see RequestContextUnaryServerInterceptor), the
+// x-api-key metadata, or their IP: the peer address, or the x-forwarded-for
+// address set by the gateway when the peer is one of proxies. When the store
+// fails, calls are let through and the failure is logged.
+func RateLimitUnaryServerInterceptor(limiter *ratelimit.Limiter, proxies ratelimit.TrustedProxies, log logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := checkRateLimit(ctx, limiter, proxies, info.FullMethod, log); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// RateLimitStreamServerInterceptor is the streaming counterpart of
+// RateLimitUnaryServerInterceptor; a stream takes one token when it starts.
+func RateLimitStreamServerInterceptor(limiter *ratelimit.Limiter, proxies ratelimit.TrustedProxies, log logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkRateLimit(stream.Context(), limiter, proxies, info.FullMethod, log); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+// checkRateLimit takes a token for the caller of fullMethod and returns the
+// status error of a rejected call.
+func checkRateLimit(ctx context.Context, limiter *ratelimit.Limiter, proxies ratelimit.TrustedProxies, fullMethod string, log logger.Logger) error {
+	rule := limiter.Match("", fullMethod)
+	if rule == nil {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	caller := ratelimit.Caller(rule.Key, callerIP(ctx, md, proxies), requestctx.Actor(ctx), firstMetadataValue(md, APIKeyHeader))
+	result, err := limiter.Allow(ctx, rule, caller)
+	if err != nil {
+		log.WithContext(ctx).Error("Rate limit check failed, letting the call through", "grpc.method", fullMethod, "error", err)
+		return nil
+	}
+	if result.Allowed {
+		return nil
+	}
+	metrics.RateLimited.WithLabelValues("grpc", rule.Pattern).Inc()
+	return RateLimitStatus(rule, result).Err()
+}
+
+// RateLimitStatus returns the ResourceExhausted status of a request rejected
+// by rule, with ErrorInfo and RetryInfo details. The gateway maps it to 429
+// with a Retry-After header.
+func RateLimitStatus(rule *ratelimit.Rule, result ratelimit.Result) *status.Status {
+	wait := math.Ceil(result.RetryAfter.Seconds())
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("rate limit exceeded, retry in %.0fs", math.Max(wait, 1)))
+	detailed, err := st.WithDetails(
+		&errdetails.ErrorInfo{Reason: ReasonRateLimited, Domain: ErrorDomain, Metadata: map[string]string{"rule": rule.Pattern}},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(result.RetryAfter)},
+	)
+	if err != nil {
+		return st
+	}
+	return detailed
+}
+
+// callerIP returns the client address of a call: the peer's host, or the
+// x-forwarded-for address the gateway sets to its client when the peer is
+// one of proxies.
+func callerIP(ctx context.Context, md metadata.MD, proxies ratelimit.TrustedProxies) string {
+	addr := peerAddress(ctx)
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	return proxies.ClientIP(addr, strings.Join(md.Get("x-forwarded-for"), ","))
+}
@@ -0,0 +1,69 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"golang-microservices-boilerplate/pkg/core/ratelimit"
+)
+
+// callFrom returns the context of a call from peerIP with the given x-forwarded-for.
+func callFrom(peerIP, forwardedFor string) context.Context {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(peerIP), Port: 41000}})
+	if forwardedFor != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", forwardedFor))
+	}
+	return ctx
+}
+
+func TestRateLimitByCallerIP(t *testing.T) {
+	const method = "/userservice.UserService/Login"
+	proxies, err := ratelimit.ParseTrustedProxies([]string{"10.0.0.0/8"})
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies failed: %v", err)
+	}
+	cases := []struct {
+		name   string
+		first  context.Context
+		second context.Context
+		// Whether the second call, after the first took the only token of its
+		// bucket, has a bucket of its own
+		separate bool
+	}{
+		{"direct caller rotating x-forwarded-for", callFrom("203.0.113.9", "198.51.100.1"), callFrom("203.0.113.9", "198.51.100.2"), false},
+		{"direct callers", callFrom("203.0.113.9", ""), callFrom("203.0.113.10", ""), true},
+		{"gateway clients", callFrom("10.0.0.5", "198.51.100.1"), callFrom("10.0.0.5", "198.51.100.2"), true},
+		{"gateway client through replicas", callFrom("10.0.0.5", "198.51.100.1"), callFrom("10.0.0.6", "198.51.100.1"), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			limiter, err := ratelimit.NewLimiter(ratelimit.NewMemoryStore(ratelimit.Config{}),
+				ratelimit.Rule{Pattern: method, Key: ratelimit.KeyIP, Limit: ratelimit.Limit{Requests: 1, Period: time.Minute}})
+			if err != nil {
+				t.Fatalf("NewLimiter failed: %v", err)
+			}
+			interceptor := RateLimitUnaryServerInterceptor(limiter, proxies, nopLogger{})
+			info := &grpc.UnaryServerInfo{FullMethod: method}
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+			if _, err := interceptor(tc.first, nil, info, handler); err != nil {
+				t.Fatalf("first call failed: %v", err)
+			}
+			_, err = interceptor(tc.second, nil, info, handler)
+			if tc.separate && err != nil {
+				t.Errorf("second call failed: %v", err)
+			}
+			if !tc.separate && status.Code(err) != codes.ResourceExhausted {
+				t.Errorf("second call: error = %v, want ResourceExhausted", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"golang-microservices-boilerplate/pkg/core/requestctx"
+)
+
+// RequestIDHeader is the metadata key carrying the request ID. The API gateway
+// forwards the X-Request-ID HTTP header under this key.
+const RequestIDHeader = "x-request-id"
+
+// RequestContextUnaryServerInterceptor stores the request ID and the actor of
+// each call in its context (see package requestctx).
+//
+// The request ID is taken from the x-request-id metadata, or generated when
+// missing, and echoed back as a response header. The actor is the subject of an
+// HS256 bearer token signed with accessTokenSecret. Invalid or missing tokens
+// leave the actor empty rather than failing the call; an empty secret disables
+// actor extraction.
+func RequestContextUnaryServerInterceptor(accessTokenSecret string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, requestID := withRequestContext(ctx, accessTokenSecret)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID))
+		return handler(ctx, req)
+	}
+}
+
+// RequestContextStreamServerInterceptor is the streaming counterpart of
+// RequestContextUnaryServerInterceptor.
+func RequestContextStreamServerInterceptor(accessTokenSecret string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, requestID := withRequestContext(stream.Context(), accessTokenSecret)
+		_ = stream.SetHeader(metadata.Pairs(RequestIDHeader, requestID))
+		wrapped := grpc_middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = ctx
+		return handler(srv, wrapped)
+	}
+}
+
+// withRequestContext populates ctx from the incoming metadata and returns the request ID used.
+func withRequestContext(ctx context.Context, accessTokenSecret string) (context.Context, string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	requestID := firstMetadataValue(md, RequestIDHeader)
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	ctx = requestctx.WithRequestID(ctx, requestID)
+
+	if actor := BearerSubject(firstMetadataValue(md, "authorization"), accessTokenSecret); actor != "" {
+		ctx = requestctx.WithActor(ctx, actor)
+	}
+	return ctx, requestID
+}
+
+// BearerSubject returns the subject of a valid "Bearer <jwt>" authorization
+// value signed with secret, or "" when it is missing or invalid.
+func BearerSubject(authorization, secret string) string {
+	claims, err := parseAccessToken(authorization, secret)
+	if err != nil {
+		return ""
+	}
+	return claims.Subject
+}
+
+// firstMetadataValue returns the first value stored under key, or "".
+func firstMetadataValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// RequestContextUnaryClientInterceptor forwards the request ID and the
+// authorization of the call being served to the calls a service makes to
+// other services, so they are traced and authorized as the original caller.
+// Metadata the caller set on the outgoing context takes precedence.
+func RequestContextUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(withOutgoingRequestContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// RequestContextStreamClientInterceptor is the streaming counterpart of
+// RequestContextUnaryClientInterceptor.
+func RequestContextStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(withOutgoingRequestContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+// withOutgoingRequestContext adds the request ID and authorization of the
+// served call to the outgoing metadata of ctx, unless they are already set.
+func withOutgoingRequestContext(ctx context.Context) context.Context {
+	outgoing, _ := metadata.FromOutgoingContext(ctx)
+	if firstMetadataValue(outgoing, RequestIDHeader) == "" {
+		if requestID := requestctx.RequestID(ctx); requestID != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, RequestIDHeader, requestID)
+		}
+	}
+	if firstMetadataValue(outgoing, "authorization") == "" {
+		incoming, _ := metadata.FromIncomingContext(ctx)
+		if authorization := firstMetadataValue(incoming, "authorization"); authorization != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", authorization)
+		}
+	}
+	return ctx
+}
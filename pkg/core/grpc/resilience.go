@@ -0,0 +1,355 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/metrics"
+)
+
+// ResilienceConfig says how a client bounds, retries, hedges and sheds the
+// unary calls it makes (see ResilienceUnaryClientInterceptor).
+type ResilienceConfig struct {
+	// Timeout is the deadline of a call, retries and hedges included, unless
+	// the caller's context ends sooner. MethodTimeouts overrides it by full
+	// method name. Zero leaves calls without a deadline of their own.
+	Timeout        time.Duration
+	MethodTimeouts map[string]time.Duration
+
+	// IdempotentMethods are the full method names that are safe to send more
+	// than once, and so may be retried or hedged. Other methods are sent once.
+	IdempotentMethods []string
+
+	Retry   RetryPolicy
+	Hedge   HedgePolicy
+	Breaker BreakerPolicy
+}
+
+// RetryPolicy retries idempotent calls failing with a retryable code, waiting
+// an exponentially growing, jittered backoff between attempts. When the error
+// carries a google.rpc.RetryInfo detail, such as the rate limit errors of
+// RateLimitUnaryServerInterceptor, at least its delay is waited, and the call
+// is not retried when that delay would pass its deadline.
+type RetryPolicy struct {
+	MaxAttempts    int // Attempts including the first; 1 or less disables retries
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	RetryableCodes []codes.Code
+}
+
+// HedgePolicy sends another attempt of an idempotent call each time Delay
+// passes without a response, up to MaxAttempts in flight; the first final
+// response wins and the others are cancelled. It replaces retries for the
+// methods it applies to, as in gRPC's service config.
+type HedgePolicy struct {
+	MaxAttempts int           // Attempts including the first; 1 or less disables hedging
+	Delay       time.Duration // Wait before each additional attempt
+}
+
+// BreakerPolicy opens the circuit of a client after FailureThreshold
+// consecutive failures, failing calls fast with Unavailable. After OpenTimeout
+// the circuit is half-open: one probe call is let through, closing the circuit
+// when it succeeds and opening it again when it fails.
+type BreakerPolicy struct {
+	FailureThreshold int // Zero disables the breaker
+	OpenTimeout      time.Duration
+}
+
+// DefaultResilienceConfig bounds calls to 5 seconds, retries idempotent
+// methods up to 3 times on Unavailable and ResourceExhausted (honouring the
+// RetryInfo of rate limited calls), and opens the circuit after 5 consecutive
+// failures for 30 seconds. Hedging is off.
+func DefaultResilienceConfig() *ResilienceConfig {
+	return &ResilienceConfig{
+		Timeout: 5 * time.Second,
+		Retry: RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: 100 * time.Millisecond,
+			MaxBackoff:     2 * time.Second,
+			Multiplier:     2,
+			RetryableCodes: []codes.Code{codes.Unavailable, codes.ResourceExhausted},
+		},
+		Breaker: BreakerPolicy{
+			FailureThreshold: 5,
+			OpenTimeout:      30 * time.Second,
+		},
+	}
+}
+
+// ResilienceUnaryClientInterceptor applies config to the unary calls of a
+// client. Retries, hedges and circuit state changes are logged and counted in
+// the grpc_client_retries_total, grpc_client_hedges_total and
+// grpc_client_circuit_state metrics, labelled with target, the address dialed.
+// Install one interceptor per connection, since the breaker is per target.
+func ResilienceUnaryClientInterceptor(target string, config *ResilienceConfig, log logger.Logger) grpc.UnaryClientInterceptor {
+	idempotent := make(map[string]bool, len(config.IdempotentMethods))
+	for _, method := range config.IdempotentMethods {
+		idempotent[method] = true
+	}
+	retryable := make(map[codes.Code]bool, len(config.Retry.RetryableCodes))
+	for _, code := range config.Retry.RetryableCodes {
+		retryable[code] = true
+	}
+	b := newBreaker(target, config.Breaker, log)
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		timeout := config.Timeout
+		if t, ok := config.MethodTimeouts[method]; ok {
+			timeout = t
+		}
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		// attempt is one call guarded by the breaker
+		attempt := func(ctx context.Context, reply interface{}) error {
+			if !b.allow() {
+				return status.Errorf(codes.Unavailable, "circuit breaker open for %s", target)
+			}
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			b.record(ctx, err)
+			return err
+		}
+
+		if !idempotent[method] {
+			return attempt(ctx, reply)
+		}
+		if config.Hedge.MaxAttempts > 1 {
+			if msg, ok := reply.(proto.Message); ok {
+				return hedge(ctx, target, method, config.Hedge, msg, attempt, log)
+			}
+		}
+
+		backoff := config.Retry.InitialBackoff
+		for n := 1; ; n++ {
+			err := attempt(ctx, reply)
+			if err == nil || n >= config.Retry.MaxAttempts || !retryable[status.Code(err)] || b.isOpen() {
+				return err
+			}
+			wait := jitter(backoff)
+			if delay, ok := retryDelay(err); ok && delay > wait {
+				wait = delay // The server said when it will take the call again
+			}
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+				return err // No time left for another attempt
+			}
+			service, name := splitFullMethod(method)
+			metrics.GrpcClientRetries.WithLabelValues(target, service, name).Inc()
+			log.WithContext(ctx).Warn("Retrying gRPC call", "grpc.method", method, "target", target, "attempt", n+1, "backoff_ms", wait.Milliseconds(), "error", status.Convert(err).Message())
+
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return err
+			case <-timer.C:
+			}
+			backoff = time.Duration(float64(backoff) * config.Retry.Multiplier)
+			if config.Retry.MaxBackoff > 0 && backoff > config.Retry.MaxBackoff {
+				backoff = config.Retry.MaxBackoff
+			}
+		}
+	}
+}
+
+// hedge runs attempts of a call in parallel, staggered by policy.Delay, and
+// copies the first successful reply, or returns the last error once all
+// attempts failed.
+func hedge(ctx context.Context, target, method string, policy HedgePolicy, reply proto.Message, attempt func(context.Context, interface{}) error, log logger.Logger) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // Cancels the attempts still in flight
+
+	type result struct {
+		reply proto.Message
+		err   error
+	}
+	results := make(chan result, policy.MaxAttempts)
+	launch := func() {
+		r := reply.ProtoReflect().New().Interface()
+		go func() {
+			err := attempt(ctx, r)
+			results <- result{r, err}
+		}()
+	}
+
+	launch()
+	launched, pending := 1, 1
+	timer := time.NewTimer(policy.Delay)
+	defer timer.Stop()
+	var lastErr error
+	for pending > 0 {
+		select {
+		case r := <-results:
+			pending--
+			if r.err == nil {
+				proto.Merge(reply, r.reply)
+				return nil
+			}
+			lastErr = r.err
+			if code := status.Code(r.err); code != codes.Unavailable && code != codes.ResourceExhausted {
+				return r.err // A final answer, such as NotFound, is not hedged away
+			}
+			if _, throttled := retryDelay(r.err); throttled {
+				return r.err // Another attempt now would be throttled as well
+			}
+		case <-timer.C:
+			if launched < policy.MaxAttempts {
+				service, name := splitFullMethod(method)
+				metrics.GrpcClientHedges.WithLabelValues(target, service, name).Inc()
+				log.WithContext(ctx).Info("Hedging slow gRPC call", "grpc.method", method, "target", target, "attempt", launched+1)
+				launch()
+				launched++
+				pending++
+				timer.Reset(policy.Delay)
+			}
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+	return lastErr
+}
+
+// retryDelay returns the delay of the google.rpc.RetryInfo detail of err, if any.
+func retryDelay(err error) (time.Duration, bool) {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return info.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+// jitter spreads d over [0.8d, 1.2d) so that clients do not retry in lockstep.
+func jitter(d time.Duration) time.Duration {
+	return time.Duration(float64(d) * (0.8 + 0.4*rand.Float64()))
+}
+
+// Circuit states, as reported by the grpc_client_circuit_state gauge.
+const (
+	circuitClosed   = 0
+	circuitOpen     = 1
+	circuitHalfOpen = 2
+)
+
+var circuitStateNames = map[int]string{circuitClosed: "closed", circuitOpen: "open", circuitHalfOpen: "half-open"}
+
+// breaker is the circuit breaker of one client connection.
+type breaker struct {
+	target   string
+	policy   BreakerPolicy
+	log      logger.Logger
+	mu       sync.Mutex
+	state    int
+	failures int       // Consecutive failures while closed
+	openedAt time.Time // When the circuit last opened
+	probing  bool      // A half-open probe is in flight
+}
+
+func newBreaker(target string, policy BreakerPolicy, log logger.Logger) *breaker {
+	b := &breaker{target: target, policy: policy, log: log}
+	if policy.FailureThreshold > 0 {
+		metrics.GrpcClientCircuitState.WithLabelValues(target).Set(circuitClosed)
+	}
+	return b
+}
+
+// allow reports whether a call may be made, moving an open circuit whose
+// timeout passed to half-open and letting its single probe through.
+func (b *breaker) allow() bool {
+	if b.policy.FailureThreshold <= 0 {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case circuitOpen:
+		if time.Since(b.openedAt) < b.policy.OpenTimeout {
+			return false
+		}
+		b.transition(circuitHalfOpen)
+		b.probing = true
+		return true
+	case circuitHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	}
+	return true
+}
+
+// record updates the circuit with the outcome of an allowed call. Calls the
+// caller gave up on do not count against the server.
+func (b *breaker) record(ctx context.Context, err error) {
+	if b.policy.FailureThreshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if errors.Is(ctx.Err(), context.Canceled) {
+		b.probing = false // Tells nothing about the server; a later call probes again
+		return
+	}
+	failed := err != nil && isServerFault(status.Code(err))
+	switch b.state {
+	case circuitHalfOpen:
+		b.probing = false
+		if failed {
+			b.openedAt = time.Now()
+			b.transition(circuitOpen)
+		} else {
+			b.failures = 0
+			b.transition(circuitClosed)
+		}
+	case circuitClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.policy.FailureThreshold {
+			b.openedAt = time.Now()
+			b.transition(circuitOpen)
+		}
+	}
+}
+
+// isOpen reports whether calls are currently failed fast.
+func (b *breaker) isOpen() bool {
+	if b.policy.FailureThreshold <= 0 {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state == circuitOpen
+}
+
+// transition changes the state, logging and exporting it. Callers hold b.mu.
+func (b *breaker) transition(state int) {
+	if b.state == state {
+		return
+	}
+	from := circuitStateNames[b.state]
+	b.state = state
+	metrics.GrpcClientCircuitState.WithLabelValues(b.target).Set(float64(state))
+	fields := []interface{}{"target", b.target, "from", from, "to", circuitStateNames[state]}
+	if state == circuitOpen {
+		b.log.Warn("Circuit breaker opened", append(fields, "failures", b.failures, "open_for", b.policy.OpenTimeout.String())...)
+	} else {
+		b.log.Info("Circuit breaker state changed", fields...)
+	}
+}
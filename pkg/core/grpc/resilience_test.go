@@ -0,0 +1,231 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+)
+
+const testMethod = "/test.Service/Get"
+
+// nopLogger discards everything.
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{})                {}
+func (nopLogger) Info(string, ...interface{})                 {}
+func (nopLogger) Warn(string, ...interface{})                 {}
+func (nopLogger) Error(string, ...interface{})                {}
+func (nopLogger) Fatal(string, ...interface{})                {}
+func (l nopLogger) With(...interface{}) logger.Logger         { return l }
+func (l nopLogger) Named(string) logger.Logger                { return l }
+func (l nopLogger) WithContext(context.Context) logger.Logger { return l }
+
+// throttled is a rate limit error asking the client to come back after delay.
+func throttled(t *testing.T, delay time.Duration) error {
+	t.Helper()
+	st, err := status.New(codes.ResourceExhausted, "rate limit exceeded").WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+	if err != nil {
+		t.Fatalf("WithDetails failed: %v", err)
+	}
+	return st.Err()
+}
+
+// testConfig retries testMethod quickly and disables the breaker.
+func testConfig() *ResilienceConfig {
+	return &ResilienceConfig{
+		Timeout:           time.Second,
+		IdempotentMethods: []string{testMethod},
+		Retry: RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			MaxBackoff:     2 * time.Millisecond,
+			Multiplier:     2,
+			RetryableCodes: []codes.Code{codes.Unavailable, codes.ResourceExhausted},
+		},
+	}
+}
+
+func TestResilienceRetries(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		errs         []error // Returned by successive attempts, then success
+		wantAttempts int
+		wantCode     codes.Code
+		minElapsed   time.Duration
+	}{
+		{name: "retries until success", method: testMethod, errs: []error{status.Error(codes.Unavailable, ""), status.Error(codes.Unavailable, "")}, wantAttempts: 3, wantCode: codes.OK},
+		{name: "gives up after MaxAttempts", method: testMethod, errs: []error{status.Error(codes.Unavailable, ""), status.Error(codes.Unavailable, ""), status.Error(codes.Unavailable, "")}, wantAttempts: 3, wantCode: codes.Unavailable},
+		{name: "final code", method: testMethod, errs: []error{status.Error(codes.NotFound, "")}, wantAttempts: 1, wantCode: codes.NotFound},
+		{name: "not idempotent", method: "/test.Service/Create", errs: []error{status.Error(codes.Unavailable, "")}, wantAttempts: 1, wantCode: codes.Unavailable},
+		{name: "waits for RetryInfo", method: testMethod, errs: []error{throttled(t, 50*time.Millisecond)}, wantAttempts: 2, wantCode: codes.OK, minElapsed: 50 * time.Millisecond},
+		{name: "RetryInfo past the deadline", method: testMethod, errs: []error{throttled(t, 10*time.Second)}, wantAttempts: 1, wantCode: codes.ResourceExhausted},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts int
+			invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				attempts++
+				if attempts <= len(tc.errs) {
+					return tc.errs[attempts-1]
+				}
+				return nil
+			}
+			interceptor := ResilienceUnaryClientInterceptor("test", testConfig(), nopLogger{})
+
+			start := time.Now()
+			err := interceptor(context.Background(), tc.method, nil, &healthpb.HealthCheckResponse{}, nil, invoker)
+			if code := status.Code(err); code != tc.wantCode {
+				t.Errorf("error = %v, want %s", err, tc.wantCode)
+			}
+			if attempts != tc.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tc.wantAttempts)
+			}
+			if elapsed := time.Since(start); elapsed < tc.minElapsed {
+				t.Errorf("returned after %s, want at least %s", elapsed, tc.minElapsed)
+			}
+		})
+	}
+}
+
+func TestBreakerStateMachine(t *testing.T) {
+	ctx := context.Background()
+	b := newBreaker("breaker-test", BreakerPolicy{FailureThreshold: 2, OpenTimeout: 20 * time.Millisecond}, nopLogger{})
+	fault := status.Error(codes.Unavailable, "down")
+
+	// Closed: client errors and successes reset the failure count
+	b.record(ctx, fault)
+	b.record(ctx, status.Error(codes.NotFound, ""))
+	b.record(ctx, fault)
+	if b.isOpen() {
+		t.Fatal("circuit opened on non-consecutive failures")
+	}
+	b.record(ctx, fault)
+	if !b.isOpen() || b.allow() {
+		t.Fatal("circuit not open after FailureThreshold consecutive failures")
+	}
+
+	// Half-open: a single probe, whose failure opens the circuit again
+	time.Sleep(30 * time.Millisecond)
+	if !b.allow() {
+		t.Fatal("probe rejected after OpenTimeout")
+	}
+	if b.allow() {
+		t.Fatal("second call let through while probing")
+	}
+	b.record(ctx, fault)
+	if !b.isOpen() || b.allow() {
+		t.Fatal("circuit not open again after a failed probe")
+	}
+
+	// A probe the caller gave up on tells nothing; the next call probes
+	time.Sleep(30 * time.Millisecond)
+	if !b.allow() {
+		t.Fatal("probe rejected after OpenTimeout")
+	}
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	b.record(canceled, status.FromContextError(canceled.Err()).Err())
+	if !b.allow() {
+		t.Fatal("no new probe after a canceled one")
+	}
+
+	// A successful probe closes the circuit
+	b.record(ctx, nil)
+	if b.isOpen() || !b.allow() || !b.allow() {
+		t.Fatal("circuit not closed after a successful probe")
+	}
+}
+
+func TestBreakerFailsFast(t *testing.T) {
+	config := testConfig()
+	config.Breaker = BreakerPolicy{FailureThreshold: 2, OpenTimeout: time.Minute}
+	var attempts int
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		attempts++
+		return status.Error(codes.Unavailable, "down")
+	}
+	interceptor := ResilienceUnaryClientInterceptor("fail-fast-test", config, nopLogger{})
+
+	// The retries of the first call open the circuit, which stops them
+	_ = interceptor(context.Background(), testMethod, nil, &healthpb.HealthCheckResponse{}, nil, invoker)
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+	err := interceptor(context.Background(), testMethod, nil, &healthpb.HealthCheckResponse{}, nil, invoker)
+	if status.Code(err) != codes.Unavailable || attempts != 2 {
+		t.Errorf("call on an open circuit: error = %v after %d attempts, want Unavailable without an attempt", err, attempts)
+	}
+}
+
+func TestHedgeCancelsSlowAttempts(t *testing.T) {
+	config := testConfig()
+	config.Hedge = HedgePolicy{MaxAttempts: 3, Delay: 10 * time.Millisecond}
+	var attempts int32
+	firstCanceled := make(chan struct{})
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			<-ctx.Done() // Slow until the hedge wins
+			close(firstCanceled)
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		reply.(*healthpb.HealthCheckResponse).Status = healthpb.HealthCheckResponse_SERVING
+		return nil
+	}
+	interceptor := ResilienceUnaryClientInterceptor("hedge-test", config, nopLogger{})
+
+	reply := &healthpb.HealthCheckResponse{}
+	if err := interceptor(context.Background(), testMethod, nil, reply, nil, invoker); err != nil {
+		t.Fatalf("hedged call failed: %v", err)
+	}
+	if reply.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("reply status = %s, want the hedged attempt's SERVING", reply.Status)
+	}
+	select {
+	case <-firstCanceled:
+	case <-time.After(time.Second):
+		t.Fatal("slow attempt not canceled after the hedge succeeded")
+	}
+	if n := atomic.LoadInt32(&attempts); n != 2 {
+		t.Errorf("attempts = %d, want 2", n)
+	}
+}
+
+func TestHedgeFinalErrors(t *testing.T) {
+	cases := map[string]error{
+		"final code": status.Error(codes.NotFound, ""),
+		"throttled":  throttled(t, time.Second),
+	}
+	for name, want := range cases {
+		t.Run(name, func(t *testing.T) {
+			config := testConfig()
+			config.Hedge = HedgePolicy{MaxAttempts: 3, Delay: 20 * time.Millisecond}
+			var attempts int32
+			invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				atomic.AddInt32(&attempts, 1)
+				return want
+			}
+			interceptor := ResilienceUnaryClientInterceptor("hedge-final-test", config, nopLogger{})
+
+			err := interceptor(context.Background(), testMethod, nil, &healthpb.HealthCheckResponse{}, nil, invoker)
+			if !errors.Is(err, want) && status.Code(err) != status.Code(want) {
+				t.Errorf("error = %v, want %v", err, want)
+			}
+			time.Sleep(30 * time.Millisecond)
+			if n := atomic.LoadInt32(&attempts); n != 1 {
+				t.Errorf("attempts = %d, want 1", n)
+			}
+		})
+	}
+}
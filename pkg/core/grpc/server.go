@@ -1,11 +1,15 @@
 package grpc
 
 import (
+	"context"
 	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/metrics"
+	"golang-microservices-boilerplate/pkg/core/ratelimit"
 	"golang-microservices-boilerplate/pkg/utils"
 
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
@@ -13,6 +17,7 @@ import (
 	grpc_validator "github.com/grpc-ecosystem/go-grpc-middleware/validator"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
@@ -27,6 +32,29 @@ type GrpcServerConfig struct {
 	MaxConnectionAgeGrace time.Duration
 	KeepAliveTime         time.Duration
 	KeepAliveTimeout      time.Duration
+	AccessTokenSecret     string        // HS256 secret used to identify the actor of a request; empty disables it
+	MetricsAddr           string        // Listen address of the Prometheus /metrics endpoint; empty disables it
+	HealthCheckInterval   time.Duration // How often the health checks (see AddHealthCheck) run
+	HealthCheckTimeout    time.Duration // How long each health check may take
+	TLS                   *TLSConfig    // Serves TLS, or mutual TLS with ClientAuth; nil serves plaintext
+
+	// AuthPolicy, when set, requires access tokens signed with AccessTokenSecret
+	// for every method it does not declare public (see AuthUnaryServerInterceptor).
+	AuthPolicy *AuthPolicy
+
+	// RateLimits, when set, rejects the calls exceeding the rate limit of
+	// their method (see RateLimitUnaryServerInterceptor).
+	RateLimits *ratelimit.Limiter
+
+	// TrustedProxies are the IPs and CIDR ranges, such as the gateway's, whose
+	// x-forwarded-for is believed when limiting callers by IP. Callers
+	// reaching the server from elsewhere are limited by their own address.
+	TrustedProxies []string
+
+	// UnaryInterceptors run after the core interceptors (request context,
+	// validation), in the given order. Panics anywhere in the chain are
+	// recovered as Internal.
+	UnaryInterceptors []grpc.UnaryServerInterceptor
 }
 
 // DefaultGrpcServerConfig provides sensible defaults for gRPC server configuration
@@ -39,15 +67,36 @@ func DefaultGrpcServerConfig() *GrpcServerConfig {
 		MaxConnectionAgeGrace: 5 * time.Second,
 		KeepAliveTime:         5 * time.Minute,
 		KeepAliveTimeout:      20 * time.Second,
+		AccessTokenSecret:     utils.GetEnv("ACCESS_TOKEN_SECRET", ""),
+		MetricsAddr:           metrics.DefaultAddr(),
+		HealthCheckInterval:   10 * time.Second,
+		HealthCheckTimeout:    2 * time.Second,
+		TLS:                   TLSConfigFromEnv(),
+		TrustedProxies:        trustedProxiesFromEnv(),
 	}
 }
 
+// trustedProxiesFromEnv returns the comma separated IPs and CIDR ranges of
+// TRUSTED_PROXIES.
+func trustedProxiesFromEnv() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(utils.GetEnv("TRUSTED_PROXIES", ""), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
 // BaseGrpcServer represents the gRPC server for a microservice
 type BaseGrpcServer struct {
 	server   *grpc.Server
 	Config   *GrpcServerConfig
 	Logger   logger.Logger
 	listener net.Listener
+	metrics  *metrics.Server
+	health   *healthChecks
+	err      error // Setup failure reported by Start, e.g. unreadable TLS files
 }
 
 // NewBaseGrpcServer creates a new base gRPC server with default config
@@ -59,7 +108,7 @@ func NewBaseGrpcServer(logger logger.Logger) *BaseGrpcServer {
 func NewBaseGrpcServerWithConfig(logger logger.Logger, config *GrpcServerConfig) *BaseGrpcServer {
 	// Set up server interceptors
 	recoveryHandler := func(p interface{}) (err error) {
-		logger.Error("Recovered from panic in gRPC handler", "panic", p)
+		logger.Error("Recovered from panic in gRPC call", "panic", p)
 		return status.Errorf(codes.Internal, "internal server error")
 	}
 
@@ -67,8 +116,54 @@ func NewBaseGrpcServerWithConfig(logger logger.Logger, config *GrpcServerConfig)
 		grpc_recovery.WithRecoveryHandler(recoveryHandler),
 	}
 
-	// Create gRPC server with middleware
-	server := grpc.NewServer(
+	// Recovery comes first, so a panic in any interceptor fails only its call.
+	// Calls are observed with their request ID and final status, including rejections
+	accessLogger := logger.Named("grpc.access")
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		grpc_recovery.UnaryServerInterceptor(opts...),
+		grpc_ctxtags.UnaryServerInterceptor(),
+		TracingUnaryServerInterceptor(),
+		RequestContextUnaryServerInterceptor(config.AccessTokenSecret),
+		ObservabilityUnaryServerInterceptor(accessLogger),
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		grpc_recovery.StreamServerInterceptor(opts...),
+		grpc_ctxtags.StreamServerInterceptor(),
+		TracingStreamServerInterceptor(),
+		RequestContextStreamServerInterceptor(config.AccessTokenSecret),
+		ObservabilityStreamServerInterceptor(accessLogger),
+	}
+	// Excess calls are rejected before any token is verified or request
+	// handled. Like unusable TLS files, malformed proxies fail Start
+	var setupErr error
+	if config.RateLimits != nil {
+		proxies, err := ratelimit.ParseTrustedProxies(config.TrustedProxies)
+		if err != nil {
+			setupErr = fmt.Errorf("invalid trusted proxies: %w", err)
+		}
+		rateLimitLogger := logger.Named("grpc.ratelimit")
+		unaryInterceptors = append(unaryInterceptors, RateLimitUnaryServerInterceptor(config.RateLimits, proxies, rateLimitLogger))
+		streamInterceptors = append(streamInterceptors, RateLimitStreamServerInterceptor(config.RateLimits, proxies, rateLimitLogger))
+	}
+	// Callers are authenticated before their requests are validated or handled
+	if config.AuthPolicy != nil {
+		if config.AccessTokenSecret == "" {
+			logger.Warn("ACCESS_TOKEN_SECRET is empty; only public gRPC methods can be called")
+		}
+		unaryInterceptors = append(unaryInterceptors, AuthUnaryServerInterceptor(config.AccessTokenSecret, config.AuthPolicy))
+		streamInterceptors = append(streamInterceptors, AuthStreamServerInterceptor(config.AccessTokenSecret, config.AuthPolicy))
+	}
+	unaryInterceptors = append(unaryInterceptors, grpc_validator.UnaryServerInterceptor()) // Make sure request types have `Validate() error` method
+	unaryInterceptors = append(unaryInterceptors, config.UnaryInterceptors...)
+	// Handler panics are also recovered innermost, so that the access log,
+	// metrics and trace record them as Internal
+	unaryInterceptors = append(unaryInterceptors, grpc_recovery.UnaryServerInterceptor(opts...))
+	streamInterceptors = append(streamInterceptors,
+		grpc_validator.StreamServerInterceptor(),
+		grpc_recovery.StreamServerInterceptor(opts...),
+	)
+
+	serverOptions := []grpc.ServerOption{
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			MaxConnectionIdle:     config.MaxConnectionIdle,
 			MaxConnectionAge:      config.MaxConnectionAge,
@@ -76,40 +171,71 @@ func NewBaseGrpcServerWithConfig(logger logger.Logger, config *GrpcServerConfig)
 			Time:                  config.KeepAliveTime,
 			Timeout:               config.KeepAliveTimeout,
 		}),
-		grpc.ChainUnaryInterceptor(
-			grpc_ctxtags.UnaryServerInterceptor(),
-			grpc_validator.UnaryServerInterceptor(), // Make sure request types have `Validate() error` method
-			grpc_recovery.UnaryServerInterceptor(opts...),
-			// TODO: Add custom interceptors (logging, auth, etc.) here
-		),
-		grpc.ChainStreamInterceptor(
-			grpc_ctxtags.StreamServerInterceptor(),
-			grpc_validator.StreamServerInterceptor(),
-			grpc_recovery.StreamServerInterceptor(opts...),
-			// TODO: Add custom interceptors (logging, auth, etc.) here
-		),
-	)
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	}
+
+	// Without usable TLS files Start fails rather than falling back to plaintext
+	if config.TLS != nil && setupErr == nil {
+		creds, err := NewServerCredentials(config.TLS, logger.Named("grpc.tls"))
+		if err != nil {
+			setupErr = fmt.Errorf("failed to load TLS credentials: %w", err)
+		} else {
+			serverOptions = append(serverOptions, grpc.Creds(creds))
+		}
+	}
+
+	// Create gRPC server with middleware
+	server := grpc.NewServer(serverOptions...)
 
 	// Enable reflection for debugging & tools like grpc_cli
 	reflection.Register(server)
 
-	return &BaseGrpcServer{
+	// Serve grpc.health.v1.Health for probes and clients (see AddHealthCheck)
+	healthChecks := newHealthChecks(config.HealthCheckInterval, config.HealthCheckTimeout)
+	healthpb.RegisterHealthServer(server, healthChecks.server)
+
+	baseServer := &BaseGrpcServer{
 		server: server,
 		Config: config,
 		Logger: logger,
+		health: healthChecks,
+		err:    setupErr,
 	}
+	if config.MetricsAddr != "" {
+		baseServer.metrics = metrics.NewServer(config.MetricsAddr, logger)
+	}
+	return baseServer
 }
 
 // Start begins listening for gRPC requests
 func (s *BaseGrpcServer) Start() error {
+	if s.err != nil {
+		return s.err
+	}
 	addr := fmt.Sprintf("%s:%s", s.Config.Host, s.Config.Port)
-	s.Logger.Info("Starting gRPC server", "address", addr)
+	s.Logger.Info("Starting gRPC server", "address", addr, "tls", s.Config.TLS != nil)
 
 	var err error
 	s.listener, err = net.Listen("tcp", addr)
 	if err != nil {
 		return fmt.Errorf("failed to listen on %s: %w", addr, err)
 	}
+	if s.metrics != nil {
+		if err := s.metrics.Start(); err != nil {
+			_ = s.listener.Close()
+			return fmt.Errorf("failed to serve metrics on %s: %w", s.Config.MetricsAddr, err)
+		}
+	}
+
+	// Report the health of every registered service, checked before serving
+	var services []string
+	for name := range s.server.GetServiceInfo() {
+		if name != healthpb.Health_ServiceDesc.ServiceName && !strings.HasPrefix(name, "grpc.reflection.") {
+			services = append(services, name)
+		}
+	}
+	s.health.start(services, s.Logger.Named("grpc.health"))
 
 	go func() {
 		s.Logger.Info("gRPC server listening", "address", s.listener.Addr().String())
@@ -124,11 +250,17 @@ func (s *BaseGrpcServer) Start() error {
 // Stop gracefully shuts down the gRPC server
 func (s *BaseGrpcServer) Stop() {
 	s.Logger.Info("Attempting to gracefully stop gRPC server...")
+	s.health.shutdown()
 	s.server.GracefulStop()
 	if s.listener != nil {
 		s.Logger.Info("Closing gRPC listener.")
 		_ = s.listener.Close() // Ignore error on close, already stopping
 	}
+	if s.metrics != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = s.metrics.Shutdown(ctx)
+	}
 	s.Logger.Info("gRPC server stopped.")
 }
 
@@ -0,0 +1,274 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// TLSConfig locates the PEM files securing gRPC connections and says how
+// peers are verified. Servers present CertFile and, with ClientAuth, require
+// client certificates signed by CAFile (mutual TLS). Clients verify servers
+// against CAFile and present CertFile when it is set.
+type TLSConfig struct {
+	CertFile string // Certificate chain presented to peers; optional for clients
+	KeyFile  string // Private key of CertFile
+	CAFile   string // CA bundle verifying peers; clients fall back to the system roots
+
+	// ClientAuth makes servers require a client certificate signed by CAFile.
+	ClientAuth bool
+
+	// AllowedIdentities restricts the clients a server with ClientAuth accepts
+	// to certificates carrying one of these DNS or URI SANs, or common names,
+	// e.g. "api-gateway". Empty accepts any certificate the CA signed.
+	AllowedIdentities []string
+
+	// ServerName is the identity clients verify the server certificate against.
+	// Empty uses the host dialed, e.g. "staff-service" for "staff-service:9090".
+	ServerName string
+
+	// ReloadInterval is how often the files are checked for rotation. Handshakes
+	// after a change use the new certificate, key and CA bundle.
+	ReloadInterval time.Duration
+}
+
+// TLSConfigFromEnv reads TLS_CERT_FILE, TLS_KEY_FILE, TLS_CA_FILE,
+// TLS_CLIENT_AUTH, TLS_ALLOWED_IDENTITIES (comma separated) and
+// TLS_RELOAD_INTERVAL (default 1m). It returns nil, meaning plaintext, when
+// neither a certificate nor a CA bundle is configured.
+func TLSConfigFromEnv() *TLSConfig {
+	certFile := utils.GetEnv("TLS_CERT_FILE", "")
+	caFile := utils.GetEnv("TLS_CA_FILE", "")
+	if certFile == "" && caFile == "" {
+		return nil
+	}
+	clientAuth, _ := strconv.ParseBool(utils.GetEnv("TLS_CLIENT_AUTH", "false"))
+	var identities []string
+	for _, identity := range strings.Split(utils.GetEnv("TLS_ALLOWED_IDENTITIES", ""), ",") {
+		if identity = strings.TrimSpace(identity); identity != "" {
+			identities = append(identities, identity)
+		}
+	}
+	return &TLSConfig{
+		CertFile:          certFile,
+		KeyFile:           utils.GetEnv("TLS_KEY_FILE", ""),
+		CAFile:            caFile,
+		ClientAuth:        clientAuth,
+		AllowedIdentities: identities,
+		ReloadInterval:    utils.GetEnvDuration("TLS_RELOAD_INTERVAL", time.Minute),
+	}
+}
+
+// NewServerCredentials returns the transport credentials of a TLS server. The
+// files are loaded now, so a misconfiguration fails at startup.
+func NewServerCredentials(config *TLSConfig, log logger.Logger) (credentials.TransportCredentials, error) {
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, errors.New("TLS servers need a certificate and a key")
+	}
+	if config.ClientAuth && config.CAFile == "" {
+		return nil, errors.New("client certificate verification needs a CA bundle")
+	}
+	files, err := newTLSFiles(config, log)
+	if err != nil {
+		return nil, err
+	}
+	return &reloadingCredentials{files: files}, nil
+}
+
+// NewClientCredentials returns the transport credentials of a TLS client. The
+// files are loaded now, so a misconfiguration fails at startup.
+func NewClientCredentials(config *TLSConfig, log logger.Logger) (credentials.TransportCredentials, error) {
+	if (config.CertFile == "") != (config.KeyFile == "") {
+		return nil, errors.New("client certificates need both a certificate and a key")
+	}
+	files, err := newTLSFiles(config, log)
+	if err != nil {
+		return nil, err
+	}
+	return &reloadingCredentials{files: files, serverName: config.ServerName}, nil
+}
+
+// reloadingCredentials performs each handshake with the current files, by
+// delegating to credentials built from them.
+type reloadingCredentials struct {
+	files      *tlsFiles
+	serverName string
+}
+
+func (c *reloadingCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return credentials.NewTLS(c.files.clientConfig(c.serverName)).ClientHandshake(ctx, authority, conn)
+}
+
+func (c *reloadingCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return credentials.NewTLS(c.files.serverConfig()).ServerHandshake(conn)
+}
+
+func (c *reloadingCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "tls", SecurityVersion: "1.2", ServerName: c.serverName}
+}
+
+func (c *reloadingCredentials) Clone() credentials.TransportCredentials {
+	clone := *c
+	return &clone
+}
+
+// OverrideServerName is deprecated in grpc-go; ServerName should be set in TLSConfig.
+func (c *reloadingCredentials) OverrideServerName(serverName string) error {
+	c.serverName = serverName
+	return nil
+}
+
+// tlsFiles holds the parsed files of a TLSConfig and reloads them when their
+// modification time changes. Checks happen lazily, on handshakes.
+type tlsFiles struct {
+	config  *TLSConfig
+	log     logger.Logger
+	mu      sync.Mutex
+	checked time.Time
+	modTime map[string]time.Time
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+}
+
+func newTLSFiles(config *TLSConfig, log logger.Logger) (*tlsFiles, error) {
+	f := &tlsFiles{config: config, log: log}
+	if err := f.load(); err != nil {
+		return nil, err
+	}
+	f.checked = time.Now()
+	return f, nil
+}
+
+// load parses the files and records their modification times.
+func (f *tlsFiles) load() error {
+	modTime := make(map[string]time.Time, 3)
+	for _, path := range []string{f.config.CertFile, f.config.KeyFile, f.config.CAFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("failed to read TLS file: %w", err)
+		}
+		modTime[path] = info.ModTime()
+	}
+
+	var cert *tls.Certificate
+	if f.config.CertFile != "" {
+		pair, err := tls.LoadX509KeyPair(f.config.CertFile, f.config.KeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load TLS certificate %s: %w", f.config.CertFile, err)
+		}
+		cert = &pair
+	}
+	var pool *x509.CertPool
+	if f.config.CAFile != "" {
+		pem, err := os.ReadFile(f.config.CAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read TLS CA bundle %s: %w", f.config.CAFile, err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("TLS CA bundle %s has no PEM certificates", f.config.CAFile)
+		}
+	}
+
+	f.cert, f.pool, f.modTime = cert, pool, modTime
+	return nil
+}
+
+// current returns the certificate and CA pool, reloading them first when the
+// reload interval passed and a file changed. A failed reload keeps the
+// previous files, so a half-written rotation does not break new connections.
+func (f *tlsFiles) current() (*tls.Certificate, *x509.CertPool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.config.ReloadInterval > 0 && time.Since(f.checked) >= f.config.ReloadInterval {
+		f.checked = time.Now()
+		if f.changed() {
+			if err := f.load(); err != nil {
+				f.log.Error("Failed to reload TLS files, keeping the previous ones", "error", err)
+			} else {
+				f.log.Info("Reloaded TLS files", "cert", f.config.CertFile, "ca", f.config.CAFile)
+			}
+		}
+	}
+	return f.cert, f.pool
+}
+
+// changed reports whether any file was modified since it was loaded.
+func (f *tlsFiles) changed() bool {
+	for path, loaded := range f.modTime {
+		info, err := os.Stat(path)
+		if err != nil || !info.ModTime().Equal(loaded) {
+			return true
+		}
+	}
+	return false
+}
+
+// serverConfig returns the tls.Config of the next server handshake.
+func (f *tlsFiles) serverConfig() *tls.Config {
+	cert, pool := f.current()
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*cert},
+	}
+	if f.config.ClientAuth {
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+		config.ClientCAs = pool
+		config.VerifyPeerCertificate = f.verifyIdentity
+	}
+	return config
+}
+
+// clientConfig returns the tls.Config of the next client handshake.
+func (f *tlsFiles) clientConfig(serverName string) *tls.Config {
+	cert, pool := f.current()
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    pool,
+		ServerName: serverName,
+	}
+	if cert != nil {
+		config.Certificates = []tls.Certificate{*cert}
+	}
+	return config
+}
+
+// verifyIdentity rejects verified clients whose certificate carries none of
+// the allowed identities.
+func (f *tlsFiles) verifyIdentity(_ [][]byte, chains [][]*x509.Certificate) error {
+	if len(f.config.AllowedIdentities) == 0 {
+		return nil
+	}
+	if len(chains) == 0 || len(chains[0]) == 0 {
+		return errors.New("client presented no verified certificate")
+	}
+	leaf := chains[0][0]
+	identities := append([]string{leaf.Subject.CommonName}, leaf.DNSNames...)
+	for _, uri := range leaf.URIs {
+		identities = append(identities, uri.String())
+	}
+	for _, allowed := range f.config.AllowedIdentities {
+		for _, identity := range identities {
+			if identity == allowed {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("client certificate identity %q is not allowed", leaf.Subject.CommonName)
+}
@@ -0,0 +1,151 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"golang-microservices-boilerplate/pkg/core/tracing"
+)
+
+// TracingUnaryServerInterceptor starts a server span for each call, continuing
+// the trace whose context the caller sent in the traceparent metadata (see
+// package tracing). Health checks are not traced.
+func TracingUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, healthMethodPrefix) {
+			return handler(ctx, req)
+		}
+		ctx, span := startServerSpan(ctx, info.FullMethod)
+		resp, err := handler(ctx, req)
+		endSpan(span, serverSide, err)
+		return resp, err
+	}
+}
+
+// TracingStreamServerInterceptor is the streaming counterpart of
+// TracingUnaryServerInterceptor.
+func TracingStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, healthMethodPrefix) {
+			return handler(srv, stream)
+		}
+		ctx, span := startServerSpan(stream.Context(), info.FullMethod)
+		wrapped := grpc_middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = ctx
+		err := handler(srv, wrapped)
+		endSpan(span, serverSide, err)
+		return err
+	}
+}
+
+// TracingUnaryClientInterceptor starts a client span for each call and sends
+// its context in the traceparent metadata. The parent is the span of ctx or,
+// in the gateway, the trace context forwarded from the HTTP request.
+func TracingUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if strings.HasPrefix(method, healthMethodPrefix) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		ctx, span := startClientSpan(ctx, method, cc.Target())
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		endSpan(span, clientSide, err)
+		return err
+	}
+}
+
+// TracingStreamClientInterceptor is the streaming counterpart of
+// TracingUnaryClientInterceptor. The span ends once RecvMsg reports the end of
+// the stream, so callers must read streams to completion.
+func TracingStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if strings.HasPrefix(method, healthMethodPrefix) {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+		ctx, span := startClientSpan(ctx, method, cc.Target())
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			endSpan(span, clientSide, err)
+			return nil, err
+		}
+		return &observedClientStream{ClientStream: stream, done: func(err error) {
+			endSpan(span, clientSide, err)
+		}}, nil
+	}
+}
+
+// startServerSpan starts the span of a served call under the trace context of
+// the incoming metadata.
+func startServerSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	return tracing.Tracer().Start(ctx, strings.TrimPrefix(fullMethod, "/"),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(rpcAttributes(fullMethod)...),
+	)
+}
+
+// startClientSpan starts the span of an outgoing call and injects its context
+// into the outgoing metadata, replacing any forwarded trace context.
+func startClientSpan(ctx context.Context, fullMethod, target string) (context.Context, trace.Span) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	}
+	ctx, span := tracing.Tracer().Start(ctx, strings.TrimPrefix(fullMethod, "/"),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(append(rpcAttributes(fullMethod), attribute.String("server.address", target))...),
+	)
+	otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+// rpcAttributes returns the semantic convention attributes of a gRPC call.
+func rpcAttributes(fullMethod string) []attribute.KeyValue {
+	service, method := splitFullMethod(fullMethod)
+	return []attribute.KeyValue{
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", service),
+		attribute.String("rpc.method", method),
+	}
+}
+
+// endSpan records the status code of a call and ends its span. Client spans
+// fail on any error; server spans only when the server is at fault, as the
+// OpenTelemetry conventions for gRPC recommend.
+func endSpan(span trace.Span, s side, err error) {
+	st := status.Convert(err)
+	span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(st.Code())))
+	if err != nil && (s == clientSide || isServerFault(st.Code())) {
+		span.SetStatus(otelcodes.Error, st.Message())
+	}
+	span.End()
+}
+
+// metadataCarrier adapts gRPC metadata to the OpenTelemetry propagators.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	return firstMetadataValue(metadata.MD(c), key)
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,195 @@
+// Package idempotency makes retried requests safe: the first request with a
+// given key runs and its response is stored, repeats within the TTL get the
+// stored response back instead of running again.
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// MaxKeyLength is the longest idempotency key accepted.
+const MaxKeyLength = 255
+
+// ErrKeyReused is returned when a key is sent again with a different request.
+var ErrKeyReused = errors.New("idempotency key was already used for a different request")
+
+// ErrInProgress is returned when a key is sent again while the first request
+// with it is still running.
+var ErrInProgress = errors.New("a request with this idempotency key is still in progress")
+
+// Config contains configuration for idempotency keys.
+type Config struct {
+	TTL           time.Duration // How long a response is replayed for its key
+	PurgeInterval time.Duration // Minimum time between deletions of expired keys
+
+	// PendingTimeout is how long a claimed key waits for its response. After
+	// that the claim is considered abandoned (e.g. the process died) and the
+	// key may run again, so it must exceed the longest request.
+	PendingTimeout time.Duration
+}
+
+// DefaultConfig provides sensible defaults, overridable through environment variables.
+func DefaultConfig() Config {
+	ttl, err := time.ParseDuration(utils.GetEnv("IDEMPOTENCY_TTL", "24h"))
+	if err != nil || ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+	purgeInterval, err := time.ParseDuration(utils.GetEnv("IDEMPOTENCY_PURGE_INTERVAL", "1h"))
+	if err != nil || purgeInterval <= 0 {
+		purgeInterval = time.Hour
+	}
+	pendingTimeout, err := time.ParseDuration(utils.GetEnv("IDEMPOTENCY_PENDING_TIMEOUT", "1m"))
+	if err != nil || pendingTimeout <= 0 {
+		pendingTimeout = time.Minute
+	}
+	return Config{TTL: ttl, PurgeInterval: purgeInterval, PendingTimeout: pendingTimeout}
+}
+
+// Key identifies an idempotent request. Keys are scoped by method and actor, so
+// clients cannot see each other's responses.
+type Key struct {
+	Method string
+	Actor  string
+	Key    string
+}
+
+// Record is a row of the idempotency_keys table.
+type Record struct {
+	Method      string    `gorm:"size:255;primaryKey"`
+	Actor       string    `gorm:"size:100;primaryKey"`
+	Key         string    `gorm:"size:255;primaryKey"`
+	RequestHash string    `gorm:"type:char(64);not null"`
+	Response    []byte    // Nil until the request completed
+	CreatedAt   time.Time `gorm:"not null"`       // When the key was claimed
+	ExpiresAt   time.Time `gorm:"not null;index"` // End of the pending timeout, then of the TTL
+}
+
+// TableName overrides the default table name.
+func (Record) TableName() string {
+	return "idempotency_keys"
+}
+
+// AuditExempt excludes idempotency bookkeeping from the audit trail (see audit.Exempt).
+func (Record) AuditExempt() {}
+
+// Store keeps the responses of idempotent requests in the idempotency_keys table.
+type Store struct {
+	db        *gorm.DB
+	config    Config
+	lastPurge atomic.Int64 // Unix nanoseconds of the last purge
+}
+
+// NewStore creates a Store on db.
+func NewStore(db *gorm.DB, config Config) *Store {
+	return &Store{db: db, config: config}
+}
+
+// Do runs fn unless key was already used within the TTL, in which case the
+// stored response is returned with replayed set. The key is claimed first in a
+// statement of its own, so fn runs outside of any transaction and may take its
+// time, e.g. calling other services. A concurrent request with the same key
+// meanwhile fails with ErrInProgress. When fn fails the claim is released and
+// the key can be retried. A key sent with a different requestHash than the
+// first time fails with ErrKeyReused.
+//
+// If storing the response fails after fn succeeded, the response is still
+// returned; the key then runs again once Config.PendingTimeout has passed.
+func (s *Store) Do(ctx context.Context, key Key, requestHash string, fn func(ctx context.Context) ([]byte, error)) (response []byte, replayed bool, err error) {
+	claimed, err := s.claim(ctx, key, requestHash)
+	if err != nil {
+		return nil, false, err
+	}
+	if claimed.IsZero() {
+		response, err := s.stored(ctx, key, requestHash)
+		return response, err == nil, err
+	}
+
+	// The claim is finished even when the request was cancelled meanwhile.
+	finishCtx := context.WithoutCancel(ctx)
+	if response, err = fn(ctx); err != nil {
+		_ = s.db.WithContext(finishCtx).Exec(
+			"DELETE FROM idempotency_keys WHERE method = ? AND actor = ? AND key = ? AND created_at = ? AND response IS NULL",
+			key.Method, key.Actor, key.Key, claimed,
+		).Error
+		return nil, false, err
+	}
+	_ = s.db.WithContext(finishCtx).Exec(
+		"UPDATE idempotency_keys SET response = ?, expires_at = ? WHERE method = ? AND actor = ? AND key = ? AND created_at = ?",
+		response, time.Now().Add(s.config.TTL), key.Method, key.Actor, key.Key, claimed,
+	).Error
+
+	s.purgeIfDue(ctx)
+	return response, false, nil
+}
+
+// claim claims key for a request until the pending timeout, taking over an
+// expired key or abandoned claim. It returns the time of the claim, which
+// identifies it, or the zero time when the key is held by another request.
+func (s *Store) claim(ctx context.Context, key Key, requestHash string) (time.Time, error) {
+	now := time.Now().Truncate(time.Microsecond) // Postgres precision, so the claim compares equal
+	result := s.db.WithContext(ctx).Exec(`INSERT INTO idempotency_keys (method, actor, key, request_hash, created_at, expires_at)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (method, actor, key) DO UPDATE
+SET request_hash = EXCLUDED.request_hash, response = NULL, created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= EXCLUDED.created_at`,
+		key.Method, key.Actor, key.Key, requestHash, now, now.Add(s.config.PendingTimeout))
+	if result.Error != nil {
+		return time.Time{}, fmt.Errorf("failed to claim idempotency key: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return time.Time{}, nil
+	}
+	return now, nil
+}
+
+// stored returns the response stored for key by another request.
+func (s *Store) stored(ctx context.Context, key Key, requestHash string) ([]byte, error) {
+	var record Record
+	result := s.db.WithContext(ctx).Raw(
+		"SELECT * FROM idempotency_keys WHERE method = ? AND actor = ? AND key = ?",
+		key.Method, key.Actor, key.Key,
+	).Scan(&record)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to read idempotency key: %w", result.Error)
+	}
+	switch {
+	case result.RowsAffected == 0: // Released by a request that failed just now
+		return nil, ErrInProgress
+	case record.RequestHash != requestHash:
+		return nil, ErrKeyReused
+	case record.Response == nil:
+		return nil, ErrInProgress
+	}
+	return record.Response, nil
+}
+
+// PurgeExpired deletes the keys whose TTL has passed and returns how many were deleted.
+func (s *Store) PurgeExpired(ctx context.Context) (int64, error) {
+	result := s.db.WithContext(ctx).Exec("DELETE FROM idempotency_keys WHERE expires_at < ?", time.Now())
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge expired idempotency keys: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// purgeIfDue purges expired keys in the background at most once per
+// PurgeInterval. Expired keys are taken over when reused, so purging only
+// keeps the table from growing and its failures are ignored.
+func (s *Store) purgeIfDue(ctx context.Context) {
+	now := time.Now().UnixNano()
+	last := s.lastPurge.Load()
+	if now-last < int64(s.config.PurgeInterval) || !s.lastPurge.CompareAndSwap(last, now) {
+		return
+	}
+	go func() {
+		_, _ = s.PurgeExpired(context.WithoutCancel(ctx))
+	}()
+}
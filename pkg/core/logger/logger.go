@@ -1,11 +1,13 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -123,6 +125,9 @@ type Logger interface {
 	Fatal(msg string, args ...interface{})
 	With(args ...interface{}) Logger
 	Named(name string) Logger
+	// WithContext adds the trace_id and span_id of the span in ctx, if any, so
+	// log lines can be joined with their trace.
+	WithContext(ctx context.Context) Logger
 }
 
 // ZapLogger implements the Logger interface using zap
@@ -251,6 +256,21 @@ func (l *ZapLogger) With(args ...interface{}) Logger {
 	return &ZapLogger{logger: l.logger.With(args...)}
 }
 
+// WithContext adds the IDs of the span in ctx to the logger
+func (l *ZapLogger) WithContext(ctx context.Context) Logger {
+	return &ZapLogger{logger: l.logger.With(TraceFields(ctx)...)}
+}
+
+// TraceFields returns the trace_id and span_id fields of the span in ctx, or
+// none when ctx carries no valid span. Logger implementations use it in WithContext.
+func TraceFields(ctx context.Context) []interface{} {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+	return []interface{}{"trace_id", spanContext.TraceID().String(), "span_id", spanContext.SpanID().String()}
+}
+
 // Named adds a sub-scope to the logger
 func (l *ZapLogger) Named(name string) Logger {
 	return &ZapLogger{logger: l.logger.Named(name)}
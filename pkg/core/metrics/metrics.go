@@ -0,0 +1,156 @@
+// Package metrics holds the Prometheus collectors shared by the services and
+// serves them over HTTP for scraping.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// Registry holds every collector of the process, including the Go runtime and
+// process collectors. Register service specific collectors with it.
+var Registry = prometheus.NewRegistry()
+
+// latencyBuckets are the histogram buckets of call and request durations, in seconds.
+var latencyBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+var (
+	// GrpcServerHandled counts the calls a gRPC server completed, by status code.
+	GrpcServerHandled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "gRPC calls completed by the server, by method and status code.",
+	}, []string{"grpc_service", "grpc_method", "grpc_type", "grpc_code"})
+
+	// GrpcServerHandlingSeconds measures how long a gRPC server took to handle calls.
+	GrpcServerHandlingSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Duration of gRPC calls handled by the server, in seconds.",
+		Buckets: latencyBuckets,
+	}, []string{"grpc_service", "grpc_method", "grpc_type"})
+
+	// GrpcClientHandled counts the calls a gRPC client completed, by status code.
+	GrpcClientHandled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_handled_total",
+		Help: "gRPC calls completed by the client, by method and status code.",
+	}, []string{"grpc_service", "grpc_method", "grpc_type", "grpc_code"})
+
+	// GrpcClientHandlingSeconds measures how long gRPC calls took as seen by the client.
+	GrpcClientHandlingSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_client_handling_seconds",
+		Help:    "Duration of gRPC calls made by the client until their status was received, in seconds.",
+		Buckets: latencyBuckets,
+	}, []string{"grpc_service", "grpc_method", "grpc_type"})
+
+	// GrpcClientRetries counts the retries of failed gRPC calls, by target.
+	GrpcClientRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_retries_total",
+		Help: "gRPC calls retried by the client after a retryable failure.",
+	}, []string{"target", "grpc_service", "grpc_method"})
+
+	// GrpcClientHedges counts the hedged attempts sent for slow gRPC calls.
+	GrpcClientHedges = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_client_hedges_total",
+		Help: "Additional attempts sent by the client for gRPC calls awaiting a response.",
+	}, []string{"target", "grpc_service", "grpc_method"})
+
+	// GrpcClientCircuitState is the circuit breaker state of each target:
+	// 0 closed, 1 open, 2 half-open.
+	GrpcClientCircuitState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "grpc_client_circuit_state",
+		Help: "Circuit breaker state of the client per target: 0 closed, 1 open, 2 half-open.",
+	}, []string{"target"})
+
+	// RateLimited counts the requests rejected by rate limits, by layer ("http"
+	// or "grpc") and rule pattern.
+	RateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "rate_limited_requests_total",
+		Help: "Requests rejected because the caller exceeded a rate limit.",
+	}, []string{"layer", "rule"})
+
+	// HTTPRequests counts the HTTP requests served, by route and status code.
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests served, by method, route and status code.",
+	}, []string{"method", "route", "code"})
+
+	// HTTPRequestSeconds measures how long HTTP requests took to serve.
+	HTTPRequestSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Duration of HTTP requests, in seconds.",
+		Buckets: latencyBuckets,
+	}, []string{"method", "route"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		GrpcServerHandled, GrpcServerHandlingSeconds,
+		GrpcClientHandled, GrpcClientHandlingSeconds,
+		GrpcClientRetries, GrpcClientHedges, GrpcClientCircuitState,
+		HTTPRequests, HTTPRequestSeconds,
+		RateLimited,
+	)
+}
+
+// Handler serves the collectors of Registry in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// Server serves /metrics on its own port, so scraping never competes with, or
+// is exposed through, the service's public listener.
+type Server struct {
+	server *http.Server
+	logger logger.Logger
+}
+
+// DefaultAddr returns the metrics listen address from METRICS_PORT (default
+// 9100). An empty METRICS_PORT disables the metrics server.
+func DefaultAddr() string {
+	port := utils.GetEnv("METRICS_PORT", "9100")
+	if port == "" {
+		return ""
+	}
+	return ":" + port
+}
+
+// NewServer creates a metrics server listening on addr.
+func NewServer(addr string, logger logger.Logger) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	return &Server{
+		server: &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second},
+		logger: logger,
+	}
+}
+
+// Start listens on the server address and serves in the background.
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return err
+	}
+	s.logger.Info("Serving metrics", "address", listener.Addr().String(), "path", "/metrics")
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("Metrics server failed", "error", err)
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the server, waiting for in-flight scrapes until ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS "audit_events" (
+    "id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 1,
+    "entity_type" varchar(100) NOT NULL,
+    "entity_id" varchar(100) NOT NULL,
+    "action" varchar(20) NOT NULL,
+    "before" jsonb,
+    "after" jsonb,
+    "actor" varchar(255),
+    "request_id" varchar(100),
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_audit_events_entity" ON "audit_events" ("entity_type", "entity_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_events_actor" ON "audit_events" ("actor");
+CREATE INDEX IF NOT EXISTS "idx_audit_events_request_id" ON "audit_events" ("request_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_events_deleted_at" ON "audit_events" ("deleted_at");
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS "outbox_messages" (
+    "id" uuid,
+    "name" varchar(100) NOT NULL,
+    "aggregate_id" varchar(100) NOT NULL,
+    "payload" jsonb NOT NULL,
+    "request_id" varchar(100),
+    "occurred_at" timestamptz NOT NULL,
+    "published_at" timestamptz,
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "last_error" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_outbox_messages_name" ON "outbox_messages" ("name");
+CREATE INDEX IF NOT EXISTS "idx_outbox_messages_aggregate_id" ON "outbox_messages" ("aggregate_id");
+CREATE INDEX IF NOT EXISTS "idx_outbox_messages_occurred_at" ON "outbox_messages" ("occurred_at");
+CREATE INDEX IF NOT EXISTS "idx_outbox_messages_published_at" ON "outbox_messages" ("published_at");
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "idempotency_keys" (
+    "method" varchar(255) NOT NULL,
+    "actor" varchar(100) NOT NULL DEFAULT '',
+    "key" varchar(255) NOT NULL,
+    "request_hash" char(64) NOT NULL,
+    "response" bytea,
+    "created_at" timestamptz NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    PRIMARY KEY ("method", "actor", "key")
+);
+CREATE INDEX IF NOT EXISTS "idx_idempotency_keys_expires_at" ON "idempotency_keys" ("expires_at");
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS "rate_limit_buckets" (
+    "key" text PRIMARY KEY,
+    "tokens" double precision NOT NULL,
+    "updated_at" timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS "idx_rate_limit_buckets_updated_at" ON "rate_limit_buckets" ("updated_at");
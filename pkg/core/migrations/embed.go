@@ -0,0 +1,16 @@
+// Package migrations holds the schema of the tables every service shares:
+// the audit trail, the outbox, the idempotency keys and the rate limit buckets.
+// Services apply it before their own migrations.
+package migrations
+
+import (
+	"embed"
+
+	"golang-microservices-boilerplate/pkg/core/database"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// Source is the shared core migration source.
+var Source = database.Source{Name: "core", FS: files}
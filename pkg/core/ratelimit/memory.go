@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps the buckets in this process, so each replica of a service
+// limits on its own.
+type MemoryStore struct {
+	idleTTL   time.Duration
+	now       func() time.Time
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+// bucket is the state of one token bucket.
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore(config Config) *MemoryStore {
+	return &MemoryStore{idleTTL: config.IdleTTL, now: time.Now, buckets: make(map[string]*bucket), lastSweep: time.Now()}
+}
+
+// Take implements Store.
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: limit.capacity(), updated: now}
+		s.buckets[key] = b
+	}
+	b.tokens = math.Min(limit.capacity(), b.tokens+now.Sub(b.updated).Seconds()*limit.rate())
+	b.updated = now
+	if b.tokens < 1 {
+		return Result{RetryAfter: retryAfter(b.tokens, limit)}, nil
+	}
+	b.tokens--
+	return Result{Allowed: true, Remaining: int(b.tokens)}, nil
+}
+
+// sweep drops the buckets idle for longer than idleTTL, at most once per
+// idleTTL. Callers hold s.mu.
+func (s *MemoryStore) sweep(now time.Time) {
+	if s.idleTTL <= 0 || now.Sub(s.lastSweep) < s.idleTTL {
+		return
+	}
+	s.lastSweep = now
+	for key, b := range s.buckets {
+		if now.Sub(b.updated) >= s.idleTTL {
+			delete(s.buckets, key)
+		}
+	}
+}
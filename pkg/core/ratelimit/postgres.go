@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PostgresStore keeps the buckets in the rate_limit_buckets table, created by
+// the core migrations, so that every replica pointing at the database shares
+// them. Refills are timed by the database clock.
+type PostgresStore struct {
+	db        *gorm.DB
+	idleTTL   time.Duration
+	lastPurge atomic.Int64 // Unix nanoseconds of the last purge
+}
+
+// NewPostgresStore creates a PostgresStore on db.
+func NewPostgresStore(db *gorm.DB, config Config) *PostgresStore {
+	return &PostgresStore{db: db, idleTTL: config.IdleTTL}
+}
+
+// takeSQL refills and takes a token from a bucket in one statement; the row
+// lock of the upsert serializes concurrent takes. No row is returned when the
+// bucket is empty, and the bucket is then left unchanged.
+const takeSQL = `INSERT INTO rate_limit_buckets AS b (key, tokens, updated_at)
+VALUES (@key, CAST(@capacity AS float8) - 1, clock_timestamp())
+ON CONFLICT (key) DO UPDATE
+SET tokens = LEAST(CAST(@capacity AS float8), b.tokens + GREATEST(EXTRACT(EPOCH FROM EXCLUDED.updated_at - b.updated_at)::float8, 0) * CAST(@rate AS float8)) - 1,
+    updated_at = EXCLUDED.updated_at
+WHERE LEAST(CAST(@capacity AS float8), b.tokens + GREATEST(EXTRACT(EPOCH FROM EXCLUDED.updated_at - b.updated_at)::float8, 0) * CAST(@rate AS float8)) >= 1
+RETURNING tokens`
+
+// peekSQL returns the tokens of a bucket now, without taking one.
+const peekSQL = `SELECT LEAST(CAST(@capacity AS float8), tokens + GREATEST(EXTRACT(EPOCH FROM clock_timestamp() - updated_at)::float8, 0) * CAST(@rate AS float8))
+FROM rate_limit_buckets WHERE key = @key`
+
+// Take implements Store.
+func (s *PostgresStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	args := map[string]interface{}{"key": key, "capacity": limit.capacity(), "rate": limit.rate()}
+	db := s.db.WithContext(ctx)
+
+	var remaining []float64
+	if err := db.Raw(takeSQL, args).Scan(&remaining).Error; err != nil {
+		return Result{}, fmt.Errorf("failed to take rate limit token: %w", err)
+	}
+	if len(remaining) > 0 {
+		s.purgeIfDue(ctx)
+		return Result{Allowed: true, Remaining: int(remaining[0])}, nil
+	}
+
+	var tokens []float64
+	if err := db.Raw(peekSQL, args).Scan(&tokens).Error; err != nil {
+		return Result{}, fmt.Errorf("failed to read rate limit bucket: %w", err)
+	}
+	result := Result{RetryAfter: limit.Period / time.Duration(limit.Requests)}
+	if len(tokens) > 0 {
+		result.RetryAfter = retryAfter(tokens[0], limit)
+	}
+	return result, nil
+}
+
+// PurgeIdle deletes the buckets idle for longer than the idle TTL and returns
+// how many were deleted.
+func (s *PostgresStore) PurgeIdle(ctx context.Context) (int64, error) {
+	result := s.db.WithContext(ctx).Exec("DELETE FROM rate_limit_buckets WHERE updated_at < ?", time.Now().Add(-s.idleTTL))
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge idle rate limit buckets: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// purgeIfDue purges idle buckets in the background at most once per idle
+// TTL. Purging only keeps the table small, so its failures are ignored.
+func (s *PostgresStore) purgeIfDue(ctx context.Context) {
+	if s.idleTTL <= 0 {
+		return
+	}
+	now := time.Now().UnixNano()
+	last := s.lastPurge.Load()
+	if now-last < int64(s.idleTTL) || !s.lastPurge.CompareAndSwap(last, now) {
+		return
+	}
+	go func() {
+		_, _ = s.PurgeIdle(context.WithoutCancel(ctx))
+	}()
+}
@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// TrustedProxies are the proxies, such as the API gateway, whose
+// X-Forwarded-For header is believed when telling callers apart by IP.
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies parses IPs and CIDR ranges, e.g. "10.0.0.7" or
+// "10.0.0.0/8". It fails on malformed entries, so a bad configuration fails
+// at startup rather than trusting nothing.
+func ParseTrustedProxies(proxies []string) (TrustedProxies, error) {
+	trusted := make(TrustedProxies, 0, len(proxies))
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if _, network, err := net.ParseCIDR(proxy); err == nil {
+			trusted = append(trusted, network)
+			continue
+		}
+		ip := net.ParseIP(proxy)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q (want an IP or CIDR range)", proxy)
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		trusted = append(trusted, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+	return trusted, nil
+}
+
+// Contains reports whether ip is a trusted proxy.
+func (p TrustedProxies) Contains(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range p {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the IP of the client of a request received from peer with
+// the X-Forwarded-For header forwardedFor. The header is only believed when
+// peer is a trusted proxy, and then up to its rightmost entry that is not a
+// trusted proxy too, since any client can send the header.
+func (p TrustedProxies) ClientIP(peer, forwardedFor string) string {
+	if forwardedFor == "" || !p.Contains(peer) {
+		return peer
+	}
+	hops := strings.Split(forwardedFor, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		if hop := strings.TrimSpace(hops[i]); hop != "" && (i == 0 || !p.Contains(hop)) {
+			return hop
+		}
+	}
+	return peer
+}
@@ -0,0 +1,185 @@
+// Package ratelimit throttles callers with token buckets: each caller, keyed
+// by client IP, authenticated subject or API key, may make a burst of requests
+// and then as many as its bucket refills. Buckets live in memory by default,
+// or in Postgres when several replicas must share them.
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// What callers are told apart by (see Rule.Key).
+const (
+	KeyIP      = "ip"      // Client IP address
+	KeySubject = "subject" // Subject of a verified access token; anonymous callers by IP
+	KeyAPIKey  = "api_key" // X-API-Key header; callers without one by IP
+)
+
+// Stores selectable with RATE_LIMIT_STORE.
+const (
+	StoreMemory   = "memory"   // Buckets of this process only
+	StorePostgres = "postgres" // Buckets shared through the rate_limit_buckets table
+)
+
+// Limit is a token bucket: Burst requests at once, refilled at Requests per Period.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int // Defaults to Requests
+}
+
+// rate returns the tokens added per second.
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// capacity returns the size of the bucket.
+func (l Limit) capacity() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return float64(l.Requests)
+}
+
+// Rule limits the requests matching Pattern, each caller in a bucket of its own.
+type Rule struct {
+	// Pattern matches requests with path.Match syntax, where * stops at "/".
+	// Gateway patterns are URL paths, optionally preceded by an HTTP method,
+	// e.g. "POST /api/v1/auth/login" or "/api/v1/users/bulk/*". gRPC patterns
+	// are full method names, e.g. "/userservice.UserService/*".
+	Pattern string
+	Key     string // KeyIP, KeySubject or KeyAPIKey
+	Limit
+}
+
+// Result is the outcome of taking a token.
+type Result struct {
+	Allowed    bool
+	Remaining  int           // Whole tokens left in the bucket
+	RetryAfter time.Duration // Until a token is available again, when not allowed
+}
+
+// Store keeps the token buckets.
+type Store interface {
+	// Take removes a token from the bucket of key, created full, after
+	// refilling it for the time since the last take.
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// Config selects the store of the buckets.
+type Config struct {
+	Store string // StoreMemory or StorePostgres
+
+	// IdleTTL is how long an untouched bucket is kept. It must exceed the time
+	// a bucket takes to refill, since dropped buckets come back full.
+	IdleTTL time.Duration
+}
+
+// DefaultConfig reads RATE_LIMIT_STORE (default memory) and
+// RATE_LIMIT_IDLE_TTL (default 1h).
+func DefaultConfig() Config {
+	return Config{
+		Store:   strings.ToLower(utils.GetEnv("RATE_LIMIT_STORE", StoreMemory)),
+		IdleTTL: utils.GetEnvDuration("RATE_LIMIT_IDLE_TTL", time.Hour),
+	}
+}
+
+// NewStore creates the store selected by config. db is only used, and then
+// required, by the Postgres store.
+func NewStore(config Config, db *gorm.DB) (Store, error) {
+	switch config.Store {
+	case StoreMemory, "":
+		return NewMemoryStore(config), nil
+	case StorePostgres:
+		if db == nil {
+			return nil, errors.New("the postgres rate limit store needs a database")
+		}
+		return NewPostgresStore(db, config), nil
+	}
+	return nil, fmt.Errorf("unknown rate limit store %q (want memory or postgres)", config.Store)
+}
+
+// Limiter applies the first of its rules that matches a request.
+type Limiter struct {
+	store Store
+	rules []Rule
+}
+
+// NewLimiter creates a Limiter keeping its buckets in store. It fails on
+// malformed patterns and limits, so a bad configuration fails at startup.
+func NewLimiter(store Store, rules ...Rule) (*Limiter, error) {
+	for _, rule := range rules {
+		_, pattern := splitPattern(rule.Pattern)
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid rate limit pattern %q: %w", rule.Pattern, err)
+		}
+		if rule.Requests <= 0 || rule.Period <= 0 || rule.Burst < 0 {
+			return nil, fmt.Errorf("rate limit of %q needs positive requests and period", rule.Pattern)
+		}
+		switch rule.Key {
+		case KeyIP, KeySubject, KeyAPIKey:
+		default:
+			return nil, fmt.Errorf("rate limit of %q has unknown key %q", rule.Pattern, rule.Key)
+		}
+	}
+	return &Limiter{store: store, rules: rules}, nil
+}
+
+// Match returns the first rule matching a request, or nil. method is the HTTP
+// method, or "" for gRPC calls, which are matched by full method name.
+func (l *Limiter) Match(method, target string) *Rule {
+	for i := range l.rules {
+		ruleMethod, pattern := splitPattern(l.rules[i].Pattern)
+		if ruleMethod != "" && !strings.EqualFold(ruleMethod, method) {
+			continue
+		}
+		if ok, _ := path.Match(pattern, target); ok {
+			return &l.rules[i]
+		}
+	}
+	return nil
+}
+
+// Allow takes a token from the bucket of caller under rule. caller identifies
+// the caller by rule.Key, e.g. "ip:10.0.0.7" or "subject:<user id>".
+func (l *Limiter) Allow(ctx context.Context, rule *Rule, caller string) (Result, error) {
+	return l.store.Take(ctx, rule.Pattern+" "+caller, rule.Limit)
+}
+
+// Caller identifies a caller by key: its subject or API key when it has one
+// and the key asks for it, otherwise its IP. API keys are hashed so that the
+// store never holds them.
+func Caller(key, ip, subject, apiKey string) string {
+	switch {
+	case key == KeySubject && subject != "":
+		return "subject:" + subject
+	case key == KeyAPIKey && apiKey != "":
+		sum := sha256.Sum256([]byte(apiKey))
+		return "api_key:" + hex.EncodeToString(sum[:16])
+	}
+	return "ip:" + ip
+}
+
+// splitPattern splits "METHOD /path" into its method and path pattern.
+func splitPattern(pattern string) (string, string) {
+	if method, rest, ok := strings.Cut(pattern, " "); ok {
+		return method, strings.TrimSpace(rest)
+	}
+	return "", pattern
+}
+
+// retryAfter returns how long a bucket holding tokens takes to hold one.
+func retryAfter(tokens float64, limit Limit) time.Duration {
+	return time.Duration((1 - tokens) / limit.rate() * float64(time.Second))
+}
@@ -0,0 +1,210 @@
+package ratelimit
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clock is a settable time source for MemoryStore.
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func newTestStore(idleTTL time.Duration) (*MemoryStore, *clock) {
+	c := &clock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	s := NewMemoryStore(Config{IdleTTL: idleTTL})
+	s.now, s.lastSweep = c.now, c.t
+	return s, c
+}
+
+func TestMemoryStoreTake(t *testing.T) {
+	perSecond := Limit{Requests: 2, Period: time.Second}
+	burst := Limit{Requests: 1, Period: time.Minute, Burst: 3}
+	steps := []struct {
+		name       string
+		limit      Limit
+		advance    time.Duration // Before taking
+		allowed    bool
+		remaining  int
+		retryAfter time.Duration
+	}{
+		{name: "full bucket", limit: perSecond, allowed: true, remaining: 1},
+		{name: "last token", limit: perSecond, allowed: true, remaining: 0},
+		{name: "empty", limit: perSecond, retryAfter: 500 * time.Millisecond},
+		{name: "half a token", limit: perSecond, advance: 250 * time.Millisecond, retryAfter: 250 * time.Millisecond},
+		{name: "refilled token", limit: perSecond, advance: 250 * time.Millisecond, allowed: true, remaining: 0},
+		{name: "refill capped at capacity", limit: perSecond, advance: time.Hour, allowed: true, remaining: 1},
+	}
+	s, c := newTestStore(0)
+	for _, step := range steps {
+		c.t = c.t.Add(step.advance)
+		got, err := s.Take(context.Background(), "key", step.limit)
+		if err != nil {
+			t.Fatalf("%s: Take failed: %v", step.name, err)
+		}
+		want := Result{Allowed: step.allowed, Remaining: step.remaining, RetryAfter: step.retryAfter}
+		if got != want {
+			t.Errorf("%s: Take = %+v, want %+v", step.name, got, want)
+		}
+	}
+
+	// Burst overrides the capacity, and the refill rate stays Requests per Period
+	for i := 0; i < 3; i++ {
+		if got, _ := s.Take(context.Background(), "burst", burst); !got.Allowed || got.Remaining != 2-i {
+			t.Errorf("burst take %d = %+v, want allowed with %d remaining", i, got, 2-i)
+		}
+	}
+	if got, _ := s.Take(context.Background(), "burst", burst); got.Allowed || got.RetryAfter != time.Minute {
+		t.Errorf("take beyond the burst = %+v, want a retry after 1m", got)
+	}
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	limit := Limit{Requests: 1, Period: time.Hour}
+	s, c := newTestStore(time.Minute)
+	take := func(key string) Result {
+		t.Helper()
+		result, err := s.Take(context.Background(), key, limit)
+		if err != nil {
+			t.Fatalf("Take(%s) failed: %v", key, err)
+		}
+		return result
+	}
+
+	take("idle")
+	c.t = c.t.Add(30 * time.Second)
+	take("recent")
+	c.t = c.t.Add(31 * time.Second)
+	take("new") // Sweeps: idle for 61s, recent for 31s
+	if _, ok := s.buckets["idle"]; ok {
+		t.Error("bucket idle for longer than IdleTTL was kept")
+	}
+	if _, ok := s.buckets["recent"]; !ok {
+		t.Error("bucket used within IdleTTL was dropped")
+	}
+	if result := take("idle"); !result.Allowed {
+		t.Error("dropped bucket did not come back full")
+	}
+
+	// Sweeps run at most once per IdleTTL
+	c.t = c.t.Add(59 * time.Second)
+	take("other")
+	if _, ok := s.buckets["recent"]; !ok {
+		t.Error("sweep ran again within IdleTTL")
+	}
+}
+
+func TestLimiterMatch(t *testing.T) {
+	limit := Limit{Requests: 1, Period: time.Minute}
+	limiter, err := NewLimiter(NewMemoryStore(Config{}),
+		Rule{Pattern: "POST /api/v1/auth/login", Key: KeyIP, Limit: limit},
+		Rule{Pattern: "/api/v1/users/bulk/*", Key: KeySubject, Limit: limit},
+		Rule{Pattern: "/userservice.UserService/Login", Key: KeyIP, Limit: limit},
+		Rule{Pattern: "/userservice.UserService/*", Key: KeySubject, Limit: limit},
+	)
+	if err != nil {
+		t.Fatalf("NewLimiter failed: %v", err)
+	}
+	cases := []struct {
+		method, target string
+		want           string // Pattern of the matched rule; "" for none
+	}{
+		{"POST", "/api/v1/auth/login", "POST /api/v1/auth/login"},
+		{"post", "/api/v1/auth/login", "POST /api/v1/auth/login"},
+		{"GET", "/api/v1/auth/login", ""},
+		{"", "/api/v1/auth/login", ""},
+		{"DELETE", "/api/v1/users/bulk/delete", "/api/v1/users/bulk/*"},
+		{"POST", "/api/v1/users/bulk/a/b", ""}, // * stops at "/"
+		{"POST", "/api/v1/users/bulk", ""},
+		{"", "/userservice.UserService/Login", "/userservice.UserService/Login"}, // First match wins
+		{"", "/userservice.UserService/DeleteMany", "/userservice.UserService/*"},
+		{"", "/userservice.UserService/", "/userservice.UserService/*"},
+		{"", "/other.Service/Login", ""},
+	}
+	for _, tc := range cases {
+		rule := limiter.Match(tc.method, tc.target)
+		var got string
+		if rule != nil {
+			got = rule.Pattern
+		}
+		if got != tc.want {
+			t.Errorf("Match(%q, %q) = %q, want %q", tc.method, tc.target, got, tc.want)
+		}
+	}
+}
+
+func TestNewLimiterRejects(t *testing.T) {
+	limit := Limit{Requests: 1, Period: time.Minute}
+	cases := map[string]Rule{
+		"malformed pattern": {Pattern: "/api/[", Key: KeyIP, Limit: limit},
+		"no requests":       {Pattern: "/api", Key: KeyIP, Limit: Limit{Period: time.Minute}},
+		"no period":         {Pattern: "/api", Key: KeyIP, Limit: Limit{Requests: 1}},
+		"negative burst":    {Pattern: "/api", Key: KeyIP, Limit: Limit{Requests: 1, Period: time.Minute, Burst: -1}},
+		"unknown key":       {Pattern: "/api", Key: "user", Limit: limit},
+	}
+	for name, rule := range cases {
+		if _, err := NewLimiter(NewMemoryStore(Config{}), rule); err == nil {
+			t.Errorf("%s: NewLimiter accepted %+v", name, rule)
+		}
+	}
+}
+
+func TestCaller(t *testing.T) {
+	cases := []struct {
+		key, ip, subject, apiKey string
+		want                     string
+	}{
+		{KeyIP, "10.0.0.7", "user-1", "secret", "ip:10.0.0.7"},
+		{KeySubject, "10.0.0.7", "user-1", "secret", "subject:user-1"},
+		{KeySubject, "10.0.0.7", "", "secret", "ip:10.0.0.7"},
+		{KeyAPIKey, "10.0.0.7", "", "", "ip:10.0.0.7"},
+		{KeyAPIKey, "10.0.0.7", "user-1", "secret", "api_key:2bb80d537b1da3e38bd30361aa855686"},
+	}
+	for _, tc := range cases {
+		if got := Caller(tc.key, tc.ip, tc.subject, tc.apiKey); got != tc.want {
+			t.Errorf("Caller(%q, %q, %q, %q) = %q, want %q", tc.key, tc.ip, tc.subject, tc.apiKey, got, tc.want)
+		}
+	}
+	if got := Caller(KeyAPIKey, "", "", "secret"); strings.Contains(got, "secret") {
+		t.Errorf("Caller kept the API key in %q", got)
+	}
+}
+
+func TestTrustedProxiesClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", " 192.168.1.5 ", "fd00::/8"})
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies failed: %v", err)
+	}
+	cases := []struct {
+		name, peer, forwardedFor string
+		want                     string
+	}{
+		{"direct caller", "203.0.113.9", "", "203.0.113.9"},
+		{"spoofed header", "203.0.113.9", "198.51.100.1", "203.0.113.9"},
+		{"gateway in a range", "10.1.2.3", "198.51.100.1", "198.51.100.1"},
+		{"gateway by IP", "192.168.1.5", "198.51.100.1", "198.51.100.1"},
+		{"other IP of its subnet", "192.168.1.6", "198.51.100.1", "192.168.1.6"},
+		{"IPv6 gateway", "fd00::1", "2001:db8::1", "2001:db8::1"},
+		{"gateway without header", "10.1.2.3", "", "10.1.2.3"},
+		{"client prepending a spoofed hop", "10.1.2.3", "198.51.100.66, 198.51.100.1", "198.51.100.1"},
+		{"chain of trusted proxies", "10.1.2.3", "198.51.100.1, 10.9.9.9", "198.51.100.1"},
+		{"only trusted hops", "10.1.2.3", "10.9.9.9", "10.9.9.9"},
+	}
+	for _, tc := range cases {
+		if got := proxies.ClientIP(tc.peer, tc.forwardedFor); got != tc.want {
+			t.Errorf("%s: ClientIP(%q, %q) = %q, want %q", tc.name, tc.peer, tc.forwardedFor, got, tc.want)
+		}
+	}
+
+	var none TrustedProxies
+	if got := none.ClientIP("10.1.2.3", "198.51.100.1"); got != "10.1.2.3" {
+		t.Errorf("ClientIP without trusted proxies = %q, want the peer", got)
+	}
+	for _, bad := range []string{"gateway", "10.0.0.0/33", ""} {
+		if _, err := ParseTrustedProxies([]string{bad}); err == nil {
+			t.Errorf("ParseTrustedProxies accepted %q", bad)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"golang-microservices-boilerplate/pkg/core/database"
+	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/utils/cache"
+)
+
+// CachedRepository decorates a BaseRepository with a cache of FindByID
+// results. Update, Delete, Restore and their bulk variants invalidate the
+// entities they change, once more after the unit of work they run in commits
+// (see database.AfterCommit). Every other method goes straight to the wrapped
+// repository.
+//
+// Reads inside a unit of work or a Transaction bypass the cache, so they see
+// uncommitted changes and never cache them. Reads marked with
+// database.WithPrimary skip the cached value and refresh it. FindByID returns a
+// shallow copy of the cached entity: callers may change its fields but must
+// not modify the slices or associations it shares with the cache.
+//
+// The cache is local to the process, so changes made by other instances are
+// only picked up when entries expire; keep Options.TTL short for entities
+// that other services write.
+type CachedRepository[T entity.Entity] struct {
+	BaseRepository[T]
+	cache *cache.Cache[uuid.UUID, T]
+}
+
+// Ensure CachedRepository implements BaseRepository.
+var _ BaseRepository[entity.BaseEntity] = (*CachedRepository[entity.BaseEntity])(nil)
+
+// NewCachedRepository wraps repo with a FindByID cache configured by opts.
+func NewCachedRepository[T entity.Entity](repo BaseRepository[T], opts cache.Options) *CachedRepository[T] {
+	return &CachedRepository[T]{BaseRepository: repo, cache: cache.New[uuid.UUID, T](opts)}
+}
+
+// Stats returns the hit, miss, load and eviction counters of the cache.
+func (r *CachedRepository[T]) Stats() cache.Stats {
+	return r.cache.Stats()
+}
+
+// Invalidate drops the cached entities with the given IDs now and again after
+// the unit of work of ctx commits. Decorators of custom repositories call it
+// for writes CachedRepository does not know about.
+func (r *CachedRepository[T]) Invalidate(ctx context.Context, ids ...uuid.UUID) {
+	r.evict(ids)
+	database.AfterCommit(ctx, func() { r.evict(ids) })
+}
+
+// evict drops the cached entities with the given IDs.
+func (r *CachedRepository[T]) evict(ids []uuid.UUID) {
+	for _, id := range ids {
+		r.cache.Delete(id)
+	}
+}
+
+// loadTimeout bounds a shared FindByID query, which does not end with the
+// caller that started it.
+const loadTimeout = 10 * time.Second
+
+// FindByID returns a copy of the cached entity, loading it from the wrapped
+// repository on a miss. Concurrent misses for the same ID share one query,
+// which runs detached from the cancellation of the caller starting it so that
+// the others still get its result.
+func (r *CachedRepository[T]) FindByID(ctx context.Context, id uuid.UUID) (*T, error) {
+	if _, inTx := database.TxFromContext(ctx); inTx {
+		return r.BaseRepository.FindByID(ctx, id)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if database.UsesPrimary(ctx) {
+		r.cache.Delete(id)
+	}
+	value, err := r.cache.GetOrLoad(id, func() (T, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+		e, err := r.BaseRepository.FindByID(loadCtx, id)
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		return *e, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// Update implements BaseRepository, invalidating the entity.
+func (r *CachedRepository[T]) Update(ctx context.Context, e *T) error {
+	defer r.Invalidate(ctx, (*e).GetID())
+	return r.BaseRepository.Update(ctx, e)
+}
+
+// Delete implements BaseRepository, invalidating the entity.
+func (r *CachedRepository[T]) Delete(ctx context.Context, id uuid.UUID, hardDelete bool) error {
+	defer r.Invalidate(ctx, id)
+	return r.BaseRepository.Delete(ctx, id, hardDelete)
+}
+
+// Restore implements BaseRepository, invalidating the entity.
+func (r *CachedRepository[T]) Restore(ctx context.Context, id uuid.UUID) error {
+	defer r.Invalidate(ctx, id)
+	return r.BaseRepository.Restore(ctx, id)
+}
+
+// UpdateMany implements BaseRepository, invalidating the entities.
+func (r *CachedRepository[T]) UpdateMany(ctx context.Context, entities []*T) error {
+	ids := make([]uuid.UUID, len(entities))
+	for i, e := range entities {
+		ids[i] = (*e).GetID()
+	}
+	defer r.Invalidate(ctx, ids...)
+	return r.BaseRepository.UpdateMany(ctx, entities)
+}
+
+// DeleteMany implements BaseRepository, invalidating the entities.
+func (r *CachedRepository[T]) DeleteMany(ctx context.Context, ids []uuid.UUID, hardDelete bool) error {
+	defer r.Invalidate(ctx, ids...)
+	return r.BaseRepository.DeleteMany(ctx, ids, hardDelete)
+}
+
+// PurgeDeleted implements BaseRepository. Soft-deleted entities are never
+// cached, so nothing needs to be invalidated.
+func (r *CachedRepository[T]) PurgeDeleted(ctx context.Context, olderThan time.Duration) (int64, error) {
+	return r.BaseRepository.PurgeDeleted(ctx, olderThan)
+}
+
+// Transaction implements BaseRepository. The transaction repository bypasses
+// the cache for reads and invalidates the entities it writes once the
+// transaction has committed.
+func (r *CachedRepository[T]) Transaction(ctx context.Context, fn func(txRepo BaseRepository[T]) error) error {
+	var written []uuid.UUID
+	err := r.BaseRepository.Transaction(ctx, func(txRepo BaseRepository[T]) error {
+		return fn(&txCachedRepository[T]{BaseRepository: txRepo, written: &written})
+	})
+	r.Invalidate(ctx, written...)
+	return err
+}
+
+// txCachedRepository is the repository CachedRepository.Transaction hands to
+// its callback. It records the IDs of written entities instead of reading
+// through the cache.
+type txCachedRepository[T entity.Entity] struct {
+	BaseRepository[T]
+	written *[]uuid.UUID
+}
+
+func (r *txCachedRepository[T]) Update(ctx context.Context, e *T) error {
+	*r.written = append(*r.written, (*e).GetID())
+	return r.BaseRepository.Update(ctx, e)
+}
+
+func (r *txCachedRepository[T]) Delete(ctx context.Context, id uuid.UUID, hardDelete bool) error {
+	*r.written = append(*r.written, id)
+	return r.BaseRepository.Delete(ctx, id, hardDelete)
+}
+
+func (r *txCachedRepository[T]) Restore(ctx context.Context, id uuid.UUID) error {
+	*r.written = append(*r.written, id)
+	return r.BaseRepository.Restore(ctx, id)
+}
+
+func (r *txCachedRepository[T]) UpdateMany(ctx context.Context, entities []*T) error {
+	for _, e := range entities {
+		*r.written = append(*r.written, (*e).GetID())
+	}
+	return r.BaseRepository.UpdateMany(ctx, entities)
+}
+
+func (r *txCachedRepository[T]) DeleteMany(ctx context.Context, ids []uuid.UUID, hardDelete bool) error {
+	*r.written = append(*r.written, ids...)
+	return r.BaseRepository.DeleteMany(ctx, ids, hardDelete)
+}
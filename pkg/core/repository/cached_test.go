@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"golang-microservices-boilerplate/pkg/core/database"
+	"golang-microservices-boilerplate/pkg/utils/cache"
+)
+
+var _ BaseRepository[widget] = (*CachedRepository[widget])(nil)
+
+func TestCachedRepositoryConformance(t *testing.T) {
+	runConformance(t, func(t *testing.T) BaseRepository[widget] {
+		return NewCachedRepository[widget](NewMemoryBaseRepository[widget](), cache.Options{TTL: time.Minute})
+	})
+}
+
+func TestCachedRepositoryFindByID(t *testing.T) {
+	ctx := context.Background()
+	repo := NewCachedRepository[widget](NewMemoryBaseRepository[widget](), cache.Options{TTL: time.Minute})
+	w := newWidget("cached", 1, "")
+	if err := repo.Create(ctx, w); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	first, err := repo.FindByID(ctx, w.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	first.Size = 99 // Callers get copies; the cached entity must not change
+	second, err := repo.FindByID(ctx, w.ID)
+	if err != nil || second.Size != 1 {
+		t.Fatalf("FindByID = %+v, %v, want the cached size 1", second, err)
+	}
+	if stats := repo.Stats(); stats.Hits != 1 || stats.Loads != 1 {
+		t.Fatalf("Stats = %+v, want 1 hit and 1 load", stats)
+	}
+
+	second.Size = 2
+	if err := repo.Update(ctx, second); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if got, err := repo.FindByID(ctx, w.ID); err != nil || got.Size != 2 {
+		t.Fatalf("FindByID after Update = %+v, %v, want size 2", got, err)
+	}
+
+	if err := repo.Delete(ctx, w.ID, false); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repo.FindByID(ctx, w.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("FindByID after Delete = %v, want not found", err)
+	}
+}
+
+func TestCachedRepositoryBypassesUnitOfWork(t *testing.T) {
+	repo := NewCachedRepository[widget](NewMemoryBaseRepository[widget](), cache.Options{})
+	w := newWidget("tx", 1, "")
+	if err := repo.Create(context.Background(), w); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	ctx := database.WithTx(context.Background(), nil)
+	for i := 0; i < 2; i++ {
+		if _, err := repo.FindByID(ctx, w.ID); err != nil {
+			t.Fatalf("FindByID: %v", err)
+		}
+	}
+	if stats := repo.Stats(); stats.Entries != 0 || stats.Loads != 0 {
+		t.Fatalf("Stats = %+v, want reads in a unit of work to bypass the cache", stats)
+	}
+}
+
+// blockingRepository holds FindByID until release is closed.
+type blockingRepository struct {
+	BaseRepository[widget]
+	started chan struct{}
+	release chan struct{}
+}
+
+func (r *blockingRepository) FindByID(ctx context.Context, id uuid.UUID) (*widget, error) {
+	close(r.started)
+	<-r.release
+	return r.BaseRepository.FindByID(ctx, id)
+}
+
+func TestCachedRepositorySharedLoadOutlivesCaller(t *testing.T) {
+	inner := &blockingRepository{BaseRepository: NewMemoryBaseRepository[widget](), started: make(chan struct{}), release: make(chan struct{})}
+	w := newWidget("shared", 1, "")
+	if err := inner.Create(context.Background(), w); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	repo := NewCachedRepository[widget](inner, cache.Options{TTL: time.Minute})
+
+	// The first caller starts the load, the second waits for it
+	firstCtx, cancel := context.WithCancel(context.Background())
+	firstDone := make(chan error, 1)
+	go func() {
+		_, err := repo.FindByID(firstCtx, w.ID)
+		firstDone <- err
+	}()
+	<-inner.started
+	type result struct {
+		w   *widget
+		err error
+	}
+	secondDone := make(chan result, 1)
+	go func() {
+		got, err := repo.FindByID(context.Background(), w.ID)
+		secondDone <- result{got, err}
+	}()
+	for repo.Stats().Misses < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Cancelling the first caller must not fail the second one
+	cancel()
+	close(inner.release)
+	<-firstDone
+	if got := <-secondDone; got.err != nil || got.w.Name != "shared" {
+		t.Fatalf("waiting FindByID = %+v, %v, want the shared widget", got.w, got.err)
+	}
+	if stats := repo.Stats(); stats.Loads != 1 || stats.Entries != 1 {
+		t.Fatalf("Stats = %+v, want one load cached", stats)
+	}
+}
@@ -0,0 +1,582 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/core/types"
+)
+
+// widget is the entity used by the conformance suite.
+type widget struct {
+	entity.BaseEntity
+	Name  string `gorm:"uniqueIndex;not null"`
+	Size  int
+	Color *string
+}
+
+func (widget) TableName() string { return "conformance_widgets" }
+
+func (widget) FilterableColumns() []string {
+	return append(entity.BaseEntity{}.FilterableColumns(), "name", "size", "color")
+}
+
+func (widget) SortableColumns() []string {
+	return append(entity.BaseEntity{}.SortableColumns(), "name", "size")
+}
+
+// newRepoFunc returns an empty repository for one conformance test.
+type newRepoFunc func(t *testing.T) BaseRepository[widget]
+
+// runConformance runs the behaviour every BaseRepository implementation must share.
+func runConformance(t *testing.T, newRepo newRepoFunc) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo BaseRepository[widget])
+	}{
+		{"CreateAndFind", testCreateAndFind},
+		{"UniqueViolation", testUniqueViolation},
+		{"UpdateWithOptimisticLocking", testUpdateWithOptimisticLocking},
+		{"UpdateClearsFields", testUpdateClearsFields},
+		{"FilterOperators", testFilterOperators},
+		{"SortAndOffsetPagination", testSortAndOffsetPagination},
+		{"CursorPagination", testCursorPagination},
+		{"ForEach", testForEach},
+		{"SoftDeleteAndRestore", testSoftDeleteAndRestore},
+		{"PurgeDeleted", testPurgeDeleted},
+		{"BulkOperations", testBulkOperations},
+		{"Transaction", testTransaction},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+func newWidget(name string, size int, color string) *widget {
+	w := &widget{Name: name, Size: size}
+	if color != "" {
+		w.Color = &color
+	}
+	return w
+}
+
+// seedWidgets creates widgets w1..wN with sizes 1..N, alternating red/blue and
+// leaving every third color NULL.
+func seedWidgets(t *testing.T, repo BaseRepository[widget], n int) []*widget {
+	t.Helper()
+	widgets := make([]*widget, n)
+	for i := range widgets {
+		color := []string{"red", "blue", ""}[i%3]
+		widgets[i] = newWidget(fmt.Sprintf("w%d", i+1), i+1, color)
+		if err := repo.Create(context.Background(), widgets[i]); err != nil {
+			t.Fatalf("Create(%s): %v", widgets[i].Name, err)
+		}
+	}
+	return widgets
+}
+
+func names(items []*widget) []string {
+	out := make([]string, len(items))
+	for i, w := range items {
+		out[i] = w.Name
+	}
+	return out
+}
+
+func assertNames(t *testing.T, got []*widget, want ...string) {
+	t.Helper()
+	if fmt.Sprint(names(got)) != fmt.Sprint(want) {
+		t.Fatalf("got %v, want %v", names(got), want)
+	}
+}
+
+func testCreateAndFind(t *testing.T, repo BaseRepository[widget]) {
+	ctx := context.Background()
+	w := newWidget("alpha", 3, "red")
+	if err := repo.Create(ctx, w); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if w.ID == uuid.Nil || w.Version != 1 || w.CreatedAt.IsZero() || w.UpdatedAt.IsZero() {
+		t.Fatalf("Create did not fill ID/version/timestamps: %+v", w.BaseEntity)
+	}
+
+	got, err := repo.FindByID(ctx, w.ID)
+	if err != nil {
+		t.Fatalf("FindByID: %v", err)
+	}
+	if got.Name != "alpha" || got.Size != 3 || got.Color == nil || *got.Color != "red" || got.Version != 1 {
+		t.Fatalf("FindByID returned %+v", got)
+	}
+
+	_, err = repo.FindByID(ctx, uuid.New())
+	var notFound *NotFoundError
+	if !errors.Is(err, ErrNotFound) || !errors.As(err, &notFound) {
+		t.Fatalf("FindByID(missing) error = %v, want *NotFoundError", err)
+	}
+
+	one, err := repo.FindOneWithFilter(ctx, map[string]interface{}{"name": "alpha"})
+	if err != nil || one.ID != w.ID {
+		t.Fatalf("FindOneWithFilter = %v, %v", one, err)
+	}
+	if _, err := repo.FindOneWithFilter(ctx, map[string]interface{}{"name": "missing"}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("FindOneWithFilter(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func testUniqueViolation(t *testing.T, repo BaseRepository[widget]) {
+	ctx := context.Background()
+	if err := repo.Create(ctx, newWidget("dup", 1, "")); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	err := repo.Create(ctx, newWidget("dup", 2, ""))
+	var constraintErr *ConstraintError
+	if !errors.Is(err, ErrUniqueViolation) || !errors.As(err, &constraintErr) {
+		t.Fatalf("Create(duplicate) error = %v, want unique violation", err)
+	}
+	if fmt.Sprint(constraintErr.Columns) != "[name]" {
+		t.Fatalf("constraint columns = %v, want [name]", constraintErr.Columns)
+	}
+
+	// Soft-deleted rows still hold their unique keys
+	existing, _ := repo.FindOneWithFilter(ctx, map[string]interface{}{"name": "dup"})
+	if err := repo.Delete(ctx, existing.ID, false); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := repo.Create(ctx, newWidget("dup", 3, "")); !errors.Is(err, ErrUniqueViolation) {
+		t.Fatalf("Create(duplicate of deleted) error = %v, want unique violation", err)
+	}
+}
+
+func testUpdateWithOptimisticLocking(t *testing.T, repo BaseRepository[widget]) {
+	ctx := context.Background()
+	w := newWidget("beta", 1, "red")
+	if err := repo.Create(ctx, w); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	stale := *w
+
+	w.Size = 7
+	if err := repo.Update(ctx, w); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if w.Version != 2 {
+		t.Fatalf("version after update = %d, want 2", w.Version)
+	}
+	got, _ := repo.FindByID(ctx, w.ID)
+	if got.Size != 7 || got.Name != "beta" || got.Version != 2 {
+		t.Fatalf("stored after update = %+v", got)
+	}
+
+	stale.Size = 9
+	if err := repo.Update(ctx, &stale); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("Update(stale) error = %v, want ErrVersionConflict", err)
+	}
+	if stale.Version != 1 {
+		t.Fatalf("stale version = %d, want it restored to 1", stale.Version)
+	}
+	if got, _ := repo.FindByID(ctx, w.ID); got.Size != 7 {
+		t.Fatalf("stale update was applied: size = %d", got.Size)
+	}
+
+	other := newWidget("gamma", 1, "")
+	if err := repo.Create(ctx, other); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	other.Name = "beta"
+	if err := repo.Update(ctx, other); !errors.Is(err, ErrUniqueViolation) {
+		t.Fatalf("Update(duplicate name) error = %v, want unique violation", err)
+	}
+
+	if err := repo.Update(ctx, &widget{Name: "no-id"}); err == nil {
+		t.Fatal("Update without ID succeeded")
+	}
+}
+
+// testUpdateClearsFields checks that zero values are written, as field masks
+// clear fields that way.
+func testUpdateClearsFields(t *testing.T, repo BaseRepository[widget]) {
+	ctx := context.Background()
+	w := newWidget("delta", 4, "red")
+	if err := repo.Create(ctx, w); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	w.Size, w.Color = 0, nil
+	if err := repo.Update(ctx, w); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, _ := repo.FindByID(ctx, w.ID)
+	if got.Size != 0 || got.Color != nil || got.Name != "delta" || got.Version != 2 {
+		t.Fatalf("stored after clearing = %+v", got)
+	}
+
+	w.Size = 0
+	if err := repo.UpdateMany(ctx, []*widget{w}); err != nil {
+		t.Fatalf("UpdateMany: %v", err)
+	}
+	if got, _ := repo.FindByID(ctx, w.ID); got.Size != 0 || got.Color != nil || got.CreatedAt.IsZero() {
+		t.Fatalf("stored after bulk update = %+v", got)
+	}
+}
+
+func testFilterOperators(t *testing.T, repo BaseRepository[widget]) {
+	ctx := context.Background()
+	seedWidgets(t, repo, 6) // colors: red, blue, NULL, red, blue, NULL
+
+	tests := []struct {
+		filters map[string]interface{}
+		want    []string
+	}{
+		{map[string]interface{}{"color": "red"}, []string{"w1", "w4"}},
+		{map[string]interface{}{"color": map[string]interface{}{"ne": "red"}}, []string{"w2", "w5"}},
+		{map[string]interface{}{"size": map[string]interface{}{"gt": 4}}, []string{"w5", "w6"}},
+		{map[string]interface{}{"size": map[string]interface{}{"gte": 2, "lt": 4}}, []string{"w2", "w3"}},
+		{map[string]interface{}{"size": map[string]interface{}{"lte": 1.0}}, []string{"w1"}},
+		{map[string]interface{}{"size": []interface{}{1, 3, 5}}, []string{"w1", "w3", "w5"}},
+		{map[string]interface{}{"size": map[string]interface{}{"between": []interface{}{2, 3}}}, []string{"w2", "w3"}},
+		{map[string]interface{}{"name": map[string]interface{}{"ilike": "W_"}}, []string{"w1", "w2", "w3", "w4", "w5", "w6"}},
+		{map[string]interface{}{"color": map[string]interface{}{"ilike": "%LU%"}}, []string{"w2", "w5"}},
+		{map[string]interface{}{"color": nil}, []string{"w3", "w6"}},
+		{map[string]interface{}{"color": map[string]interface{}{"is_null": false}, "size": map[string]interface{}{"gt": 3}}, []string{"w4", "w5"}},
+	}
+	for _, tt := range tests {
+		opts := types.FilterOptions{SortBy: "size", Filters: tt.filters}
+		result, err := repo.FindAll(ctx, opts)
+		if err != nil {
+			t.Fatalf("FindAll(%v): %v", tt.filters, err)
+		}
+		if fmt.Sprint(names(result.Items)) != fmt.Sprint(tt.want) || result.TotalItems != int64(len(tt.want)) {
+			t.Errorf("FindAll(%v) = %v (total %d), want %v", tt.filters, names(result.Items), result.TotalItems, tt.want)
+		}
+		count, err := repo.Count(ctx, tt.filters)
+		if err != nil || count != int64(len(tt.want)) {
+			t.Errorf("Count(%v) = %d, %v, want %d", tt.filters, count, err, len(tt.want))
+		}
+	}
+
+	result, err := repo.FindWithFilter(ctx, map[string]interface{}{"color": "blue"}, types.FilterOptions{SortBy: "size", SortDesc: true})
+	if err != nil {
+		t.Fatalf("FindWithFilter: %v", err)
+	}
+	assertNames(t, result.Items, "w5", "w2")
+
+	invalid := []map[string]interface{}{
+		{"version": 1}, // not filterable
+		{"size": map[string]interface{}{"like": "1"}},    // unknown operator
+		{"size": map[string]interface{}{"between": 1.0}}, // malformed operand
+	}
+	for _, filters := range invalid {
+		if _, err := repo.FindAll(ctx, types.FilterOptions{Filters: filters}); !errors.Is(err, types.ErrInvalidFilter) {
+			t.Errorf("FindAll(%v) error = %v, want ErrInvalidFilter", filters, err)
+		}
+		if _, err := repo.Count(ctx, filters); !errors.Is(err, types.ErrInvalidFilter) {
+			t.Errorf("Count(%v) error = %v, want ErrInvalidFilter", filters, err)
+		}
+	}
+	if _, err := repo.FindAll(ctx, types.FilterOptions{SortBy: "color"}); !errors.Is(err, types.ErrInvalidFilter) {
+		t.Errorf("FindAll(sort by color) error = %v, want ErrInvalidFilter", err)
+	}
+}
+
+func testSortAndOffsetPagination(t *testing.T, repo BaseRepository[widget]) {
+	ctx := context.Background()
+	seedWidgets(t, repo, 5)
+
+	result, err := repo.FindAll(ctx, types.FilterOptions{SortBy: "size", SortDesc: true, Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	assertNames(t, result.Items, "w4", "w3")
+	if result.TotalItems != 5 || result.Limit != 2 || result.Offset != 1 || result.NextCursor == "" {
+		t.Fatalf("pagination = total %d, limit %d, offset %d, cursor %q", result.TotalItems, result.Limit, result.Offset, result.NextCursor)
+	}
+
+	result, err = repo.FindAll(ctx, types.FilterOptions{SortBy: "name", Offset: 3, SkipCount: true})
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	assertNames(t, result.Items, "w4", "w5")
+	if result.TotalItems != -1 || result.Limit != 50 || result.NextCursor != "" {
+		t.Fatalf("pagination = total %d, limit %d, cursor %q", result.TotalItems, result.Limit, result.NextCursor)
+	}
+}
+
+func testCursorPagination(t *testing.T, repo BaseRepository[widget]) {
+	ctx := context.Background()
+	seedWidgets(t, repo, 5)
+	// Two widgets sharing a size exercise the ID tie-breaker
+	if err := repo.Create(ctx, newWidget("w3b", 3, "")); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	for _, desc := range []bool{false, true} {
+		opts := types.FilterOptions{SortBy: "size", SortDesc: desc, Limit: 2}
+		var seen []*widget
+		for page := 0; ; page++ {
+			if page > 6 {
+				t.Fatal("cursor pagination does not terminate")
+			}
+			result, err := repo.FindAll(ctx, opts)
+			if err != nil {
+				t.Fatalf("FindAll(page %d): %v", page, err)
+			}
+			seen = append(seen, result.Items...)
+			if result.NextCursor == "" {
+				break
+			}
+			opts.Cursor = result.NextCursor
+		}
+		if len(seen) != 6 {
+			t.Fatalf("desc=%v: walked %v, want 6 distinct items", desc, names(seen))
+		}
+		ids := map[uuid.UUID]bool{}
+		for i, w := range seen {
+			if ids[w.ID] {
+				t.Fatalf("desc=%v: %s returned twice", desc, w.Name)
+			}
+			ids[w.ID] = true
+			if i > 0 && ((!desc && w.Size < seen[i-1].Size) || (desc && w.Size > seen[i-1].Size)) {
+				t.Fatalf("desc=%v: out of order: %v", desc, names(seen))
+			}
+		}
+	}
+
+	first, err := repo.FindAll(ctx, types.FilterOptions{SortBy: "size", Limit: 2})
+	if err != nil {
+		t.Fatalf("FindAll: %v", err)
+	}
+	if _, err := repo.FindAll(ctx, types.FilterOptions{SortBy: "name", Cursor: first.NextCursor}); !errors.Is(err, types.ErrInvalidCursor) {
+		t.Fatalf("FindAll(mismatched cursor) error = %v, want ErrInvalidCursor", err)
+	}
+	if _, err := repo.FindAll(ctx, types.FilterOptions{Cursor: "not-a-cursor"}); !errors.Is(err, types.ErrInvalidCursor) {
+		t.Fatalf("FindAll(garbage cursor) error = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func testForEach(t *testing.T, repo BaseRepository[widget]) {
+	ctx := context.Background()
+	seedWidgets(t, repo, 7)
+
+	var seen []*widget
+	opts := types.FilterOptions{SortBy: "size", SortDesc: true, Limit: 3, Offset: 5, Filters: map[string]interface{}{"size": map[string]interface{}{"gt": 1}}}
+	if err := ForEach(ctx, repo, opts, func(w *widget) error {
+		seen = append(seen, w)
+		return nil
+	}); err != nil {
+		t.Fatalf("ForEach: %v", err)
+	}
+	assertNames(t, seen, "w7", "w6", "w5", "w4", "w3", "w2")
+
+	stop := errors.New("stop")
+	calls := 0
+	err := ForEach(ctx, repo, types.FilterOptions{Limit: 2}, func(*widget) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Fatalf("ForEach = %v after %d calls, want the callback error after 1 call", err, calls)
+	}
+}
+
+func testSoftDeleteAndRestore(t *testing.T, repo BaseRepository[widget]) {
+	ctx := context.Background()
+	widgets := seedWidgets(t, repo, 3)
+	deleted := widgets[1]
+
+	if err := repo.Delete(ctx, deleted.ID, false); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repo.FindByID(ctx, deleted.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("FindByID(deleted) error = %v, want ErrNotFound", err)
+	}
+	if err := repo.Delete(ctx, deleted.ID, false); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Delete(deleted) error = %v, want ErrNotFound", err)
+	}
+	if count, _ := repo.Count(ctx, nil); count != 2 {
+		t.Fatalf("Count = %d, want 2", count)
+	}
+
+	live, _ := repo.FindAll(ctx, types.FilterOptions{SortBy: "size"})
+	assertNames(t, live.Items, "w1", "w3")
+	all, _ := repo.FindAll(ctx, types.FilterOptions{SortBy: "size", IncludeDeleted: true})
+	assertNames(t, all.Items, "w1", "w2", "w3")
+	onlyDeleted, err := repo.ListDeleted(ctx, types.FilterOptions{})
+	if err != nil {
+		t.Fatalf("ListDeleted: %v", err)
+	}
+	assertNames(t, onlyDeleted.Items, "w2")
+	if onlyDeleted.Items[0].GetDeletedAt() == nil || onlyDeleted.TotalItems != 1 {
+		t.Fatalf("ListDeleted item has no deleted_at or wrong total: %+v", onlyDeleted)
+	}
+
+	if err := repo.Restore(ctx, deleted.ID); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	restored, err := repo.FindByID(ctx, deleted.ID)
+	if err != nil {
+		t.Fatalf("FindByID(restored): %v", err)
+	}
+	if restored.GetDeletedAt() != nil || restored.Version != deleted.Version+1 {
+		t.Fatalf("restored = deleted_at %v, version %d", restored.GetDeletedAt(), restored.Version)
+	}
+	if err := repo.Restore(ctx, deleted.ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Restore(live) error = %v, want ErrNotFound", err)
+	}
+
+	// Hard delete works on live and soft-deleted rows alike
+	if err := repo.Delete(ctx, widgets[0].ID, false); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := repo.Delete(ctx, widgets[0].ID, true); err != nil {
+		t.Fatalf("Delete(hard, soft-deleted): %v", err)
+	}
+	if err := repo.Delete(ctx, widgets[2].ID, true); err != nil {
+		t.Fatalf("Delete(hard, live): %v", err)
+	}
+	all, _ = repo.FindAll(ctx, types.FilterOptions{IncludeDeleted: true})
+	assertNames(t, all.Items, "w2")
+	if err := repo.Restore(ctx, widgets[0].ID); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Restore(hard-deleted) error = %v, want ErrNotFound", err)
+	}
+}
+
+func testPurgeDeleted(t *testing.T, repo BaseRepository[widget]) {
+	ctx := context.Background()
+	widgets := seedWidgets(t, repo, 3)
+	if err := repo.DeleteMany(ctx, []uuid.UUID{widgets[0].ID, widgets[1].ID}, false); err != nil {
+		t.Fatalf("DeleteMany: %v", err)
+	}
+
+	if _, err := repo.PurgeDeleted(ctx, -time.Hour); err == nil {
+		t.Fatal("PurgeDeleted accepted a negative retention")
+	}
+	if purged, err := repo.PurgeDeleted(ctx, time.Hour); err != nil || purged != 0 {
+		t.Fatalf("PurgeDeleted(1h) = %d, %v, want 0", purged, err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if purged, err := repo.PurgeDeleted(ctx, 0); err != nil || purged != 2 {
+		t.Fatalf("PurgeDeleted(0) = %d, %v, want 2", purged, err)
+	}
+	all, _ := repo.FindAll(ctx, types.FilterOptions{IncludeDeleted: true})
+	assertNames(t, all.Items, "w3")
+}
+
+func testBulkOperations(t *testing.T, repo BaseRepository[widget]) {
+	ctx := context.Background()
+	batch := []*widget{newWidget("b1", 1, ""), newWidget("b2", 2, ""), newWidget("b3", 3, "")}
+	if err := repo.CreateMany(ctx, batch); err != nil {
+		t.Fatalf("CreateMany: %v", err)
+	}
+	for _, w := range batch {
+		if w.ID == uuid.Nil || w.Version != 1 {
+			t.Fatalf("CreateMany did not fill %s: %+v", w.Name, w.BaseEntity)
+		}
+	}
+
+	// A failing batch creates nothing
+	if err := repo.CreateMany(ctx, []*widget{newWidget("b4", 4, ""), newWidget("b1", 5, "")}); !errors.Is(err, ErrUniqueViolation) {
+		t.Fatalf("CreateMany(duplicate) error = %v, want unique violation", err)
+	}
+	if count, _ := repo.Count(ctx, nil); count != 3 {
+		t.Fatalf("Count after failed CreateMany = %d, want 3", count)
+	}
+
+	batch[0].Size, batch[1].Size = 10, 20
+	if err := repo.UpdateMany(ctx, batch[:2]); err != nil {
+		t.Fatalf("UpdateMany: %v", err)
+	}
+	if count, _ := repo.Count(ctx, map[string]interface{}{"size": map[string]interface{}{"gte": 10}}); count != 2 {
+		t.Fatalf("Count after UpdateMany = %d, want 2", count)
+	}
+
+	// A stale entity rolls back the whole batch
+	stale := *batch[0]
+	stale.Version = 1
+	batch[2].Size = 30
+	err := repo.UpdateMany(ctx, []*widget{batch[2], &stale})
+	if !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("UpdateMany(stale) error = %v, want ErrVersionConflict", err)
+	}
+	if got, _ := repo.FindByID(ctx, batch[2].ID); got.Size != 3 {
+		t.Fatalf("UpdateMany was partially applied: size = %d", got.Size)
+	}
+	if err := repo.UpdateMany(ctx, []*widget{{Name: "no-id"}}); err == nil {
+		t.Fatal("UpdateMany without ID succeeded")
+	}
+
+	// An unknown ID fails the whole batch
+	if err := repo.DeleteMany(ctx, []uuid.UUID{batch[0].ID, uuid.New()}, false); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("DeleteMany(unknown) error = %v, want ErrNotFound", err)
+	}
+	if _, err := repo.FindByID(ctx, batch[0].ID); err != nil {
+		t.Fatalf("DeleteMany(unknown) was partially applied: %v", err)
+	}
+	if err := repo.DeleteMany(ctx, []uuid.UUID{batch[0].ID}, false); err != nil {
+		t.Fatalf("DeleteMany(soft): %v", err)
+	}
+	// Already soft-deleted rows can only be deleted again for good
+	if err := repo.DeleteMany(ctx, []uuid.UUID{batch[0].ID}, false); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("DeleteMany(soft, deleted) error = %v, want ErrNotFound", err)
+	}
+	if err := repo.DeleteMany(ctx, []uuid.UUID{batch[0].ID, batch[1].ID}, true); err != nil {
+		t.Fatalf("DeleteMany(hard): %v", err)
+	}
+	all, _ := repo.FindAll(ctx, types.FilterOptions{IncludeDeleted: true})
+	assertNames(t, all.Items, "b3")
+}
+
+func testTransaction(t *testing.T, repo BaseRepository[widget]) {
+	ctx := context.Background()
+	existing := newWidget("tx0", 1, "")
+	if err := repo.Create(ctx, existing); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	errAbort := errors.New("abort")
+	err := repo.Transaction(ctx, func(tx BaseRepository[widget]) error {
+		if err := tx.Create(ctx, newWidget("tx1", 2, "")); err != nil {
+			return err
+		}
+		if err := tx.Delete(ctx, existing.ID, true); err != nil {
+			return err
+		}
+		// The transaction sees its own writes
+		if count, err := tx.Count(ctx, nil); err != nil || count != 1 {
+			t.Errorf("Count inside transaction = %d, %v, want 1", count, err)
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Transaction error = %v, want the callback error", err)
+	}
+	all, _ := repo.FindAll(ctx, types.FilterOptions{IncludeDeleted: true})
+	assertNames(t, all.Items, "tx0")
+
+	err = repo.Transaction(ctx, func(tx BaseRepository[widget]) error {
+		if err := tx.Create(ctx, newWidget("tx1", 2, "")); err != nil {
+			return err
+		}
+		w, err := tx.FindByID(ctx, existing.ID)
+		if err != nil {
+			return err
+		}
+		w.Size = 5
+		return tx.Update(ctx, w)
+	})
+	if err != nil {
+		t.Fatalf("Transaction: %v", err)
+	}
+	all, _ = repo.FindAll(ctx, types.FilterOptions{SortBy: "name"})
+	assertNames(t, all.Items, "tx0", "tx1")
+	if all.Items[0].Size != 5 || all.Items[0].Version != 2 {
+		t.Fatalf("committed update = size %d, version %d", all.Items[0].Size, all.Items[0].Version)
+	}
+}
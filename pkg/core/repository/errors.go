@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// ErrVersionConflict is returned by Update/UpdateMany when the stored entity
+// version no longer matches the version of the entity being written, i.e. the
+// row was modified by someone else since it was read (optimistic locking).
+var ErrVersionConflict = errors.New("entity version conflict")
+
+// ErrNotFound is returned when no (visible) row matches the requested ID or filter.
+// Use errors.Is to detect it; the concrete error is usually a *NotFoundError.
+var ErrNotFound = errors.New("entity not found")
+
+// ErrUniqueViolation is returned when a write would duplicate a unique key.
+var ErrUniqueViolation = errors.New("unique constraint violation")
+
+// ErrForeignKeyViolation is returned when a write references a missing row, or
+// a delete would leave rows referencing the deleted one.
+var ErrForeignKeyViolation = errors.New("foreign key constraint violation")
+
+// Postgres SQLSTATE codes translated by TranslateError.
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+// NotFoundError describes which entity could not be found. It matches ErrNotFound.
+type NotFoundError struct {
+	Entity string // Table or entity name, e.g. "users"
+	Key    string // Looked-up identifier, empty for filter lookups
+}
+
+// Error implements the error interface.
+func (e *NotFoundError) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("%s: %s", ErrNotFound, e.Entity)
+	}
+	return fmt.Sprintf("%s: %s %s", ErrNotFound, e.Entity, e.Key)
+}
+
+// Unwrap makes errors.Is(err, ErrNotFound) succeed.
+func (e *NotFoundError) Unwrap() error {
+	return ErrNotFound
+}
+
+// NewNotFoundError creates a *NotFoundError for the given entity and key.
+func NewNotFoundError(entity string, key interface{}) error {
+	e := &NotFoundError{Entity: entity}
+	if key != nil {
+		e.Key = fmt.Sprint(key)
+	}
+	return e
+}
+
+// ConstraintError is a database constraint violation translated from the driver.
+// It matches its Kind (ErrUniqueViolation or ErrForeignKeyViolation) as well as
+// the original driver error.
+type ConstraintError struct {
+	Kind       error    // ErrUniqueViolation or ErrForeignKeyViolation
+	Constraint string   // Constraint name, e.g. "idx_users_email"
+	Table      string   // Table the constraint belongs to
+	Columns    []string // Key columns, parsed from the driver detail when available
+	Detail     string   // Driver detail message, e.g. "Key (email)=(a@b.c) already exists."
+	Err        error    // Original driver error, nil when raised by an in-memory repository
+}
+
+// Error implements the error interface.
+func (e *ConstraintError) Error() string {
+	msg := e.Kind.Error()
+	if e.Constraint != "" {
+		msg += fmt.Sprintf(" on %q", e.Constraint)
+	}
+	if len(e.Columns) > 0 {
+		msg += fmt.Sprintf(" (%s)", strings.Join(e.Columns, ", "))
+	}
+	return msg
+}
+
+// Unwrap exposes both the violation kind and the driver error to errors.Is/As.
+func (e *ConstraintError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// keyColumnsPattern extracts the column list from Postgres details such as
+// `Key (email)=(a@b.c) already exists.` or `Key (role_id)=(x) is not present in table "staff_roles".`
+var keyColumnsPattern = regexp.MustCompile(`^Key \(([^)]+)\)=`)
+
+// TranslateError converts GORM and Postgres errors into the repository error
+// model: record-not-found becomes ErrNotFound and unique/foreign key violations
+// become *ConstraintError. Other errors are returned unchanged.
+func TranslateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	var kind error
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		kind = ErrUniqueViolation
+	case pgForeignKeyViolation:
+		kind = ErrForeignKeyViolation
+	default:
+		return err
+	}
+
+	constraintErr := &ConstraintError{
+		Kind:       kind,
+		Constraint: pgErr.ConstraintName,
+		Table:      pgErr.TableName,
+		Detail:     pgErr.Detail,
+		Err:        err,
+	}
+	if m := keyColumnsPattern.FindStringSubmatch(pgErr.Detail); m != nil {
+		for _, col := range strings.Split(m[1], ",") {
+			constraintErr.Columns = append(constraintErr.Columns, strings.TrimSpace(col))
+		}
+	} else if pgErr.ColumnName != "" {
+		constraintErr.Columns = []string{pgErr.ColumnName}
+	}
+	return constraintErr
+}
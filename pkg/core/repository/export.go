@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/core/types"
+)
+
+// DefaultExportBatchSize is the page size ForEach reads with when opts.Limit is not set.
+const DefaultExportBatchSize = 500
+
+// ForEach calls fn for every entity matching opts, in opts' sort order, without
+// holding more than one page in memory. Pages of opts.Limit entities (default
+// DefaultExportBatchSize) are read with keyset cursors, so each page is a short
+// query that neither skips nor repeats rows when others are inserted or deleted
+// meanwhile. opts.Offset and opts.SkipCount are ignored; a non-empty opts.Cursor
+// starts after that position. Iteration stops at the first error of fn or of
+// the repository, which is returned as is.
+func ForEach[T entity.Entity](ctx context.Context, repo BaseRepository[T], opts types.FilterOptions, fn func(*T) error) error {
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultExportBatchSize
+	}
+	opts.Offset = 0
+	opts.SkipCount = true
+
+	for {
+		page, err := repo.FindAll(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, item := range page.Items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		opts.Cursor = page.NextCursor
+	}
+}
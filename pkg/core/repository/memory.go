@@ -0,0 +1,944 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/schema"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/core/types"
+)
+
+// errTxDone is returned when a transaction repository is used after its
+// Transaction callback has returned.
+var errTxDone = errors.New("transaction has already been committed or rolled back")
+
+// memorySchemas caches parsed model schemas for in-memory repositories.
+var memorySchemas sync.Map
+
+// MemoryBaseRepository implements the BaseRepository interface in memory.
+// It is safe for concurrent use and is meant for unit tests that should not need
+// Postgres. It mirrors GormBaseRepository: column names come from the GORM schema,
+// FilterOptions, cursors and soft deletion behave the same way, GORM create/update
+// hooks run, unique indexes are enforced and Transaction rolls back when fn fails.
+// Foreign keys are not enforced. Entities are stored and returned as shallow copies.
+type MemoryBaseRepository[T entity.Entity] struct {
+	ModelType reflect.Type
+
+	schema *schema.Schema
+	store  *memoryStore[T]
+}
+
+// memoryStore holds the rows of a repository or of an open transaction.
+type memoryStore[T entity.Entity] struct {
+	mu   sync.RWMutex
+	rows map[uuid.UUID]*T
+
+	// Only set for transaction stores
+	dirty map[uuid.UUID]struct{} // IDs written inside the transaction
+	done  bool
+}
+
+// NewMemoryBaseRepository creates a new, empty in-memory repository.
+// It panics if T cannot be parsed as a GORM model.
+func NewMemoryBaseRepository[T entity.Entity]() *MemoryBaseRepository[T] {
+	var modelPtr *T
+	modelType := reflect.TypeOf(modelPtr).Elem()
+	s, err := schema.Parse(reflect.New(modelType).Interface(), &memorySchemas, schema.NamingStrategy{})
+	if err != nil {
+		panic(fmt.Sprintf("repository: cannot parse model %s: %v", modelType, err))
+	}
+	return &MemoryBaseRepository[T]{
+		ModelType: modelType,
+		schema:    s,
+		store:     &memoryStore[T]{rows: map[uuid.UUID]*T{}},
+	}
+}
+
+// Create adds a new entity, running BeforeSave/BeforeCreate hooks and filling
+// the ID, timestamps and column defaults like the database would.
+func (r *MemoryBaseRepository[T]) Create(ctx context.Context, entity *T) error {
+	return r.CreateMany(ctx, []*T{entity})
+}
+
+// FindByID retrieves a live entity by its ID
+func (r *MemoryBaseRepository[T]) FindByID(ctx context.Context, id uuid.UUID) (*T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	if err := r.store.usable(); err != nil {
+		return nil, err
+	}
+	row, ok := r.store.rows[id]
+	if !ok || r.isDeleted(row) {
+		return nil, r.notFound(id)
+	}
+	return cloneRow(row), nil
+}
+
+// FindAll retrieves entities matching the filter options, with the same
+// sorting, offset and cursor pagination semantics as GormBaseRepository.FindAll.
+func (r *MemoryBaseRepository[T]) FindAll(ctx context.Context, opts types.FilterOptions) (*types.PaginationResult[T], error) {
+	return r.find(ctx, opts, scopeFor(opts))
+}
+
+// ListDeleted retrieves only soft-deleted entities. opts.IncludeDeleted is ignored.
+func (r *MemoryBaseRepository[T]) ListDeleted(ctx context.Context, opts types.FilterOptions) (*types.PaginationResult[T], error) {
+	return r.find(ctx, opts, scopeDeleted)
+}
+
+// find implements FindAll and ListDeleted for the given soft-delete scope.
+func (r *MemoryBaseRepository[T]) find(ctx context.Context, opts types.FilterOptions, scope deletedScope) (*types.PaginationResult[T], error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	field, err := r.sortField(opts.SortBy)
+	if err != nil {
+		return nil, err
+	}
+	conditions, err := r.parseFilters(opts.Filters)
+	if err != nil {
+		return nil, err
+	}
+	var cursor *types.Cursor
+	if opts.Cursor != "" {
+		decoded, err := types.DecodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if decoded.SortBy != field.DBName || decoded.SortDesc != opts.SortDesc {
+			return nil, fmt.Errorf("%w: cursor does not match sort options", types.ErrInvalidCursor)
+		}
+		cursor = &decoded
+	}
+
+	r.store.mu.RLock()
+	matches, err := r.match(ctx, scope, conditions)
+	r.store.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount := int64(-1)
+	if !opts.SkipCount {
+		totalCount = int64(len(matches))
+	}
+
+	sortKey := func(row *T) interface{} { return r.valueOf(ctx, field, row) }
+	sort.SliceStable(matches, func(i, j int) bool {
+		return compareRows(sortKey(matches[i]), sortKey(matches[j]), (*matches[i]).GetID(), (*matches[j]).GetID(), opts.SortDesc) < 0
+	})
+
+	if cursor != nil {
+		after := matches[:0]
+		for _, row := range matches {
+			if afterCursor(sortKey(row), (*row).GetID(), cursor) {
+				after = append(after, row)
+			}
+		}
+		matches = after
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	offset := opts.Offset
+	if offset < 0 || opts.Cursor != "" {
+		offset = 0
+	}
+	if offset > len(matches) {
+		offset = len(matches)
+	}
+	matches = matches[offset:]
+
+	var nextCursor string
+	if len(matches) > limit {
+		matches = matches[:limit]
+		last := matches[limit-1]
+		nextCursor, err = types.EncodeCursor(types.Cursor{
+			SortBy:   field.DBName,
+			SortDesc: opts.SortDesc,
+			Value:    sortKey(last),
+			ID:       (*last).GetID(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to build next cursor: %w", err)
+		}
+	}
+
+	items := make([]*T, len(matches))
+	for i, row := range matches {
+		items[i] = cloneRow(row)
+	}
+	return &types.PaginationResult[T]{
+		Items:      items,
+		TotalItems: totalCount,
+		Limit:      limit,
+		Offset:     offset,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// FindWithFilter retrieves entities that match the provided filter criteria
+func (r *MemoryBaseRepository[T]) FindWithFilter(ctx context.Context, filter map[string]interface{}, opts types.FilterOptions) (*types.PaginationResult[T], error) {
+	merged := make(map[string]interface{}, len(opts.Filters)+len(filter))
+	for k, v := range opts.Filters {
+		merged[k] = v
+	}
+	for k, v := range filter {
+		merged[k] = v
+	}
+	opts.Filters = merged
+	return r.FindAll(ctx, opts)
+}
+
+// FindOneWithFilter retrieves the live entity with the lowest ID that matches
+// the filter, like GORM's First.
+func (r *MemoryBaseRepository[T]) FindOneWithFilter(ctx context.Context, filter map[string]interface{}) (*T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	conditions, err := r.parseFilters(filter)
+	if err != nil {
+		return nil, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	matches, err := r.match(ctx, scopeLive, conditions)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, r.notFound(nil)
+	}
+	first := matches[0]
+	for _, row := range matches[1:] {
+		if compareIDs((*row).GetID(), (*first).GetID()) < 0 {
+			first = row
+		}
+	}
+	return cloneRow(first), nil
+}
+
+// Count returns the number of live entities matching the filter
+func (r *MemoryBaseRepository[T]) Count(ctx context.Context, filter map[string]interface{}) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	conditions, err := r.parseFilters(filter)
+	if err != nil {
+		return 0, err
+	}
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+	matches, err := r.match(ctx, scopeLive, conditions)
+	return int64(len(matches)), err
+}
+
+// Update writes the non-zero fields of a live entity, like GORM's Updates.
+// Entities implementing entity.Versioned are updated with optimistic locking and
+// ErrVersionConflict is returned when the stored version has moved on.
+func (r *MemoryBaseRepository[T]) Update(ctx context.Context, entity *T) error {
+	id := (*entity).GetID()
+	if id == uuid.Nil {
+		return errors.New("entity must have a valid ID for update")
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := r.runUpdateHooks(ctx, entity); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := r.store.usable(); err != nil {
+		return err
+	}
+	updated, err := r.applyUpdate(ctx, r.liveRow(r.store.rows, id), id, entity)
+	if err != nil || updated == nil {
+		return err
+	}
+	if err := r.checkUnique(ctx, r.store.rows, []*T{updated}, false); err != nil {
+		r.rollbackVersion(entity)
+		return err
+	}
+	r.store.put(id, updated)
+	return nil
+}
+
+// liveRow returns the live row with id, or nil.
+func (r *MemoryBaseRepository[T]) liveRow(rows map[uuid.UUID]*T, id uuid.UUID) *T {
+	row, ok := rows[id]
+	if !ok || r.isDeleted(row) {
+		return nil
+	}
+	return row
+}
+
+// applyUpdate returns a copy of the live row stored with the update applied,
+// or nil when a non-versioned entity matched no live row. Like the GORM
+// repository it writes every column but created_at, zero values included.
+func (r *MemoryBaseRepository[T]) applyUpdate(ctx context.Context, stored *T, id uuid.UUID, e *T) (*T, error) {
+	versioned, isVersioned := any(e).(entity.Versioned)
+	if isVersioned {
+		current := versioned.GetVersion()
+		if stored == nil || any(stored).(entity.Versioned).GetVersion() != current {
+			return nil, fmt.Errorf("%w: entity %s (version %d)", ErrVersionConflict, id, current)
+		}
+		versioned.SetVersion(current + 1)
+	}
+	if stored == nil {
+		return nil, nil // GORM reports no error when nothing matched
+	}
+
+	updated := cloneRow(stored)
+	src, dst := reflect.ValueOf(e).Elem(), reflect.ValueOf(updated).Elem()
+	now := time.Now()
+	for _, field := range r.schema.Fields {
+		if field.DBName == "" || field.DBName == "created_at" || field.PrimaryKey || !field.Updatable {
+			continue
+		}
+		if field.AutoUpdateTime > 0 {
+			if err := field.Set(ctx, src, now); err != nil {
+				return nil, err
+			}
+		}
+		value, _ := field.ValueOf(ctx, src)
+		if err := field.Set(ctx, dst, value); err != nil {
+			return nil, err
+		}
+	}
+	return updated, nil
+}
+
+// rollbackVersion undoes the version bump of a rejected update.
+func (r *MemoryBaseRepository[T]) rollbackVersion(e *T) {
+	if versioned, ok := any(e).(entity.Versioned); ok {
+		versioned.SetVersion(versioned.GetVersion() - 1)
+	}
+}
+
+// Delete removes an entity by ID. A soft delete stamps deleted_at on a live
+// entity; a hard delete removes the entity whether or not it was soft-deleted.
+func (r *MemoryBaseRepository[T]) Delete(ctx context.Context, id uuid.UUID, hardDelete bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := r.store.usable(); err != nil {
+		return err
+	}
+	if !r.deleteRow(ctx, id, hardDelete, time.Now()) {
+		return r.notFound(id)
+	}
+	return nil
+}
+
+// deleteRow soft- or hard-deletes a single row and reports whether a row was
+// affected. The caller must hold the write lock.
+func (r *MemoryBaseRepository[T]) deleteRow(ctx context.Context, id uuid.UUID, hardDelete bool, now time.Time) bool {
+	row, ok := r.store.rows[id]
+	if !ok {
+		return false
+	}
+	if hardDelete {
+		r.store.remove(id)
+		return true
+	}
+	if r.isDeleted(row) {
+		return false
+	}
+	deleted := cloneRow(row)
+	_ = r.setColumn(ctx, deleted, "deleted_at", gorm.DeletedAt{Time: now, Valid: true})
+	r.store.put(id, deleted)
+	return true
+}
+
+// Restore clears deleted_at on a soft-deleted entity, making it visible again.
+// Versioned entities get their version bumped so stale copies cannot overwrite it.
+func (r *MemoryBaseRepository[T]) Restore(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := r.store.usable(); err != nil {
+		return err
+	}
+	row, ok := r.store.rows[id]
+	if !ok || !r.isDeleted(row) {
+		return r.notFound(id)
+	}
+	restored := cloneRow(row)
+	_ = r.setColumn(ctx, restored, "deleted_at", gorm.DeletedAt{})
+	_ = r.setColumn(ctx, restored, "updated_at", time.Now())
+	if versioned, ok := any(restored).(entity.Versioned); ok {
+		versioned.SetVersion(versioned.GetVersion() + 1)
+	}
+	r.store.put(id, restored)
+	return nil
+}
+
+// PurgeDeleted permanently removes entities that were soft-deleted more than
+// olderThan ago and returns the number of entities removed.
+func (r *MemoryBaseRepository[T]) PurgeDeleted(ctx context.Context, olderThan time.Duration) (int64, error) {
+	if olderThan < 0 {
+		return 0, fmt.Errorf("purge retention must not be negative, got %s", olderThan)
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	cutoff := time.Now().Add(-olderThan)
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := r.store.usable(); err != nil {
+		return 0, err
+	}
+	var purged int64
+	for id, row := range r.store.rows {
+		if deletedAt := (*row).GetDeletedAt(); deletedAt != nil && deletedAt.Before(cutoff) {
+			r.store.remove(id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// Transaction runs fn against a snapshot of the repository. Writes made through
+// txRepo become visible to others only when fn returns nil; otherwise they are
+// discarded. Unique indexes are checked again on commit.
+func (r *MemoryBaseRepository[T]) Transaction(ctx context.Context, fn func(txRepo BaseRepository[T]) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	txStore, err := r.store.begin()
+	if err != nil {
+		return err
+	}
+	txRepo := &MemoryBaseRepository[T]{ModelType: r.ModelType, schema: r.schema, store: txStore}
+
+	committed := false
+	defer func() {
+		if !committed {
+			txStore.close() // Rollback, including when fn panics
+		}
+	}()
+	if err := fn(txRepo); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := r.store.usable(); err != nil {
+		return err
+	}
+	txStore.mu.Lock()
+	defer txStore.mu.Unlock()
+	written := make([]*T, 0, len(txStore.dirty))
+	for id := range txStore.dirty {
+		if row, ok := txStore.rows[id]; ok {
+			written = append(written, row)
+		}
+	}
+	if err := r.checkUnique(ctx, r.store.rows, written, false); err != nil {
+		return err
+	}
+	for id := range txStore.dirty {
+		if row, ok := txStore.rows[id]; ok {
+			r.store.put(id, row)
+		} else {
+			r.store.remove(id)
+		}
+	}
+	txStore.done = true
+	committed = true
+	return nil
+}
+
+// --- Bulk Operations Implementation ---
+
+// CreateMany adds multiple entities atomically: either all are created or none.
+func (r *MemoryBaseRepository[T]) CreateMany(ctx context.Context, entities []*T) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	now := time.Now()
+	rows := make([]*T, len(entities))
+	for i, e := range entities {
+		if err := r.prepareCreate(ctx, e, now); err != nil {
+			return err
+		}
+		rows[i] = cloneRow(e)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := r.store.usable(); err != nil {
+		return err
+	}
+	if err := r.checkUnique(ctx, r.store.rows, rows, true); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		r.store.put((*row).GetID(), row)
+	}
+	return nil
+}
+
+// UpdateMany updates multiple entities atomically: either all are updated or none.
+func (r *MemoryBaseRepository[T]) UpdateMany(ctx context.Context, entities []*T) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for _, e := range entities {
+		if (*e).GetID() == uuid.Nil {
+			return fmt.Errorf("entity in bulk update list missing ID")
+		}
+		if err := r.runUpdateHooks(ctx, e); err != nil {
+			return err
+		}
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := r.store.usable(); err != nil {
+		return err
+	}
+
+	// Stage the updates so that a failure leaves the store untouched
+	staged := overlay(r.store.rows, nil)
+	written := map[uuid.UUID]*T{}
+	for _, e := range entities {
+		id := (*e).GetID()
+		updated, err := r.applyUpdate(ctx, r.liveRow(staged, id), id, e)
+		if err == nil && updated != nil {
+			if err = r.checkUnique(ctx, staged, []*T{updated}, false); err != nil {
+				r.rollbackVersion(e)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update entity with ID %s during bulk update: %w", id, err)
+		}
+		if updated != nil {
+			staged[id] = updated
+			written[id] = updated
+		}
+	}
+	for id, row := range written {
+		r.store.put(id, row)
+	}
+	return nil
+}
+
+// DeleteMany removes the entities with the given IDs. When an ID matches no
+// deletable row, nothing is deleted and a *NotFoundError is returned.
+func (r *MemoryBaseRepository[T]) DeleteMany(ctx context.Context, ids []uuid.UUID, hardDelete bool) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if err := r.store.usable(); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if row, ok := r.store.rows[id]; !ok || (!hardDelete && r.isDeleted(row)) {
+			return r.notFound(id)
+		}
+	}
+	now := time.Now()
+	for _, id := range ids {
+		r.deleteRow(ctx, id, hardDelete, now)
+	}
+	return nil
+}
+
+// --- Helpers ---
+
+// notFound builds the *NotFoundError for this repository's entity type.
+func (r *MemoryBaseRepository[T]) notFound(key interface{}) error {
+	return NewNotFoundError(r.ModelType.Name(), key)
+}
+
+// isDeleted reports whether a stored row is soft-deleted.
+func (r *MemoryBaseRepository[T]) isDeleted(row *T) bool {
+	return (*row).GetDeletedAt() != nil
+}
+
+// prepareCreate runs the create hooks on e and fills in the ID, column defaults
+// and timestamps, mirroring what GORM and Postgres do on insert.
+func (r *MemoryBaseRepository[T]) prepareCreate(ctx context.Context, e *T, now time.Time) error {
+	hookDB := r.hookDB(ctx)
+	if hook, ok := any(e).(callbacks.BeforeSaveInterface); ok {
+		if err := hook.BeforeSave(hookDB); err != nil {
+			return err
+		}
+	}
+	if hook, ok := any(e).(callbacks.BeforeCreateInterface); ok {
+		if err := hook.BeforeCreate(hookDB); err != nil {
+			return err
+		}
+	}
+
+	rv := reflect.ValueOf(e).Elem()
+	for _, field := range r.schema.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		if _, zero := field.ValueOf(ctx, rv); !zero {
+			continue
+		}
+		var value interface{}
+		switch {
+		case field.PrimaryKey && field.FieldType == reflect.TypeOf(uuid.UUID{}):
+			value = uuid.New()
+		case field.AutoCreateTime > 0 || field.AutoUpdateTime > 0:
+			value = now
+		case field.HasDefaultValue && field.DefaultValueInterface != nil:
+			value = field.DefaultValueInterface
+		default:
+			continue
+		}
+		if err := field.Set(ctx, rv, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runUpdateHooks runs the BeforeSave/BeforeUpdate hooks GORM runs for Updates.
+func (r *MemoryBaseRepository[T]) runUpdateHooks(ctx context.Context, e *T) error {
+	hookDB := r.hookDB(ctx)
+	if hook, ok := any(e).(callbacks.BeforeSaveInterface); ok {
+		if err := hook.BeforeSave(hookDB); err != nil {
+			return err
+		}
+	}
+	if hook, ok := any(e).(callbacks.BeforeUpdateInterface); ok {
+		if err := hook.BeforeUpdate(hookDB); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hookDB returns the minimal *gorm.DB handed to model hooks. Hooks can read the
+// context from it but must not run queries.
+func (r *MemoryBaseRepository[T]) hookDB(ctx context.Context) *gorm.DB {
+	return &gorm.DB{Config: &gorm.Config{}, Statement: &gorm.Statement{Context: ctx, Schema: r.schema}}
+}
+
+// valueOf returns the value of a column of a stored row.
+func (r *MemoryBaseRepository[T]) valueOf(ctx context.Context, field *schema.Field, row *T) interface{} {
+	value, _ := field.ValueOf(ctx, reflect.ValueOf(row).Elem())
+	return value
+}
+
+// setColumn sets a column of a row by its database name.
+func (r *MemoryBaseRepository[T]) setColumn(ctx context.Context, row *T, column string, value interface{}) error {
+	field := r.schema.LookUpField(column)
+	if field == nil {
+		return fmt.Errorf("model %s has no column %q", r.ModelType.Name(), column)
+	}
+	return field.Set(ctx, reflect.ValueOf(row).Elem(), value)
+}
+
+// sortField resolves the schema field used as the sort key, with the same
+// defaults and validation as GormBaseRepository.
+func (r *MemoryBaseRepository[T]) sortField(sortBy string) (*schema.Field, error) {
+	if sortBy == "" {
+		sortBy = "created_at"
+	}
+	if _, sortable := queryableColumnsOf(r.ModelType); !containsColumn(sortable, sortBy) {
+		return nil, fmt.Errorf("%w: field %q is not sortable", types.ErrInvalidFilter, sortBy)
+	}
+	field := r.schema.LookUpField(sortBy)
+	if field == nil || field.DBName == "" {
+		return nil, fmt.Errorf("%w: unknown sort column %q", types.ErrInvalidFilter, sortBy)
+	}
+	return field, nil
+}
+
+// parseFilters parses the filter map and checks every column is filterable.
+func (r *MemoryBaseRepository[T]) parseFilters(filters map[string]interface{}) ([]types.FilterCondition, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+	conditions, err := types.ParseFilters(filters)
+	if err != nil {
+		return nil, err
+	}
+	filterable, _ := queryableColumnsOf(r.ModelType)
+	for _, cond := range conditions {
+		if !containsColumn(filterable, cond.Field) {
+			return nil, fmt.Errorf("%w: field %q is not filterable", types.ErrInvalidFilter, cond.Field)
+		}
+		if field := r.schema.LookUpField(cond.Field); field == nil || field.DBName == "" {
+			return nil, fmt.Errorf("%w: unknown filter column %q", types.ErrInvalidFilter, cond.Field)
+		}
+	}
+	return conditions, nil
+}
+
+// match returns the rows in scope that satisfy all conditions. The caller must
+// hold at least the read lock.
+func (r *MemoryBaseRepository[T]) match(ctx context.Context, scope deletedScope, conditions []types.FilterCondition) ([]*T, error) {
+	if err := r.store.usable(); err != nil {
+		return nil, err
+	}
+	matches := make([]*T, 0, len(r.store.rows))
+rows:
+	for _, row := range r.store.rows {
+		deleted := r.isDeleted(row)
+		if (scope == scopeLive && deleted) || (scope == scopeDeleted && !deleted) {
+			continue
+		}
+		for _, cond := range conditions {
+			if !matchCondition(r.valueOf(ctx, r.schema.LookUpField(cond.Field), row), cond) {
+				continue rows
+			}
+		}
+		matches = append(matches, row)
+	}
+	return matches, nil
+}
+
+// checkUnique verifies that writing rows over existing violates neither the
+// primary key (only checked for inserts) nor a unique index. Rows whose ID is
+// already in existing replace the stored row when insert is false.
+func (r *MemoryBaseRepository[T]) checkUnique(ctx context.Context, existing map[uuid.UUID]*T, rows []*T, insert bool) error {
+	writing := make(map[uuid.UUID]*T, len(rows))
+	for _, row := range rows {
+		id := (*row).GetID()
+		_, stored := existing[id]
+		_, repeated := writing[id]
+		if insert && (stored || repeated) {
+			return r.uniqueViolation(uniqueKey{name: r.schema.Table + "_pkey", columns: r.schema.PrimaryFieldDBNames}, id.String())
+		}
+		writing[id] = row
+	}
+
+	for _, key := range r.uniqueKeys() {
+		seen := map[string]uuid.UUID{}
+		for id, row := range existing {
+			if _, overwritten := writing[id]; overwritten {
+				continue
+			}
+			if value, ok := r.keyOf(ctx, key.columns, row); ok {
+				seen[value] = id
+			}
+		}
+		for id, row := range writing {
+			value, ok := r.keyOf(ctx, key.columns, row)
+			if !ok {
+				continue // NULLs never conflict
+			}
+			if _, dup := seen[value]; dup {
+				return r.uniqueViolation(key, value)
+			}
+			seen[value] = id
+		}
+	}
+	return nil
+}
+
+// uniqueViolation builds the *ConstraintError for a duplicate key, with the same
+// detail format as Postgres.
+func (r *MemoryBaseRepository[T]) uniqueViolation(key uniqueKey, value string) error {
+	return &ConstraintError{
+		Kind:       ErrUniqueViolation,
+		Constraint: key.name,
+		Table:      r.schema.Table,
+		Columns:    key.columns,
+		Detail:     fmt.Sprintf("Key (%s)=(%s) already exists.", strings.Join(key.columns, ", "), value),
+	}
+}
+
+// uniqueKey is a set of columns that must be unique across all rows.
+type uniqueKey struct {
+	name    string
+	columns []string
+}
+
+// uniqueKeys returns the unique constraints and indexes declared on the model.
+func (r *MemoryBaseRepository[T]) uniqueKeys() []uniqueKey {
+	var keys []uniqueKey
+	for _, field := range r.schema.Fields {
+		if field.Unique && field.DBName != "" {
+			keys = append(keys, uniqueKey{name: fmt.Sprintf("%s_%s_key", r.schema.Table, field.DBName), columns: []string{field.DBName}})
+		}
+	}
+	indexes := r.schema.ParseIndexes()
+	names := make([]string, 0, len(indexes))
+	for name := range indexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		index := indexes[name]
+		if index.Class != "UNIQUE" || index.Where != "" {
+			continue
+		}
+		key := uniqueKey{name: index.Name}
+		for _, opt := range index.Fields {
+			if opt.Field != nil {
+				key.columns = append(key.columns, opt.DBName)
+			}
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// keyOf renders the values of columns in row as a comparable key. It reports
+// false when any of the values is NULL.
+func (r *MemoryBaseRepository[T]) keyOf(ctx context.Context, columns []string, row *T) (string, bool) {
+	parts := make([]string, len(columns))
+	for i, column := range columns {
+		value := normalizeValue(r.valueOf(ctx, r.schema.LookUpField(column), row))
+		if value == nil {
+			return "", false
+		}
+		if t, ok := value.(time.Time); ok {
+			value = t.UTC().Format(time.RFC3339Nano)
+		}
+		parts[i] = fmt.Sprint(value)
+	}
+	return strings.Join(parts, ", "), true
+}
+
+// cloneRow returns a shallow copy of row.
+func cloneRow[T any](row *T) *T {
+	clone := *row
+	return &clone
+}
+
+// compareIDs orders UUIDs the way Postgres does (bytewise).
+func compareIDs(a, b uuid.UUID) int {
+	return strings.Compare(a.String(), b.String())
+}
+
+// compareRows orders two rows by sort value and then ID. NULL sort values come
+// last in ascending and first in descending order, as in Postgres.
+func compareRows(a, b interface{}, aID, bID uuid.UUID, desc bool) int {
+	c := compareSortValues(a, b)
+	if c == 0 {
+		c = compareIDs(aID, bID)
+	}
+	if desc {
+		return -c
+	}
+	return c
+}
+
+// compareSortValues compares two sort values, treating NULL as the largest value.
+func compareSortValues(a, b interface{}) int {
+	a, b = normalizeValue(a), normalizeValue(b)
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	c, _ := compareValues(a, b)
+	return c
+}
+
+// afterCursor reports whether a row comes strictly after the cursor position,
+// i.e. (value, id) > (cursor value, cursor id) in the direction of the cursor.
+func afterCursor(value interface{}, id uuid.UUID, cursor *types.Cursor) bool {
+	c, ok := compareValues(normalizeValue(value), normalizeValue(cursor.Value))
+	if !ok {
+		return false // Comparisons with NULL are never true in SQL
+	}
+	if c == 0 {
+		c = compareIDs(id, cursor.ID)
+	}
+	if cursor.SortDesc {
+		return c < 0
+	}
+	return c > 0
+}
+
+// begin opens a transaction store holding a snapshot of s.
+func (s *memoryStore[T]) begin() (*memoryStore[T], error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if err := s.usable(); err != nil {
+		return nil, err
+	}
+	rows := overlay(s.rows, nil) // Rows are never mutated in place, so sharing them is safe
+	return &memoryStore[T]{rows: rows, dirty: map[uuid.UUID]struct{}{}}, nil
+}
+
+// close marks a transaction store as finished.
+func (s *memoryStore[T]) close() {
+	s.mu.Lock()
+	s.done = true
+	s.mu.Unlock()
+}
+
+// usable returns errTxDone for finished transaction stores.
+func (s *memoryStore[T]) usable() error {
+	if s.done {
+		return errTxDone
+	}
+	return nil
+}
+
+// put stores row under id. The caller must hold the write lock.
+func (s *memoryStore[T]) put(id uuid.UUID, row *T) {
+	s.rows[id] = row
+	if s.dirty != nil {
+		s.dirty[id] = struct{}{}
+	}
+}
+
+// remove deletes the row with id. The caller must hold the write lock.
+func (s *memoryStore[T]) remove(id uuid.UUID) {
+	delete(s.rows, id)
+	if s.dirty != nil {
+		s.dirty[id] = struct{}{}
+	}
+}
+
+// overlay returns a copy of base with the staged rows applied on top.
+func overlay[T any](base map[uuid.UUID]*T, staged map[uuid.UUID]*T) map[uuid.UUID]*T {
+	merged := make(map[uuid.UUID]*T, len(base))
+	for id, row := range base {
+		merged[id] = row
+	}
+	for id, row := range staged {
+		merged[id] = row
+	}
+	return merged
+}
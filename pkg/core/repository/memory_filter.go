@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/core/types"
+)
+
+// matchCondition evaluates a filter condition against a column value with SQL
+// semantics: any comparison involving NULL is false.
+func matchCondition(value interface{}, cond types.FilterCondition) bool {
+	value = normalizeValue(value)
+	switch cond.Operator {
+	case types.OpIsNull:
+		return (value == nil) == cond.Value.(bool)
+	case types.OpIn:
+		for _, candidate := range cond.Value.([]interface{}) {
+			if c, ok := compareValues(value, normalizeValue(candidate)); ok && c == 0 {
+				return true
+			}
+		}
+		return false
+	case types.OpBetween:
+		bounds := cond.Value.([]interface{})
+		low, okLow := compareValues(value, normalizeValue(bounds[0]))
+		high, okHigh := compareValues(value, normalizeValue(bounds[1]))
+		return okLow && okHigh && low >= 0 && high <= 0
+	case types.OpILike:
+		s, ok := value.(string)
+		return ok && likePattern(cond.Value.(string)).MatchString(s)
+	}
+
+	c, ok := compareValues(value, normalizeValue(cond.Value))
+	if !ok {
+		return false
+	}
+	switch cond.Operator {
+	case types.OpNe:
+		return c != 0
+	case types.OpGt:
+		return c > 0
+	case types.OpGte:
+		return c >= 0
+	case types.OpLt:
+		return c < 0
+	case types.OpLte:
+		return c <= 0
+	default: // types.OpEq
+		return c == 0
+	}
+}
+
+// normalizeValue reduces a column or filter value to nil, string, float64, bool
+// or time.Time, going through driver.Valuer the way the SQL driver would.
+func normalizeValue(v interface{}) interface{} {
+	for {
+		if v == nil {
+			return nil
+		}
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return nil
+			}
+			v = rv.Elem().Interface()
+			continue
+		}
+		if valuer, ok := v.(driver.Valuer); ok {
+			value, err := valuer.Value()
+			if err != nil {
+				return nil
+			}
+			if _, again := value.(driver.Valuer); again {
+				return value
+			}
+			v = value
+			continue
+		}
+		break
+	}
+
+	switch t := v.(type) {
+	case time.Time:
+		return t
+	case []byte:
+		return string(t)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	}
+	return v
+}
+
+// compareValues compares two normalized values. Strings are coerced to the type
+// of the other operand, like Postgres does for untyped literals. It reports false
+// when either value is NULL or the values cannot be compared.
+func compareValues(a, b interface{}) (int, bool) {
+	if a == nil || b == nil {
+		return 0, false
+	}
+	switch x := a.(type) {
+	case string:
+		switch y := b.(type) {
+		case string:
+			return strings.Compare(x, y), true
+		case float64, bool, time.Time:
+			if coerced, ok := coerceString(x, y); ok {
+				return compareValues(coerced, y)
+			}
+		}
+	case float64:
+		switch y := b.(type) {
+		case float64:
+			return compareOrdered(x, y), true
+		case string:
+			if coerced, ok := coerceString(y, x); ok {
+				return compareValues(x, coerced)
+			}
+		}
+	case bool:
+		switch y := b.(type) {
+		case bool:
+			return compareOrdered(boolRank(x), boolRank(y)), true
+		case string:
+			if coerced, ok := coerceString(y, x); ok {
+				return compareValues(x, coerced)
+			}
+		}
+	case time.Time:
+		switch y := b.(type) {
+		case time.Time:
+			return x.Compare(y), true
+		case string:
+			if coerced, ok := coerceString(y, x); ok {
+				return compareValues(x, coerced)
+			}
+		}
+	}
+	return 0, false
+}
+
+// coerceString parses s as a value of the same type as like.
+func coerceString(s string, like interface{}) (interface{}, bool) {
+	switch like.(type) {
+	case float64:
+		f, err := strconv.ParseFloat(s, 64)
+		return f, err == nil
+	case bool:
+		b, err := strconv.ParseBool(s)
+		return b, err == nil
+	case time.Time:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999Z07:00", "2006-01-02 15:04:05", "2006-01-02"} {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// compareOrdered compares two ordered values.
+func compareOrdered[V float64 | int](a, b V) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// boolRank orders false before true, as Postgres does.
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// likePattern converts a SQL LIKE pattern into a case-insensitive regular
+// expression: % matches any sequence, _ any single character and \ escapes.
+func likePattern(pattern string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("(?is)^")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			sb.WriteString(".*")
+		case r == '_':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
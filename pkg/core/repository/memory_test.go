@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"golang-microservices-boilerplate/pkg/core/types"
+)
+
+var _ BaseRepository[widget] = (*MemoryBaseRepository[widget])(nil)
+
+func TestMemoryBaseRepositoryConformance(t *testing.T) {
+	runConformance(t, func(t *testing.T) BaseRepository[widget] {
+		return NewMemoryBaseRepository[widget]()
+	})
+}
+
+func TestMemoryBaseRepositoryConcurrentUse(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryBaseRepository[widget]()
+
+	const workers, perWorker = 8, 25
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				w := newWidget(fmt.Sprintf("c%d-%d", worker, j), j+1, "")
+				if err := repo.Create(ctx, w); err != nil {
+					t.Errorf("Create: %v", err)
+					return
+				}
+				w.Size += 100
+				if err := repo.Update(ctx, w); err != nil {
+					t.Errorf("Update: %v", err)
+					return
+				}
+				if _, err := repo.FindAll(ctx, types.FilterOptions{Limit: 5}); err != nil {
+					t.Errorf("FindAll: %v", err)
+					return
+				}
+				_ = repo.Transaction(ctx, func(tx BaseRepository[widget]) error {
+					_ = tx.Delete(ctx, w.ID, false)
+					return errors.New("rollback")
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	count, err := repo.Count(ctx, map[string]interface{}{"size": map[string]interface{}{"gt": 100}})
+	if err != nil || count != workers*perWorker {
+		t.Fatalf("Count = %d, %v, want %d", count, err, workers*perWorker)
+	}
+}
+
+func TestMemoryBaseRepositoryTransactionRepoIsClosed(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryBaseRepository[widget]()
+
+	var leaked BaseRepository[widget]
+	if err := repo.Transaction(ctx, func(tx BaseRepository[widget]) error {
+		leaked = tx
+		return nil
+	}); err != nil {
+		t.Fatalf("Transaction: %v", err)
+	}
+	if err := leaked.Create(ctx, newWidget("late", 1, "")); !errors.Is(err, errTxDone) {
+		t.Fatalf("Create after commit error = %v, want errTxDone", err)
+	}
+}
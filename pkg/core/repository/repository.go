@@ -5,16 +5,22 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 
+	"golang-microservices-boilerplate/pkg/core/database"
 	"golang-microservices-boilerplate/pkg/core/entity"
 	"golang-microservices-boilerplate/pkg/core/types"
 )
 
 // BaseRepository defines common database operations for all repositories
 // Operates on pointers to entities (*T) where T implements entity.Entity
+// Errors follow the repository error model: ErrNotFound (*NotFoundError),
+// ErrUniqueViolation/ErrForeignKeyViolation (*ConstraintError) and ErrVersionConflict.
 type BaseRepository[T entity.Entity] interface {
 	Create(ctx context.Context, entity *T) error
 	FindByID(ctx context.Context, id uuid.UUID) (*T, error)
@@ -30,10 +36,16 @@ type BaseRepository[T entity.Entity] interface {
 	CreateMany(ctx context.Context, entities []*T) error
 	UpdateMany(ctx context.Context, entities []*T) error
 	DeleteMany(ctx context.Context, ids []uuid.UUID, hardDelete bool) error
+
+	// Soft-delete Lifecycle
+	Restore(ctx context.Context, id uuid.UUID) error
+	ListDeleted(ctx context.Context, opts types.FilterOptions) (*types.PaginationResult[T], error)
+	PurgeDeleted(ctx context.Context, olderThan time.Duration) (int64, error)
 }
 
 // GormBaseRepository implements the BaseRepository interface using GORM
-// Reverted type parameters
+// Statements run in the transaction of a database.UnitOfWork when the context
+// carries one.
 type GormBaseRepository[T entity.Entity] struct {
 	DB        *gorm.DB
 	ModelType reflect.Type
@@ -51,80 +63,254 @@ func NewGormBaseRepository[T entity.Entity](db *gorm.DB) *GormBaseRepository[T]
 	}
 }
 
+// Conn returns the handle to run statements on for ctx: the unit of work's
+// transaction, or r.DB outside of one. Custom repositories embedding
+// GormBaseRepository should use it instead of r.DB.
+func (r *GormBaseRepository[T]) Conn(ctx context.Context) *gorm.DB {
+	return database.Conn(ctx, r.DB)
+}
+
+// ReadConn returns the handle for read-only queries of ctx: a healthy replica
+// when replicas are configured and ctx is neither in a transaction nor marked
+// with database.WithPrimary, otherwise the same handle as Conn.
+func (r *GormBaseRepository[T]) ReadConn(ctx context.Context) *gorm.DB {
+	return database.ReadConn(ctx, r.DB)
+}
+
 // Create adds a new entity to the database
 func (r *GormBaseRepository[T]) Create(ctx context.Context, entity *T) error {
-	return r.DB.WithContext(ctx).Create(entity).Error
+	return TranslateError(r.Conn(ctx).Create(entity).Error)
 }
 
 // FindByID retrieves an entity by its ID
 func (r *GormBaseRepository[T]) FindByID(ctx context.Context, id uuid.UUID) (*T, error) {
 	entityPtr := reflect.New(r.ModelType).Interface().(*T)
-	result := r.DB.WithContext(ctx).Where("id = ?", id).First(entityPtr)
+	result := r.ReadConn(ctx).Where("id = ?", id).First(entityPtr)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("entity not found")
+			return nil, r.notFound(id)
 		}
 		return nil, result.Error
 	}
 	return entityPtr, nil
 }
 
-// applyFilterOptions applies the provided filter options to a GORM query
-func (r *GormBaseRepository[T]) applyFilterOptions(db *gorm.DB, opts types.FilterOptions) *gorm.DB {
-	if len(opts.Filters) > 0 {
-		db = db.Where(opts.Filters)
+// notFound builds the *NotFoundError for this repository's entity type.
+func (r *GormBaseRepository[T]) notFound(key interface{}) error {
+	return NewNotFoundError(r.ModelType.Name(), key)
+}
+
+// queryableColumns returns the filterable and sortable column sets declared by
+// the entity through entity.Queryable.
+func (r *GormBaseRepository[T]) queryableColumns() (filterable, sortable map[string]struct{}) {
+	return queryableColumnsOf(r.ModelType)
+}
+
+// queryableColumnsOf returns the filterable and sortable column sets declared by
+// modelType through entity.Queryable.
+func queryableColumnsOf(modelType reflect.Type) (filterable, sortable map[string]struct{}) {
+	filterable, sortable = map[string]struct{}{}, map[string]struct{}{}
+	if q, ok := reflect.New(modelType).Interface().(entity.Queryable); ok {
+		for _, col := range q.FilterableColumns() {
+			filterable[col] = struct{}{}
+		}
+		for _, col := range q.SortableColumns() {
+			sortable[col] = struct{}{}
+		}
 	}
+	return filterable, sortable
+}
 
-	sortDirection := "ASC"
-	if opts.SortDesc {
-		sortDirection = "DESC"
+// applyFilters parses the filter map and adds one WHERE condition per operator.
+// Columns that are not filterable for the entity are rejected with types.ErrInvalidFilter.
+func (r *GormBaseRepository[T]) applyFilters(db *gorm.DB, filters map[string]interface{}) (*gorm.DB, error) {
+	if len(filters) == 0 {
+		return db, nil
 	}
-	if opts.SortBy != "" {
-		db = db.Order(fmt.Sprintf("%s %s", opts.SortBy, sortDirection))
+	conditions, err := types.ParseFilters(filters)
+	if err != nil {
+		return nil, err
+	}
+	filterable, _ := r.queryableColumns()
+	for _, cond := range conditions {
+		if _, ok := filterable[cond.Field]; !ok {
+			return nil, fmt.Errorf("%w: field %q is not filterable", types.ErrInvalidFilter, cond.Field)
+		}
+		db = db.Where(filterExpression(cond))
+	}
+	return db, nil
+}
+
+// filterExpression builds the SQL expression for a validated filter condition.
+// The column name is always quoted as an identifier and values are bound as parameters.
+func filterExpression(cond types.FilterCondition) clause.Expression {
+	column := clause.Column{Table: clause.CurrentTable, Name: cond.Field}
+	switch cond.Operator {
+	case types.OpNe:
+		return clause.Neq{Column: column, Value: cond.Value}
+	case types.OpGt:
+		return clause.Gt{Column: column, Value: cond.Value}
+	case types.OpGte:
+		return clause.Gte{Column: column, Value: cond.Value}
+	case types.OpLt:
+		return clause.Lt{Column: column, Value: cond.Value}
+	case types.OpLte:
+		return clause.Lte{Column: column, Value: cond.Value}
+	case types.OpIn:
+		return clause.IN{Column: column, Values: cond.Value.([]interface{})}
+	case types.OpBetween:
+		bounds := cond.Value.([]interface{})
+		return clause.Expr{SQL: "? BETWEEN ? AND ?", Vars: []interface{}{column, bounds[0], bounds[1]}}
+	case types.OpILike:
+		return clause.Expr{SQL: "? ILIKE ?", Vars: []interface{}{column, cond.Value}}
+	case types.OpIsNull:
+		if cond.Value.(bool) {
+			return clause.Expr{SQL: "? IS NULL", Vars: []interface{}{column}}
+		}
+		return clause.Expr{SQL: "? IS NOT NULL", Vars: []interface{}{column}}
+	default: // types.OpEq
+		return clause.Eq{Column: column, Value: cond.Value}
+	}
+}
+
+// sortField resolves the schema field used as the sort key.
+// Falls back to created_at when no sort column is given; columns that are not
+// sortable for the entity are rejected with types.ErrInvalidFilter.
+func (r *GormBaseRepository[T]) sortField(sortBy string) (*schema.Field, error) {
+	if sortBy == "" {
+		sortBy = "created_at"
+	}
+	if _, sortable := r.queryableColumns(); !containsColumn(sortable, sortBy) {
+		return nil, fmt.Errorf("%w: field %q is not sortable", types.ErrInvalidFilter, sortBy)
+	}
+	stmt := &gorm.Statement{DB: r.DB}
+	if err := stmt.Parse(reflect.New(r.ModelType).Interface()); err != nil {
+		return nil, fmt.Errorf("failed to parse model schema: %w", err)
+	}
+	field := stmt.Schema.LookUpField(sortBy)
+	if field == nil || field.DBName == "" {
+		return nil, fmt.Errorf("%w: unknown sort column %q", types.ErrInvalidFilter, sortBy)
+	}
+	return field, nil
+}
+
+// containsColumn reports whether col is in the given column set.
+func containsColumn(columns map[string]struct{}, col string) bool {
+	_, ok := columns[col]
+	return ok
+}
+
+// applyCursor restricts the query to rows strictly after the cursor position and
+// orders by (sort column, id) so that pages are stable across ties.
+func (r *GormBaseRepository[T]) applyCursor(db *gorm.DB, field *schema.Field, opts types.FilterOptions) (*gorm.DB, error) {
+	cmp := ">"
+	if opts.SortDesc {
+		cmp = "<"
 	}
+	column := clause.Column{Table: clause.CurrentTable, Name: field.DBName}
+	idColumn := clause.Column{Table: clause.CurrentTable, Name: "id"}
 
-	// Apply Limit and Offset
-	if opts.Limit > 0 {
-		db = db.Limit(opts.Limit)
+	if opts.Cursor != "" {
+		cursor, err := types.DecodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if cursor.SortBy != field.DBName || cursor.SortDesc != opts.SortDesc {
+			return nil, fmt.Errorf("%w: cursor does not match sort options", types.ErrInvalidCursor)
+		}
+		db = db.Where(
+			fmt.Sprintf("? %s ? OR (? = ? AND ? %s ?)", cmp, cmp),
+			column, cursor.Value, column, cursor.Value, idColumn, cursor.ID,
+		)
 	}
-	if opts.Offset >= 0 { // Allow offset 0
-		db = db.Offset(opts.Offset)
+
+	return db.Order(clause.OrderBy{Columns: []clause.OrderByColumn{
+		{Column: column, Desc: opts.SortDesc},
+		{Column: idColumn, Desc: opts.SortDesc},
+	}}), nil
+}
+
+// nextCursor builds the cursor pointing at the given (last) item of a page.
+func (r *GormBaseRepository[T]) nextCursor(ctx context.Context, field *schema.Field, sortDesc bool, last *T) (string, error) {
+	value, _ := field.ValueOf(ctx, reflect.ValueOf(last).Elem())
+	return types.EncodeCursor(types.Cursor{
+		SortBy:   field.DBName,
+		SortDesc: sortDesc,
+		Value:    value,
+		ID:       (*last).GetID(),
+	})
+}
+
+// deletedScope selects which rows a query sees with respect to soft deletion.
+type deletedScope int
+
+const (
+	scopeLive    deletedScope = iota // only rows that are not soft-deleted (GORM default)
+	scopeAll                         // live and soft-deleted rows
+	scopeDeleted                     // only soft-deleted rows
+)
+
+// scopeFor returns the soft-delete scope requested by the filter options.
+func scopeFor(opts types.FilterOptions) deletedScope {
+	if opts.IncludeDeleted {
+		return scopeAll
 	}
+	return scopeLive
+}
 
-	return db
+// applyDeletedScope restricts db to the rows visible in the given scope.
+func applyDeletedScope(db *gorm.DB, scope deletedScope) *gorm.DB {
+	switch scope {
+	case scopeAll:
+		return db.Unscoped()
+	case scopeDeleted:
+		return db.Unscoped().Where(clause.Expr{
+			SQL:  "? IS NOT NULL",
+			Vars: []interface{}{clause.Column{Table: clause.CurrentTable, Name: "deleted_at"}},
+		})
+	default:
+		return db
+	}
 }
 
 // FindAll retrieves all entities of type *T with filter options
-// Returns PaginationResult[T], Items field will hold []*T
+// Returns PaginationResult[T], Items field will hold []*T.
+// When opts.Cursor is set, keyset pagination on (sort column, id) is used and
+// opts.Offset is ignored. NextCursor is populated whenever more items exist.
 func (r *GormBaseRepository[T]) FindAll(ctx context.Context, opts types.FilterOptions) (*types.PaginationResult[T], error) {
+	return r.find(ctx, opts, scopeFor(opts))
+}
+
+// ListDeleted retrieves only soft-deleted entities, with the same filtering,
+// sorting and pagination semantics as FindAll. opts.IncludeDeleted is ignored.
+func (r *GormBaseRepository[T]) ListDeleted(ctx context.Context, opts types.FilterOptions) (*types.PaginationResult[T], error) {
+	return r.find(ctx, opts, scopeDeleted)
+}
+
+// find implements FindAll and ListDeleted for the given soft-delete scope.
+func (r *GormBaseRepository[T]) find(ctx context.Context, opts types.FilterOptions, scope deletedScope) (*types.PaginationResult[T], error) {
 	var entities []*T // Slice of pointers
-	var totalCount int64
+	totalCount := int64(-1)
 
 	modelInstance := reflect.New(r.ModelType).Interface()
-	db := r.DB.WithContext(ctx).Model(modelInstance)
+	conn := r.ReadConn(ctx) // One replica for the page and its count
+	db := applyDeletedScope(conn.Model(modelInstance), scope)
 
-	if !opts.IncludeDeleted {
-		db = db.Where("deleted_at IS NULL")
+	field, err := r.sortField(opts.SortBy)
+	if err != nil {
+		return nil, err
 	}
 
 	// Apply filters/search for counting total items (without pagination)
-	countDB := r.DB.WithContext(ctx).Model(modelInstance)
-	if !opts.IncludeDeleted {
-		countDB = countDB.Where("deleted_at IS NULL")
-	}
-	countOpts := types.FilterOptions{
-		Filters:        opts.Filters,
-		IncludeDeleted: opts.IncludeDeleted,
-	}
-	countDB = r.applyFilterOptions(countDB, countOpts)
-	if err := countDB.Count(&totalCount).Error; err != nil {
-		return nil, fmt.Errorf("failed to count items: %w", err)
-	}
-
-	// Apply all options for fetching items
-	queryDB := r.applyFilterOptions(db, opts)
-	if err := queryDB.Find(&entities).Error; err != nil {
-		return nil, fmt.Errorf("failed to find items: %w", err)
+	if !opts.SkipCount {
+		countDB := applyDeletedScope(conn.Model(modelInstance), scope)
+		countDB, err := r.applyFilters(countDB, opts.Filters)
+		if err != nil {
+			return nil, err
+		}
+		if err := countDB.Count(&totalCount).Error; err != nil {
+			return nil, fmt.Errorf("failed to count items: %w", err)
+		}
 	}
 
 	limit := opts.Limit
@@ -132,15 +318,37 @@ func (r *GormBaseRepository[T]) FindAll(ctx context.Context, opts types.FilterOp
 		limit = 50
 	}
 	offset := opts.Offset
-	if offset < 0 {
+	if offset < 0 || opts.Cursor != "" {
 		offset = 0
 	}
 
+	queryDB, err := r.applyFilters(db, opts.Filters)
+	if err != nil {
+		return nil, err
+	}
+	if queryDB, err = r.applyCursor(queryDB, field, opts); err != nil {
+		return nil, err
+	}
+	// Fetch one extra row to detect whether a next page exists
+	queryDB = queryDB.Limit(limit + 1).Offset(offset)
+	if err := queryDB.Find(&entities).Error; err != nil {
+		return nil, fmt.Errorf("failed to find items: %w", err)
+	}
+
+	var nextCursor string
+	if len(entities) > limit {
+		entities = entities[:limit]
+		if nextCursor, err = r.nextCursor(ctx, field, opts.SortDesc, entities[limit-1]); err != nil {
+			return nil, fmt.Errorf("failed to build next cursor: %w", err)
+		}
+	}
+
 	return &types.PaginationResult[T]{
 		Items:      entities, // GORM Find populates []*T
 		TotalItems: totalCount,
 		Limit:      limit,
 		Offset:     offset,
+		NextCursor: nextCursor,
 	}, nil
 }
 
@@ -155,39 +363,69 @@ func (r *GormBaseRepository[T]) FindWithFilter(ctx context.Context, filter map[s
 	return r.FindAll(ctx, opts)
 }
 
-// Update modifies an existing entity
+// Update modifies an existing entity.
+// Entities implementing entity.Versioned are updated with optimistic locking and
+// ErrVersionConflict is returned when the stored version has moved on.
 func (r *GormBaseRepository[T]) Update(ctx context.Context, entity *T) error {
 	id := (*entity).GetID()
 	if id == uuid.Nil {
 		return errors.New("entity must have a valid ID for update")
 	}
-	return r.DB.WithContext(ctx).Model(entity).Where("id = ?", id).Updates(entity).Error
+	return TranslateError(updateEntity(r.Conn(ctx), id, entity))
+}
+
+// updateEntity writes every column of e but created_at, zero values included,
+// so fields cleared through a field mask are written too. e is therefore the
+// full entity as loaded and then modified. For versioned entities the version
+// is part of the WHERE clause and is incremented on success; on failure the
+// in-memory version is restored.
+func updateEntity[T entity.Entity](db *gorm.DB, id uuid.UUID, e *T) error {
+	db = db.Model(e).Select("*").Omit("created_at")
+	versioned, ok := any(e).(entity.Versioned)
+	if !ok {
+		return db.Where("id = ?", id).Updates(e).Error
+	}
+
+	current := versioned.GetVersion()
+	versioned.SetVersion(current + 1)
+	result := db.Where("id = ? AND version = ?", id, current).Updates(e)
+	if result.Error != nil {
+		versioned.SetVersion(current)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		versioned.SetVersion(current)
+		return fmt.Errorf("%w: entity %s (version %d)", ErrVersionConflict, id, current)
+	}
+	return nil
 }
 
 // FindOneWithFilter retrieves the first entity that matches the provided filter criteria
 func (r *GormBaseRepository[T]) FindOneWithFilter(ctx context.Context, filter map[string]interface{}) (*T, error) {
 	entityPtr := reflect.New(r.ModelType).Interface().(*T)
-	db := r.DB.WithContext(ctx).Model(reflect.New(r.ModelType).Interface())
+	db := r.Conn(ctx).Model(reflect.New(r.ModelType).Interface())
 
-	if len(filter) > 0 {
-		db = db.Where(filter)
+	db, err := r.applyFilters(db, filter)
+	if err != nil {
+		return nil, err
 	}
-	db = db.Where("deleted_at IS NULL")
 
 	result := db.First(entityPtr)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return nil, errors.New("entity not found")
+			return nil, r.notFound(nil)
 		}
 		return nil, result.Error
 	}
 	return entityPtr, nil
 }
 
-// Delete removes an entity from the database by ID
+// Delete removes an entity from the database by ID.
+// A soft delete sets deleted_at on a live row; a hard delete permanently removes
+// the row whether or not it was soft-deleted before.
 func (r *GormBaseRepository[T]) Delete(ctx context.Context, id uuid.UUID, hardDelete bool) error {
 	entityInstance := reflect.New(r.ModelType).Interface()
-	db := r.DB.WithContext(ctx).Where("id = ?", id)
+	db := r.Conn(ctx).Where("id = ?", id)
 
 	var result *gorm.DB
 	if hardDelete {
@@ -195,27 +433,73 @@ func (r *GormBaseRepository[T]) Delete(ctx context.Context, id uuid.UUID, hardDe
 	} else {
 		result = db.Delete(entityInstance)
 	}
-	return result.Error
+	if result.Error != nil {
+		return TranslateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return r.notFound(id)
+	}
+	return nil
+}
+
+// Restore clears deleted_at on a soft-deleted entity, making it visible again.
+// Versioned entities get their version bumped so stale copies cannot overwrite it.
+func (r *GormBaseRepository[T]) Restore(ctx context.Context, id uuid.UUID) error {
+	modelInstance := reflect.New(r.ModelType).Interface()
+	updates := map[string]interface{}{
+		"deleted_at": nil,
+		"updated_at": time.Now(),
+	}
+	if _, ok := modelInstance.(entity.Versioned); ok {
+		updates["version"] = gorm.Expr("version + 1")
+	}
+
+	result := r.Conn(ctx).Unscoped().Model(modelInstance).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		UpdateColumns(updates)
+	if result.Error != nil {
+		return TranslateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return r.notFound(id)
+	}
+	return nil
+}
+
+// PurgeDeleted permanently removes entities that were soft-deleted more than
+// olderThan ago and returns the number of rows removed.
+func (r *GormBaseRepository[T]) PurgeDeleted(ctx context.Context, olderThan time.Duration) (int64, error) {
+	if olderThan < 0 {
+		return 0, fmt.Errorf("purge retention must not be negative, got %s", olderThan)
+	}
+	cutoff := time.Now().Add(-olderThan)
+	result := r.Conn(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Delete(reflect.New(r.ModelType).Interface())
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge deleted items: %w", TranslateError(result.Error))
+	}
+	return result.RowsAffected, nil
 }
 
 // Count returns the count of entities matching the filter
 func (r *GormBaseRepository[T]) Count(ctx context.Context, filter map[string]interface{}) (int64, error) {
 	var count int64
 	modelInstance := reflect.New(r.ModelType).Interface()
-	db := r.DB.WithContext(ctx).Model(modelInstance)
+	db := r.ReadConn(ctx).Model(modelInstance)
 
-	if len(filter) > 0 {
-		db = db.Where(filter)
+	db, err := r.applyFilters(db, filter)
+	if err != nil {
+		return 0, err
 	}
-	db = db.Where("deleted_at IS NULL")
 
-	err := db.Count(&count).Error
+	err = db.Count(&count).Error
 	return count, err
 }
 
 // Transaction runs a function within a database transaction
 func (r *GormBaseRepository[T]) Transaction(ctx context.Context, fn func(txRepo BaseRepository[T]) error) error {
-	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return r.Conn(ctx).Transaction(func(tx *gorm.DB) error {
 		txRepo := &GormBaseRepository[T]{
 			DB:        tx,
 			ModelType: r.ModelType,
@@ -231,7 +515,7 @@ func (r *GormBaseRepository[T]) CreateMany(ctx context.Context, entities []*T) e
 	if len(entities) == 0 {
 		return nil
 	}
-	return r.DB.WithContext(ctx).Create(entities).Error
+	return TranslateError(r.Conn(ctx).Create(entities).Error)
 }
 
 // UpdateMany updates multiple entities within a transaction.
@@ -239,39 +523,65 @@ func (r *GormBaseRepository[T]) UpdateMany(ctx context.Context, entities []*T) e
 	if len(entities) == 0 {
 		return nil
 	}
-	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return r.Conn(ctx).Transaction(func(tx *gorm.DB) error {
 		for _, entity := range entities {
 			id := (*entity).GetID()
 			if id == uuid.Nil {
 				return fmt.Errorf("entity in bulk update list missing ID")
 			}
-			if err := tx.Model(entity).Where("id = ?", id).Updates(entity).Error; err != nil {
-				return fmt.Errorf("failed to update entity with ID %s during bulk update: %w", id, err)
+			if err := updateEntity(tx, id, entity); err != nil {
+				return fmt.Errorf("failed to update entity with ID %s during bulk update: %w", id, TranslateError(err))
 			}
 		}
 		return nil
 	})
 }
 
-// DeleteMany removes multiple entities matching the provided IDs.
+// DeleteMany removes multiple entities matching the provided IDs within a
+// transaction. When an ID matches no deletable row (a live row for a soft
+// delete, any row for a hard delete), nothing is deleted and a *NotFoundError
+// is returned, so events recorded for the batch are never written for it.
 func (r *GormBaseRepository[T]) DeleteMany(ctx context.Context, ids []uuid.UUID, hardDelete bool) error {
 	if len(ids) == 0 {
 		return nil
 	}
 
-	modelInstance := reflect.New(r.ModelType).Interface()
-	db := r.DB.WithContext(ctx).Where("id IN (?)", ids)
+	return r.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		modelInstance := reflect.New(r.ModelType).Interface()
+		rows := func() *gorm.DB {
+			if hardDelete {
+				return tx.Unscoped()
+			}
+			return tx
+		}
 
-	var result *gorm.DB
-	if hardDelete {
-		result = db.Unscoped().Delete(modelInstance)
-	} else {
-		result = db.Delete(modelInstance)
-	}
+		var found []uuid.UUID
+		err := rows().Model(modelInstance).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN (?)", ids).Pluck("id", &found).Error
+		if err != nil {
+			return fmt.Errorf("failed during bulk delete: %w", TranslateError(err))
+		}
+		if missing, ok := firstMissing(ids, found); ok {
+			return r.notFound(missing)
+		}
 
-	if result.Error != nil {
-		return fmt.Errorf("failed during bulk delete: %w", result.Error)
-	}
+		if err := rows().Where("id IN (?)", ids).Delete(modelInstance).Error; err != nil {
+			return fmt.Errorf("failed during bulk delete: %w", TranslateError(err))
+		}
+		return nil
+	})
+}
 
-	return nil
+// firstMissing returns the first of ids that is not in found.
+func firstMissing(ids, found []uuid.UUID) (uuid.UUID, bool) {
+	present := make(map[uuid.UUID]bool, len(found))
+	for _, id := range found {
+		present[id] = true
+	}
+	for _, id := range ids {
+		if !present[id] {
+			return id, true
+		}
+	}
+	return uuid.Nil, false
 }
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/core/types"
+)
+
+// Searcher is implemented by repositories of entity.Searchable entities.
+// GormBaseRepository implements it; the in-memory repository does not.
+type Searcher[T entity.Entity] interface {
+	Search(ctx context.Context, opts types.SearchOptions) (*types.SearchResult[T], error)
+}
+
+// Search finds the entities matching opts.Query through the search_vector and
+// search_text columns of entity.Searchable entities. Every word of the query
+// matches as a word prefix ("ali smi" finds "Alicia Smith"); the whole query
+// also matches fuzzily by trigram word similarity (typos) and as a substring,
+// ignoring punctuation for digits so formatted phone numbers match. Hits are
+// ranked by full-text rank plus similarity and carry the search columns whose
+// words matched, HTML-escaped with the matches wrapped in <mark></mark>.
+// Soft-deleted entities are never found.
+func (r *GormBaseRepository[T]) Search(ctx context.Context, opts types.SearchOptions) (*types.SearchResult[T], error) {
+	searchable, ok := reflect.New(r.ModelType).Interface().(entity.Searchable)
+	if !ok {
+		return nil, fmt.Errorf("%s does not implement entity.Searchable", r.ModelType.Name())
+	}
+	query := strings.ToLower(strings.TrimSpace(opts.Query))
+	if query == "" {
+		return nil, fmt.Errorf("%w: query must not be empty", types.ErrInvalidSearch)
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	offset := opts.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	// Matching conditions and rank; the tsquery only exists when the query has words
+	tsQuery := prefixTSQuery(query)
+	conditions := []string{"? <% search_text", "search_text LIKE ?"}
+	args := []interface{}{query, "%" + escapeLike(query) + "%"}
+	if digits := onlyDigits(query); len(digits) >= 3 && digits != query {
+		conditions = append(conditions, "search_text LIKE ?")
+		args = append(args, "%"+digits+"%")
+	}
+	rank := "word_similarity(?, search_text)"
+	rankArgs := []interface{}{query}
+	highlights := "'{}'"
+	var highlightArgs []interface{}
+	if tsQuery != "" {
+		conditions = append(conditions, "search_vector @@ to_tsquery('simple', ?)")
+		args = append(args, tsQuery)
+		rank = "ts_rank(search_vector, to_tsquery('simple', ?)) + " + rank
+		rankArgs = append([]interface{}{tsQuery}, rankArgs...)
+
+		// The key is bound and the column quoted by GORM, so any column name is safe.
+		// Matches are delimited by characters removed from the text beforehand, and
+		// only turned into <mark> tags once the text is escaped (see markMatches)
+		pairs := make([]string, 0, len(searchable.SearchColumns()))
+		for _, col := range searchable.SearchColumns() {
+			pairs = append(pairs, "CAST(? AS text), ts_headline('simple', translate(coalesce(CAST(? AS text), ''), ?, ''), to_tsquery('simple', ?), ?)")
+			highlightArgs = append(highlightArgs, col, clause.Column{Name: col}, matchStart+matchStop, tsQuery, headlineOptions)
+		}
+		highlights = "jsonb_build_object(" + strings.Join(pairs, ", ") + ")"
+	}
+	where := "(" + strings.Join(conditions, " OR ") + ")"
+
+	modelInstance := reflect.New(r.ModelType).Interface()
+	conn := r.ReadConn(ctx) // One replica for the page, its count and its entities
+
+	var total int64
+	if err := conn.Model(modelInstance).Where(where, args...).Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count search hits: %w", err)
+	}
+
+	var rows []struct {
+		ID               uuid.UUID
+		SearchRank       float64
+		SearchHighlights string
+	}
+	selectArgs := append(append([]interface{}{}, rankArgs...), highlightArgs...)
+	if err := conn.Model(modelInstance).
+		Select(fmt.Sprintf("id, %s AS search_rank, %s::text AS search_highlights", rank, highlights), selectArgs...).
+		Where(where, args...).
+		Order("search_rank DESC, id").
+		Limit(limit).Offset(offset).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to search items: %w", err)
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	var entities []*T
+	if len(ids) > 0 {
+		if err := conn.Where("id IN ?", ids).Find(&entities).Error; err != nil {
+			return nil, fmt.Errorf("failed to load search hits: %w", err)
+		}
+	}
+	byID := make(map[uuid.UUID]*T, len(entities))
+	for _, e := range entities {
+		byID[(*e).GetID()] = e
+	}
+
+	hits := make([]types.SearchHit[T], 0, len(rows))
+	for _, row := range rows {
+		item, ok := byID[row.ID]
+		if !ok {
+			continue // Deleted between the two queries
+		}
+		hits = append(hits, types.SearchHit[T]{Item: item, Rank: row.SearchRank, Highlights: matchedHighlights(row.SearchHighlights)})
+	}
+	return &types.SearchResult[T]{Hits: hits, TotalItems: total, Limit: limit, Offset: offset}, nil
+}
+
+// prefixTSQuery turns the words of a query into a tsquery matching documents
+// containing every word as a prefix, e.g. "ali smi" becomes "ali:* & smi:*".
+// Words only keep letters and digits, so the result is always valid syntax.
+func prefixTSQuery(query string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = w + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// onlyDigits returns the digits of s.
+func onlyDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// escapeLike escapes the LIKE wildcards in s.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// matchStart and matchStop delimit the matches in ts_headline output. They are
+// Unicode noncharacters, which are removed from the column text first, so they
+// only ever delimit matches.
+const (
+	matchStart = "\uFDD0"
+	matchStop  = "\uFDD1"
+)
+
+// headlineOptions are the ts_headline options of search highlights.
+const headlineOptions = "StartSel=" + matchStart + ", StopSel=" + matchStop + ", HighlightAll=true"
+
+// matchedHighlights decodes the ts_headline object of a hit, keeping only the
+// columns that contain a match, with their text made safe by markMatches.
+func matchedHighlights(raw string) map[string]string {
+	var all map[string]string
+	if err := json.Unmarshal([]byte(raw), &all); err != nil {
+		return map[string]string{}
+	}
+	matched := make(map[string]string, len(all))
+	for col, text := range all {
+		if strings.Contains(text, matchStart) {
+			matched[col] = markMatches(text)
+		}
+	}
+	return matched
+}
+
+// markMatches HTML-escapes column text and wraps its delimited matches in
+// <mark></mark>, the only tags in the result: stored text cannot inject markup
+// into clients rendering highlights as HTML.
+func markMatches(text string) string {
+	return strings.NewReplacer(matchStart, "<mark>", matchStop, "</mark>").Replace(html.EscapeString(text))
+}
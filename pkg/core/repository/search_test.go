@@ -0,0 +1,42 @@
+package repository
+
+import "testing"
+
+func TestPrefixTSQuery(t *testing.T) {
+	cases := map[string]string{
+		"ali smi":         "ali:* & smi:*",
+		"o'brien":         "o:* & brien:*",
+		"(555) 010-2030":  "555:* & 010:* & 2030:*",
+		"smith & !jones|": "smith:* & jones:*",
+		"  ":              "",
+	}
+	for in, want := range cases {
+		if got := prefixTSQuery(in); got != want {
+			t.Errorf("prefixTSQuery(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestMatchedHighlights(t *testing.T) {
+	got := matchedHighlights(`{"first_name": "\ufdd0Alicia\ufdd1", "last_name": "Smith", "address": ""}`)
+	if len(got) != 1 || got["first_name"] != "<mark>Alicia</mark>" {
+		t.Errorf("matchedHighlights kept %v, want only first_name", got)
+	}
+	if got := matchedHighlights("not json"); len(got) != 0 {
+		t.Errorf("matchedHighlights of invalid JSON = %v, want empty", got)
+	}
+}
+
+func TestMarkMatches(t *testing.T) {
+	cases := map[string]string{
+		matchStart + "Smith" + matchStop + " & Sons":                              "<mark>Smith</mark> &amp; Sons",
+		`<img src=x onerror="alert(1)"> ` + matchStart + "Ali" + matchStop:        `&lt;img src=x onerror=&#34;alert(1)&#34;&gt; <mark>Ali</mark>`,
+		"<mark>fake</mark> " + matchStart + "O'Brien" + matchStop:                 "&lt;mark&gt;fake&lt;/mark&gt; <mark>O&#39;Brien</mark>",
+		matchStart + "<b>" + matchStop + "bold" + matchStart + "</b>" + matchStop: "<mark>&lt;b&gt;</mark>bold<mark>&lt;/b&gt;</mark>",
+	}
+	for in, want := range cases {
+		if got := markMatches(in); got != want {
+			t.Errorf("markMatches(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
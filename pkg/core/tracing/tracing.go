@@ -0,0 +1,133 @@
+// Package tracing sets up OpenTelemetry tracing for a process: the tracer
+// provider, its exporter and the W3C trace context propagator used by the
+// gateway, the gRPC interceptors and the database spans.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// InstrumentationName names the tracer of the shared instrumentation.
+const InstrumentationName = "golang-microservices-boilerplate"
+
+// Exporters selectable with TRACING_EXPORTER.
+const (
+	ExporterNone   = "none"   // Propagate trace context without recording spans
+	ExporterOTLP   = "otlp"   // OTLP over gRPC, configured by the OTEL_EXPORTER_OTLP_* variables
+	ExporterStdout = "stdout" // Pretty-printed spans on stdout, for local debugging
+	ExporterMemory = "memory" // Spans kept in memory, read with Provider.Spans in tests
+)
+
+// Config selects how spans are recorded and exported.
+type Config struct {
+	ServiceName string  // service.name of the spans
+	Exporter    string  // One of the Exporter* constants
+	SampleRatio float64 // Share of new traces recorded; traces started upstream follow their parent
+}
+
+// ConfigFromEnv reads TRACING_EXPORTER (default none), TRACING_SAMPLE_RATIO
+// (default 1) and OTEL_SERVICE_NAME (default serviceName). The OTLP exporter
+// reads its endpoint and headers from the standard OTEL_EXPORTER_OTLP_*
+// variables, e.g. OTEL_EXPORTER_OTLP_ENDPOINT.
+func ConfigFromEnv(serviceName string) Config {
+	ratio, err := strconv.ParseFloat(utils.GetEnv("TRACING_SAMPLE_RATIO", "1"), 64)
+	if err != nil {
+		ratio = 1
+	}
+	return Config{
+		ServiceName: utils.GetEnv("OTEL_SERVICE_NAME", serviceName),
+		Exporter:    strings.ToLower(utils.GetEnv("TRACING_EXPORTER", ExporterNone)),
+		SampleRatio: ratio,
+	}
+}
+
+// Provider is the installed tracer provider.
+type Provider struct {
+	provider *sdktrace.TracerProvider // Nil for ExporterNone
+	memory   *tracetest.InMemoryExporter
+}
+
+// Setup installs the global tracer provider and the W3C trace context and
+// baggage propagator. Call Shutdown before exiting to flush buffered spans.
+func Setup(ctx context.Context, config Config) (*Provider, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	p := &Provider{}
+	var exporter sdktrace.SpanExporter
+	switch config.Exporter {
+	case ExporterNone, "":
+		return p, nil
+	case ExporterOTLP:
+		otlp, err := otlptracegrpc.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+		}
+		exporter = otlp
+	case ExporterStdout:
+		stdout, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout trace exporter: %w", err)
+		}
+		exporter = stdout
+	case ExporterMemory:
+		p.memory = tracetest.NewInMemoryExporter()
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q (want none, otlp, stdout or memory)", config.Exporter)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(config.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	}
+	if p.memory != nil {
+		opts = append(opts, sdktrace.WithSyncer(p.memory)) // Spans are readable as soon as they end
+	} else {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+	p.provider = sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(p.provider)
+	return p, nil
+}
+
+// Spans returns the spans ended so far with ExporterMemory, or nil.
+func (p *Provider) Spans() tracetest.SpanStubs {
+	if p.memory == nil {
+		return nil
+	}
+	return p.memory.GetSpans()
+}
+
+// Shutdown flushes the spans not exported yet and stops the provider.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p.provider == nil {
+		return nil
+	}
+	return p.provider.Shutdown(ctx)
+}
+
+// Tracer returns the tracer of the shared instrumentation.
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
@@ -4,7 +4,9 @@ import (
 	"golang-microservices-boilerplate/pkg/core/entity"
 )
 
-// FilterOptions contains common filtering, pagination, and sorting options using Limit/Offset
+// FilterOptions contains common filtering, pagination, and sorting options.
+// Pagination uses Limit/Offset by default; when Cursor is set, keyset pagination
+// is used instead and Offset is ignored.
 type FilterOptions struct {
 	Limit          int                    `json:"limit"`           // Maximum number of items to return
 	Offset         int                    `json:"offset"`          // Number of items to skip
@@ -12,6 +14,8 @@ type FilterOptions struct {
 	SortDesc       bool                   `json:"sort_desc"`       // True for descending order
 	Filters        map[string]interface{} `json:"filters"`         // Key-value pairs for filtering
 	IncludeDeleted bool                   `json:"include_deleted"` // Whether to include soft-deleted records
+	Cursor         string                 `json:"cursor"`          // Opaque keyset cursor from a previous page's NextCursor
+	SkipCount      bool                   `json:"skip_count"`      // Skip the total count query (TotalItems is reported as -1)
 }
 
 // DefaultFilterOptions returns a default set of filter options using Limit/Offset
@@ -26,11 +30,12 @@ func DefaultFilterOptions() FilterOptions {
 	}
 }
 
-// PaginationResult represents a paginated result containing entity pointers using Limit/Offset.
+// PaginationResult represents a paginated result containing entity pointers.
 // We use type parameter E constrained by entity.Entity here.
 type PaginationResult[E entity.Entity] struct {
-	Items      []*E  `json:"items"`       // Slice of entity pointers (*E)
-	TotalItems int64 `json:"total_items"` // Total number of items matching the query
-	Limit      int   `json:"limit"`       // The limit used for this query
-	Offset     int   `json:"offset"`      // The offset used for this query
+	Items      []*E   `json:"items"`                 // Slice of entity pointers (*E)
+	TotalItems int64  `json:"total_items"`           // Total number of items matching the query (-1 if not counted)
+	Limit      int    `json:"limit"`                 // The limit used for this query
+	Offset     int    `json:"offset"`                // The offset used for this query
+	NextCursor string `json:"next_cursor,omitempty"` // Cursor for the next page; empty when there are no more items
 }
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded or
+// does not match the sort options of the query it is used with.
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// Cursor is the decoded form of an opaque keyset pagination cursor.
+// It records the sort key and ID of the last item of a page so the next page
+// can continue strictly after it, independent of concurrent inserts/deletes.
+type Cursor struct {
+	SortBy   string      `json:"s"`  // Sort column the cursor was produced for
+	SortDesc bool        `json:"d"`  // Sort direction the cursor was produced for
+	Value    interface{} `json:"v"`  // Sort column value of the last item
+	ID       uuid.UUID   `json:"id"` // ID of the last item (tie-breaker)
+}
+
+// EncodeCursor serializes a Cursor into an opaque, URL-safe string.
+func EncodeCursor(c Cursor) (string, error) {
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// DecodeCursor parses an opaque cursor string produced by EncodeCursor.
+func DecodeCursor(s string) (Cursor, error) {
+	var c Cursor
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == uuid.Nil {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
@@ -0,0 +1,139 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ErrInvalidFilter is returned when FilterOptions reference a column that is not
+// filterable/sortable for the entity, or use an unknown or malformed operator.
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// FilterOperator is a comparison operator usable in FilterOptions.Filters.
+type FilterOperator string
+
+const (
+	OpEq      FilterOperator = "eq"      // column = value
+	OpNe      FilterOperator = "ne"      // column <> value
+	OpGt      FilterOperator = "gt"      // column > value
+	OpGte     FilterOperator = "gte"     // column >= value
+	OpLt      FilterOperator = "lt"      // column < value
+	OpLte     FilterOperator = "lte"     // column <= value
+	OpIn      FilterOperator = "in"      // column IN (values...), value must be a non-empty list
+	OpBetween FilterOperator = "between" // column BETWEEN a AND b, value must be a 2-element list
+	OpILike   FilterOperator = "ilike"   // case-insensitive pattern match, value must be a string
+	OpIsNull  FilterOperator = "is_null" // column IS NULL (true) / IS NOT NULL (false)
+)
+
+// FilterCondition is a single validated "column operator value" condition.
+type FilterCondition struct {
+	Field    string
+	Operator FilterOperator
+	Value    interface{}
+}
+
+// ParseFilters converts FilterOptions.Filters into a list of conditions.
+//
+// Each key is a column name. The value is either:
+//   - a scalar, meaning equality (a nil value means is_null: true),
+//   - a list, meaning "in",
+//   - an object mapping operators to operands, e.g. {"gte": 18, "lt": 65}.
+//
+// Conditions are returned in a deterministic order (by field, then operator).
+func ParseFilters(filters map[string]interface{}) ([]FilterCondition, error) {
+	conditions := make([]FilterCondition, 0, len(filters))
+	for field, raw := range filters {
+		if field == "" {
+			return nil, fmt.Errorf("%w: empty field name", ErrInvalidFilter)
+		}
+		switch v := raw.(type) {
+		case nil:
+			conditions = append(conditions, FilterCondition{Field: field, Operator: OpIsNull, Value: true})
+		case map[string]interface{}:
+			if len(v) == 0 {
+				return nil, fmt.Errorf("%w: no operator given for field %q", ErrInvalidFilter, field)
+			}
+			for op, operand := range v {
+				cond, err := newFilterCondition(field, FilterOperator(op), operand)
+				if err != nil {
+					return nil, err
+				}
+				conditions = append(conditions, cond)
+			}
+		default:
+			op := OpEq
+			if _, isList := asList(raw); isList {
+				op = OpIn
+			}
+			cond, err := newFilterCondition(field, op, raw)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, cond)
+		}
+	}
+
+	sort.Slice(conditions, func(i, j int) bool {
+		if conditions[i].Field != conditions[j].Field {
+			return conditions[i].Field < conditions[j].Field
+		}
+		return conditions[i].Operator < conditions[j].Operator
+	})
+	return conditions, nil
+}
+
+// newFilterCondition validates the operand shape for the given operator.
+func newFilterCondition(field string, op FilterOperator, operand interface{}) (FilterCondition, error) {
+	cond := FilterCondition{Field: field, Operator: op, Value: operand}
+	list, isList := asList(operand)
+	_, isMap := operand.(map[string]interface{})
+
+	switch op {
+	case OpEq, OpNe, OpGt, OpGte, OpLt, OpLte:
+		if isList || isMap {
+			return cond, fmt.Errorf("%w: operator %q on field %q requires a scalar value", ErrInvalidFilter, op, field)
+		}
+		if operand == nil && op != OpEq && op != OpNe {
+			return cond, fmt.Errorf("%w: operator %q on field %q requires a non-null value", ErrInvalidFilter, op, field)
+		}
+	case OpIn:
+		if !isList || len(list) == 0 {
+			return cond, fmt.Errorf("%w: operator %q on field %q requires a non-empty list", ErrInvalidFilter, op, field)
+		}
+		cond.Value = list
+	case OpBetween:
+		if !isList || len(list) != 2 || list[0] == nil || list[1] == nil {
+			return cond, fmt.Errorf("%w: operator %q on field %q requires a list of two values", ErrInvalidFilter, op, field)
+		}
+		cond.Value = list
+	case OpILike:
+		if _, ok := operand.(string); !ok {
+			return cond, fmt.Errorf("%w: operator %q on field %q requires a string value", ErrInvalidFilter, op, field)
+		}
+	case OpIsNull:
+		if _, ok := operand.(bool); !ok {
+			return cond, fmt.Errorf("%w: operator %q on field %q requires a boolean value", ErrInvalidFilter, op, field)
+		}
+	default:
+		return cond, fmt.Errorf("%w: unknown operator %q on field %q", ErrInvalidFilter, op, field)
+	}
+	return cond, nil
+}
+
+// asList returns the elements of slice values (other than []byte) as []interface{}.
+func asList(v interface{}) ([]interface{}, bool) {
+	if list, ok := v.([]interface{}); ok {
+		return list, true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+	list := make([]interface{}, rv.Len())
+	for i := range list {
+		list[i] = rv.Index(i).Interface()
+	}
+	return list, true
+}
@@ -0,0 +1,33 @@
+package types
+
+import (
+	"errors"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+)
+
+// ErrInvalidSearch is returned when SearchOptions cannot be used for a search,
+// e.g. because the query is empty.
+var ErrInvalidSearch = errors.New("invalid search")
+
+// SearchOptions contains the query and pagination of a full-text search.
+type SearchOptions struct {
+	Query  string `json:"query"`  // Free text; words match as prefixes, typos match fuzzily
+	Limit  int    `json:"limit"`  // Maximum number of hits to return
+	Offset int    `json:"offset"` // Number of hits to skip
+}
+
+// SearchHit is one search result.
+type SearchHit[E entity.Entity] struct {
+	Item       *E                `json:"item"`
+	Rank       float64           `json:"rank"`       // Relevance; hits are ordered by descending rank
+	Highlights map[string]string `json:"highlights"` // Matched columns with matches wrapped in <mark></mark>
+}
+
+// SearchResult is a page of search hits.
+type SearchResult[E entity.Entity] struct {
+	Hits       []SearchHit[E] `json:"hits"`
+	TotalItems int64          `json:"total_items"` // Total number of hits across all pages
+	Limit      int            `json:"limit"`
+	Offset     int            `json:"offset"`
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
+	"golang-microservices-boilerplate/pkg/core/database"
 	coreDTO "golang-microservices-boilerplate/pkg/core/dto"
 	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/core/events"
 	"golang-microservices-boilerplate/pkg/core/logger"
 	"golang-microservices-boilerplate/pkg/core/repository"
 	"golang-microservices-boilerplate/pkg/core/types"
@@ -19,18 +23,31 @@ type BaseUseCase[T entity.Entity, CreateDTO any, UpdateDTO any] interface {
 	Create(ctx context.Context, dto CreateDTO) (*T, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*T, error)
 	List(ctx context.Context, opts types.FilterOptions) (*types.PaginationResult[T], error)
+	Export(ctx context.Context, opts types.FilterOptions, fn func(*T) error) error
 	Update(ctx context.Context, id uuid.UUID, dto UpdateDTO) (*T, error)
 	Delete(ctx context.Context, id uuid.UUID, hardDelete bool) error
 	FindWithFilter(ctx context.Context, filter map[string]interface{}, opts types.FilterOptions) (*types.PaginationResult[T], error)
 	Count(ctx context.Context, filter map[string]interface{}) (int64, error)
 
-	// Bulk Operations
+	// Bulk Operations (all-or-nothing)
 	CreateMany(ctx context.Context, dtos []CreateDTO) ([]*T, error)
 	UpdateMany(ctx context.Context, updates map[uuid.UUID]UpdateDTO) error
 	DeleteMany(ctx context.Context, ids []uuid.UUID, hardDelete bool) error
+
+	// Bulk Operations (partial success, one result per item)
+	CreateManyPartial(ctx context.Context, dtos []CreateDTO) []BulkItemResult[T]
+	UpdateManyPartial(ctx context.Context, updates []BulkUpdate[UpdateDTO]) []BulkItemResult[T]
+	DeleteManyPartial(ctx context.Context, ids []uuid.UUID, hardDelete bool) []BulkItemResult[T]
+
+	// Soft-delete Lifecycle
+	Restore(ctx context.Context, id uuid.UUID) (*T, error)
+	ListDeleted(ctx context.Context, opts types.FilterOptions) (*types.PaginationResult[T], error)
+	PurgeDeleted(ctx context.Context, olderThanDays int) (int64, error)
 }
 
-// BaseUseCaseImpl implements the BaseUseCase interface for entity pointers (*T)
+// BaseUseCaseImpl implements the BaseUseCase interface for entity pointers (*T).
+// Every write records an events.EntityEvent ("<entity>.created", ".updated",
+// ".deleted" or ".restored") that is stored in the outbox with the change.
 type BaseUseCaseImpl[T entity.Entity, CreateDTO any, UpdateDTO any] struct {
 	Repository repository.BaseRepository[T]
 	Logger     logger.Logger
@@ -54,7 +71,7 @@ func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) Create(ctx context.Context,
 		var validationErrs coreDTO.ValidationErrors
 		if errors.As(err, &validationErrs) {
 			uc.Logger.Warn("DTO validation failed", "errors", validationErrs.Error())
-			return nil, NewUseCaseError(ErrInvalidInput, validationErrs.Error())
+			return nil, newValidationError(validationErrs)
 		}
 		uc.Logger.Error("Validation setup error", "error", err)
 		return nil, NewUseCaseError(ErrInternal, fmt.Sprintf("validation error: %v", err))
@@ -67,10 +84,14 @@ func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) Create(ctx context.Context,
 		return nil, NewUseCaseError(ErrInternal, "failed to process input data mapping")
 	}
 
-	// Create entity in repository
+	// Create entity in repository, together with its "<entity>.created" event
+	ctx = events.Record(ctx, events.EntityEvent[T]{Change: events.EntityCreated, Entity: &entityPtr})
 	if err := uc.Repository.Create(ctx, &entityPtr); err != nil {
+		if ucErr := TranslateRepositoryError(err); ucErr != nil {
+			uc.Logger.Warn("Entity creation rejected", "error", err)
+			return nil, ucErr
+		}
 		uc.Logger.Error("Failed to create entity in repository", "error", err)
-		// Consider checking for specific DB errors (e.g., unique constraint)
 		return nil, err // Return original repository error
 	}
 
@@ -81,9 +102,8 @@ func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) Create(ctx context.Context,
 func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) GetByID(ctx context.Context, id uuid.UUID) (*T, error) {
 	entityPtr, err := uc.Repository.FindByID(ctx, id)
 	if err != nil {
-		// Specific handling for not found remains, but others are passed through
-		if err.Error() == "entity not found" { // Match error from repository
-			return nil, NewUseCaseError(ErrNotFound, fmt.Sprintf("resource with ID %s not found", id))
+		if ucErr := TranslateRepositoryError(err); ucErr != nil {
+			return nil, ucErr
 		}
 		uc.Logger.Error("Failed to get entity by ID", "id", id, "error", err)
 		return nil, err // Return original repository error
@@ -95,12 +115,34 @@ func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) GetByID(ctx context.Context,
 func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) List(ctx context.Context, opts types.FilterOptions) (*types.PaginationResult[T], error) {
 	result, err := uc.Repository.FindAll(ctx, opts)
 	if err != nil {
+		if ucErr := TranslateRepositoryError(err); ucErr != nil {
+			return nil, ucErr
+		}
 		uc.Logger.Error("Failed to list entities", "error", err)
 		return nil, err // Return original repository error
 	}
 	return result, nil
 }
 
+// Export calls fn for every entity matching opts, reading them page by page
+// (see repository.ForEach), e.g. to stream them to a client. An error of fn ends
+// the export and is returned unchanged.
+func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) Export(ctx context.Context, opts types.FilterOptions, fn func(*T) error) error {
+	var fnErr error
+	err := repository.ForEach(ctx, uc.Repository, opts, func(e *T) error {
+		fnErr = fn(e)
+		return fnErr
+	})
+	if err == nil || fnErr != nil {
+		return err
+	}
+	if ucErr := TranslateRepositoryError(err); ucErr != nil {
+		return ucErr
+	}
+	uc.Logger.Error("Failed to export entities", "error", err)
+	return err // Return original repository error
+}
+
 // Update modifies an existing entity using coreDTO functions
 func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) Update(ctx context.Context, id uuid.UUID, dto UpdateDTO) (*T, error) {
 	// Validate DTO using coreDTO.Validate
@@ -108,53 +150,58 @@ func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) Update(ctx context.Context,
 		var validationErrs coreDTO.ValidationErrors
 		if errors.As(err, &validationErrs) {
 			uc.Logger.Warn("Update DTO validation failed", "id", id, "errors", validationErrs.Error())
-			return nil, NewUseCaseError(ErrInvalidInput, validationErrs.Error())
+			return nil, newValidationError(validationErrs)
 		}
 		uc.Logger.Error("Update validation setup error", "id", id, "error", err)
 		return nil, NewUseCaseError(ErrInternal, fmt.Sprintf("validation error: %v", err))
 	}
 
-	// Fetch the existing entity pointer
+	// Fetch the existing entity pointer from the primary; a lagging replica
+	// would turn the write into a version conflict or lose concurrent changes
+	ctx = database.WithPrimary(ctx)
 	entityPtr, err := uc.Repository.FindByID(ctx, id)
 	if err != nil {
-		// Specific handling for not found remains
-		if err.Error() == "entity not found" {
-			return nil, NewUseCaseError(ErrNotFound, fmt.Sprintf("resource with ID %s not found for update", id))
+		if ucErr := TranslateRepositoryError(err); ucErr != nil {
+			return nil, ucErr
 		}
 		uc.Logger.Error("Failed to get entity for update", "id", id, "error", err)
 		return nil, err // Return original repository error
 	}
 
-	// Apply updates from DTO to the existing entity pointer using coreDTO.MapDTOToEntity
-	if err := coreDTO.MapToEntity(dto, entityPtr); err != nil {
+	// Apply updates from DTO to the existing entity pointer: only the masked
+	// fields when the DTO carries a field mask, otherwise every field
+	if err := applyUpdate(dto, entityPtr); err != nil {
+		if ucErr := TranslateMaskError(err); ucErr != nil {
+			uc.Logger.Warn("Update mask rejected", "id", id, "error", err)
+			return nil, ucErr
+		}
 		uc.Logger.Error("Failed to map update DTO to entity", "id", id, "error", err)
 		return nil, NewUseCaseError(ErrInternal, "failed to apply updates mapping") // Keep internal error for mapping issues
 	}
 
 	// Save the updated entity
+	ctx = events.Record(ctx, events.EntityEvent[T]{Change: events.EntityUpdated, Entity: entityPtr})
 	if err := uc.Repository.Update(ctx, entityPtr); err != nil {
+		if ucErr := TranslateRepositoryError(err); ucErr != nil {
+			uc.Logger.Warn("Entity update rejected", "id", id, "error", err)
+			return nil, ucErr
+		}
 		uc.Logger.Error("Failed to update entity in repository", "id", id, "error", err)
-		// Consider checking for specific DB errors
 		return nil, err // Return original repository error
 	}
 
 	return entityPtr, nil
 }
 
-// Delete soft-deletes or hard-deletes an entity based on the flag
+// Delete soft-deletes or hard-deletes an entity based on the flag.
+// A hard delete also removes entities that were already soft-deleted.
 func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) Delete(ctx context.Context, id uuid.UUID, hardDelete bool) error {
-	// Check if entity exists first to provide a NotFound error if it doesn't
-	_, err := uc.Repository.FindByID(ctx, id)
-	if err != nil {
-		if err.Error() == "entity not found" {
-			return NewUseCaseError(ErrNotFound, fmt.Sprintf("resource with ID %s not found for deletion", id))
-		}
-		uc.Logger.Error("Failed to find entity for deletion", "id", id, "hardDelete", hardDelete, "error", err)
-		return err // Return original repository error
-	}
-
-	// Perform delete (soft or hard)
+	// The repository reports ErrNotFound when no row was affected
+	ctx = events.Record(ctx, events.EntityEvent[T]{Change: events.EntityDeleted, ID: id})
 	if err := uc.Repository.Delete(ctx, id, hardDelete); err != nil {
+		if ucErr := TranslateRepositoryError(err); ucErr != nil {
+			return ucErr
+		}
 		uc.Logger.Error("Failed to delete entity", "id", id, "hardDelete", hardDelete, "error", err)
 		return err // Return original repository error
 	}
@@ -170,6 +217,9 @@ func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) FindWithFilter(
 ) (*types.PaginationResult[T], error) {
 	result, err := uc.Repository.FindWithFilter(ctx, filter, opts)
 	if err != nil {
+		if ucErr := TranslateRepositoryError(err); ucErr != nil {
+			return nil, ucErr
+		}
 		uc.Logger.Error("Failed to find entities with filter", "error", err)
 		return nil, err // Return original repository error
 	}
@@ -180,6 +230,9 @@ func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) FindWithFilter(
 func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) Count(ctx context.Context, filter map[string]interface{}) (int64, error) {
 	count, err := uc.Repository.Count(ctx, filter)
 	if err != nil {
+		if ucErr := TranslateRepositoryError(err); ucErr != nil {
+			return 0, ucErr
+		}
 		uc.Logger.Error("Failed to count entities", "error", err)
 		return 0, err // Return original repository error
 	}
@@ -212,7 +265,12 @@ func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) CreateMany(ctx context.Conte
 	}
 
 	// Create entities in repository
+	ctx = events.Record(ctx, entityEvents(events.EntityCreated, entities)...)
 	if err := uc.Repository.CreateMany(ctx, entities); err != nil {
+		if ucErr := TranslateRepositoryError(err); ucErr != nil {
+			uc.Logger.Warn("Bulk creation rejected", "error", err)
+			return nil, ucErr
+		}
 		uc.Logger.Error("Failed to bulk create entities", "error", err)
 		return nil, err // Return original repository error
 	}
@@ -227,6 +285,7 @@ func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) UpdateMany(ctx context.Conte
 		return nil // Nothing to update
 	}
 
+	ctx = database.WithPrimary(ctx) // Read the entities to update from the primary, as in Update
 	updatedEntities := make([]*T, 0, len(updates))
 	for id, dto := range updates {
 		// Validate DTO
@@ -238,15 +297,19 @@ func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) UpdateMany(ctx context.Conte
 		// Fetch the existing entity
 		entityPtr, err := uc.Repository.FindByID(ctx, id)
 		if err != nil {
-			if err.Error() == "entity not found" {
-				return NewUseCaseError(ErrNotFound, fmt.Sprintf("resource with ID %s not found for bulk update", id))
+			if ucErr := TranslateRepositoryError(err); ucErr != nil {
+				return ucErr
 			}
 			uc.Logger.Error("Failed to get entity for bulk update", "id", id, "error", err)
 			return err // Return original repository error
 		}
 
-		// Apply updates from DTO to the existing entity pointer
-		if err := coreDTO.MapToEntity(dto, entityPtr); err != nil {
+		// Apply updates from DTO to the existing entity pointer, honouring its field mask as in Update
+		if err := applyUpdate(dto, entityPtr); err != nil {
+			if ucErr := TranslateMaskError(err); ucErr != nil {
+				uc.Logger.Warn("Bulk update mask rejected", "id", id, "error", err)
+				return ucErr
+			}
 			uc.Logger.Error("Failed to map update DTO to entity for bulk update", "id", id, "error", err)
 			return NewUseCaseError(ErrInternal, fmt.Sprintf("failed to apply updates mapping for ID %s", id))
 		}
@@ -255,7 +318,12 @@ func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) UpdateMany(ctx context.Conte
 	}
 
 	// Call repository's UpdateMany with the prepared entities
+	ctx = events.Record(ctx, entityEvents(events.EntityUpdated, updatedEntities)...)
 	if err := uc.Repository.UpdateMany(ctx, updatedEntities); err != nil {
+		if ucErr := TranslateRepositoryError(err); ucErr != nil {
+			uc.Logger.Warn("Bulk update rejected", "count", len(updatedEntities), "error", err)
+			return ucErr
+		}
 		uc.Logger.Error("Failed to bulk update entities in repository", "count", len(updatedEntities), "error", err)
 		return err // Return original repository error
 	}
@@ -269,33 +337,165 @@ func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) DeleteMany(ctx context.Conte
 		return nil // Nothing to delete
 	}
 
-	// We might want to check if all IDs exist first, but that could be expensive.
-	// The repository level might handle non-existent IDs gracefully (e.g., deleting those that exist).
-	// Alternatively, add a check here if strict existence is required.
-
+	// The repository deletes all IDs or none, failing with NotFound when one is
+	// unknown, so the delete events are only written for entities actually deleted.
+	deleted := make([]events.Event, len(ids))
+	for i, id := range ids {
+		deleted[i] = events.EntityEvent[T]{Change: events.EntityDeleted, ID: id}
+	}
+	ctx = events.Record(ctx, deleted...)
 	if err := uc.Repository.DeleteMany(ctx, ids, hardDelete); err != nil {
+		if ucErr := TranslateRepositoryError(err); ucErr != nil {
+			return ucErr
+		}
 		uc.Logger.Error("Failed to bulk delete entities", "count", len(ids), "hardDelete", hardDelete, "error", err)
 		return err // Return original repository error
 	}
 	return nil
 }
 
+// BulkItemResult is the outcome of one item of a partial-success bulk operation.
+type BulkItemResult[T entity.Entity] struct {
+	Index  int       // Position of the item in the request
+	ID     uuid.UUID // Entity the item refers to; uuid.Nil for creates that failed
+	Entity *T        // Created or updated entity; nil for deletes and failed items
+	Err    error     // Nil on success, otherwise what the single-item operation returned
+}
+
+// BulkUpdate is one item of UpdateManyPartial. Unlike the map taken by
+// UpdateMany it keeps the order of the request.
+type BulkUpdate[UpdateDTO any] struct {
+	ID  uuid.UUID
+	DTO UpdateDTO
+}
+
+// CreateManyPartial creates every DTO on its own through Create, so valid items
+// are stored even when others fail. Each item commits independently.
+func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) CreateManyPartial(ctx context.Context, dtos []CreateDTO) []BulkItemResult[T] {
+	results := make([]BulkItemResult[T], len(dtos))
+	for i, dto := range dtos {
+		results[i].Index = i
+		if results[i].Err = ctx.Err(); results[i].Err != nil {
+			continue
+		}
+		results[i].Entity, results[i].Err = uc.Create(ctx, dto)
+		if results[i].Entity != nil {
+			results[i].ID = (*results[i].Entity).GetID()
+		}
+	}
+	return results
+}
+
+// UpdateManyPartial applies every update on its own through Update, so valid
+// items are stored even when others fail. Each item commits independently.
+func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) UpdateManyPartial(ctx context.Context, updates []BulkUpdate[UpdateDTO]) []BulkItemResult[T] {
+	results := make([]BulkItemResult[T], len(updates))
+	for i, update := range updates {
+		results[i].Index, results[i].ID = i, update.ID
+		if results[i].Err = ctx.Err(); results[i].Err != nil {
+			continue
+		}
+		results[i].Entity, results[i].Err = uc.Update(ctx, update.ID, update.DTO)
+	}
+	return results
+}
+
+// DeleteManyPartial deletes every ID on its own through Delete. Unlike
+// DeleteMany, IDs that match nothing are reported as not found.
+func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) DeleteManyPartial(ctx context.Context, ids []uuid.UUID, hardDelete bool) []BulkItemResult[T] {
+	results := make([]BulkItemResult[T], len(ids))
+	for i, id := range ids {
+		results[i].Index, results[i].ID = i, id
+		if results[i].Err = ctx.Err(); results[i].Err != nil {
+			continue
+		}
+		results[i].Err = uc.Delete(ctx, id, hardDelete)
+	}
+	return results
+}
+
+// entityEvents builds one EntityEvent per entity.
+func entityEvents[T entity.Entity](change string, entities []*T) []events.Event {
+	evts := make([]events.Event, len(entities))
+	for i, e := range entities {
+		evts[i] = events.EntityEvent[T]{Change: change, Entity: e}
+	}
+	return evts
+}
+
+// --- Soft-delete Lifecycle Implementation ---
+
+// Restore undeletes a soft-deleted entity and returns its current state.
+func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) Restore(ctx context.Context, id uuid.UUID) (*T, error) {
+	ctx = events.Record(ctx, events.EntityEvent[T]{Change: events.EntityRestored, ID: id})
+	if err := uc.Repository.Restore(ctx, id); err != nil {
+		if ucErr := TranslateRepositoryError(err); ucErr != nil {
+			return nil, ucErr
+		}
+		uc.Logger.Error("Failed to restore entity", "id", id, "error", err)
+		return nil, err // Return original repository error
+	}
+	return uc.GetByID(database.WithPrimary(ctx), id) // Replicas may not have seen the restore yet
+}
+
+// ListDeleted retrieves soft-deleted entities with pagination
+func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) ListDeleted(ctx context.Context, opts types.FilterOptions) (*types.PaginationResult[T], error) {
+	result, err := uc.Repository.ListDeleted(ctx, opts)
+	if err != nil {
+		if ucErr := TranslateRepositoryError(err); ucErr != nil {
+			return nil, ucErr
+		}
+		uc.Logger.Error("Failed to list deleted entities", "error", err)
+		return nil, err // Return original repository error
+	}
+	return result, nil
+}
+
+// PurgeDeleted permanently removes entities soft-deleted more than olderThanDays days ago.
+// Zero purges every soft-deleted entity.
+func (uc *BaseUseCaseImpl[T, CreateDTO, UpdateDTO]) PurgeDeleted(ctx context.Context, olderThanDays int) (int64, error) {
+	if olderThanDays < 0 {
+		return 0, NewUseCaseError(ErrInvalidInput, "older_than_days must not be negative")
+	}
+	purged, err := uc.Repository.PurgeDeleted(ctx, time.Duration(olderThanDays)*24*time.Hour)
+	if err != nil {
+		if ucErr := TranslateRepositoryError(err); ucErr != nil {
+			return 0, ucErr
+		}
+		uc.Logger.Error("Failed to purge deleted entities", "olderThanDays", olderThanDays, "error", err)
+		return 0, err // Return original repository error
+	}
+	uc.Logger.Info("Purged soft-deleted entities", "olderThanDays", olderThanDays, "count", purged)
+	return purged, nil
+}
+
 // UseCaseErrorType defines the type of error
 type UseCaseErrorType string
 
 const (
-	ErrNotFound     UseCaseErrorType = "not_found"
-	ErrInvalidInput UseCaseErrorType = "invalid_input"
-	ErrUnauthorized UseCaseErrorType = "unauthorized"
-	ErrForbidden    UseCaseErrorType = "forbidden"
-	ErrConflict     UseCaseErrorType = "conflict"
-	ErrInternal     UseCaseErrorType = "internal_error"
+	ErrNotFound      UseCaseErrorType = "not_found"
+	ErrInvalidInput  UseCaseErrorType = "invalid_input"
+	ErrUnauthorized  UseCaseErrorType = "unauthorized"
+	ErrForbidden     UseCaseErrorType = "forbidden"
+	ErrConflict      UseCaseErrorType = "conflict"
+	ErrAlreadyExists UseCaseErrorType = "already_exists"
+	ErrInternal      UseCaseErrorType = "internal_error"
 )
 
+// FieldViolation describes why a single request field was rejected.
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
 // UseCaseError represents an error from a use case
 type UseCaseError struct {
-	Type    UseCaseErrorType
-	Message string
+	Type       UseCaseErrorType
+	Message    string
+	Reason     string            // Machine-readable cause, e.g. "UNIQUE_VIOLATION"; see ReasonCode
+	Metadata   map[string]string // Structured context, e.g. resource or constraint name
+	Violations []FieldViolation  // Offending request fields (invalid input)
+	Err        error             // Underlying cause, if any
 }
 
 // Error returns the error message
@@ -303,6 +503,19 @@ func (e *UseCaseError) Error() string {
 	return e.Message
 }
 
+// Unwrap returns the underlying cause so errors.Is/As can inspect it.
+func (e *UseCaseError) Unwrap() error {
+	return e.Err
+}
+
+// ReasonCode returns Reason, falling back to the upper-cased Type (e.g. "NOT_FOUND").
+func (e *UseCaseError) ReasonCode() string {
+	if e.Reason != "" {
+		return e.Reason
+	}
+	return strings.ToUpper(string(e.Type))
+}
+
 // NewUseCaseError creates a new use case error
 func NewUseCaseError(errorType UseCaseErrorType, message string) error {
 	return &UseCaseError{
@@ -310,3 +523,169 @@ func NewUseCaseError(errorType UseCaseErrorType, message string) error {
 		Message: message,
 	}
 }
+
+// Reasons attached to errors translated from the repository layer.
+const (
+	ReasonValidationFailed    = "VALIDATION_FAILED"
+	ReasonInvalidFilter       = "INVALID_FILTER"
+	ReasonInvalidCursor       = "INVALID_CURSOR"
+	ReasonUniqueViolation     = "UNIQUE_VIOLATION"
+	ReasonForeignKeyViolation = "FOREIGN_KEY_VIOLATION"
+	ReasonStillReferenced     = "STILL_REFERENCED"
+	ReasonVersionConflict     = "VERSION_CONFLICT"
+)
+
+// TranslateRepositoryError converts errors of the repository error model
+// (not found, constraint violations, version conflicts, invalid filters, cursors or searches)
+// into a *UseCaseError. It returns nil for any other error, which callers should
+// treat as an internal failure.
+func TranslateRepositoryError(err error) *UseCaseError {
+	var notFound *repository.NotFoundError
+	var constraint *repository.ConstraintError
+	switch {
+	case err == nil:
+		return nil
+	case errors.As(err, &notFound):
+		ucErr := &UseCaseError{Type: ErrNotFound, Err: err, Metadata: map[string]string{"resource": notFound.Entity}}
+		if notFound.Key != "" {
+			ucErr.Message = fmt.Sprintf("%s with ID %s not found", notFound.Entity, notFound.Key)
+			ucErr.Metadata["id"] = notFound.Key
+		} else {
+			ucErr.Message = fmt.Sprintf("%s not found", notFound.Entity)
+		}
+		return ucErr
+	case errors.Is(err, repository.ErrNotFound):
+		return &UseCaseError{Type: ErrNotFound, Message: "resource not found", Err: err}
+	case errors.As(err, &constraint):
+		return constraintUseCaseError(constraint)
+	case errors.Is(err, repository.ErrVersionConflict):
+		return &UseCaseError{
+			Type:    ErrConflict,
+			Message: "resource was modified concurrently; reload and retry",
+			Reason:  ReasonVersionConflict,
+			Err:     err,
+		}
+	case errors.Is(err, types.ErrInvalidCursor):
+		return &UseCaseError{
+			Type:       ErrInvalidInput,
+			Message:    err.Error(),
+			Reason:     ReasonInvalidCursor,
+			Violations: []FieldViolation{{Field: "options.cursor", Description: err.Error()}},
+			Err:        err,
+		}
+	case errors.Is(err, types.ErrInvalidFilter):
+		return &UseCaseError{
+			Type:       ErrInvalidInput,
+			Message:    err.Error(),
+			Reason:     ReasonInvalidFilter,
+			Violations: []FieldViolation{{Field: "options", Description: err.Error()}},
+			Err:        err,
+		}
+	case errors.Is(err, types.ErrInvalidSearch):
+		return &UseCaseError{
+			Type:       ErrInvalidInput,
+			Message:    err.Error(),
+			Reason:     ReasonValidationFailed,
+			Violations: []FieldViolation{{Field: "query", Description: err.Error()}},
+			Err:        err,
+		}
+	}
+	return nil
+}
+
+// constraintUseCaseError maps a database constraint violation to a use case error.
+func constraintUseCaseError(c *repository.ConstraintError) *UseCaseError {
+	metadata := map[string]string{}
+	if c.Constraint != "" {
+		metadata["constraint"] = c.Constraint
+	}
+	if c.Table != "" {
+		metadata["table"] = c.Table
+	}
+	columns := strings.Join(c.Columns, ", ")
+
+	if errors.Is(c, repository.ErrUniqueViolation) {
+		ucErr := &UseCaseError{
+			Type:     ErrAlreadyExists,
+			Message:  "a record with the same unique key already exists",
+			Reason:   ReasonUniqueViolation,
+			Metadata: metadata,
+			Err:      c,
+		}
+		if columns != "" {
+			ucErr.Message = fmt.Sprintf("a record with the same %s already exists", columns)
+			for _, col := range c.Columns {
+				ucErr.Violations = append(ucErr.Violations, FieldViolation{Field: col, Description: "must be unique"})
+			}
+		}
+		return ucErr
+	}
+
+	// A foreign key violation on delete means other rows still point at this one
+	if strings.Contains(c.Detail, "is still referenced") {
+		return &UseCaseError{
+			Type:     ErrConflict,
+			Message:  "resource is still referenced by other records",
+			Reason:   ReasonStillReferenced,
+			Metadata: metadata,
+			Err:      c,
+		}
+	}
+	ucErr := &UseCaseError{
+		Type:     ErrInvalidInput,
+		Message:  "referenced record does not exist",
+		Reason:   ReasonForeignKeyViolation,
+		Metadata: metadata,
+		Err:      c,
+	}
+	if columns != "" {
+		ucErr.Message = fmt.Sprintf("%s references a record that does not exist", columns)
+		for _, col := range c.Columns {
+			ucErr.Violations = append(ucErr.Violations, FieldViolation{Field: col, Description: "references a record that does not exist"})
+		}
+	}
+	return ucErr
+}
+
+// applyUpdate maps an update DTO onto an entity, honouring its field mask if it has one.
+func applyUpdate(dto interface{}, entityPtr interface{}) error {
+	if masker, ok := dto.(coreDTO.Masker); ok && masker.FieldMask() != nil {
+		return coreDTO.ApplyMask(dto, entityPtr, masker.FieldMask())
+	}
+	return coreDTO.MapToEntity(dto, entityPtr)
+}
+
+// TranslateMaskError converts a *dto.MaskError returned by dto.ApplyMask into
+// an invalid-input *UseCaseError with a violation on the update_mask field. It
+// returns nil for any other error.
+func TranslateMaskError(err error) *UseCaseError {
+	var maskErr *coreDTO.MaskError
+	if !errors.As(err, &maskErr) {
+		return nil
+	}
+	return &UseCaseError{
+		Type:       ErrInvalidInput,
+		Message:    maskErr.Error(),
+		Reason:     ReasonValidationFailed,
+		Violations: []FieldViolation{{Field: "update_mask", Description: fmt.Sprintf("'%s' %s", maskErr.Path, maskErr.Reason)}},
+		Err:        err,
+	}
+}
+
+// newValidationError converts DTO validation failures into an invalid-input error
+// with one FieldViolation per failed field.
+func newValidationError(validationErrs coreDTO.ValidationErrors) error {
+	ucErr := &UseCaseError{
+		Type:    ErrInvalidInput,
+		Message: validationErrs.Error(),
+		Reason:  ReasonValidationFailed,
+		Err:     validationErrs,
+	}
+	for _, fe := range validationErrs.GetErrors() {
+		ucErr.Violations = append(ucErr.Violations, FieldViolation{
+			Field:       fe.Field(),
+			Description: fmt.Sprintf("failed on the '%s' tag", fe.Tag()),
+		})
+	}
+	return ucErr
+}
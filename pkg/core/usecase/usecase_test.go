@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"golang-microservices-boilerplate/pkg/core/entity"
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/repository"
+)
+
+// gadget is the entity used by the use case tests.
+type gadget struct {
+	entity.BaseEntity
+	Name string `gorm:"uniqueIndex;not null"`
+	Size int
+}
+
+type createGadget struct {
+	Name string `validate:"required"`
+	Size int    `validate:"gte=0"`
+}
+
+type updateGadget struct {
+	Size int `validate:"gte=0"`
+}
+
+// patchGadget is an update DTO with a field mask.
+type patchGadget struct {
+	Name string `json:"name"`
+	Size int    `json:"size" validate:"gte=0"`
+	Mask []string
+}
+
+func (d patchGadget) FieldMask() []string { return d.Mask }
+
+// nopLogger discards everything.
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{})                {}
+func (nopLogger) Info(string, ...interface{})                 {}
+func (nopLogger) Warn(string, ...interface{})                 {}
+func (nopLogger) Error(string, ...interface{})                {}
+func (nopLogger) Fatal(string, ...interface{})                {}
+func (l nopLogger) With(...interface{}) logger.Logger         { return l }
+func (l nopLogger) Named(string) logger.Logger                { return l }
+func (l nopLogger) WithContext(context.Context) logger.Logger { return l }
+
+func newGadgetUseCase() *BaseUseCaseImpl[gadget, createGadget, updateGadget] {
+	return NewBaseUseCase[gadget, createGadget, updateGadget](repository.NewMemoryBaseRepository[gadget](), nopLogger{})
+}
+
+// assertItem checks one partial bulk result against the expected use case error type.
+func assertItem(t *testing.T, r BulkItemResult[gadget], index int, want UseCaseErrorType) {
+	t.Helper()
+	if r.Index != index {
+		t.Errorf("result %d has Index %d", index, r.Index)
+	}
+	if want == "" {
+		if r.Err != nil {
+			t.Errorf("item %d failed: %v", index, r.Err)
+		}
+		return
+	}
+	var ucErr *UseCaseError
+	if !errors.As(r.Err, &ucErr) || ucErr.Type != want {
+		t.Errorf("item %d error = %v, want %s", index, r.Err, want)
+	}
+	if r.Entity != nil {
+		t.Errorf("failed item %d returned an entity", index)
+	}
+}
+
+func TestBulkPartialSuccess(t *testing.T) {
+	ctx := context.Background()
+	uc := newGadgetUseCase()
+
+	created := uc.CreateManyPartial(ctx, []createGadget{
+		{Name: "a", Size: 1},
+		{Name: "", Size: 2}, // Invalid
+		{Name: "b", Size: 3},
+		{Name: "a", Size: 4}, // Duplicate
+	})
+	if len(created) != 4 {
+		t.Fatalf("CreateManyPartial returned %d results, want 4", len(created))
+	}
+	assertItem(t, created[0], 0, "")
+	assertItem(t, created[1], 1, ErrInvalidInput)
+	assertItem(t, created[2], 2, "")
+	assertItem(t, created[3], 3, ErrAlreadyExists)
+	if created[0].ID == uuid.Nil || created[0].ID != created[0].Entity.ID {
+		t.Errorf("created item has ID %s, entity %s", created[0].ID, created[0].Entity.ID)
+	}
+	if created[1].ID != uuid.Nil {
+		t.Errorf("failed create has ID %s", created[1].ID)
+	}
+	if count, _ := uc.Count(ctx, nil); count != 2 {
+		t.Fatalf("Count after partial create = %d, want 2", count)
+	}
+
+	a, b := created[0].ID, created[2].ID
+	updated := uc.UpdateManyPartial(ctx, []BulkUpdate[updateGadget]{
+		{ID: a, DTO: updateGadget{Size: 10}},
+		{ID: uuid.New(), DTO: updateGadget{Size: 20}}, // Unknown
+		{ID: b, DTO: updateGadget{Size: -1}},          // Invalid
+	})
+	assertItem(t, updated[0], 0, "")
+	assertItem(t, updated[1], 1, ErrNotFound)
+	assertItem(t, updated[2], 2, ErrInvalidInput)
+	if got, _ := uc.GetByID(ctx, a); got.Size != 10 {
+		t.Errorf("updated size = %d, want 10", got.Size)
+	}
+	if got, _ := uc.GetByID(ctx, b); got.Size != 3 {
+		t.Errorf("rejected update changed size to %d", got.Size)
+	}
+
+	missing := uuid.New()
+	deleted := uc.DeleteManyPartial(ctx, []uuid.UUID{a, missing}, false)
+	assertItem(t, deleted[0], 0, "")
+	assertItem(t, deleted[1], 1, ErrNotFound)
+	if deleted[1].ID != missing {
+		t.Errorf("delete result ID = %s, want %s", deleted[1].ID, missing)
+	}
+	if count, _ := uc.Count(ctx, nil); count != 1 {
+		t.Fatalf("Count after partial delete = %d, want 1", count)
+	}
+}
+
+func TestBulkAllOrNothing(t *testing.T) {
+	ctx := context.Background()
+	uc := newGadgetUseCase()
+
+	if _, err := uc.CreateMany(ctx, []createGadget{{Name: "a"}, {Name: ""}}); err == nil {
+		t.Fatal("CreateMany with an invalid item succeeded")
+	}
+	if count, _ := uc.Count(ctx, nil); count != 0 {
+		t.Fatalf("CreateMany stored %d items of a rejected batch", count)
+	}
+}
+
+func TestBulkPartialCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := newGadgetUseCase().CreateManyPartial(ctx, []createGadget{{Name: "a"}, {Name: "b"}})
+	for i, r := range results {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("item %d error = %v, want context.Canceled", i, r.Err)
+		}
+	}
+}
+
+func TestUpdateFieldMask(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryBaseRepository[gadget]()
+	uc := NewBaseUseCase[gadget, createGadget, patchGadget](repo, nopLogger{})
+
+	created, err := uc.Create(ctx, createGadget{Name: "a", Size: 5})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	// A masked zero value clears the field, unmasked fields are kept
+	updated, err := uc.Update(ctx, created.ID, patchGadget{Name: "ignored", Mask: []string{"size"}})
+	if err != nil {
+		t.Fatalf("masked Update failed: %v", err)
+	}
+	if updated.Size != 0 || updated.Name != "a" {
+		t.Errorf("masked Update gave name %q size %d, want \"a\" 0", updated.Name, updated.Size)
+	}
+
+	for _, mask := range [][]string{{"name"}, {"colour"}, {"id"}} {
+		_, err := uc.Update(ctx, created.ID, patchGadget{Mask: mask})
+		var ucErr *UseCaseError
+		if !errors.As(err, &ucErr) || ucErr.Type != ErrInvalidInput || len(ucErr.Violations) != 1 || ucErr.Violations[0].Field != "update_mask" {
+			t.Errorf("Update with mask %v: error = %v, want invalid update_mask", mask, err)
+		}
+	}
+	if got, _ := uc.GetByID(ctx, created.ID); got.Name != "a" {
+		t.Errorf("rejected mask changed name to %q", got.Name)
+	}
+
+	// Bulk updates honour the mask as well
+	if _, err := uc.Update(ctx, created.ID, patchGadget{Size: 5, Mask: []string{"size"}}); err != nil {
+		t.Fatalf("masked Update failed: %v", err)
+	}
+	if err := uc.UpdateMany(ctx, map[uuid.UUID]patchGadget{created.ID: {Name: "ignored", Mask: []string{"size"}}}); err != nil {
+		t.Fatalf("masked UpdateMany failed: %v", err)
+	}
+	if got, _ := uc.GetByID(ctx, created.ID); got.Size != 0 || got.Name != "a" {
+		t.Errorf("masked UpdateMany gave name %q size %d, want \"a\" 0", got.Name, got.Size)
+	}
+	err = uc.UpdateMany(ctx, map[uuid.UUID]patchGadget{created.ID: {Mask: []string{"colour"}}})
+	var ucErr *UseCaseError
+	if !errors.As(err, &ucErr) || ucErr.Type != ErrInvalidInput {
+		t.Errorf("UpdateMany with an invalid mask: error = %v, want invalid input", err)
+	}
+}
@@ -1,24 +1,67 @@
 package middleware
 
 import (
-	"log"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/metrics"
 )
 
-// Logger is a middleware function that logs incoming requests and responses
-func LoggerMiddleware() fiber.Handler {
+// RequestIDHeader carries the ID correlating a request across the gateway and
+// the services; it is forwarded to them as x-request-id metadata.
+const RequestIDHeader = "X-Request-ID"
+
+// LoggerMiddleware writes one structured access log line per request and
+// records it in the http_* metrics. Requests without an X-Request-ID header get
+// a generated one, which is passed on to the services and echoed back.
+func LoggerMiddleware(log logger.Logger) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		start := time.Now()
+		requestID := c.Get(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+			c.Request().Header.Set(RequestIDHeader, requestID)
+		}
+		c.Set(RequestIDHeader, requestID)
 
-		// Process the request
+		// Process the request; errors are rendered by the app's error handler
+		// after this returns, so the status is taken from the error as well
 		err := c.Next()
+		code := c.Response().StatusCode()
+		if err != nil {
+			code = fiber.StatusInternalServerError
+			if fe, ok := err.(*fiber.Error); ok {
+				code = fe.Code
+			}
+		}
+		elapsed := time.Since(start)
 
-		// Log the request and response details
-		log.Printf("Request: %s %s | Response Status: %d | Duration: %s",
-			c.Method(), c.Path(), c.Response().StatusCode(), time.Since(start))
+		route := c.Route().Path
+		metrics.HTTPRequests.WithLabelValues(c.Method(), route, strconv.Itoa(code)).Inc()
+		metrics.HTTPRequestSeconds.WithLabelValues(c.Method(), route).Observe(elapsed.Seconds())
 
+		fields := []interface{}{
+			"method", c.Method(),
+			"path", c.Path(),
+			"route", route,
+			"status", code,
+			"latency_ms", float64(elapsed.Microseconds()) / 1000,
+			"ip", c.IP(),
+			"request_id", requestID,
+		}
+		log := log.WithContext(c.UserContext()) // Trace and span IDs set by TracingMiddleware
+		switch {
+		case code >= fiber.StatusInternalServerError:
+			log.Error("HTTP request handled", fields...)
+		case code >= fiber.StatusBadRequest:
+			log.Warn("HTTP request handled", fields...)
+		default:
+			log.Info("HTTP request handled", fields...)
+		}
 		return err
 	}
 }
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"golang-microservices-boilerplate/pkg/core/tracing"
+)
+
+// TracingMiddleware starts a server span for each request, continuing the trace
+// of an incoming traceparent header, and stores it in the user context of the
+// request. The span's context is written back to the request headers, so the
+// calls the gateway makes for the request are its children.
+func TracingMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		headers := propagation.HeaderCarrier{}
+		c.Request().Header.VisitAll(func(key, value []byte) {
+			headers.Set(string(key), string(value))
+		})
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), headers)
+		ctx, span := tracing.Tracer().Start(ctx, "HTTP "+c.Method(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Method()),
+				attribute.String("url.path", c.Path()),
+				attribute.String("client.address", c.IP()),
+			),
+		)
+		defer span.End()
+		c.SetUserContext(ctx)
+
+		propagated := propagation.HeaderCarrier{}
+		otel.GetTextMapPropagator().Inject(ctx, propagated)
+		for _, key := range propagated.Keys() {
+			c.Request().Header.Set(key, propagated.Get(key))
+		}
+
+		err := c.Next()
+		code := c.Response().StatusCode()
+		if err != nil {
+			code = fiber.StatusInternalServerError
+			if fe, ok := err.(*fiber.Error); ok {
+				code = fe.Code
+			}
+		}
+		route := c.Route().Path
+		span.SetName("HTTP " + c.Method() + " " + route)
+		span.SetAttributes(
+			attribute.String("http.route", route),
+			attribute.Int("http.response.status_code", code),
+		)
+		if code >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, "")
+		}
+		return err
+	}
+}
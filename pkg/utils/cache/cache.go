@@ -1,47 +1,223 @@
 package cache
 
 import (
+	"container/list"
+	"errors"
+	"strconv"
 	"sync"
+	"time"
+
+	"golang-microservices-boilerplate/pkg/utils"
 )
 
-// Cache is a simple in-memory cache
-type Cache struct {
-	mu    sync.RWMutex
-	store map[string]interface{}
+// Options configures a Cache.
+type Options struct {
+	TTL        time.Duration // How long an entry is served after it was set; 0 keeps entries until evicted
+	MaxEntries int           // Least recently used entries are evicted beyond this size; 0 is unbounded
+}
+
+// DefaultOptions provides sensible defaults, overridable through environment variables.
+func DefaultOptions() Options {
+	ttl, err := time.ParseDuration(utils.GetEnv("CACHE_TTL", "5m"))
+	if err != nil || ttl < 0 {
+		ttl = 5 * time.Minute
+	}
+	maxEntries, err := strconv.Atoi(utils.GetEnv("CACHE_MAX_ENTRIES", "10000"))
+	if err != nil || maxEntries < 0 {
+		maxEntries = 10000
+	}
+	return Options{TTL: ttl, MaxEntries: maxEntries}
+}
+
+// Stats reports the activity of a Cache since it was created.
+type Stats struct {
+	Hits      uint64 // Lookups served from the cache
+	Misses    uint64 // Lookups of missing or expired keys
+	Loads     uint64 // Loader calls made by GetOrLoad
+	Evictions uint64 // Entries removed for exceeding MaxEntries or their TTL
+	Entries   int    // Entries currently held, including expired ones not yet removed
 }
 
-// NewCache creates a new Cache instance
-func NewCache() *Cache {
-	return &Cache{
-		store: make(map[string]interface{}),
+// HitRatio returns the share of lookups served from the cache, or 0 without lookups.
+func (s Stats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
 	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// entry is a cached value and its expiry.
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time // Zero when the entry never expires
 }
 
-// Get retrieves an item from the cache
-func (c *Cache) Get(key string) (interface{}, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	value, exists := c.store[key]
-	return value, exists
+// call is a load in flight shared by concurrent GetOrLoad callers.
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
 }
 
-// Set adds an item to the cache
-func (c *Cache) Set(key string, value interface{}) {
+// Cache is an in-memory key-value cache with TTL expiry, LRU eviction and
+// de-duplicated loading. It is safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	mu      sync.Mutex
+	opts    Options
+	items   map[K]*list.Element // Values are *entry[K, V]
+	lru     *list.List          // Most recently used at the front
+	loading map[K]*call[V]
+	gen     uint64 // Incremented by Delete and Clear so loads started before them are not stored
+	stats   Stats
+	now     func() time.Time
+}
+
+// New creates an empty Cache.
+func New[K comparable, V any](opts Options) *Cache[K, V] {
+	return &Cache[K, V]{
+		opts:    opts,
+		items:   make(map[K]*list.Element),
+		lru:     list.New(),
+		loading: make(map[K]*call[V]),
+		now:     time.Now,
+	}
+}
+
+// Get returns the value cached for key.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.store[key] = value
+	return c.get(key)
+}
+
+// get looks key up, counting the hit or miss. c.mu must be held.
+func (c *Cache[K, V]) get(key K) (V, bool) {
+	var zero V
+	el, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		return zero, false
+	}
+	e := el.Value.(*entry[K, V])
+	if !e.expiresAt.IsZero() && !c.now().Before(e.expiresAt) {
+		c.remove(el)
+		c.stats.Evictions++
+		c.stats.Misses++
+		return zero, false
+	}
+	c.lru.MoveToFront(el)
+	c.stats.Hits++
+	return e.value, true
 }
 
-// Delete removes an item from the cache
-func (c *Cache) Delete(key string) {
+// Set caches value for key, evicting the least recently used entries when the
+// cache is full.
+func (c *Cache[K, V]) Set(key K, value V) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	delete(c.store, key)
+	c.set(key, value)
+}
+
+// set stores value for key. c.mu must be held.
+func (c *Cache[K, V]) set(key K, value V) {
+	var expiresAt time.Time
+	if c.opts.TTL > 0 {
+		expiresAt = c.now().Add(c.opts.TTL)
+	}
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*entry[K, V])
+		e.value, e.expiresAt = value, expiresAt
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.lru.PushFront(&entry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	for c.opts.MaxEntries > 0 && c.lru.Len() > c.opts.MaxEntries {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
 }
 
-// Clear removes all items from the cache
-func (c *Cache) Clear() {
+// GetOrLoad returns the value cached for key, calling load to fetch and cache
+// it on a miss. Concurrent callers missing the same key share a single load.
+// Errors are returned to every waiting caller and are not cached. A load that
+// was started before Delete or Clear is returned but not cached.
+func (c *Cache[K, V]) GetOrLoad(key K, load func() (V, error)) (V, error) {
+	c.mu.Lock()
+	if value, ok := c.get(key); ok {
+		c.mu.Unlock()
+		return value, nil
+	}
+	if inflight, ok := c.loading[key]; ok {
+		c.mu.Unlock()
+		<-inflight.done
+		return inflight.value, inflight.err
+	}
+	inflight := &call[V]{done: make(chan struct{})}
+	c.loading[key] = inflight
+	gen := c.gen
+	c.stats.Loads++
+	c.mu.Unlock()
+
+	completed := false
+	defer func() {
+		if !completed { // load panicked; waiting callers get an error instead
+			inflight.err = errors.New("cache: load panicked")
+		}
+		c.mu.Lock()
+		if c.loading[key] == inflight {
+			delete(c.loading, key)
+		}
+		if inflight.err == nil && c.gen == gen {
+			c.set(key, inflight.value)
+		}
+		c.mu.Unlock()
+		close(inflight.done)
+	}()
+	inflight.value, inflight.err = load()
+	completed = true
+	return inflight.value, inflight.err
+}
+
+// Delete removes the value cached for key.
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+	delete(c.loading, key)
+	c.gen++
+}
+
+// Clear removes every cached value.
+func (c *Cache[K, V]) Clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.store = make(map[string]interface{})
-}
\ No newline at end of file
+	c.items = make(map[K]*list.Element)
+	c.lru.Init()
+	c.loading = make(map[K]*call[V])
+	c.gen++
+}
+
+// Len returns the number of cached entries, including expired ones not yet removed.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Stats returns the hit, miss, load and eviction counters of the cache.
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	return stats
+}
+
+// remove drops an entry. c.mu must be held.
+func (c *Cache[K, V]) remove(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.items, el.Value.(*entry[K, V]).key)
+}
@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// clock is a settable time source for Cache.
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func newTestCache(opts Options) (*Cache[string, int], *clock) {
+	c := &clock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	cache := New[string, int](opts)
+	cache.now = c.now
+	return cache, c
+}
+
+func TestCacheTTL(t *testing.T) {
+	cache, c := newTestCache(Options{TTL: time.Minute})
+	cache.Set("a", 1)
+
+	c.t = c.t.Add(59 * time.Second)
+	if v, ok := cache.Get("a"); !ok || v != 1 {
+		t.Errorf("Get within TTL = %d, %v, want 1", v, ok)
+	}
+	cache.Set("a", 2) // Setting again restarts the TTL
+	c.t = c.t.Add(59 * time.Second)
+	if v, ok := cache.Get("a"); !ok || v != 2 {
+		t.Errorf("Get within the TTL of the new value = %d, %v, want 2", v, ok)
+	}
+	c.t = c.t.Add(time.Second)
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Get after TTL returned the expired value")
+	}
+	if n := cache.Len(); n != 0 {
+		t.Errorf("Len after expiry = %d, want 0", n)
+	}
+
+	// Without TTL entries never expire
+	forever, c := newTestCache(Options{})
+	forever.Set("a", 1)
+	c.t = c.t.Add(1000 * time.Hour)
+	if _, ok := forever.Get("a"); !ok {
+		t.Error("entry without TTL expired")
+	}
+}
+
+func TestCacheLRUEviction(t *testing.T) {
+	cache, _ := newTestCache(Options{MaxEntries: 2})
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Get("a") // b is now the least recently used
+	cache.Set("c", 3)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("least recently used entry was kept")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("entry %s was evicted", key)
+		}
+	}
+	cache.Set("a", 10) // Updating an entry does not evict
+	if n := cache.Len(); n != 2 {
+		t.Errorf("Len = %d, want MaxEntries", n)
+	}
+}
+
+func TestCacheGetOrLoadSharesLoads(t *testing.T) {
+	cache, _ := newTestCache(Options{})
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func() (int, error) {
+		loads.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	const callers = 10
+	var wg sync.WaitGroup
+	results := make([]int, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = cache.GetOrLoad("a", load)
+		}(i)
+	}
+	// Wait until every caller missed, then let the single load finish
+	for cache.Stats().Misses < callers {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if n := loads.Load(); n != 1 {
+		t.Errorf("load called %d times, want 1", n)
+	}
+	for i, v := range results {
+		if v != 42 {
+			t.Errorf("caller %d got %d, want 42", i, v)
+		}
+	}
+	if v, ok := cache.Get("a"); !ok || v != 42 {
+		t.Errorf("loaded value not cached: %d, %v", v, ok)
+	}
+}
+
+func TestCacheGetOrLoadErrors(t *testing.T) {
+	cache, _ := newTestCache(Options{})
+	errLoad := errors.New("load failed")
+	if _, err := cache.GetOrLoad("a", func() (int, error) { return 0, errLoad }); !errors.Is(err, errLoad) {
+		t.Errorf("GetOrLoad error = %v, want the load error", err)
+	}
+	if _, ok := cache.Get("a"); ok {
+		t.Error("failed load was cached")
+	}
+	if v, err := cache.GetOrLoad("a", func() (int, error) { return 7, nil }); err != nil || v != 7 {
+		t.Errorf("GetOrLoad after a failed load = %d, %v, want 7", v, err)
+	}
+}
+
+func TestCacheDeleteDuringLoad(t *testing.T) {
+	cache, _ := newTestCache(Options{})
+	started, release := make(chan struct{}), make(chan struct{})
+	done := make(chan int)
+	go func() {
+		v, _ := cache.GetOrLoad("a", func() (int, error) {
+			close(started)
+			<-release
+			return 1, nil // Stale: read before the Delete below
+		})
+		done <- v
+	}()
+	<-started
+	cache.Delete("a")
+	close(release)
+
+	if v := <-done; v != 1 {
+		t.Errorf("GetOrLoad = %d, want the loaded value", v)
+	}
+	if _, ok := cache.Get("a"); ok {
+		t.Error("value loaded before Delete was cached")
+	}
+	if v, _ := cache.GetOrLoad("a", func() (int, error) { return 2, nil }); v != 2 {
+		t.Errorf("GetOrLoad after Delete = %d, want a new load", v)
+	}
+}
+
+func TestCacheStats(t *testing.T) {
+	cache, c := newTestCache(Options{TTL: time.Minute, MaxEntries: 2})
+	cache.Set("a", 1)
+	cache.Get("a")                                              // Hit
+	cache.Get("b")                                              // Miss
+	cache.GetOrLoad("b", func() (int, error) { return 2, nil }) // Miss and load
+	cache.GetOrLoad("b", func() (int, error) { return 0, nil }) // Hit
+	cache.Set("c", 3)                                           // Evicts a
+	c.t = c.t.Add(time.Minute)
+	cache.Get("c") // Expired: miss and eviction
+
+	want := Stats{Hits: 2, Misses: 3, Loads: 1, Evictions: 2, Entries: 1}
+	if got := cache.Stats(); got != want {
+		t.Errorf("Stats = %+v, want %+v", got, want)
+	}
+	if got := want.HitRatio(); got != 0.4 {
+		t.Errorf("HitRatio = %v, want 0.4", got)
+	}
+	if got := (Stats{}).HitRatio(); got != 0 {
+		t.Errorf("HitRatio without lookups = %v, want 0", got)
+	}
+}
@@ -8,6 +8,7 @@ package appointment_service
 
 import (
 	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	core "golang-microservices-boilerplate/proto/core"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -97,6 +98,8 @@ type Appointment struct {
 	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	Place           string                 `protobuf:"bytes,11,opt,name=place,proto3" json:"place,omitempty"`
+	Version         int64                  `protobuf:"varint,12,opt,name=version,proto3" json:"version,omitempty"`
+	DeletedAt       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=deleted_at,json=deletedAt,proto3,oneof" json:"deleted_at,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -208,6 +211,20 @@ func (x *Appointment) GetPlace() string {
 	return ""
 }
 
+func (x *Appointment) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Appointment) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
 type ScheduleAppointmentRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	PatientId       string                 `protobuf:"bytes,1,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
@@ -428,6 +445,7 @@ type UpdateAppointmentStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AppointmentId string                 `protobuf:"bytes,1,opt,name=appointment_id,json=appointmentId,proto3" json:"appointment_id,omitempty"`
 	Status        AppointmentStatus      `protobuf:"varint,2,opt,name=status,proto3,enum=appointmentservice.AppointmentStatus" json:"status,omitempty"`
+	Version       *int64                 `protobuf:"varint,3,opt,name=version,proto3,oneof" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -476,6 +494,13 @@ func (x *UpdateAppointmentStatusRequest) GetStatus() AppointmentStatus {
 	return AppointmentStatus_APPOINTMENT_STATUS_UNSPECIFIED
 }
 
+func (x *UpdateAppointmentStatusRequest) GetVersion() int64 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
 type UpdateAppointmentStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Appointment   *Appointment           `protobuf:"bytes,1,opt,name=appointment,proto3" json:"appointment,omitempty"`
@@ -526,6 +551,7 @@ type RescheduleAppointmentRequest struct {
 	NewTime       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=new_time,json=newTime,proto3" json:"new_time,omitempty"`
 	NewDuration   *durationpb.Duration   `protobuf:"bytes,3,opt,name=new_duration,json=newDuration,proto3" json:"new_duration,omitempty"`
 	Place         string                 `protobuf:"bytes,4,opt,name=place,proto3" json:"place,omitempty"`
+	Version       *int64                 `protobuf:"varint,5,opt,name=version,proto3,oneof" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -588,6 +614,13 @@ func (x *RescheduleAppointmentRequest) GetPlace() string {
 	return ""
 }
 
+func (x *RescheduleAppointmentRequest) GetVersion() int64 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
 type RescheduleAppointmentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Appointment   *Appointment           `protobuf:"bytes,1,opt,name=appointment,proto3" json:"appointment,omitempty"`
@@ -677,8 +710,10 @@ func (x *CancelAppointmentRequest) GetAppointmentId() string {
 }
 
 type GetAppointmentsForPatientRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PatientId     string                 `protobuf:"bytes,1,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"` // Optional: Add time range filters?
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PatientId string                 `protobuf:"bytes,1,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	// Sorted by appointment_time, newest first, unless options.sort_by is set
+	Options       *core.FilterOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -720,11 +755,19 @@ func (x *GetAppointmentsForPatientRequest) GetPatientId() string {
 	return ""
 }
 
+func (x *GetAppointmentsForPatientRequest) GetOptions() *core.FilterOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
 type GetAppointmentsForPatientResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Appointments  []*Appointment         `protobuf:"bytes,1,rep,name=appointments,proto3" json:"appointments,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Appointments   []*Appointment         `protobuf:"bytes,1,rep,name=appointments,proto3" json:"appointments,omitempty"`
+	PaginationInfo *core.PaginationInfo   `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GetAppointmentsForPatientResponse) Reset() {
@@ -764,11 +807,20 @@ func (x *GetAppointmentsForPatientResponse) GetAppointments() []*Appointment {
 	return nil
 }
 
+func (x *GetAppointmentsForPatientResponse) GetPaginationInfo() *core.PaginationInfo {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
 type GetAppointmentsForDoctorRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DoctorId      string                 `protobuf:"bytes,1,opt,name=doctor_id,json=doctorId,proto3" json:"doctor_id,omitempty"`
-	StartTime     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	DoctorId  string                 `protobuf:"bytes,1,opt,name=doctor_id,json=doctorId,proto3" json:"doctor_id,omitempty"`
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Sorted by appointment_time, earliest first, unless options.sort_by is set
+	Options       *core.FilterOptions `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -824,11 +876,19 @@ func (x *GetAppointmentsForDoctorRequest) GetEndTime() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *GetAppointmentsForDoctorRequest) GetOptions() *core.FilterOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
 type GetAppointmentsForDoctorResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Appointments  []*Appointment         `protobuf:"bytes,1,rep,name=appointments,proto3" json:"appointments,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Appointments   []*Appointment         `protobuf:"bytes,1,rep,name=appointments,proto3" json:"appointments,omitempty"`
+	PaginationInfo *core.PaginationInfo   `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GetAppointmentsForDoctorResponse) Reset() {
@@ -868,11 +928,426 @@ func (x *GetAppointmentsForDoctorResponse) GetAppointments() []*Appointment {
 	return nil
 }
 
+func (x *GetAppointmentsForDoctorResponse) GetPaginationInfo() *core.PaginationInfo {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
+// Request for ExportAppointments
+type ExportAppointmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DoctorId      string                 `protobuf:"bytes,1,opt,name=doctor_id,json=doctorId,proto3" json:"doctor_id,omitempty"`
+	PatientId     string                 `protobuf:"bytes,2,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Options       *core.FilterOptions    `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportAppointmentsRequest) Reset() {
+	*x = ExportAppointmentsRequest{}
+	mi := &file_proto_appointment_service_appointment_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportAppointmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportAppointmentsRequest) ProtoMessage() {}
+
+func (x *ExportAppointmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_appointment_service_appointment_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportAppointmentsRequest.ProtoReflect.Descriptor instead.
+func (*ExportAppointmentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_appointment_service_appointment_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ExportAppointmentsRequest) GetDoctorId() string {
+	if x != nil {
+		return x.DoctorId
+	}
+	return ""
+}
+
+func (x *ExportAppointmentsRequest) GetPatientId() string {
+	if x != nil {
+		return x.PatientId
+	}
+	return ""
+}
+
+func (x *ExportAppointmentsRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ExportAppointmentsRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ExportAppointmentsRequest) GetOptions() *core.FilterOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// Request for DeleteAppointment
+type DeleteAppointmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppointmentId string                 `protobuf:"bytes,1,opt,name=appointment_id,json=appointmentId,proto3" json:"appointment_id,omitempty"`
+	HardDelete    bool                   `protobuf:"varint,2,opt,name=hard_delete,json=hardDelete,proto3" json:"hard_delete,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAppointmentRequest) Reset() {
+	*x = DeleteAppointmentRequest{}
+	mi := &file_proto_appointment_service_appointment_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAppointmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAppointmentRequest) ProtoMessage() {}
+
+func (x *DeleteAppointmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_appointment_service_appointment_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAppointmentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAppointmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_appointment_service_appointment_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteAppointmentRequest) GetAppointmentId() string {
+	if x != nil {
+		return x.AppointmentId
+	}
+	return ""
+}
+
+func (x *DeleteAppointmentRequest) GetHardDelete() bool {
+	if x != nil {
+		return x.HardDelete
+	}
+	return false
+}
+
+// Request for RestoreAppointment
+type RestoreAppointmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppointmentId string                 `protobuf:"bytes,1,opt,name=appointment_id,json=appointmentId,proto3" json:"appointment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreAppointmentRequest) Reset() {
+	*x = RestoreAppointmentRequest{}
+	mi := &file_proto_appointment_service_appointment_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreAppointmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreAppointmentRequest) ProtoMessage() {}
+
+func (x *RestoreAppointmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_appointment_service_appointment_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreAppointmentRequest.ProtoReflect.Descriptor instead.
+func (*RestoreAppointmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_appointment_service_appointment_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RestoreAppointmentRequest) GetAppointmentId() string {
+	if x != nil {
+		return x.AppointmentId
+	}
+	return ""
+}
+
+// Response for RestoreAppointment
+type RestoreAppointmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Appointment   *Appointment           `protobuf:"bytes,1,opt,name=appointment,proto3" json:"appointment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreAppointmentResponse) Reset() {
+	*x = RestoreAppointmentResponse{}
+	mi := &file_proto_appointment_service_appointment_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreAppointmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreAppointmentResponse) ProtoMessage() {}
+
+func (x *RestoreAppointmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_appointment_service_appointment_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreAppointmentResponse.ProtoReflect.Descriptor instead.
+func (*RestoreAppointmentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_appointment_service_appointment_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RestoreAppointmentResponse) GetAppointment() *Appointment {
+	if x != nil {
+		return x.Appointment
+	}
+	return nil
+}
+
+// Request for ListDeletedAppointments
+type ListDeletedAppointmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *core.FilterOptions    `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedAppointmentsRequest) Reset() {
+	*x = ListDeletedAppointmentsRequest{}
+	mi := &file_proto_appointment_service_appointment_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedAppointmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedAppointmentsRequest) ProtoMessage() {}
+
+func (x *ListDeletedAppointmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_appointment_service_appointment_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedAppointmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedAppointmentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_appointment_service_appointment_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListDeletedAppointmentsRequest) GetOptions() *core.FilterOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// Response for ListDeletedAppointments
+type ListDeletedAppointmentsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Appointments   []*Appointment         `protobuf:"bytes,1,rep,name=appointments,proto3" json:"appointments,omitempty"`
+	PaginationInfo *core.PaginationInfo   `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListDeletedAppointmentsResponse) Reset() {
+	*x = ListDeletedAppointmentsResponse{}
+	mi := &file_proto_appointment_service_appointment_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedAppointmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedAppointmentsResponse) ProtoMessage() {}
+
+func (x *ListDeletedAppointmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_appointment_service_appointment_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedAppointmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedAppointmentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_appointment_service_appointment_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListDeletedAppointmentsResponse) GetAppointments() []*Appointment {
+	if x != nil {
+		return x.Appointments
+	}
+	return nil
+}
+
+func (x *ListDeletedAppointmentsResponse) GetPaginationInfo() *core.PaginationInfo {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
+// Request for PurgeDeletedAppointments
+type PurgeDeletedAppointmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OlderThanDays int32                  `protobuf:"varint,1,opt,name=older_than_days,json=olderThanDays,proto3" json:"older_than_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeletedAppointmentsRequest) Reset() {
+	*x = PurgeDeletedAppointmentsRequest{}
+	mi := &file_proto_appointment_service_appointment_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeletedAppointmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeletedAppointmentsRequest) ProtoMessage() {}
+
+func (x *PurgeDeletedAppointmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_appointment_service_appointment_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeletedAppointmentsRequest.ProtoReflect.Descriptor instead.
+func (*PurgeDeletedAppointmentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_appointment_service_appointment_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PurgeDeletedAppointmentsRequest) GetOlderThanDays() int32 {
+	if x != nil {
+		return x.OlderThanDays
+	}
+	return 0
+}
+
+// Response for PurgeDeletedAppointments
+type PurgeDeletedAppointmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PurgedCount   int64                  `protobuf:"varint,1,opt,name=purged_count,json=purgedCount,proto3" json:"purged_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeletedAppointmentsResponse) Reset() {
+	*x = PurgeDeletedAppointmentsResponse{}
+	mi := &file_proto_appointment_service_appointment_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeletedAppointmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeletedAppointmentsResponse) ProtoMessage() {}
+
+func (x *PurgeDeletedAppointmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_appointment_service_appointment_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeletedAppointmentsResponse.ProtoReflect.Descriptor instead.
+func (*PurgeDeletedAppointmentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_appointment_service_appointment_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PurgeDeletedAppointmentsResponse) GetPurgedCount() int64 {
+	if x != nil {
+		return x.PurgedCount
+	}
+	return 0
+}
+
 var File_proto_appointment_service_appointment_proto protoreflect.FileDescriptor
 
 const file_proto_appointment_service_appointment_proto_rawDesc = "" +
 	"\n" +
-	"+proto/appointment-service/appointment.proto\x12\x12appointmentservice\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xa8\f\n" +
+	"+proto/appointment-service/appointment.proto\x12\x12appointmentservice\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x17proto/core/common.proto\x1a\x16proto/core/audit.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\x99\x0f\n" +
 	"\vAppointment\x12u\n" +
 	"\x02id\x18\x01 \x01(\tBe\x92Ab24Unique identifier for the appointment (UUID format).J*\"apt-a1b2c3d4-e5f6-7890-1234-567890abcdef\"R\x02id\x12t\n" +
 	"\n" +
@@ -888,11 +1363,15 @@ const file_proto_appointment_service_appointment_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampBb\x92A_2ETimestamp when the appointment was last updated (RFC3339 UTC format).J\x16\"2023-03-21T09:30:00Z\"R\tupdatedAt\x12z\n" +
-	"\x05place\x18\v \x01(\tBd\x92Aa2NLocation/Place of the appointment (e.g., Room number, Clinic name) (optional).J\x0f\"Clinic Room 3\"R\x05place:\x98\x01\x92A\x94\x01\n" +
+	"\x05place\x18\v \x01(\tBd\x92Aa2NLocation/Place of the appointment (e.g., Room number, Clinic name) (optional).J\x0f\"Clinic Room 3\"R\x05place\x12\xa5\x01\n" +
+	"\aversion\x18\f \x01(\x03B\x8a\x01\x92A\x86\x012\x80\x01Optimistic locking version of the appointment. Send it back on status updates or reschedules to detect concurrent modifications.J\x012R\aversion\x12\xb7\x01\n" +
+	"\n" +
+	"deleted_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampBw\x92At2ZTimestamp when the appointment was soft-deleted (RFC3339 UTC format). Null if not deleted.J\x16\"2023-04-01T12:00:00Z\"H\x00R\tdeletedAt\x88\x01\x01:\x98\x01\x92A\x94\x01\n" +
 	"\x91\x01*\vAppointment2#Represents a scheduled appointment.\xd2\x01\x02id\xd2\x01\n" +
 	"patient_id\xd2\x01\tdoctor_id\xd2\x01\x10appointment_time\xd2\x01\bduration\xd2\x01\x06status\xd2\x01\n" +
 	"created_at\xd2\x01\n" +
-	"updated_at\"\xea\x06\n" +
+	"updated_atB\r\n" +
+	"\v_deleted_at\"\xea\x06\n" +
 	"\x1aScheduleAppointmentRequest\x12d\n" +
 	"\n" +
 	"patient_id\x18\x01 \x01(\tBE\x92AB2\x18The UUID of the patient.J&\"p1a2b3c4-e5f6-7890-1234-567890abcdef\"R\tpatientId\x12g\n" +
@@ -911,50 +1390,93 @@ const file_proto_appointment_service_appointment_proto_rawDesc = "" +
 	"S*\x1fGet Appointment Details Request20Specifies the ID of the appointment to retrieve.\"\xbf\x01\n" +
 	"\x1dGetAppointmentDetailsResponse\x12A\n" +
 	"\vappointment\x18\x01 \x01(\v2\x1f.appointmentservice.AppointmentR\vappointment:[\x92AX\n" +
-	"V* Get Appointment Details Response22Contains the details of the requested appointment.\"\x93\x03\n" +
+	"V* Get Appointment Details Response22Contains the details of the requested appointment.\"\xc0\x04\n" +
 	"\x1eUpdateAppointmentStatusRequest\x12~\n" +
 	"\x0eappointment_id\x18\x01 \x01(\tBW\x92AT2&The UUID of the appointment to update.J*\"apt-a1b2c3d4-e5f6-7890-1234-567890abcdef\"R\rappointmentId\x12t\n" +
-	"\x06status\x18\x02 \x01(\x0e2%.appointmentservice.AppointmentStatusB5\x92A22#The new status for the appointment.J\v\"CONFIRMED\"R\x06status:{\x92Ax\n" +
-	"v*!Update Appointment Status Request27Specifies the ID of the appointment and the new status.\xd2\x01\x0eappointment_id\xd2\x01\x06status\"\xca\x01\n" +
+	"\x06status\x18\x02 \x01(\x0e2%.appointmentservice.AppointmentStatusB5\x92A22#The new status for the appointment.J\v\"CONFIRMED\"R\x06status\x12\x9e\x01\n" +
+	"\aversion\x18\x03 \x01(\x03B\x7f\x92A|2wExpected current version of the appointment (optional). If it was modified since, the request is rejected with ABORTED.J\x012H\x00R\aversion\x88\x01\x01:{\x92Ax\n" +
+	"v*!Update Appointment Status Request27Specifies the ID of the appointment and the new status.\xd2\x01\x0eappointment_id\xd2\x01\x06statusB\n" +
+	"\n" +
+	"\b_version\"\xca\x01\n" +
 	"\x1fUpdateAppointmentStatusResponse\x12A\n" +
 	"\vappointment\x18\x01 \x01(\v2\x1f.appointmentservice.AppointmentR\vappointment:d\x92Aa\n" +
-	"_*\"Update Appointment Status Response29Contains the appointment details after the status update.\"\xc1\x05\n" +
+	"_*\"Update Appointment Status Response29Contains the appointment details after the status update.\"\xee\x06\n" +
 	"\x1cRescheduleAppointmentRequest\x12\x82\x01\n" +
 	"\x0eappointment_id\x18\x01 \x01(\tB[\x92AX2*The UUID of the appointment to reschedule.J*\"apt-a1b2c3d4-e5f6-7890-1234-567890abcdef\"R\rappointmentId\x12\x93\x01\n" +
 	"\bnew_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampB\\\x92AY2?The new date and time for the appointment (RFC3339 UTC format).J\x16\"2023-04-11T11:00:00Z\"R\anewTime\x12\x92\x01\n" +
 	"\fnew_duration\x18\x03 \x01(\v2\x19.google.protobuf.DurationBT\x92AQ2FOptional new duration for the appointment (e.g., \"1800s\" for 30 mins).J\a\"1800s\"R\vnewDuration\x12i\n" +
-	"\x05place\x18\x04 \x01(\tBS\x92AP2<Optional new Location/Place for the rescheduled appointment.J\x10\"Online Meeting\"R\x05place:\x85\x01\x92A\x81\x01\n" +
-	"\x7f*\x1eReschedule Appointment Request2ASpecifies the ID and new details for rescheduling an appointment.\xd2\x01\x0eappointment_id\xd2\x01\bnew_time\"\xc0\x01\n" +
+	"\x05place\x18\x04 \x01(\tBS\x92AP2<Optional new Location/Place for the rescheduled appointment.J\x10\"Online Meeting\"R\x05place\x12\x9e\x01\n" +
+	"\aversion\x18\x05 \x01(\x03B\x7f\x92A|2wExpected current version of the appointment (optional). If it was modified since, the request is rejected with ABORTED.J\x012H\x00R\aversion\x88\x01\x01:\x85\x01\x92A\x81\x01\n" +
+	"\x7f*\x1eReschedule Appointment Request2ASpecifies the ID and new details for rescheduling an appointment.\xd2\x01\x0eappointment_id\xd2\x01\bnew_timeB\n" +
+	"\n" +
+	"\b_version\"\xc0\x01\n" +
 	"\x1dRescheduleAppointmentResponse\x12A\n" +
 	"\vappointment\x18\x01 \x01(\v2\x1f.appointmentservice.AppointmentR\vappointment:\\\x92AY\n" +
 	"W*\x1fReschedule Appointment Response24Contains the appointment details after rescheduling.\"\xed\x01\n" +
 	"\x18CancelAppointmentRequest\x12~\n" +
 	"\x0eappointment_id\x18\x01 \x01(\tBW\x92AT2&The UUID of the appointment to cancel.J*\"apt-a1b2c3d4-e5f6-7890-1234-567890abcdef\"R\rappointmentId:Q\x92AN\n" +
-	"L*\x1aCancel Appointment Request2.Specifies the ID of the appointment to cancel.\"\xf5\x01\n" +
+	"L*\x1aCancel Appointment Request2.Specifies the ID of the appointment to cancel.\"\xa4\x02\n" +
 	" GetAppointmentsForPatientRequest\x12d\n" +
 	"\n" +
-	"patient_id\x18\x01 \x01(\tBE\x92AB2\x18The UUID of the patient.J&\"p1a2b3c4-e5f6-7890-1234-567890abcdef\"R\tpatientId:k\x92Ah\n" +
-	"f*$Get Appointments For Patient Request2>Specifies the ID of the patient whose appointments are needed.\"\xd2\x01\n" +
+	"patient_id\x18\x01 \x01(\tBE\x92AB2\x18The UUID of the patient.J&\"p1a2b3c4-e5f6-7890-1234-567890abcdef\"R\tpatientId\x12-\n" +
+	"\aoptions\x18\x02 \x01(\v2\x13.core.FilterOptionsR\aoptions:k\x92Ah\n" +
+	"f*$Get Appointments For Patient Request2>Specifies the ID of the patient whose appointments are needed.\"\x92\x02\n" +
 	"!GetAppointmentsForPatientResponse\x12C\n" +
-	"\fappointments\x18\x01 \x03(\v2\x1f.appointmentservice.AppointmentR\fappointments:h\x92Ae\n" +
-	"c*%Get Appointments For Patient Response2:Contains a list of appointments for the specified patient.\"\xb1\x04\n" +
+	"\fappointments\x18\x01 \x03(\v2\x1f.appointmentservice.AppointmentR\fappointments\x12=\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x14.core.PaginationInfoR\x0epaginationInfo:i\x92Af\n" +
+	"d*%Get Appointments For Patient Response2;A paginated list of appointments for the specified patient.\"\xe0\x04\n" +
 	"\x1fGetAppointmentsForDoctorRequest\x12g\n" +
 	"\tdoctor_id\x18\x01 \x01(\tBJ\x92AG2\x1dThe UUID of the doctor/staff.J&\"s1a2b3c4-e5f6-7890-1234-567890abcdef\"R\bdoctorId\x12\x85\x01\n" +
 	"\n" +
 	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampBJ\x92AG2-Start of the time range (RFC3339 UTC format).J\x16\"2023-04-01T00:00:00Z\"R\tstartTime\x12\x7f\n" +
-	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampBH\x92AE2+End of the time range (RFC3339 UTC format).J\x16\"2023-04-30T23:59:59Z\"R\aendTime:\x9b\x01\x92A\x97\x01\n" +
+	"\bend_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampBH\x92AE2+End of the time range (RFC3339 UTC format).J\x16\"2023-04-30T23:59:59Z\"R\aendTime\x12-\n" +
+	"\aoptions\x18\x04 \x01(\v2\x13.core.FilterOptionsR\aoptions:\x9b\x01\x92A\x97\x01\n" +
 	"\x94\x01*#Get Appointments For Doctor Request2ISpecifies the ID of the doctor and a time range to retrieve appointments.\xd2\x01\tdoctor_id\xd2\x01\n" +
-	"start_time\xd2\x01\bend_time\"\xe5\x01\n" +
+	"start_time\xd2\x01\bend_time\"\xa5\x02\n" +
 	" GetAppointmentsForDoctorResponse\x12C\n" +
-	"\fappointments\x18\x01 \x03(\v2\x1f.appointmentservice.AppointmentR\fappointments:|\x92Ay\n" +
-	"w*$Get Appointments For Doctor Response2OContains a list of appointments for the specified doctor within the time range.*\x80\x01\n" +
+	"\fappointments\x18\x01 \x03(\v2\x1f.appointmentservice.AppointmentR\fappointments\x12=\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x14.core.PaginationInfoR\x0epaginationInfo:}\x92Az\n" +
+	"x*$Get Appointments For Doctor Response2PA paginated list of appointments for the specified doctor within the time range.\"\xb7\x06\n" +
+	"\x19ExportAppointmentsRequest\x12\x7f\n" +
+	"\tdoctor_id\x18\x01 \x01(\tBb\x92A_25Only export appointments with this doctor/staff UUID.J&\"s1a2b3c4-e5f6-7890-1234-567890abcdef\"R\bdoctorId\x12z\n" +
+	"\n" +
+	"patient_id\x18\x02 \x01(\tB[\x92AX2.Only export appointments of this patient UUID.J&\"p1a2b3c4-e5f6-7890-1234-567890abcdef\"R\tpatientId\x12\x9c\x01\n" +
+	"\n" +
+	"start_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampBa\x92A^2DOnly export appointments at or after this time (RFC3339 UTC format).J\x16\"2023-01-01T00:00:00Z\"R\tstartTime\x12\x93\x01\n" +
+	"\bend_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampB\\\x92AY2?Only export appointments before this time (RFC3339 UTC format).J\x16\"2024-01-01T00:00:00Z\"R\aendTime\x12-\n" +
+	"\aoptions\x18\x05 \x01(\v2\x13.core.FilterOptionsR\aoptions:\xb8\x01\x92A\xb4\x01\n" +
+	"\xb1\x01*\x1bExport Appointments Request2\x91\x01Selects the appointments to export. Every matching appointment is streamed; limit sets how many are read per page, offset and cursor are ignored.\"\xa6\x03\n" +
+	"\x18DeleteAppointmentRequest\x12~\n" +
+	"\x0eappointment_id\x18\x01 \x01(\tBW\x92AT2&The UUID of the appointment to delete.J*\"apt-a1b2c3d4-e5f6-7890-1234-567890abcdef\"R\rappointmentId\x12\x8d\x01\n" +
+	"\vhard_delete\x18\x02 \x01(\bBl\x92Ai2YIf true, performs a permanent (hard) delete. If false or omitted, performs a soft delete.:\x05falseJ\x05falseR\n" +
+	"hardDelete:z\x92Aw\n" +
+	"u*\x1aDelete Appointment Request2WSpecifies the appointment to delete and whether it should be a permanent (hard) delete.\"\xf7\x01\n" +
+	"\x19RestoreAppointmentRequest\x12\x81\x01\n" +
+	"\x0eappointment_id\x18\x01 \x01(\tBZ\x92AW2)The UUID of the soft-deleted appointment.J*\"apt-a1b2c3d4-e5f6-7890-1234-567890abcdef\"R\rappointmentId:V\x92AS\n" +
+	"Q*\x1bRestore Appointment Request22Specifies the soft-deleted appointment to restore.\"\xb7\x01\n" +
+	"\x1aRestoreAppointmentResponse\x12A\n" +
+	"\vappointment\x18\x01 \x01(\v2\x1f.appointmentservice.AppointmentR\vappointment:V\x92AS\n" +
+	"Q*\x1cRestore Appointment Response21Contains the details of the restored appointment.\"\xcb\x01\n" +
+	"\x1eListDeletedAppointmentsRequest\x12-\n" +
+	"\aoptions\x18\x01 \x01(\v2\x13.core.FilterOptionsR\aoptions:z\x92Aw\n" +
+	"u*!List Deleted Appointments Request2POptions for filtering, sorting, and paginating soft-deleted appointment records.\"\x87\x02\n" +
+	"\x1fListDeletedAppointmentsResponse\x12C\n" +
+	"\fappointments\x18\x01 \x03(\v2\x1f.appointmentservice.AppointmentR\fappointments\x12=\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x14.core.PaginationInfoR\x0epaginationInfo:`\x92A]\n" +
+	"[*\"List Deleted Appointments Response25A paginated list of soft-deleted appointment records.\"\xcb\x02\n" +
+	"\x1fPurgeDeletedAppointmentsRequest\x12\x9d\x01\n" +
+	"\x0folder_than_days\x18\x01 \x01(\x05Bu\x92Ar2hPermanently remove records soft-deleted more than this many days ago. 0 purges all soft-deleted records.:\x0230J\x0230R\rolderThanDays:\x87\x01\x92A\x83\x01\n" +
+	"\x80\x01*\"Purge Deleted Appointments Request2ZRetention window for soft-deleted appointment records; older ones are permanently removed.\"\xd6\x01\n" +
+	" PurgeDeletedAppointmentsResponse\x12R\n" +
+	"\fpurged_count\x18\x01 \x01(\x03B/\x92A,2&Number of records permanently removed.J\x0212R\vpurgedCount:^\x92A[\n" +
+	"Y*#Purge Deleted Appointments Response22Number of appointment records permanently removed.*\x80\x01\n" +
 	"\x11AppointmentStatus\x12\"\n" +
 	"\x1eAPPOINTMENT_STATUS_UNSPECIFIED\x10\x00\x12\r\n" +
 	"\tSCHEDULED\x10\x01\x12\r\n" +
 	"\tCONFIRMED\x10\x02\x12\r\n" +
 	"\tCANCELLED\x10\x03\x12\r\n" +
 	"\tCOMPLETED\x10\x04\x12\v\n" +
-	"\aNO_SHOW\x10\x052\x87\x10\n" +
+	"\aNO_SHOW\x10\x052\x98\x1c\n" +
 	"\x12AppointmentService\x12\xdc\x01\n" +
 	"\x13ScheduleAppointment\x12..appointmentservice.ScheduleAppointmentRequest\x1a/.appointmentservice.ScheduleAppointmentResponse\"d\x92AB\n" +
 	"\fAppointments\x12\x14Schedule Appointment\x1a\x1cSchedules a new appointment.\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/api/v1/appointments\x12\x8f\x02\n" +
@@ -971,7 +1493,18 @@ const file_proto_appointment_service_appointment_proto_rawDesc = "" +
 	"\bPatients\x12\x18Get Patient Appointments\x1a8Retrieves a list of appointments for a specific patient.\x82\xd3\xe4\x93\x02,\x12*/api/v1/patients/{patient_id}/appointments\x12\xbf\x02\n" +
 	"\x18GetAppointmentsForDoctor\x123.appointmentservice.GetAppointmentsForDoctorRequest\x1a4.appointmentservice.GetAppointmentsForDoctorResponse\"\xb7\x01\x92A\x83\x01\n" +
 	"\fAppointments\n" +
-	"\aDoctors\x12\x17Get Doctor Appointments\x1aQRetrieves a list of appointments for a specific doctor within a given time range.\x82\xd3\xe4\x93\x02*\x12(/api/v1/doctors/{doctor_id}/appointments\x1a \x92A\x1d\x12\x1bManage patient appointmentsB\xa7\x01\x92Ah\x12>\n" +
+	"\aDoctors\x12\x17Get Doctor Appointments\x1aQRetrieves a list of appointments for a specific doctor within a given time range.\x82\xd3\xe4\x93\x02*\x12(/api/v1/doctors/{doctor_id}/appointments\x12f\n" +
+	"\x12ExportAppointments\x12-.appointmentservice.ExportAppointmentsRequest\x1a\x1f.appointmentservice.Appointment0\x01\x12\xab\x02\n" +
+	"\x11DeleteAppointment\x12,.appointmentservice.DeleteAppointmentRequest\x1a\x16.google.protobuf.Empty\"\xcf\x01\x92A\x9e\x01\n" +
+	"\fAppointments\x12\x1eDelete Appointment (Soft/Hard)\x1anDeletes a appointment. Defaults to soft delete. Set 'hard_delete=true' query parameter for permanent deletion.\x82\xd3\xe4\x93\x02'*%/api/v1/appointments/{appointment_id}\x12\xfa\x01\n" +
+	"\x12RestoreAppointment\x12-.appointmentservice.RestoreAppointmentRequest\x1a..appointmentservice.RestoreAppointmentResponse\"\x84\x01\x92AI\n" +
+	"\fAppointments\x12\x13Restore Appointment\x1a$Restores a soft-deleted appointment.\x82\xd3\xe4\x93\x022:\x01*\"-/api/v1/appointments/{appointment_id}:restore\x12\x96\x02\n" +
+	"\x17ListDeletedAppointments\x122.appointmentservice.ListDeletedAppointmentsRequest\x1a3.appointmentservice.ListDeletedAppointmentsResponse\"\x91\x01\x92Aj\n" +
+	"\fAppointments\x12\x19List Deleted Appointments\x1a?Retrieves a paginated list of soft-deleted appointment records.\x82\xd3\xe4\x93\x02\x1e\x12\x1c/api/v1/appointments:deleted\x12\xb7\x02\n" +
+	"\x18PurgeDeletedAppointments\x123.appointmentservice.PurgeDeletedAppointmentsRequest\x1a4.appointmentservice.PurgeDeletedAppointmentsResponse\"\xaf\x01\x92A\x86\x01\n" +
+	"\fAppointments\x12\x1aPurge Deleted Appointments\x1aZPermanently removes appointment records soft-deleted more than 'older_than_days' days ago.\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/api/v1/appointments:purge\x12\xa8\x02\n" +
+	"\x0fListAuditEvents\x12\x1c.core.ListAuditEventsRequest\x1a\x1d.core.ListAuditEventsResponse\"\xd7\x01\x92A\xab\x01\n" +
+	"\fAppointments\x12\x11List Audit Events\x1a\x87\x01Retrieves the recorded changes of appointments, newest first. Filter by 'entity_type' and 'entity_id' to get the history of one entity.\x82\xd3\xe4\x93\x02\"\x12 /api/v1/appointments:auditEvents\x1a \x92A\x1d\x12\x1bManage patient appointmentsB\xa7\x01\x92Ah\x12>\n" +
 	"\x17Appointment Service API\x12\x1eAPI for managing appointments.2\x031.0*\x02\x01\x022\x10application/json:\x10application/jsonZ:golang-microservices-boilerplate/proto/appointment-serviceb\x06proto3"
 
 var (
@@ -987,7 +1520,7 @@ func file_proto_appointment_service_appointment_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_appointment_service_appointment_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_appointment_service_appointment_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_proto_appointment_service_appointment_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
 var file_proto_appointment_service_appointment_proto_goTypes = []any{
 	(AppointmentStatus)(0),                    // 0: appointmentservice.AppointmentStatus
 	(*Appointment)(nil),                       // 1: appointmentservice.Appointment
@@ -1004,48 +1537,84 @@ var file_proto_appointment_service_appointment_proto_goTypes = []any{
 	(*GetAppointmentsForPatientResponse)(nil), // 12: appointmentservice.GetAppointmentsForPatientResponse
 	(*GetAppointmentsForDoctorRequest)(nil),   // 13: appointmentservice.GetAppointmentsForDoctorRequest
 	(*GetAppointmentsForDoctorResponse)(nil),  // 14: appointmentservice.GetAppointmentsForDoctorResponse
-	(*timestamppb.Timestamp)(nil),             // 15: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),               // 16: google.protobuf.Duration
-	(*emptypb.Empty)(nil),                     // 17: google.protobuf.Empty
+	(*ExportAppointmentsRequest)(nil),         // 15: appointmentservice.ExportAppointmentsRequest
+	(*DeleteAppointmentRequest)(nil),          // 16: appointmentservice.DeleteAppointmentRequest
+	(*RestoreAppointmentRequest)(nil),         // 17: appointmentservice.RestoreAppointmentRequest
+	(*RestoreAppointmentResponse)(nil),        // 18: appointmentservice.RestoreAppointmentResponse
+	(*ListDeletedAppointmentsRequest)(nil),    // 19: appointmentservice.ListDeletedAppointmentsRequest
+	(*ListDeletedAppointmentsResponse)(nil),   // 20: appointmentservice.ListDeletedAppointmentsResponse
+	(*PurgeDeletedAppointmentsRequest)(nil),   // 21: appointmentservice.PurgeDeletedAppointmentsRequest
+	(*PurgeDeletedAppointmentsResponse)(nil),  // 22: appointmentservice.PurgeDeletedAppointmentsResponse
+	(*timestamppb.Timestamp)(nil),             // 23: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),               // 24: google.protobuf.Duration
+	(*core.FilterOptions)(nil),                // 25: core.FilterOptions
+	(*core.PaginationInfo)(nil),               // 26: core.PaginationInfo
+	(*core.ListAuditEventsRequest)(nil),       // 27: core.ListAuditEventsRequest
+	(*emptypb.Empty)(nil),                     // 28: google.protobuf.Empty
+	(*core.ListAuditEventsResponse)(nil),      // 29: core.ListAuditEventsResponse
 }
 var file_proto_appointment_service_appointment_proto_depIdxs = []int32{
-	15, // 0: appointmentservice.Appointment.appointment_time:type_name -> google.protobuf.Timestamp
-	16, // 1: appointmentservice.Appointment.duration:type_name -> google.protobuf.Duration
+	23, // 0: appointmentservice.Appointment.appointment_time:type_name -> google.protobuf.Timestamp
+	24, // 1: appointmentservice.Appointment.duration:type_name -> google.protobuf.Duration
 	0,  // 2: appointmentservice.Appointment.status:type_name -> appointmentservice.AppointmentStatus
-	15, // 3: appointmentservice.Appointment.created_at:type_name -> google.protobuf.Timestamp
-	15, // 4: appointmentservice.Appointment.updated_at:type_name -> google.protobuf.Timestamp
-	15, // 5: appointmentservice.ScheduleAppointmentRequest.appointment_time:type_name -> google.protobuf.Timestamp
-	16, // 6: appointmentservice.ScheduleAppointmentRequest.duration:type_name -> google.protobuf.Duration
-	1,  // 7: appointmentservice.ScheduleAppointmentResponse.appointment:type_name -> appointmentservice.Appointment
-	1,  // 8: appointmentservice.GetAppointmentDetailsResponse.appointment:type_name -> appointmentservice.Appointment
-	0,  // 9: appointmentservice.UpdateAppointmentStatusRequest.status:type_name -> appointmentservice.AppointmentStatus
-	1,  // 10: appointmentservice.UpdateAppointmentStatusResponse.appointment:type_name -> appointmentservice.Appointment
-	15, // 11: appointmentservice.RescheduleAppointmentRequest.new_time:type_name -> google.protobuf.Timestamp
-	16, // 12: appointmentservice.RescheduleAppointmentRequest.new_duration:type_name -> google.protobuf.Duration
-	1,  // 13: appointmentservice.RescheduleAppointmentResponse.appointment:type_name -> appointmentservice.Appointment
-	1,  // 14: appointmentservice.GetAppointmentsForPatientResponse.appointments:type_name -> appointmentservice.Appointment
-	15, // 15: appointmentservice.GetAppointmentsForDoctorRequest.start_time:type_name -> google.protobuf.Timestamp
-	15, // 16: appointmentservice.GetAppointmentsForDoctorRequest.end_time:type_name -> google.protobuf.Timestamp
-	1,  // 17: appointmentservice.GetAppointmentsForDoctorResponse.appointments:type_name -> appointmentservice.Appointment
-	2,  // 18: appointmentservice.AppointmentService.ScheduleAppointment:input_type -> appointmentservice.ScheduleAppointmentRequest
-	4,  // 19: appointmentservice.AppointmentService.GetAppointmentDetails:input_type -> appointmentservice.GetAppointmentDetailsRequest
-	6,  // 20: appointmentservice.AppointmentService.UpdateAppointmentStatus:input_type -> appointmentservice.UpdateAppointmentStatusRequest
-	8,  // 21: appointmentservice.AppointmentService.RescheduleAppointment:input_type -> appointmentservice.RescheduleAppointmentRequest
-	10, // 22: appointmentservice.AppointmentService.CancelAppointment:input_type -> appointmentservice.CancelAppointmentRequest
-	11, // 23: appointmentservice.AppointmentService.GetAppointmentsForPatient:input_type -> appointmentservice.GetAppointmentsForPatientRequest
-	13, // 24: appointmentservice.AppointmentService.GetAppointmentsForDoctor:input_type -> appointmentservice.GetAppointmentsForDoctorRequest
-	3,  // 25: appointmentservice.AppointmentService.ScheduleAppointment:output_type -> appointmentservice.ScheduleAppointmentResponse
-	5,  // 26: appointmentservice.AppointmentService.GetAppointmentDetails:output_type -> appointmentservice.GetAppointmentDetailsResponse
-	7,  // 27: appointmentservice.AppointmentService.UpdateAppointmentStatus:output_type -> appointmentservice.UpdateAppointmentStatusResponse
-	9,  // 28: appointmentservice.AppointmentService.RescheduleAppointment:output_type -> appointmentservice.RescheduleAppointmentResponse
-	17, // 29: appointmentservice.AppointmentService.CancelAppointment:output_type -> google.protobuf.Empty
-	12, // 30: appointmentservice.AppointmentService.GetAppointmentsForPatient:output_type -> appointmentservice.GetAppointmentsForPatientResponse
-	14, // 31: appointmentservice.AppointmentService.GetAppointmentsForDoctor:output_type -> appointmentservice.GetAppointmentsForDoctorResponse
-	25, // [25:32] is the sub-list for method output_type
-	18, // [18:25] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	23, // 3: appointmentservice.Appointment.created_at:type_name -> google.protobuf.Timestamp
+	23, // 4: appointmentservice.Appointment.updated_at:type_name -> google.protobuf.Timestamp
+	23, // 5: appointmentservice.Appointment.deleted_at:type_name -> google.protobuf.Timestamp
+	23, // 6: appointmentservice.ScheduleAppointmentRequest.appointment_time:type_name -> google.protobuf.Timestamp
+	24, // 7: appointmentservice.ScheduleAppointmentRequest.duration:type_name -> google.protobuf.Duration
+	1,  // 8: appointmentservice.ScheduleAppointmentResponse.appointment:type_name -> appointmentservice.Appointment
+	1,  // 9: appointmentservice.GetAppointmentDetailsResponse.appointment:type_name -> appointmentservice.Appointment
+	0,  // 10: appointmentservice.UpdateAppointmentStatusRequest.status:type_name -> appointmentservice.AppointmentStatus
+	1,  // 11: appointmentservice.UpdateAppointmentStatusResponse.appointment:type_name -> appointmentservice.Appointment
+	23, // 12: appointmentservice.RescheduleAppointmentRequest.new_time:type_name -> google.protobuf.Timestamp
+	24, // 13: appointmentservice.RescheduleAppointmentRequest.new_duration:type_name -> google.protobuf.Duration
+	1,  // 14: appointmentservice.RescheduleAppointmentResponse.appointment:type_name -> appointmentservice.Appointment
+	25, // 15: appointmentservice.GetAppointmentsForPatientRequest.options:type_name -> core.FilterOptions
+	1,  // 16: appointmentservice.GetAppointmentsForPatientResponse.appointments:type_name -> appointmentservice.Appointment
+	26, // 17: appointmentservice.GetAppointmentsForPatientResponse.pagination_info:type_name -> core.PaginationInfo
+	23, // 18: appointmentservice.GetAppointmentsForDoctorRequest.start_time:type_name -> google.protobuf.Timestamp
+	23, // 19: appointmentservice.GetAppointmentsForDoctorRequest.end_time:type_name -> google.protobuf.Timestamp
+	25, // 20: appointmentservice.GetAppointmentsForDoctorRequest.options:type_name -> core.FilterOptions
+	1,  // 21: appointmentservice.GetAppointmentsForDoctorResponse.appointments:type_name -> appointmentservice.Appointment
+	26, // 22: appointmentservice.GetAppointmentsForDoctorResponse.pagination_info:type_name -> core.PaginationInfo
+	23, // 23: appointmentservice.ExportAppointmentsRequest.start_time:type_name -> google.protobuf.Timestamp
+	23, // 24: appointmentservice.ExportAppointmentsRequest.end_time:type_name -> google.protobuf.Timestamp
+	25, // 25: appointmentservice.ExportAppointmentsRequest.options:type_name -> core.FilterOptions
+	1,  // 26: appointmentservice.RestoreAppointmentResponse.appointment:type_name -> appointmentservice.Appointment
+	25, // 27: appointmentservice.ListDeletedAppointmentsRequest.options:type_name -> core.FilterOptions
+	1,  // 28: appointmentservice.ListDeletedAppointmentsResponse.appointments:type_name -> appointmentservice.Appointment
+	26, // 29: appointmentservice.ListDeletedAppointmentsResponse.pagination_info:type_name -> core.PaginationInfo
+	2,  // 30: appointmentservice.AppointmentService.ScheduleAppointment:input_type -> appointmentservice.ScheduleAppointmentRequest
+	4,  // 31: appointmentservice.AppointmentService.GetAppointmentDetails:input_type -> appointmentservice.GetAppointmentDetailsRequest
+	6,  // 32: appointmentservice.AppointmentService.UpdateAppointmentStatus:input_type -> appointmentservice.UpdateAppointmentStatusRequest
+	8,  // 33: appointmentservice.AppointmentService.RescheduleAppointment:input_type -> appointmentservice.RescheduleAppointmentRequest
+	10, // 34: appointmentservice.AppointmentService.CancelAppointment:input_type -> appointmentservice.CancelAppointmentRequest
+	11, // 35: appointmentservice.AppointmentService.GetAppointmentsForPatient:input_type -> appointmentservice.GetAppointmentsForPatientRequest
+	13, // 36: appointmentservice.AppointmentService.GetAppointmentsForDoctor:input_type -> appointmentservice.GetAppointmentsForDoctorRequest
+	15, // 37: appointmentservice.AppointmentService.ExportAppointments:input_type -> appointmentservice.ExportAppointmentsRequest
+	16, // 38: appointmentservice.AppointmentService.DeleteAppointment:input_type -> appointmentservice.DeleteAppointmentRequest
+	17, // 39: appointmentservice.AppointmentService.RestoreAppointment:input_type -> appointmentservice.RestoreAppointmentRequest
+	19, // 40: appointmentservice.AppointmentService.ListDeletedAppointments:input_type -> appointmentservice.ListDeletedAppointmentsRequest
+	21, // 41: appointmentservice.AppointmentService.PurgeDeletedAppointments:input_type -> appointmentservice.PurgeDeletedAppointmentsRequest
+	27, // 42: appointmentservice.AppointmentService.ListAuditEvents:input_type -> core.ListAuditEventsRequest
+	3,  // 43: appointmentservice.AppointmentService.ScheduleAppointment:output_type -> appointmentservice.ScheduleAppointmentResponse
+	5,  // 44: appointmentservice.AppointmentService.GetAppointmentDetails:output_type -> appointmentservice.GetAppointmentDetailsResponse
+	7,  // 45: appointmentservice.AppointmentService.UpdateAppointmentStatus:output_type -> appointmentservice.UpdateAppointmentStatusResponse
+	9,  // 46: appointmentservice.AppointmentService.RescheduleAppointment:output_type -> appointmentservice.RescheduleAppointmentResponse
+	28, // 47: appointmentservice.AppointmentService.CancelAppointment:output_type -> google.protobuf.Empty
+	12, // 48: appointmentservice.AppointmentService.GetAppointmentsForPatient:output_type -> appointmentservice.GetAppointmentsForPatientResponse
+	14, // 49: appointmentservice.AppointmentService.GetAppointmentsForDoctor:output_type -> appointmentservice.GetAppointmentsForDoctorResponse
+	1,  // 50: appointmentservice.AppointmentService.ExportAppointments:output_type -> appointmentservice.Appointment
+	28, // 51: appointmentservice.AppointmentService.DeleteAppointment:output_type -> google.protobuf.Empty
+	18, // 52: appointmentservice.AppointmentService.RestoreAppointment:output_type -> appointmentservice.RestoreAppointmentResponse
+	20, // 53: appointmentservice.AppointmentService.ListDeletedAppointments:output_type -> appointmentservice.ListDeletedAppointmentsResponse
+	22, // 54: appointmentservice.AppointmentService.PurgeDeletedAppointments:output_type -> appointmentservice.PurgeDeletedAppointmentsResponse
+	29, // 55: appointmentservice.AppointmentService.ListAuditEvents:output_type -> core.ListAuditEventsResponse
+	43, // [43:56] is the sub-list for method output_type
+	30, // [30:43] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_proto_appointment_service_appointment_proto_init() }
@@ -1053,13 +1622,16 @@ func file_proto_appointment_service_appointment_proto_init() {
 	if File_proto_appointment_service_appointment_proto != nil {
 		return
 	}
+	file_proto_appointment_service_appointment_proto_msgTypes[0].OneofWrappers = []any{}
+	file_proto_appointment_service_appointment_proto_msgTypes[5].OneofWrappers = []any{}
+	file_proto_appointment_service_appointment_proto_msgTypes[7].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_appointment_service_appointment_proto_rawDesc), len(file_proto_appointment_service_appointment_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   14,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
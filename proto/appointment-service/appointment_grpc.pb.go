@@ -8,6 +8,7 @@ package appointment_service
 
 import (
 	context "context"
+	core "golang-microservices-boilerplate/proto/core"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -27,6 +28,12 @@ const (
 	AppointmentService_CancelAppointment_FullMethodName         = "/appointmentservice.AppointmentService/CancelAppointment"
 	AppointmentService_GetAppointmentsForPatient_FullMethodName = "/appointmentservice.AppointmentService/GetAppointmentsForPatient"
 	AppointmentService_GetAppointmentsForDoctor_FullMethodName  = "/appointmentservice.AppointmentService/GetAppointmentsForDoctor"
+	AppointmentService_ExportAppointments_FullMethodName        = "/appointmentservice.AppointmentService/ExportAppointments"
+	AppointmentService_DeleteAppointment_FullMethodName         = "/appointmentservice.AppointmentService/DeleteAppointment"
+	AppointmentService_RestoreAppointment_FullMethodName        = "/appointmentservice.AppointmentService/RestoreAppointment"
+	AppointmentService_ListDeletedAppointments_FullMethodName   = "/appointmentservice.AppointmentService/ListDeletedAppointments"
+	AppointmentService_PurgeDeletedAppointments_FullMethodName  = "/appointmentservice.AppointmentService/PurgeDeletedAppointments"
+	AppointmentService_ListAuditEvents_FullMethodName           = "/appointmentservice.AppointmentService/ListAuditEvents"
 )
 
 // AppointmentServiceClient is the client API for AppointmentService service.
@@ -40,6 +47,16 @@ type AppointmentServiceClient interface {
 	CancelAppointment(ctx context.Context, in *CancelAppointmentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetAppointmentsForPatient(ctx context.Context, in *GetAppointmentsForPatientRequest, opts ...grpc.CallOption) (*GetAppointmentsForPatientResponse, error)
 	GetAppointmentsForDoctor(ctx context.Context, in *GetAppointmentsForDoctorRequest, opts ...grpc.CallOption) (*GetAppointmentsForDoctorResponse, error)
+	// Streams every matching appointment. Not mapped by grpc-gateway: the API
+	// gateway serves it as an NDJSON or CSV download at GET /api/v1/appointments:export.
+	ExportAppointments(ctx context.Context, in *ExportAppointmentsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Appointment], error)
+	// --- Soft-delete Lifecycle ---
+	DeleteAppointment(ctx context.Context, in *DeleteAppointmentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreAppointment(ctx context.Context, in *RestoreAppointmentRequest, opts ...grpc.CallOption) (*RestoreAppointmentResponse, error)
+	ListDeletedAppointments(ctx context.Context, in *ListDeletedAppointmentsRequest, opts ...grpc.CallOption) (*ListDeletedAppointmentsResponse, error)
+	PurgeDeletedAppointments(ctx context.Context, in *PurgeDeletedAppointmentsRequest, opts ...grpc.CallOption) (*PurgeDeletedAppointmentsResponse, error)
+	// --- Audit Trail ---
+	ListAuditEvents(ctx context.Context, in *core.ListAuditEventsRequest, opts ...grpc.CallOption) (*core.ListAuditEventsResponse, error)
 }
 
 type appointmentServiceClient struct {
@@ -120,6 +137,75 @@ func (c *appointmentServiceClient) GetAppointmentsForDoctor(ctx context.Context,
 	return out, nil
 }
 
+func (c *appointmentServiceClient) ExportAppointments(ctx context.Context, in *ExportAppointmentsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Appointment], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AppointmentService_ServiceDesc.Streams[0], AppointmentService_ExportAppointments_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportAppointmentsRequest, Appointment]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AppointmentService_ExportAppointmentsClient = grpc.ServerStreamingClient[Appointment]
+
+func (c *appointmentServiceClient) DeleteAppointment(ctx context.Context, in *DeleteAppointmentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AppointmentService_DeleteAppointment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appointmentServiceClient) RestoreAppointment(ctx context.Context, in *RestoreAppointmentRequest, opts ...grpc.CallOption) (*RestoreAppointmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreAppointmentResponse)
+	err := c.cc.Invoke(ctx, AppointmentService_RestoreAppointment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appointmentServiceClient) ListDeletedAppointments(ctx context.Context, in *ListDeletedAppointmentsRequest, opts ...grpc.CallOption) (*ListDeletedAppointmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeletedAppointmentsResponse)
+	err := c.cc.Invoke(ctx, AppointmentService_ListDeletedAppointments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appointmentServiceClient) PurgeDeletedAppointments(ctx context.Context, in *PurgeDeletedAppointmentsRequest, opts ...grpc.CallOption) (*PurgeDeletedAppointmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeDeletedAppointmentsResponse)
+	err := c.cc.Invoke(ctx, AppointmentService_PurgeDeletedAppointments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *appointmentServiceClient) ListAuditEvents(ctx context.Context, in *core.ListAuditEventsRequest, opts ...grpc.CallOption) (*core.ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(core.ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, AppointmentService_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AppointmentServiceServer is the server API for AppointmentService service.
 // All implementations must embed UnimplementedAppointmentServiceServer
 // for forward compatibility.
@@ -131,6 +217,16 @@ type AppointmentServiceServer interface {
 	CancelAppointment(context.Context, *CancelAppointmentRequest) (*emptypb.Empty, error)
 	GetAppointmentsForPatient(context.Context, *GetAppointmentsForPatientRequest) (*GetAppointmentsForPatientResponse, error)
 	GetAppointmentsForDoctor(context.Context, *GetAppointmentsForDoctorRequest) (*GetAppointmentsForDoctorResponse, error)
+	// Streams every matching appointment. Not mapped by grpc-gateway: the API
+	// gateway serves it as an NDJSON or CSV download at GET /api/v1/appointments:export.
+	ExportAppointments(*ExportAppointmentsRequest, grpc.ServerStreamingServer[Appointment]) error
+	// --- Soft-delete Lifecycle ---
+	DeleteAppointment(context.Context, *DeleteAppointmentRequest) (*emptypb.Empty, error)
+	RestoreAppointment(context.Context, *RestoreAppointmentRequest) (*RestoreAppointmentResponse, error)
+	ListDeletedAppointments(context.Context, *ListDeletedAppointmentsRequest) (*ListDeletedAppointmentsResponse, error)
+	PurgeDeletedAppointments(context.Context, *PurgeDeletedAppointmentsRequest) (*PurgeDeletedAppointmentsResponse, error)
+	// --- Audit Trail ---
+	ListAuditEvents(context.Context, *core.ListAuditEventsRequest) (*core.ListAuditEventsResponse, error)
 	mustEmbedUnimplementedAppointmentServiceServer()
 }
 
@@ -162,6 +258,24 @@ func (UnimplementedAppointmentServiceServer) GetAppointmentsForPatient(context.C
 func (UnimplementedAppointmentServiceServer) GetAppointmentsForDoctor(context.Context, *GetAppointmentsForDoctorRequest) (*GetAppointmentsForDoctorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAppointmentsForDoctor not implemented")
 }
+func (UnimplementedAppointmentServiceServer) ExportAppointments(*ExportAppointmentsRequest, grpc.ServerStreamingServer[Appointment]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportAppointments not implemented")
+}
+func (UnimplementedAppointmentServiceServer) DeleteAppointment(context.Context, *DeleteAppointmentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAppointment not implemented")
+}
+func (UnimplementedAppointmentServiceServer) RestoreAppointment(context.Context, *RestoreAppointmentRequest) (*RestoreAppointmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreAppointment not implemented")
+}
+func (UnimplementedAppointmentServiceServer) ListDeletedAppointments(context.Context, *ListDeletedAppointmentsRequest) (*ListDeletedAppointmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedAppointments not implemented")
+}
+func (UnimplementedAppointmentServiceServer) PurgeDeletedAppointments(context.Context, *PurgeDeletedAppointmentsRequest) (*PurgeDeletedAppointmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeDeletedAppointments not implemented")
+}
+func (UnimplementedAppointmentServiceServer) ListAuditEvents(context.Context, *core.ListAuditEventsRequest) (*core.ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
 func (UnimplementedAppointmentServiceServer) mustEmbedUnimplementedAppointmentServiceServer() {}
 func (UnimplementedAppointmentServiceServer) testEmbeddedByValue()                            {}
 
@@ -309,6 +423,107 @@ func _AppointmentService_GetAppointmentsForDoctor_Handler(srv interface{}, ctx c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AppointmentService_ExportAppointments_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportAppointmentsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AppointmentServiceServer).ExportAppointments(m, &grpc.GenericServerStream[ExportAppointmentsRequest, Appointment]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AppointmentService_ExportAppointmentsServer = grpc.ServerStreamingServer[Appointment]
+
+func _AppointmentService_DeleteAppointment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAppointmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppointmentServiceServer).DeleteAppointment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AppointmentService_DeleteAppointment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppointmentServiceServer).DeleteAppointment(ctx, req.(*DeleteAppointmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppointmentService_RestoreAppointment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreAppointmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppointmentServiceServer).RestoreAppointment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AppointmentService_RestoreAppointment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppointmentServiceServer).RestoreAppointment(ctx, req.(*RestoreAppointmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppointmentService_ListDeletedAppointments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedAppointmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppointmentServiceServer).ListDeletedAppointments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AppointmentService_ListDeletedAppointments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppointmentServiceServer).ListDeletedAppointments(ctx, req.(*ListDeletedAppointmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppointmentService_PurgeDeletedAppointments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeDeletedAppointmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppointmentServiceServer).PurgeDeletedAppointments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AppointmentService_PurgeDeletedAppointments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppointmentServiceServer).PurgeDeletedAppointments(ctx, req.(*PurgeDeletedAppointmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AppointmentService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(core.ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AppointmentServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AppointmentService_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AppointmentServiceServer).ListAuditEvents(ctx, req.(*core.ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AppointmentService_ServiceDesc is the grpc.ServiceDesc for AppointmentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -344,7 +559,33 @@ var AppointmentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAppointmentsForDoctor",
 			Handler:    _AppointmentService_GetAppointmentsForDoctor_Handler,
 		},
+		{
+			MethodName: "DeleteAppointment",
+			Handler:    _AppointmentService_DeleteAppointment_Handler,
+		},
+		{
+			MethodName: "RestoreAppointment",
+			Handler:    _AppointmentService_RestoreAppointment_Handler,
+		},
+		{
+			MethodName: "ListDeletedAppointments",
+			Handler:    _AppointmentService_ListDeletedAppointments_Handler,
+		},
+		{
+			MethodName: "PurgeDeletedAppointments",
+			Handler:    _AppointmentService_PurgeDeletedAppointments_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _AppointmentService_ListAuditEvents_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportAppointments",
+			Handler:       _AppointmentService_ExportAppointments_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/appointment-service/appointment.proto",
 }
@@ -0,0 +1,349 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: proto/core/audit.proto
+
+package core
+
+import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A recorded change of one entity.
+// Based on pkg/core/audit Event struct.
+type AuditEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Type of the changed entity, e.g. "Patient".
+	EntityType string `protobuf:"bytes,2,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	// Primary key of the changed entity.
+	EntityId string `protobuf:"bytes,3,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	// One of create, update, delete or restore.
+	Action string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	// Previous values of the changed columns. Empty for creates.
+	Before *structpb.Struct `protobuf:"bytes,5,opt,name=before,proto3" json:"before,omitempty"`
+	// New values of the changed columns. Empty for hard deletes.
+	After *structpb.Struct `protobuf:"bytes,6,opt,name=after,proto3" json:"after,omitempty"`
+	// Subject of the caller's access token, empty for anonymous requests.
+	Actor string `protobuf:"bytes,7,opt,name=actor,proto3" json:"actor,omitempty"`
+	// ID of the request the change was made in (X-Request-ID).
+	RequestId     string                 `protobuf:"bytes,8,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_proto_core_audit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_core_audit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_proto_core_audit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuditEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetBefore() *structpb.Struct {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetAfter() *structpb.Struct {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Request message for listing the audit trail of an entity.
+type ListAuditEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Type of the entity, e.g. "Patient". Empty matches every type.
+	EntityType string `protobuf:"bytes,1,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	// Primary key of the entity. Empty matches every entity.
+	EntityId string `protobuf:"bytes,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	// Pagination, sorting and extra filters (action, actor, request_id, created_at).
+	Options       *FilterOptions `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_proto_core_audit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_core_audit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_core_audit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListAuditEventsRequest) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetOptions() *FilterOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// Response message containing a page of audit events.
+type ListAuditEventsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Events         []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	PaginationInfo *PaginationInfo        `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_proto_core_audit_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_core_audit_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_core_audit_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListAuditEventsResponse) GetPaginationInfo() *PaginationInfo {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
+var File_proto_core_audit_proto protoreflect.FileDescriptor
+
+const file_proto_core_audit_proto_rawDesc = "" +
+	"\n" +
+	"\x16proto/core/audit.proto\x12\x04core\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\x1a\x17proto/core/common.proto\"\xb1\t\n" +
+	"\n" +
+	"AuditEvent\x12p\n" +
+	"\x02id\x18\x01 \x01(\tB`\x92A]23Unique identifier of the audit event (UUID format).J&\"a1b2c3d4-e5f6-7890-1234-567890abcdef\"R\x02id\x12L\n" +
+	"\ventity_type\x18\x02 \x01(\tB+\x92A(2\x1bType of the changed entity.J\t\"Patient\"R\n" +
+	"entityType\x12l\n" +
+	"\tentity_id\x18\x03 \x01(\tBO\x92AL2\"Primary key of the changed entity.J&\"f0e1d2c3-b4a5-6789-0123-456789abcdef\"R\bentityId\x12Y\n" +
+	"\x06action\x18\x04 \x01(\tBA\x92A>22Kind of change: create, update, delete or restore.J\b\"update\"R\x06action\x12\xb4\x01\n" +
+	"\x06before\x18\x05 \x01(\v2\x17.google.protobuf.StructB\x82\x01\x92A\x7f2`Previous values of the changed columns, keyed by column name. Redacted fields show \"[REDACTED]\".J\x1b{\"address\": \"1 Old Street\"}R\x06before\x12\xac\x01\n" +
+	"\x05after\x18\x06 \x01(\v2\x17.google.protobuf.StructB}\x92Az2[New values of the changed columns, keyed by column name. Redacted fields show \"[REDACTED]\".J\x1b{\"address\": \"2 New Street\"}R\x05after\x12\x8e\x01\n" +
+	"\x05actor\x18\a \x01(\tBx\x92Au2KSubject of the caller's access token. Empty when the request was anonymous.J&\"a1b2c3d4-e5f6-7890-1234-567890abcdef\"R\x05actor\x12\x8b\x01\n" +
+	"\n" +
+	"request_id\x18\b \x01(\tBl\x92Ai2?ID of the request the change was made in (X-Request-ID header).J&\"5f0c6f1e-2b7d-4a43-9a55-0c3b1e2f4d6a\"R\trequestId\x12\x94\x01\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampBY\x92AV2<Timestamp when the change was recorded (RFC3339 UTC format).J\x16\"2024-01-15T10:30:00Z\"R\tcreatedAt\"\xd0\x02\n" +
+	"\x16ListAuditEventsRequest\x12q\n" +
+	"\ventity_type\x18\x01 \x01(\tBP\x92AM2@Type of the entity to list events for. Empty matches every type.J\t\"Patient\"R\n" +
+	"entityType\x12\x93\x01\n" +
+	"\tentity_id\x18\x02 \x01(\tBv\x92As2IPrimary key of the entity to list events for. Empty matches every entity.J&\"f0e1d2c3-b4a5-6789-0123-456789abcdef\"R\bentityId\x12-\n" +
+	"\aoptions\x18\x03 \x01(\v2\x13.core.FilterOptionsR\aoptions\"\x82\x01\n" +
+	"\x17ListAuditEventsResponse\x12(\n" +
+	"\x06events\x18\x01 \x03(\v2\x10.core.AuditEventR\x06events\x12=\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x14.core.PaginationInfoR\x0epaginationInfoB\xc2\x01\x92A\x91\x01\x12g\n" +
+	"\x16Core Audit Definitions\x12HAudit trail messages shared by the ListAuditEvents RPC of every service.2\x031.0*\x02\x01\x022\x10application/json:\x10application/jsonZ+golang-microservices-boilerplate/proto/coreb\x06proto3"
+
+var (
+	file_proto_core_audit_proto_rawDescOnce sync.Once
+	file_proto_core_audit_proto_rawDescData []byte
+)
+
+func file_proto_core_audit_proto_rawDescGZIP() []byte {
+	file_proto_core_audit_proto_rawDescOnce.Do(func() {
+		file_proto_core_audit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_core_audit_proto_rawDesc), len(file_proto_core_audit_proto_rawDesc)))
+	})
+	return file_proto_core_audit_proto_rawDescData
+}
+
+var file_proto_core_audit_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_core_audit_proto_goTypes = []any{
+	(*AuditEvent)(nil),              // 0: core.AuditEvent
+	(*ListAuditEventsRequest)(nil),  // 1: core.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil), // 2: core.ListAuditEventsResponse
+	(*structpb.Struct)(nil),         // 3: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),   // 4: google.protobuf.Timestamp
+	(*FilterOptions)(nil),           // 5: core.FilterOptions
+	(*PaginationInfo)(nil),          // 6: core.PaginationInfo
+}
+var file_proto_core_audit_proto_depIdxs = []int32{
+	3, // 0: core.AuditEvent.before:type_name -> google.protobuf.Struct
+	3, // 1: core.AuditEvent.after:type_name -> google.protobuf.Struct
+	4, // 2: core.AuditEvent.created_at:type_name -> google.protobuf.Timestamp
+	5, // 3: core.ListAuditEventsRequest.options:type_name -> core.FilterOptions
+	0, // 4: core.ListAuditEventsResponse.events:type_name -> core.AuditEvent
+	6, // 5: core.ListAuditEventsResponse.pagination_info:type_name -> core.PaginationInfo
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_proto_core_audit_proto_init() }
+func file_proto_core_audit_proto_init() {
+	if File_proto_core_audit_proto != nil {
+		return
+	}
+	file_proto_core_common_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_core_audit_proto_rawDesc), len(file_proto_core_audit_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_core_audit_proto_goTypes,
+		DependencyIndexes: file_proto_core_audit_proto_depIdxs,
+		MessageInfos:      file_proto_core_audit_proto_msgTypes,
+	}.Build()
+	File_proto_core_audit_proto = out.File
+	file_proto_core_audit_proto_goTypes = nil
+	file_proto_core_audit_proto_depIdxs = nil
+}
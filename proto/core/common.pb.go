@@ -37,11 +37,18 @@ type FilterOptions struct {
 	SortDesc *bool `protobuf:"varint,4,opt,name=sort_desc,json=sortDesc,proto3,oneof" json:"sort_desc,omitempty"`
 	// Key-value pairs for specific field filtering.
 	// Uses google.protobuf.Value to allow various types (string, number, bool, null).
+	// A scalar value means equality, a list means "in", and an object maps operators
+	// (eq, ne, gt, gte, lt, lte, in, between, ilike, is_null) to their operands.
+	// Only columns allowed by the entity may be used; others are rejected with INVALID_ARGUMENT.
 	Filters map[string]*structpb.Value `protobuf:"bytes,5,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// Whether to include soft-deleted records in the results.
 	IncludeDeleted *bool `protobuf:"varint,8,opt,name=include_deleted,json=includeDeleted,proto3,oneof" json:"include_deleted,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Opaque cursor returned as next_cursor by a previous page. Enables keyset pagination.
+	Cursor *string `protobuf:"bytes,9,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	// Whether to skip computing the total item count.
+	SkipCount     *bool `protobuf:"varint,10,opt,name=skip_count,json=skipCount,proto3,oneof" json:"skip_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FilterOptions) Reset() {
@@ -116,17 +123,33 @@ func (x *FilterOptions) GetIncludeDeleted() bool {
 	return false
 }
 
+func (x *FilterOptions) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *FilterOptions) GetSkipCount() bool {
+	if x != nil && x.SkipCount != nil {
+		return *x.SkipCount
+	}
+	return false
+}
+
 // Represents common pagination metadata included in list responses.
 // Based on pkg/core/types/common.go PaginationResult struct (metadata fields only).
 // Specific list responses should include this alongside their repeated items field.
 type PaginationInfo struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Total number of items matching the query criteria across all pages.
+	// Total number of items matching the query criteria across all pages (-1 when skip_count was set).
 	TotalItems int64 `protobuf:"varint,1,opt,name=total_items,json=totalItems,proto3" json:"total_items,omitempty"`
 	// The limit (page size) used for the current response.
 	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	// The offset (number of items skipped) used for the current response.
-	Offset        int32 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	Offset int32 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Opaque cursor for fetching the next page. Empty when there are no more items.
+	NextCursor    string `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -182,18 +205,113 @@ func (x *PaginationInfo) GetOffset() int32 {
 	return 0
 }
 
+func (x *PaginationInfo) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// Outcome of one item of a bulk request.
+// Items are reported in request order; in partial-success mode failed items
+// carry the gRPC code and reason they would have been rejected with on their own.
+type BulkItemResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position of the item in the request.
+	Index int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// ID of the entity the item refers to. Empty for creates that failed.
+	Id string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// gRPC status code name of the item, "OK" on success.
+	Code string `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	// Machine-readable cause of a failure, as in google.rpc.ErrorInfo.reason.
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Human-readable error message. Empty on success.
+	Message       string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkItemResult) Reset() {
+	*x = BulkItemResult{}
+	mi := &file_proto_core_common_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkItemResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkItemResult) ProtoMessage() {}
+
+func (x *BulkItemResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_core_common_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkItemResult.ProtoReflect.Descriptor instead.
+func (*BulkItemResult) Descriptor() ([]byte, []int) {
+	return file_proto_core_common_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BulkItemResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BulkItemResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BulkItemResult) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *BulkItemResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BulkItemResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 var File_proto_core_common_proto protoreflect.FileDescriptor
 
 const file_proto_core_common_proto_rawDesc = "" +
 	"\n" +
-	"\x17proto/core/common.proto\x12\x04core\x1a\x1cgoogle/protobuf/struct.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xc5\a\n" +
+	"\x17proto/core/common.proto\x12\x04core\x1a\x1cgoogle/protobuf/struct.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xd5\f\n" +
 	"\rFilterOptions\x12S\n" +
 	"\x05limit\x18\x01 \x01(\x05B8\x92A52+Maximum number of items to return per page.:\x0250J\x0250H\x00R\x05limit\x88\x01\x01\x12{\n" +
-	"\x06offset\x18\x02 \x01(\x05B^\x92A[2SNumber of items to skip before starting to collect the result set (for pagination).:\x010J\x010H\x01R\x06offset\x88\x01\x01\x12~\n" +
-	"\asort_by\x18\x03 \x01(\tB`\x92A]2?Field name to sort the results by (e.g., 'created_at', 'name').:\f\"created_at\"J\f\"created_at\"H\x02R\x06sortBy\x88\x01\x01\x12[\n" +
-	"\tsort_desc\x18\x04 \x01(\bB9\x92A62(Set to true to sort in descending order.:\x04trueJ\x04trueH\x03R\bsortDesc\x88\x01\x01\x12\xef\x01\n" +
-	"\afilters\x18\x05 \x03(\v2 .core.FilterOptions.FiltersEntryB\xb2\x01\x92A\xae\x012\x8e\x01Key-value pairs for specific field filtering. Values should correspond to google.protobuf.Value structure (e.g., {\"email\": \"user@gmail.com\"}).J\x1b{\"email\": \"user@gmail.com\"}R\afilters\x12|\n" +
-	"\x0finclude_deleted\x18\b \x01(\bBN\x92AK2;Set to true to include soft-deleted records in the results.:\x05falseJ\x05falseH\x04R\x0eincludeDeleted\x88\x01\x01\x1aR\n" +
+	"\x06offset\x18\x02 \x01(\x05B^\x92A[2SNumber of items to skip before starting to collect the result set (for pagination).:\x010J\x010H\x01R\x06offset\x88\x01\x01\x12\xb2\x01\n" +
+	"\asort_by\x18\x03 \x01(\tB\x93\x01\x92A\x8f\x012qField name to sort the results by (e.g., 'created_at', 'name'). Only sortable columns of the entity are accepted.:\f\"created_at\"J\f\"created_at\"H\x02R\x06sortBy\x88\x01\x01\x12[\n" +
+	"\tsort_desc\x18\x04 \x01(\bB9\x92A62(Set to true to sort in descending order.:\x04trueJ\x04trueH\x03R\bsortDesc\x88\x01\x01\x12\xac\x03\n" +
+	"\afilters\x18\x05 \x03(\v2 .core.FilterOptions.FiltersEntryB\xef\x02\x92A\xeb\x022\xb7\x02Key-value pairs for specific field filtering. A scalar value means equality, a list means 'in', and an object maps operators (eq, ne, gt, gte, lt, lte, in, between, ilike, is_null) to operands (e.g., {\"email\": \"user@gmail.com\", \"age\": {\"gte\": 18, \"lt\": 65}}). Only filterable columns of the entity are accepted.J/{\"email\": \"user@gmail.com\", \"age\": {\"gte\": 18}}R\afilters\x12|\n" +
+	"\x0finclude_deleted\x18\b \x01(\bBN\x92AK2;Set to true to include soft-deleted records in the results.:\x05falseJ\x05falseH\x04R\x0eincludeDeleted\x88\x01\x01\x12\xf9\x01\n" +
+	"\x06cursor\x18\t \x01(\tB\xdb\x01\x92A\xd7\x012\xac\x01Opaque cursor from a previous response's next_cursor. When set, keyset pagination is used and offset is ignored. Sort options must match the ones used to obtain the cursor.J&\"eyJzIjoiY3JlYXRlZF9hdCIsImQiOnRydWV9\"H\x05R\x06cursor\x88\x01\x01\x12\x85\x01\n" +
+	"\n" +
+	"skip_count\x18\n" +
+	" \x01(\bBa\x92A^2NSet to true to skip the total count query. total_items is then reported as -1.:\x05falseJ\x05falseH\x06R\tskipCount\x88\x01\x01\x1aR\n" +
 	"\fFiltersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
 	"\x05value\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x05value:\x028\x01B\b\n" +
@@ -203,12 +321,22 @@ const file_proto_core_common_proto_rawDesc = "" +
 	"\b_sort_byB\f\n" +
 	"\n" +
 	"_sort_descB\x12\n" +
-	"\x10_include_deleted\"\xbb\x02\n" +
-	"\x0ePaginationInfo\x12o\n" +
-	"\vtotal_items\x18\x01 \x01(\x03BN\x92AK2CTotal number of items matching the query criteria across all pages.J\x041234R\n" +
+	"\x10_include_deletedB\t\n" +
+	"\a_cursorB\r\n" +
+	"\v_skip_count\"\x8f\x04\n" +
+	"\x0ePaginationInfo\x12\x8c\x01\n" +
+	"\vtotal_items\x18\x01 \x01(\x03Bk\x92Ah2`Total number of items matching the query criteria across all pages (-1 when skip_count was set).J\x041234R\n" +
 	"totalItems\x12S\n" +
 	"\x05limit\x18\x02 \x01(\x05B=\x92A:24The limit (page size) used for the current response.J\x0250R\x05limit\x12c\n" +
-	"\x06offset\x18\x03 \x01(\x05BK\x92AH2CThe offset (number of items skipped) used for the current response.J\x010R\x06offsetB\xba\x01\x92A\x89\x01\x12_\n" +
+	"\x06offset\x18\x03 \x01(\x05BK\x92AH2CThe offset (number of items skipped) used for the current response.J\x010R\x06offset\x12\xb3\x01\n" +
+	"\vnext_cursor\x18\x04 \x01(\tB\x91\x01\x92A\x8d\x012cOpaque cursor to pass as options.cursor to fetch the next page. Empty when there are no more items.J&\"eyJzIjoiY3JlYXRlZF9hdCIsImQiOnRydWV9\"R\n" +
+	"nextCursor\"\x81\x05\n" +
+	"\x0eBulkItemResult\x12M\n" +
+	"\x05index\x18\x01 \x01(\x05B7\x92A42/Zero-based position of the item in the request.J\x010R\x05index\x12\x88\x01\n" +
+	"\x02id\x18\x02 \x01(\tBx\x92Au2KUUID of the created, updated or deleted entity. Empty when a create failed.J&\"a1b2c3d4-e5f6-7890-1234-567890abcdef\"R\x02id\x12\x88\x01\n" +
+	"\x04code\x18\x03 \x01(\tBt\x92Aq2]gRPC status code of the item (e.g. OK, INVALID_ARGUMENT, ALREADY_EXISTS, NOT_FOUND, ABORTED).J\x10\"ALREADY_EXISTS\"R\x04code\x12\x92\x01\n" +
+	"\x06reason\x18\x04 \x01(\tBz\x92Aw2aMachine-readable cause of a failure (e.g. VALIDATION_FAILED, UNIQUE_VIOLATION). Empty on success.J\x12\"UNIQUE_VIOLATION\"R\x06reason\x12u\n" +
+	"\amessage\x18\x05 \x01(\tB[\x92AX2/Human-readable error message. Empty on success.J%\"user with this email already exists\"R\amessageB\xba\x01\x92A\x89\x01\x12_\n" +
 	"\x17Core Common Definitions\x12?Commonly used Protobuf messages for filtering, pagination, etc.2\x031.0*\x02\x01\x022\x10application/json:\x10application/jsonZ+golang-microservices-boilerplate/proto/coreb\x06proto3"
 
 var (
@@ -223,16 +351,17 @@ func file_proto_core_common_proto_rawDescGZIP() []byte {
 	return file_proto_core_common_proto_rawDescData
 }
 
-var file_proto_core_common_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_core_common_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_proto_core_common_proto_goTypes = []any{
 	(*FilterOptions)(nil),  // 0: core.FilterOptions
 	(*PaginationInfo)(nil), // 1: core.PaginationInfo
-	nil,                    // 2: core.FilterOptions.FiltersEntry
-	(*structpb.Value)(nil), // 3: google.protobuf.Value
+	(*BulkItemResult)(nil), // 2: core.BulkItemResult
+	nil,                    // 3: core.FilterOptions.FiltersEntry
+	(*structpb.Value)(nil), // 4: google.protobuf.Value
 }
 var file_proto_core_common_proto_depIdxs = []int32{
-	2, // 0: core.FilterOptions.filters:type_name -> core.FilterOptions.FiltersEntry
-	3, // 1: core.FilterOptions.FiltersEntry.value:type_name -> google.protobuf.Value
+	3, // 0: core.FilterOptions.filters:type_name -> core.FilterOptions.FiltersEntry
+	4, // 1: core.FilterOptions.FiltersEntry.value:type_name -> google.protobuf.Value
 	2, // [2:2] is the sub-list for method output_type
 	2, // [2:2] is the sub-list for method input_type
 	2, // [2:2] is the sub-list for extension type_name
@@ -252,7 +381,7 @@ func file_proto_core_common_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_core_common_proto_rawDesc), len(file_proto_core_common_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
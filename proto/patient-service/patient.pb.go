@@ -8,10 +8,12 @@ package patient_service
 
 import (
 	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	core "golang-microservices-boilerplate/proto/core"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -37,6 +39,8 @@ type Patient struct {
 	MedicalHistory []*MedicalRecord       `protobuf:"bytes,8,rep,name=medical_history,json=medicalHistory,proto3" json:"medical_history,omitempty"`
 	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version        int64                  `protobuf:"varint,11,opt,name=version,proto3" json:"version,omitempty"`
+	DeletedAt      *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=deleted_at,json=deletedAt,proto3,oneof" json:"deleted_at,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -141,6 +145,20 @@ func (x *Patient) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Patient) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Patient) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
 type MedicalRecord struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -472,6 +490,7 @@ func (x *GetPatientDetailsResponse) GetPatient() *Patient {
 // Request for ListPatients
 type ListPatientsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *core.FilterOptions    `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -506,12 +525,20 @@ func (*ListPatientsRequest) Descriptor() ([]byte, []int) {
 	return file_proto_patient_service_patient_proto_rawDescGZIP(), []int{6}
 }
 
+func (x *ListPatientsRequest) GetOptions() *core.FilterOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
 // Response for ListPatients
 type ListPatientsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Patients      []*Patient             `protobuf:"bytes,1,rep,name=patients,proto3" json:"patients,omitempty"` // Add pagination fields like next_page_token if needed
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Patients       []*Patient             `protobuf:"bytes,1,rep,name=patients,proto3" json:"patients,omitempty"`
+	PaginationInfo *core.PaginationInfo   `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ListPatientsResponse) Reset() {
@@ -551,17 +578,25 @@ func (x *ListPatientsResponse) GetPatients() []*Patient {
 	return nil
 }
 
+func (x *ListPatientsResponse) GetPaginationInfo() *core.PaginationInfo {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
 // Request for UpdatePatientDetails
 type UpdatePatientDetailsRequest struct {
-	state     protoimpl.MessageState `protogen:"open.v1"`
-	PatientId string                 `protobuf:"bytes,1,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
-	// Fields below are optional for update (use wrappers or field masks in real app)
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PatientId     string                 `protobuf:"bytes,1,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
 	FirstName     string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
 	LastName      string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
 	Gender        string                 `protobuf:"bytes,4,opt,name=gender,proto3" json:"gender,omitempty"`
 	PhoneNumber   string                 `protobuf:"bytes,5,opt,name=phone_number,json=phoneNumber,proto3" json:"phone_number,omitempty"`
 	Address       string                 `protobuf:"bytes,6,opt,name=address,proto3" json:"address,omitempty"`
 	DateOfBirth   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
+	Version       *int64                 `protobuf:"varint,8,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,9,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -645,6 +680,20 @@ func (x *UpdatePatientDetailsRequest) GetDateOfBirth() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *UpdatePatientDetailsRequest) GetVersion() int64 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+func (x *UpdatePatientDetailsRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 // Response for UpdatePatientDetails
 type UpdatePatientDetailsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -865,11 +914,562 @@ func (x *GetPatientMedicalHistoryResponse) GetMedicalHistory() []*MedicalRecord
 	return nil
 }
 
+// Request for DeletePatient
+type DeletePatientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PatientId     string                 `protobuf:"bytes,1,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	HardDelete    bool                   `protobuf:"varint,2,opt,name=hard_delete,json=hardDelete,proto3" json:"hard_delete,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePatientRequest) Reset() {
+	*x = DeletePatientRequest{}
+	mi := &file_proto_patient_service_patient_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePatientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePatientRequest) ProtoMessage() {}
+
+func (x *DeletePatientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_patient_service_patient_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePatientRequest.ProtoReflect.Descriptor instead.
+func (*DeletePatientRequest) Descriptor() ([]byte, []int) {
+	return file_proto_patient_service_patient_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeletePatientRequest) GetPatientId() string {
+	if x != nil {
+		return x.PatientId
+	}
+	return ""
+}
+
+func (x *DeletePatientRequest) GetHardDelete() bool {
+	if x != nil {
+		return x.HardDelete
+	}
+	return false
+}
+
+// Request for RestorePatient
+type RestorePatientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PatientId     string                 `protobuf:"bytes,1,opt,name=patient_id,json=patientId,proto3" json:"patient_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestorePatientRequest) Reset() {
+	*x = RestorePatientRequest{}
+	mi := &file_proto_patient_service_patient_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestorePatientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestorePatientRequest) ProtoMessage() {}
+
+func (x *RestorePatientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_patient_service_patient_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestorePatientRequest.ProtoReflect.Descriptor instead.
+func (*RestorePatientRequest) Descriptor() ([]byte, []int) {
+	return file_proto_patient_service_patient_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RestorePatientRequest) GetPatientId() string {
+	if x != nil {
+		return x.PatientId
+	}
+	return ""
+}
+
+// Response for RestorePatient
+type RestorePatientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Patient       *Patient               `protobuf:"bytes,1,opt,name=patient,proto3" json:"patient,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestorePatientResponse) Reset() {
+	*x = RestorePatientResponse{}
+	mi := &file_proto_patient_service_patient_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestorePatientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestorePatientResponse) ProtoMessage() {}
+
+func (x *RestorePatientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_patient_service_patient_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestorePatientResponse.ProtoReflect.Descriptor instead.
+func (*RestorePatientResponse) Descriptor() ([]byte, []int) {
+	return file_proto_patient_service_patient_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RestorePatientResponse) GetPatient() *Patient {
+	if x != nil {
+		return x.Patient
+	}
+	return nil
+}
+
+// Request for SearchPatients
+type SearchPatientsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchPatientsRequest) Reset() {
+	*x = SearchPatientsRequest{}
+	mi := &file_proto_patient_service_patient_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPatientsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPatientsRequest) ProtoMessage() {}
+
+func (x *SearchPatientsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_patient_service_patient_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPatientsRequest.ProtoReflect.Descriptor instead.
+func (*SearchPatientsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_patient_service_patient_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SearchPatientsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchPatientsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchPatientsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// One hit of SearchPatients
+type PatientSearchHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Patient       *Patient               `protobuf:"bytes,1,opt,name=patient,proto3" json:"patient,omitempty"`
+	Rank          float64                `protobuf:"fixed64,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	Highlights    map[string]string      `protobuf:"bytes,3,rep,name=highlights,proto3" json:"highlights,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatientSearchHit) Reset() {
+	*x = PatientSearchHit{}
+	mi := &file_proto_patient_service_patient_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatientSearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatientSearchHit) ProtoMessage() {}
+
+func (x *PatientSearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_patient_service_patient_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatientSearchHit.ProtoReflect.Descriptor instead.
+func (*PatientSearchHit) Descriptor() ([]byte, []int) {
+	return file_proto_patient_service_patient_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PatientSearchHit) GetPatient() *Patient {
+	if x != nil {
+		return x.Patient
+	}
+	return nil
+}
+
+func (x *PatientSearchHit) GetRank() float64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *PatientSearchHit) GetHighlights() map[string]string {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+// Response for SearchPatients
+type SearchPatientsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Hits           []*PatientSearchHit    `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	PaginationInfo *core.PaginationInfo   `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SearchPatientsResponse) Reset() {
+	*x = SearchPatientsResponse{}
+	mi := &file_proto_patient_service_patient_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchPatientsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchPatientsResponse) ProtoMessage() {}
+
+func (x *SearchPatientsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_patient_service_patient_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchPatientsResponse.ProtoReflect.Descriptor instead.
+func (*SearchPatientsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_patient_service_patient_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SearchPatientsResponse) GetHits() []*PatientSearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *SearchPatientsResponse) GetPaginationInfo() *core.PaginationInfo {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
+// Request for ExportPatients
+type ExportPatientsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *core.FilterOptions    `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportPatientsRequest) Reset() {
+	*x = ExportPatientsRequest{}
+	mi := &file_proto_patient_service_patient_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportPatientsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportPatientsRequest) ProtoMessage() {}
+
+func (x *ExportPatientsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_patient_service_patient_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportPatientsRequest.ProtoReflect.Descriptor instead.
+func (*ExportPatientsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_patient_service_patient_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ExportPatientsRequest) GetOptions() *core.FilterOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// Request for ListDeletedPatients
+type ListDeletedPatientsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *core.FilterOptions    `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedPatientsRequest) Reset() {
+	*x = ListDeletedPatientsRequest{}
+	mi := &file_proto_patient_service_patient_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedPatientsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedPatientsRequest) ProtoMessage() {}
+
+func (x *ListDeletedPatientsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_patient_service_patient_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedPatientsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedPatientsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_patient_service_patient_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListDeletedPatientsRequest) GetOptions() *core.FilterOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// Response for ListDeletedPatients
+type ListDeletedPatientsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Patients       []*Patient             `protobuf:"bytes,1,rep,name=patients,proto3" json:"patients,omitempty"`
+	PaginationInfo *core.PaginationInfo   `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListDeletedPatientsResponse) Reset() {
+	*x = ListDeletedPatientsResponse{}
+	mi := &file_proto_patient_service_patient_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedPatientsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedPatientsResponse) ProtoMessage() {}
+
+func (x *ListDeletedPatientsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_patient_service_patient_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedPatientsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedPatientsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_patient_service_patient_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListDeletedPatientsResponse) GetPatients() []*Patient {
+	if x != nil {
+		return x.Patients
+	}
+	return nil
+}
+
+func (x *ListDeletedPatientsResponse) GetPaginationInfo() *core.PaginationInfo {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
+// Request for PurgeDeletedPatients
+type PurgeDeletedPatientsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OlderThanDays int32                  `protobuf:"varint,1,opt,name=older_than_days,json=olderThanDays,proto3" json:"older_than_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeletedPatientsRequest) Reset() {
+	*x = PurgeDeletedPatientsRequest{}
+	mi := &file_proto_patient_service_patient_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeletedPatientsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeletedPatientsRequest) ProtoMessage() {}
+
+func (x *PurgeDeletedPatientsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_patient_service_patient_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeletedPatientsRequest.ProtoReflect.Descriptor instead.
+func (*PurgeDeletedPatientsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_patient_service_patient_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PurgeDeletedPatientsRequest) GetOlderThanDays() int32 {
+	if x != nil {
+		return x.OlderThanDays
+	}
+	return 0
+}
+
+// Response for PurgeDeletedPatients
+type PurgeDeletedPatientsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PurgedCount   int64                  `protobuf:"varint,1,opt,name=purged_count,json=purgedCount,proto3" json:"purged_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeletedPatientsResponse) Reset() {
+	*x = PurgeDeletedPatientsResponse{}
+	mi := &file_proto_patient_service_patient_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeletedPatientsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeletedPatientsResponse) ProtoMessage() {}
+
+func (x *PurgeDeletedPatientsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_patient_service_patient_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeletedPatientsResponse.ProtoReflect.Descriptor instead.
+func (*PurgeDeletedPatientsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_patient_service_patient_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PurgeDeletedPatientsResponse) GetPurgedCount() int64 {
+	if x != nil {
+		return x.PurgedCount
+	}
+	return 0
+}
+
 var File_proto_patient_service_patient_proto protoreflect.FileDescriptor
 
 const file_proto_patient_service_patient_proto_rawDesc = "" +
 	"\n" +
-	"#proto/patient-service/patient.proto\x12\x0epatientservice\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xf9\t\n" +
+	"#proto/patient-service/patient.proto\x12\x0epatientservice\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x17proto/core/common.proto\x1a\x16proto/core/audit.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xd6\f\n" +
 	"\aPatient\x12m\n" +
 	"\x02id\x18\x01 \x01(\tB]\x92AZ20Unique identifier for the patient (UUID format).J&\"p1a2b3c4-e5f6-7890-1234-567890abcdef\"R\x02id\x12B\n" +
 	"\n" +
@@ -884,11 +1484,15 @@ const file_proto_patient_service_patient_proto_rawDesc = "" +
 	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampB`\x92A]2CTimestamp when the patient record was created (RFC3339 UTC format).J\x16\"2023-02-01T09:00:00Z\"R\tcreatedAt\x12\xa0\x01\n" +
 	"\n" +
 	"updated_at\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampBe\x92Ab2HTimestamp when the patient record was last updated (RFC3339 UTC format).J\x16\"2023-02-10T14:30:00Z\"R\tupdatedAt:\x9f\x01\x92A\x9b\x01\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampBe\x92Ab2HTimestamp when the patient record was last updated (RFC3339 UTC format).J\x16\"2023-02-10T14:30:00Z\"R\tupdatedAt\x12\x8e\x01\n" +
+	"\aversion\x18\v \x01(\x03Bt\x92Aq2lOptimistic locking version of the patient record. Send it back on update to detect concurrent modifications.J\x012R\aversion\x12\xba\x01\n" +
+	"\n" +
+	"deleted_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampBz\x92Aw2]Timestamp when the patient record was soft-deleted (RFC3339 UTC format). Null if not deleted.J\x16\"2023-04-01T12:00:00Z\"H\x00R\tdeletedAt\x88\x01\x01:\x9f\x01\x92A\x9b\x01\n" +
 	"\x98\x01*\aPatient2#Represents a patient in the system.\xd2\x01\x02id\xd2\x01\n" +
 	"first_name\xd2\x01\tlast_name\xd2\x01\rdate_of_birth\xd2\x01\x06gender\xd2\x01\fphone_number\xd2\x01\aaddress\xd2\x01\n" +
 	"created_at\xd2\x01\n" +
-	"updated_at\"\xcf\n" +
+	"updated_atB\r\n" +
+	"\v_deleted_at\"\xcf\n" +
 	"\n" +
 	"\rMedicalRecord\x12t\n" +
 	"\x02id\x18\x01 \x01(\tBd\x92Aa27Unique identifier for the medical record (UUID format).J&\"r1a2b3c4-e5f6-7890-1234-567890abcdef\"R\x02id\x12\x8b\x01\n" +
@@ -926,12 +1530,15 @@ const file_proto_patient_service_patient_proto_rawDesc = "" +
 	"K*\x1bGet Patient Details Request2,Specifies the ID of the patient to retrieve.\"\xa3\x01\n" +
 	"\x19GetPatientDetailsResponse\x121\n" +
 	"\apatient\x18\x01 \x01(\v2\x17.patientservice.PatientR\apatient:S\x92AP\n" +
-	"N*\x1cGet Patient Details Response2.Contains the details of the requested patient.\"x\n" +
-	"\x13ListPatientsRequest:a\x92A^\n" +
-	"\\*\x15List Patients Request2CRequest to list all patients (add pagination parameters if needed).\"\x88\x01\n" +
+	"N*\x1cGet Patient Details Response2.Contains the details of the requested patient.\"\xf2\x01\n" +
+	"\x13ListPatientsRequest\x12-\n" +
+	"\aoptions\x18\x01 \x01(\v2\x13.core.FilterOptionsR\aoptions:\xab\x01\x92A\xa7\x01\n" +
+	"\xa4\x01*\x15List Patients Request2\x8a\x01Options for filtering, sorting, and paginating patients. Pass the previous response's next_cursor as options.cursor for keyset pagination.\"\xc8\x01\n" +
 	"\x14ListPatientsResponse\x123\n" +
-	"\bpatients\x18\x01 \x03(\v2\x17.patientservice.PatientR\bpatients:;\x92A8\n" +
-	"6*\x16List Patients Response2\x1cContains a list of patients.\"\xb7\x06\n" +
+	"\bpatients\x18\x01 \x03(\v2\x17.patientservice.PatientR\bpatients\x12=\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x14.core.PaginationInfoR\x0epaginationInfo:<\x92A9\n" +
+	"7*\x16List Patients Response2\x1dA paginated list of patients.\"\xaa\n" +
+	"\n" +
 	"\x1bUpdatePatientDetailsRequest\x12n\n" +
 	"\n" +
 	"patient_id\x18\x01 \x01(\tBO\x92AL2\"The UUID of the patient to update.J&\"p1a2b3c4-e5f6-7890-1234-567890abcdef\"R\tpatientId\x12H\n" +
@@ -942,9 +1549,14 @@ const file_proto_patient_service_patient_proto_rawDesc = "" +
 	"\x06gender\x18\x04 \x01(\tB%\x92A\"2\x16New gender (optional).J\b\"Female\"R\x06gender\x12T\n" +
 	"\fphone_number\x18\x05 \x01(\tB1\x92A.2\x1cNew phone number (optional).J\x0e\"+15551112233\"R\vphoneNumber\x12[\n" +
 	"\aaddress\x18\x06 \x01(\tBA\x92A>2\x17New address (optional).J#\"789 Recuperation Ave, Healthville\"R\aaddress\x12\x8e\x01\n" +
-	"\rdate_of_birth\x18\a \x01(\v2\x1a.google.protobuf.TimestampBN\x92AK21New date of birth (optional, RFC3339 UTC format).J\x16\"1990-05-15T00:00:00Z\"R\vdateOfBirth:\x91\x01\x92A\x8d\x01\n" +
-	"\x8a\x01*\x1eUpdate Patient Details Request2[Data for updating an existing patient. Include only fields to change (use PATCH semantics).\xd2\x01\n" +
-	"patient_id\"\xa0\x01\n" +
+	"\rdate_of_birth\x18\a \x01(\v2\x1a.google.protobuf.TimestampBN\x92AK21New date of birth (optional, RFC3339 UTC format).J\x16\"1990-05-15T00:00:00Z\"R\vdateOfBirth\x12\xab\x01\n" +
+	"\aversion\x18\b \x01(\x03B\x8b\x01\x92A\x87\x012\x81\x01Expected current version of the patient record (optional). If the record was modified since, the update is rejected with ABORTED.J\x012H\x00R\aversion\x88\x01\x01\x12\x8f\x02\n" +
+	"\vupdate_mask\x18\t \x01(\v2\x1a.google.protobuf.FieldMaskB\xd1\x01\x92A\xcd\x012\xb3\x01Fields to update (optional). Masked fields are set even when empty, which clears them; fields outside the mask are left unchanged. Without a mask, empty fields are left unchanged.J\x15\"phoneNumber,address\"R\n" +
+	"updateMask:\xb8\x01\x92A\xb4\x01\n" +
+	"\xb1\x01*\x1eUpdate Patient Details Request2\x81\x01Data for updating an existing patient. Include only fields to change, or list the fields to set in update_mask (PATCH semantics).\xd2\x01\n" +
+	"patient_idB\n" +
+	"\n" +
+	"\b_version\"\xa0\x01\n" +
 	"\x1cUpdatePatientDetailsResponse\x121\n" +
 	"\apatient\x18\x01 \x01(\v2\x17.patientservice.PatientR\apatient:M\x92AJ\n" +
 	"H*\x1fUpdate Patient Details Response2%Contains the updated patient details.\"\xa5\x06\n" +
@@ -964,20 +1576,81 @@ const file_proto_patient_service_patient_proto_rawDesc = "" +
 	"g*#Get Patient Medical History Request2@Specifies the ID of the patient whose medical history is needed.\"\xd6\x01\n" +
 	" GetPatientMedicalHistoryResponse\x12F\n" +
 	"\x0fmedical_history\x18\x01 \x03(\v2\x1d.patientservice.MedicalRecordR\x0emedicalHistory:j\x92Ag\n" +
-	"e*$Get Patient Medical History Response2=Contains a list of medical records for the requested patient.2\xbc\v\n" +
+	"e*$Get Patient Medical History Response2=Contains a list of medical records for the requested patient.\"\x8a\x03\n" +
+	"\x14DeletePatientRequest\x12n\n" +
+	"\n" +
+	"patient_id\x18\x01 \x01(\tBO\x92AL2\"The UUID of the patient to delete.J&\"p1a2b3c4-e5f6-7890-1234-567890abcdef\"R\tpatientId\x12\x8d\x01\n" +
+	"\vhard_delete\x18\x02 \x01(\bBl\x92Ai2YIf true, performs a permanent (hard) delete. If false or omitted, performs a soft delete.:\x05falseJ\x05falseR\n" +
+	"hardDelete:r\x92Ao\n" +
+	"m*\x16Delete Patient Request2SSpecifies the patient to delete and whether it should be a permanent (hard) delete.\"\xda\x01\n" +
+	"\x15RestorePatientRequest\x12q\n" +
+	"\n" +
+	"patient_id\x18\x01 \x01(\tBR\x92AO2%The UUID of the soft-deleted patient.J&\"p1a2b3c4-e5f6-7890-1234-567890abcdef\"R\tpatientId:N\x92AK\n" +
+	"I*\x17Restore Patient Request2.Specifies the soft-deleted patient to restore.\"\x9b\x01\n" +
+	"\x16RestorePatientResponse\x121\n" +
+	"\apatient\x18\x01 \x01(\v2\x17.patientservice.PatientR\apatient:N\x92AK\n" +
+	"I*\x18Restore Patient Response2-Contains the details of the restored patient.\"\xc5\x03\n" +
+	"\x15SearchPatientsRequest\x12\xaf\x01\n" +
+	"\x05query\x18\x01 \x01(\tB\x98\x01\x92A\x94\x012\x86\x01Words to search for. Each word matches as a prefix of a name, phone or address word; misspellings and partial phone numbers match too.J\t\"ali 555\"R\x05query\x12D\n" +
+	"\x05limit\x18\x02 \x01(\x05B.\x92A+2!Maximum number of hits to return.:\x0220J\x0220R\x05limit\x12:\n" +
+	"\x06offset\x18\x03 \x01(\x05B\"\x92A\x1f2\x17Number of hits to skip.:\x010J\x010R\x06offset:x\x92Au\n" +
+	"s*\x17Search Patients Request2PFree-text query and pagination for searching patients by name, phone or address.\xd2\x01\x05query\"\xac\x04\n" +
+	"\x10PatientSearchHit\x121\n" +
+	"\apatient\x18\x01 \x01(\v2\x17.patientservice.PatientR\apatient\x12Y\n" +
+	"\x04rank\x18\x02 \x01(\x01BE\x92AB2:Relevance of the hit; hits are ordered by descending rank.J\x040.87R\x04rank\x12\xef\x01\n" +
+	"\n" +
+	"highlights\x18\x03 \x03(\v20.patientservice.PatientSearchHit.HighlightsEntryB\x9c\x01\x92A\x98\x012oMatched fields (first_name, last_name, phone_number, address) with the matching words wrapped in <mark></mark>.J%{\"first_name\": \"<mark>Alicia</mark>\"}R\n" +
+	"highlights\x1a=\n" +
+	"\x0fHighlightsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01:Y\x92AV\n" +
+	"T*\x12Patient Search Hit2>A matching patient with its relevance and highlighted matches.\"\xde\x01\n" +
+	"\x16SearchPatientsResponse\x124\n" +
+	"\x04hits\x18\x01 \x03(\v2 .patientservice.PatientSearchHitR\x04hits\x12=\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x14.core.PaginationInfoR\x0epaginationInfo:O\x92AL\n" +
+	"J*\x18Search Patients Response2.A ranked, paginated page of matching patients.\"\x87\x02\n" +
+	"\x15ExportPatientsRequest\x12-\n" +
+	"\aoptions\x18\x01 \x01(\v2\x13.core.FilterOptionsR\aoptions:\xbe\x01\x92A\xba\x01\n" +
+	"\xb7\x01*\x17Export Patients Request2\x9b\x01Filters and sort order of the patients to export. Every matching patient is streamed; limit sets how many are read per page, offset and cursor are ignored.\"\xbf\x01\n" +
+	"\x1aListDeletedPatientsRequest\x12-\n" +
+	"\aoptions\x18\x01 \x01(\v2\x13.core.FilterOptionsR\aoptions:r\x92Ao\n" +
+	"m*\x1dList Deleted Patients Request2LOptions for filtering, sorting, and paginating soft-deleted patient records.\"\xeb\x01\n" +
+	"\x1bListDeletedPatientsResponse\x123\n" +
+	"\bpatients\x18\x01 \x03(\v2\x17.patientservice.PatientR\bpatients\x12=\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x14.core.PaginationInfoR\x0epaginationInfo:X\x92AU\n" +
+	"S*\x1eList Deleted Patients Response21A paginated list of soft-deleted patient records.\"\xbc\x02\n" +
+	"\x1bPurgeDeletedPatientsRequest\x12\x9d\x01\n" +
+	"\x0folder_than_days\x18\x01 \x01(\x05Bu\x92Ar2hPermanently remove records soft-deleted more than this many days ago. 0 purges all soft-deleted records.:\x0230J\x0230R\rolderThanDays:}\x92Az\n" +
+	"x*\x1ePurge Deleted Patients Request2VRetention window for soft-deleted patient records; older ones are permanently removed.\"\xca\x01\n" +
+	"\x1cPurgeDeletedPatientsResponse\x12R\n" +
+	"\fpurged_count\x18\x01 \x01(\x03B/\x92A,2&Number of records permanently removed.J\x0212R\vpurgedCount:V\x92AS\n" +
+	"Q*\x1fPurge Deleted Patients Response2.Number of patient records permanently removed.2\xcb\x18\n" +
 	"\x0ePatientService\x12\xc6\x01\n" +
 	"\x0fRegisterPatient\x12&.patientservice.RegisterPatientRequest\x1a'.patientservice.RegisterPatientResponse\"b\x92AD\n" +
 	"\bPatients\x12\x10Register Patient\x1a&Registers a new patient in the system.\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/api/v1/patients\x12\xe8\x01\n" +
 	"\x11GetPatientDetails\x12(.patientservice.GetPatientDetailsRequest\x1a).patientservice.GetPatientDetailsResponse\"~\x92AV\n" +
 	"\bPatients\x12\x13Get Patient Details\x1a5Retrieves details for a specific patient by their ID.\x82\xd3\xe4\x93\x02\x1f\x12\x1d/api/v1/patients/{patient_id}\x12\xbd\x01\n" +
 	"\fListPatients\x12#.patientservice.ListPatientsRequest\x1a$.patientservice.ListPatientsResponse\"b\x92AG\n" +
-	"\bPatients\x12\rList Patients\x1a,Retrieves a list of all registered patients.\x82\xd3\xe4\x93\x02\x12\x12\x10/api/v1/patients\x12\xf4\x01\n" +
+	"\bPatients\x12\rList Patients\x1a,Retrieves a list of all registered patients.\x82\xd3\xe4\x93\x02\x12\x12\x10/api/v1/patients\x12\x92\x02\n" +
+	"\x0eSearchPatients\x12%.patientservice.SearchPatientsRequest\x1a&.patientservice.SearchPatientsResponse\"\xb0\x01\x92A\x8d\x01\n" +
+	"\bPatients\x12\x0fSearch Patients\x1apFull-text and fuzzy search of patients by name, phone or address. Results are ranked, paginated and highlighted.\x82\xd3\xe4\x93\x02\x19\x12\x17/api/v1/patients:search\x12R\n" +
+	"\x0eExportPatients\x12%.patientservice.ExportPatientsRequest\x1a\x17.patientservice.Patient0\x01\x12\xf4\x01\n" +
 	"\x14UpdatePatientDetails\x12+.patientservice.UpdatePatientDetailsRequest\x1a,.patientservice.UpdatePatientDetailsResponse\"\x80\x01\x92AU\n" +
 	"\bPatients\x12\x16Update Patient Details\x1a1Updates specific details for an existing patient.\x82\xd3\xe4\x93\x02\":\x01*2\x1d/api/v1/patients/{patient_id}\x12\xe9\x01\n" +
 	"\x10AddMedicalRecord\x12'.patientservice.AddMedicalRecordRequest\x1a\x16.google.protobuf.Empty\"\x93\x01\x92AX\n" +
 	"\x0fMedical Records\x12\x12Add Medical Record\x1a1Adds a new medical record to a patient's history.\x82\xd3\xe4\x93\x022:\x01*\"-/api/v1/patients/{patient_id}/medical-records\x12\x9d\x02\n" +
 	"\x18GetPatientMedicalHistory\x12/.patientservice.GetPatientMedicalHistoryRequest\x1a0.patientservice.GetPatientMedicalHistoryResponse\"\x9d\x01\x92Ae\n" +
-	"\x0fMedical Records\x12\x13Get Medical History\x1a=Retrieves the list of medical records for a specific patient.\x82\xd3\xe4\x93\x02/\x12-/api/v1/patients/{patient_id}/medical-history\x1a3\x92A0\x12.Manage patient information and medical recordsB\xb6\x01\x92A{\x12Q\n" +
+	"\x0fMedical Records\x12\x13Get Medical History\x1a=Retrieves the list of medical records for a specific patient.\x82\xd3\xe4\x93\x02/\x12-/api/v1/patients/{patient_id}/medical-history\x12\x8b\x02\n" +
+	"\rDeletePatient\x12$.patientservice.DeletePatientRequest\x1a\x16.google.protobuf.Empty\"\xbb\x01\x92A\x92\x01\n" +
+	"\bPatients\x12\x1aDelete Patient (Soft/Hard)\x1ajDeletes a patient. Defaults to soft delete. Set 'hard_delete=true' query parameter for permanent deletion.\x82\xd3\xe4\x93\x02\x1f*\x1d/api/v1/patients/{patient_id}\x12\xd1\x01\n" +
+	"\x0eRestorePatient\x12%.patientservice.RestorePatientRequest\x1a&.patientservice.RestorePatientResponse\"p\x92A=\n" +
+	"\bPatients\x12\x0fRestore Patient\x1a Restores a soft-deleted patient.\x82\xd3\xe4\x93\x02*:\x01*\"%/api/v1/patients/{patient_id}:restore\x12\xf2\x01\n" +
+	"\x13ListDeletedPatients\x12*.patientservice.ListDeletedPatientsRequest\x1a+.patientservice.ListDeletedPatientsResponse\"\x81\x01\x92A^\n" +
+	"\bPatients\x12\x15List Deleted Patients\x1a;Retrieves a paginated list of soft-deleted patient records.\x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/patients:deleted\x12\x92\x02\n" +
+	"\x14PurgeDeletedPatients\x12+.patientservice.PurgeDeletedPatientsRequest\x1a,.patientservice.PurgeDeletedPatientsResponse\"\x9e\x01\x92Az\n" +
+	"\bPatients\x12\x16Purge Deleted Patients\x1aVPermanently removes patient records soft-deleted more than 'older_than_days' days ago.\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/api/v1/patients:purge\x12\xb7\x02\n" +
+	"\x0fListAuditEvents\x12\x1c.core.ListAuditEventsRequest\x1a\x1d.core.ListAuditEventsResponse\"\xe6\x01\x92A\xbe\x01\n" +
+	"\bPatients\x12\x11List Audit Events\x1a\x9e\x01Retrieves the recorded changes of patient records and medical records, newest first. Filter by 'entity_type' and 'entity_id' to get the history of one entity.\x82\xd3\xe4\x93\x02\x1e\x12\x1c/api/v1/patients:auditEvents\x1a3\x92A0\x12.Manage patient information and medical recordsB\xb6\x01\x92A{\x12Q\n" +
 	"\x13Patient Service API\x125API for managing patient records and medical history.2\x031.0*\x02\x01\x022\x10application/json:\x10application/jsonZ6golang-microservices-boilerplate/proto/patient-serviceb\x06proto3"
 
 var (
@@ -992,7 +1665,7 @@ func file_proto_patient_service_patient_proto_rawDescGZIP() []byte {
 	return file_proto_patient_service_patient_proto_rawDescData
 }
 
-var file_proto_patient_service_patient_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_proto_patient_service_patient_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_proto_patient_service_patient_proto_goTypes = []any{
 	(*Patient)(nil),                          // 0: patientservice.Patient
 	(*MedicalRecord)(nil),                    // 1: patientservice.MedicalRecord
@@ -1007,42 +1680,86 @@ var file_proto_patient_service_patient_proto_goTypes = []any{
 	(*AddMedicalRecordRequest)(nil),          // 10: patientservice.AddMedicalRecordRequest
 	(*GetPatientMedicalHistoryRequest)(nil),  // 11: patientservice.GetPatientMedicalHistoryRequest
 	(*GetPatientMedicalHistoryResponse)(nil), // 12: patientservice.GetPatientMedicalHistoryResponse
-	(*timestamppb.Timestamp)(nil),            // 13: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),                    // 14: google.protobuf.Empty
+	(*DeletePatientRequest)(nil),             // 13: patientservice.DeletePatientRequest
+	(*RestorePatientRequest)(nil),            // 14: patientservice.RestorePatientRequest
+	(*RestorePatientResponse)(nil),           // 15: patientservice.RestorePatientResponse
+	(*SearchPatientsRequest)(nil),            // 16: patientservice.SearchPatientsRequest
+	(*PatientSearchHit)(nil),                 // 17: patientservice.PatientSearchHit
+	(*SearchPatientsResponse)(nil),           // 18: patientservice.SearchPatientsResponse
+	(*ExportPatientsRequest)(nil),            // 19: patientservice.ExportPatientsRequest
+	(*ListDeletedPatientsRequest)(nil),       // 20: patientservice.ListDeletedPatientsRequest
+	(*ListDeletedPatientsResponse)(nil),      // 21: patientservice.ListDeletedPatientsResponse
+	(*PurgeDeletedPatientsRequest)(nil),      // 22: patientservice.PurgeDeletedPatientsRequest
+	(*PurgeDeletedPatientsResponse)(nil),     // 23: patientservice.PurgeDeletedPatientsResponse
+	nil,                                      // 24: patientservice.PatientSearchHit.HighlightsEntry
+	(*timestamppb.Timestamp)(nil),            // 25: google.protobuf.Timestamp
+	(*core.FilterOptions)(nil),               // 26: core.FilterOptions
+	(*core.PaginationInfo)(nil),              // 27: core.PaginationInfo
+	(*fieldmaskpb.FieldMask)(nil),            // 28: google.protobuf.FieldMask
+	(*core.ListAuditEventsRequest)(nil),      // 29: core.ListAuditEventsRequest
+	(*emptypb.Empty)(nil),                    // 30: google.protobuf.Empty
+	(*core.ListAuditEventsResponse)(nil),     // 31: core.ListAuditEventsResponse
 }
 var file_proto_patient_service_patient_proto_depIdxs = []int32{
-	13, // 0: patientservice.Patient.date_of_birth:type_name -> google.protobuf.Timestamp
+	25, // 0: patientservice.Patient.date_of_birth:type_name -> google.protobuf.Timestamp
 	1,  // 1: patientservice.Patient.medical_history:type_name -> patientservice.MedicalRecord
-	13, // 2: patientservice.Patient.created_at:type_name -> google.protobuf.Timestamp
-	13, // 3: patientservice.Patient.updated_at:type_name -> google.protobuf.Timestamp
-	13, // 4: patientservice.MedicalRecord.date:type_name -> google.protobuf.Timestamp
-	13, // 5: patientservice.MedicalRecord.created_at:type_name -> google.protobuf.Timestamp
-	13, // 6: patientservice.MedicalRecord.updated_at:type_name -> google.protobuf.Timestamp
-	13, // 7: patientservice.RegisterPatientRequest.date_of_birth:type_name -> google.protobuf.Timestamp
-	0,  // 8: patientservice.RegisterPatientResponse.patient:type_name -> patientservice.Patient
-	0,  // 9: patientservice.GetPatientDetailsResponse.patient:type_name -> patientservice.Patient
-	0,  // 10: patientservice.ListPatientsResponse.patients:type_name -> patientservice.Patient
-	13, // 11: patientservice.UpdatePatientDetailsRequest.date_of_birth:type_name -> google.protobuf.Timestamp
-	0,  // 12: patientservice.UpdatePatientDetailsResponse.patient:type_name -> patientservice.Patient
-	13, // 13: patientservice.AddMedicalRecordRequest.date:type_name -> google.protobuf.Timestamp
-	1,  // 14: patientservice.GetPatientMedicalHistoryResponse.medical_history:type_name -> patientservice.MedicalRecord
-	2,  // 15: patientservice.PatientService.RegisterPatient:input_type -> patientservice.RegisterPatientRequest
-	4,  // 16: patientservice.PatientService.GetPatientDetails:input_type -> patientservice.GetPatientDetailsRequest
-	6,  // 17: patientservice.PatientService.ListPatients:input_type -> patientservice.ListPatientsRequest
-	8,  // 18: patientservice.PatientService.UpdatePatientDetails:input_type -> patientservice.UpdatePatientDetailsRequest
-	10, // 19: patientservice.PatientService.AddMedicalRecord:input_type -> patientservice.AddMedicalRecordRequest
-	11, // 20: patientservice.PatientService.GetPatientMedicalHistory:input_type -> patientservice.GetPatientMedicalHistoryRequest
-	3,  // 21: patientservice.PatientService.RegisterPatient:output_type -> patientservice.RegisterPatientResponse
-	5,  // 22: patientservice.PatientService.GetPatientDetails:output_type -> patientservice.GetPatientDetailsResponse
-	7,  // 23: patientservice.PatientService.ListPatients:output_type -> patientservice.ListPatientsResponse
-	9,  // 24: patientservice.PatientService.UpdatePatientDetails:output_type -> patientservice.UpdatePatientDetailsResponse
-	14, // 25: patientservice.PatientService.AddMedicalRecord:output_type -> google.protobuf.Empty
-	12, // 26: patientservice.PatientService.GetPatientMedicalHistory:output_type -> patientservice.GetPatientMedicalHistoryResponse
-	21, // [21:27] is the sub-list for method output_type
-	15, // [15:21] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	25, // 2: patientservice.Patient.created_at:type_name -> google.protobuf.Timestamp
+	25, // 3: patientservice.Patient.updated_at:type_name -> google.protobuf.Timestamp
+	25, // 4: patientservice.Patient.deleted_at:type_name -> google.protobuf.Timestamp
+	25, // 5: patientservice.MedicalRecord.date:type_name -> google.protobuf.Timestamp
+	25, // 6: patientservice.MedicalRecord.created_at:type_name -> google.protobuf.Timestamp
+	25, // 7: patientservice.MedicalRecord.updated_at:type_name -> google.protobuf.Timestamp
+	25, // 8: patientservice.RegisterPatientRequest.date_of_birth:type_name -> google.protobuf.Timestamp
+	0,  // 9: patientservice.RegisterPatientResponse.patient:type_name -> patientservice.Patient
+	0,  // 10: patientservice.GetPatientDetailsResponse.patient:type_name -> patientservice.Patient
+	26, // 11: patientservice.ListPatientsRequest.options:type_name -> core.FilterOptions
+	0,  // 12: patientservice.ListPatientsResponse.patients:type_name -> patientservice.Patient
+	27, // 13: patientservice.ListPatientsResponse.pagination_info:type_name -> core.PaginationInfo
+	25, // 14: patientservice.UpdatePatientDetailsRequest.date_of_birth:type_name -> google.protobuf.Timestamp
+	28, // 15: patientservice.UpdatePatientDetailsRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 16: patientservice.UpdatePatientDetailsResponse.patient:type_name -> patientservice.Patient
+	25, // 17: patientservice.AddMedicalRecordRequest.date:type_name -> google.protobuf.Timestamp
+	1,  // 18: patientservice.GetPatientMedicalHistoryResponse.medical_history:type_name -> patientservice.MedicalRecord
+	0,  // 19: patientservice.RestorePatientResponse.patient:type_name -> patientservice.Patient
+	0,  // 20: patientservice.PatientSearchHit.patient:type_name -> patientservice.Patient
+	24, // 21: patientservice.PatientSearchHit.highlights:type_name -> patientservice.PatientSearchHit.HighlightsEntry
+	17, // 22: patientservice.SearchPatientsResponse.hits:type_name -> patientservice.PatientSearchHit
+	27, // 23: patientservice.SearchPatientsResponse.pagination_info:type_name -> core.PaginationInfo
+	26, // 24: patientservice.ExportPatientsRequest.options:type_name -> core.FilterOptions
+	26, // 25: patientservice.ListDeletedPatientsRequest.options:type_name -> core.FilterOptions
+	0,  // 26: patientservice.ListDeletedPatientsResponse.patients:type_name -> patientservice.Patient
+	27, // 27: patientservice.ListDeletedPatientsResponse.pagination_info:type_name -> core.PaginationInfo
+	2,  // 28: patientservice.PatientService.RegisterPatient:input_type -> patientservice.RegisterPatientRequest
+	4,  // 29: patientservice.PatientService.GetPatientDetails:input_type -> patientservice.GetPatientDetailsRequest
+	6,  // 30: patientservice.PatientService.ListPatients:input_type -> patientservice.ListPatientsRequest
+	16, // 31: patientservice.PatientService.SearchPatients:input_type -> patientservice.SearchPatientsRequest
+	19, // 32: patientservice.PatientService.ExportPatients:input_type -> patientservice.ExportPatientsRequest
+	8,  // 33: patientservice.PatientService.UpdatePatientDetails:input_type -> patientservice.UpdatePatientDetailsRequest
+	10, // 34: patientservice.PatientService.AddMedicalRecord:input_type -> patientservice.AddMedicalRecordRequest
+	11, // 35: patientservice.PatientService.GetPatientMedicalHistory:input_type -> patientservice.GetPatientMedicalHistoryRequest
+	13, // 36: patientservice.PatientService.DeletePatient:input_type -> patientservice.DeletePatientRequest
+	14, // 37: patientservice.PatientService.RestorePatient:input_type -> patientservice.RestorePatientRequest
+	20, // 38: patientservice.PatientService.ListDeletedPatients:input_type -> patientservice.ListDeletedPatientsRequest
+	22, // 39: patientservice.PatientService.PurgeDeletedPatients:input_type -> patientservice.PurgeDeletedPatientsRequest
+	29, // 40: patientservice.PatientService.ListAuditEvents:input_type -> core.ListAuditEventsRequest
+	3,  // 41: patientservice.PatientService.RegisterPatient:output_type -> patientservice.RegisterPatientResponse
+	5,  // 42: patientservice.PatientService.GetPatientDetails:output_type -> patientservice.GetPatientDetailsResponse
+	7,  // 43: patientservice.PatientService.ListPatients:output_type -> patientservice.ListPatientsResponse
+	18, // 44: patientservice.PatientService.SearchPatients:output_type -> patientservice.SearchPatientsResponse
+	0,  // 45: patientservice.PatientService.ExportPatients:output_type -> patientservice.Patient
+	9,  // 46: patientservice.PatientService.UpdatePatientDetails:output_type -> patientservice.UpdatePatientDetailsResponse
+	30, // 47: patientservice.PatientService.AddMedicalRecord:output_type -> google.protobuf.Empty
+	12, // 48: patientservice.PatientService.GetPatientMedicalHistory:output_type -> patientservice.GetPatientMedicalHistoryResponse
+	30, // 49: patientservice.PatientService.DeletePatient:output_type -> google.protobuf.Empty
+	15, // 50: patientservice.PatientService.RestorePatient:output_type -> patientservice.RestorePatientResponse
+	21, // 51: patientservice.PatientService.ListDeletedPatients:output_type -> patientservice.ListDeletedPatientsResponse
+	23, // 52: patientservice.PatientService.PurgeDeletedPatients:output_type -> patientservice.PurgeDeletedPatientsResponse
+	31, // 53: patientservice.PatientService.ListAuditEvents:output_type -> core.ListAuditEventsResponse
+	41, // [41:54] is the sub-list for method output_type
+	28, // [28:41] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_proto_patient_service_patient_proto_init() }
@@ -1050,13 +1767,15 @@ func file_proto_patient_service_patient_proto_init() {
 	if File_proto_patient_service_patient_proto != nil {
 		return
 	}
+	file_proto_patient_service_patient_proto_msgTypes[0].OneofWrappers = []any{}
+	file_proto_patient_service_patient_proto_msgTypes[8].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_patient_service_patient_proto_rawDesc), len(file_proto_patient_service_patient_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
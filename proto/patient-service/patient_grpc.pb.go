@@ -8,6 +8,7 @@ package patient_service
 
 import (
 	context "context"
+	core "golang-microservices-boilerplate/proto/core"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -23,9 +24,16 @@ const (
 	PatientService_RegisterPatient_FullMethodName          = "/patientservice.PatientService/RegisterPatient"
 	PatientService_GetPatientDetails_FullMethodName        = "/patientservice.PatientService/GetPatientDetails"
 	PatientService_ListPatients_FullMethodName             = "/patientservice.PatientService/ListPatients"
+	PatientService_SearchPatients_FullMethodName           = "/patientservice.PatientService/SearchPatients"
+	PatientService_ExportPatients_FullMethodName           = "/patientservice.PatientService/ExportPatients"
 	PatientService_UpdatePatientDetails_FullMethodName     = "/patientservice.PatientService/UpdatePatientDetails"
 	PatientService_AddMedicalRecord_FullMethodName         = "/patientservice.PatientService/AddMedicalRecord"
 	PatientService_GetPatientMedicalHistory_FullMethodName = "/patientservice.PatientService/GetPatientMedicalHistory"
+	PatientService_DeletePatient_FullMethodName            = "/patientservice.PatientService/DeletePatient"
+	PatientService_RestorePatient_FullMethodName           = "/patientservice.PatientService/RestorePatient"
+	PatientService_ListDeletedPatients_FullMethodName      = "/patientservice.PatientService/ListDeletedPatients"
+	PatientService_PurgeDeletedPatients_FullMethodName     = "/patientservice.PatientService/PurgeDeletedPatients"
+	PatientService_ListAuditEvents_FullMethodName          = "/patientservice.PatientService/ListAuditEvents"
 )
 
 // PatientServiceClient is the client API for PatientService service.
@@ -35,9 +43,20 @@ type PatientServiceClient interface {
 	RegisterPatient(ctx context.Context, in *RegisterPatientRequest, opts ...grpc.CallOption) (*RegisterPatientResponse, error)
 	GetPatientDetails(ctx context.Context, in *GetPatientDetailsRequest, opts ...grpc.CallOption) (*GetPatientDetailsResponse, error)
 	ListPatients(ctx context.Context, in *ListPatientsRequest, opts ...grpc.CallOption) (*ListPatientsResponse, error)
+	SearchPatients(ctx context.Context, in *SearchPatientsRequest, opts ...grpc.CallOption) (*SearchPatientsResponse, error)
+	// Streams every matching patient. Not mapped by grpc-gateway: the API
+	// gateway serves it as an NDJSON or CSV download at GET /api/v1/patients:export.
+	ExportPatients(ctx context.Context, in *ExportPatientsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Patient], error)
 	UpdatePatientDetails(ctx context.Context, in *UpdatePatientDetailsRequest, opts ...grpc.CallOption) (*UpdatePatientDetailsResponse, error)
 	AddMedicalRecord(ctx context.Context, in *AddMedicalRecordRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetPatientMedicalHistory(ctx context.Context, in *GetPatientMedicalHistoryRequest, opts ...grpc.CallOption) (*GetPatientMedicalHistoryResponse, error)
+	// --- Soft-delete Lifecycle ---
+	DeletePatient(ctx context.Context, in *DeletePatientRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestorePatient(ctx context.Context, in *RestorePatientRequest, opts ...grpc.CallOption) (*RestorePatientResponse, error)
+	ListDeletedPatients(ctx context.Context, in *ListDeletedPatientsRequest, opts ...grpc.CallOption) (*ListDeletedPatientsResponse, error)
+	PurgeDeletedPatients(ctx context.Context, in *PurgeDeletedPatientsRequest, opts ...grpc.CallOption) (*PurgeDeletedPatientsResponse, error)
+	// --- Audit Trail ---
+	ListAuditEvents(ctx context.Context, in *core.ListAuditEventsRequest, opts ...grpc.CallOption) (*core.ListAuditEventsResponse, error)
 }
 
 type patientServiceClient struct {
@@ -78,6 +97,35 @@ func (c *patientServiceClient) ListPatients(ctx context.Context, in *ListPatient
 	return out, nil
 }
 
+func (c *patientServiceClient) SearchPatients(ctx context.Context, in *SearchPatientsRequest, opts ...grpc.CallOption) (*SearchPatientsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchPatientsResponse)
+	err := c.cc.Invoke(ctx, PatientService_SearchPatients_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *patientServiceClient) ExportPatients(ctx context.Context, in *ExportPatientsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Patient], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PatientService_ServiceDesc.Streams[0], PatientService_ExportPatients_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportPatientsRequest, Patient]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PatientService_ExportPatientsClient = grpc.ServerStreamingClient[Patient]
+
 func (c *patientServiceClient) UpdatePatientDetails(ctx context.Context, in *UpdatePatientDetailsRequest, opts ...grpc.CallOption) (*UpdatePatientDetailsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdatePatientDetailsResponse)
@@ -108,6 +156,56 @@ func (c *patientServiceClient) GetPatientMedicalHistory(ctx context.Context, in
 	return out, nil
 }
 
+func (c *patientServiceClient) DeletePatient(ctx context.Context, in *DeletePatientRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PatientService_DeletePatient_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *patientServiceClient) RestorePatient(ctx context.Context, in *RestorePatientRequest, opts ...grpc.CallOption) (*RestorePatientResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestorePatientResponse)
+	err := c.cc.Invoke(ctx, PatientService_RestorePatient_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *patientServiceClient) ListDeletedPatients(ctx context.Context, in *ListDeletedPatientsRequest, opts ...grpc.CallOption) (*ListDeletedPatientsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeletedPatientsResponse)
+	err := c.cc.Invoke(ctx, PatientService_ListDeletedPatients_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *patientServiceClient) PurgeDeletedPatients(ctx context.Context, in *PurgeDeletedPatientsRequest, opts ...grpc.CallOption) (*PurgeDeletedPatientsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeDeletedPatientsResponse)
+	err := c.cc.Invoke(ctx, PatientService_PurgeDeletedPatients_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *patientServiceClient) ListAuditEvents(ctx context.Context, in *core.ListAuditEventsRequest, opts ...grpc.CallOption) (*core.ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(core.ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, PatientService_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PatientServiceServer is the server API for PatientService service.
 // All implementations must embed UnimplementedPatientServiceServer
 // for forward compatibility.
@@ -115,9 +213,20 @@ type PatientServiceServer interface {
 	RegisterPatient(context.Context, *RegisterPatientRequest) (*RegisterPatientResponse, error)
 	GetPatientDetails(context.Context, *GetPatientDetailsRequest) (*GetPatientDetailsResponse, error)
 	ListPatients(context.Context, *ListPatientsRequest) (*ListPatientsResponse, error)
+	SearchPatients(context.Context, *SearchPatientsRequest) (*SearchPatientsResponse, error)
+	// Streams every matching patient. Not mapped by grpc-gateway: the API
+	// gateway serves it as an NDJSON or CSV download at GET /api/v1/patients:export.
+	ExportPatients(*ExportPatientsRequest, grpc.ServerStreamingServer[Patient]) error
 	UpdatePatientDetails(context.Context, *UpdatePatientDetailsRequest) (*UpdatePatientDetailsResponse, error)
 	AddMedicalRecord(context.Context, *AddMedicalRecordRequest) (*emptypb.Empty, error)
 	GetPatientMedicalHistory(context.Context, *GetPatientMedicalHistoryRequest) (*GetPatientMedicalHistoryResponse, error)
+	// --- Soft-delete Lifecycle ---
+	DeletePatient(context.Context, *DeletePatientRequest) (*emptypb.Empty, error)
+	RestorePatient(context.Context, *RestorePatientRequest) (*RestorePatientResponse, error)
+	ListDeletedPatients(context.Context, *ListDeletedPatientsRequest) (*ListDeletedPatientsResponse, error)
+	PurgeDeletedPatients(context.Context, *PurgeDeletedPatientsRequest) (*PurgeDeletedPatientsResponse, error)
+	// --- Audit Trail ---
+	ListAuditEvents(context.Context, *core.ListAuditEventsRequest) (*core.ListAuditEventsResponse, error)
 	mustEmbedUnimplementedPatientServiceServer()
 }
 
@@ -137,6 +246,12 @@ func (UnimplementedPatientServiceServer) GetPatientDetails(context.Context, *Get
 func (UnimplementedPatientServiceServer) ListPatients(context.Context, *ListPatientsRequest) (*ListPatientsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListPatients not implemented")
 }
+func (UnimplementedPatientServiceServer) SearchPatients(context.Context, *SearchPatientsRequest) (*SearchPatientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchPatients not implemented")
+}
+func (UnimplementedPatientServiceServer) ExportPatients(*ExportPatientsRequest, grpc.ServerStreamingServer[Patient]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportPatients not implemented")
+}
 func (UnimplementedPatientServiceServer) UpdatePatientDetails(context.Context, *UpdatePatientDetailsRequest) (*UpdatePatientDetailsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdatePatientDetails not implemented")
 }
@@ -146,6 +261,21 @@ func (UnimplementedPatientServiceServer) AddMedicalRecord(context.Context, *AddM
 func (UnimplementedPatientServiceServer) GetPatientMedicalHistory(context.Context, *GetPatientMedicalHistoryRequest) (*GetPatientMedicalHistoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPatientMedicalHistory not implemented")
 }
+func (UnimplementedPatientServiceServer) DeletePatient(context.Context, *DeletePatientRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePatient not implemented")
+}
+func (UnimplementedPatientServiceServer) RestorePatient(context.Context, *RestorePatientRequest) (*RestorePatientResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestorePatient not implemented")
+}
+func (UnimplementedPatientServiceServer) ListDeletedPatients(context.Context, *ListDeletedPatientsRequest) (*ListDeletedPatientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedPatients not implemented")
+}
+func (UnimplementedPatientServiceServer) PurgeDeletedPatients(context.Context, *PurgeDeletedPatientsRequest) (*PurgeDeletedPatientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeDeletedPatients not implemented")
+}
+func (UnimplementedPatientServiceServer) ListAuditEvents(context.Context, *core.ListAuditEventsRequest) (*core.ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
 func (UnimplementedPatientServiceServer) mustEmbedUnimplementedPatientServiceServer() {}
 func (UnimplementedPatientServiceServer) testEmbeddedByValue()                        {}
 
@@ -221,6 +351,35 @@ func _PatientService_ListPatients_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PatientService_SearchPatients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchPatientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PatientServiceServer).SearchPatients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PatientService_SearchPatients_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PatientServiceServer).SearchPatients(ctx, req.(*SearchPatientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PatientService_ExportPatients_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportPatientsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PatientServiceServer).ExportPatients(m, &grpc.GenericServerStream[ExportPatientsRequest, Patient]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PatientService_ExportPatientsServer = grpc.ServerStreamingServer[Patient]
+
 func _PatientService_UpdatePatientDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdatePatientDetailsRequest)
 	if err := dec(in); err != nil {
@@ -275,6 +434,96 @@ func _PatientService_GetPatientMedicalHistory_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PatientService_DeletePatient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePatientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PatientServiceServer).DeletePatient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PatientService_DeletePatient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PatientServiceServer).DeletePatient(ctx, req.(*DeletePatientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PatientService_RestorePatient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestorePatientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PatientServiceServer).RestorePatient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PatientService_RestorePatient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PatientServiceServer).RestorePatient(ctx, req.(*RestorePatientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PatientService_ListDeletedPatients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedPatientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PatientServiceServer).ListDeletedPatients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PatientService_ListDeletedPatients_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PatientServiceServer).ListDeletedPatients(ctx, req.(*ListDeletedPatientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PatientService_PurgeDeletedPatients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeDeletedPatientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PatientServiceServer).PurgeDeletedPatients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PatientService_PurgeDeletedPatients_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PatientServiceServer).PurgeDeletedPatients(ctx, req.(*PurgeDeletedPatientsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PatientService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(core.ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PatientServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PatientService_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PatientServiceServer).ListAuditEvents(ctx, req.(*core.ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PatientService_ServiceDesc is the grpc.ServiceDesc for PatientService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -294,6 +543,10 @@ var PatientService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListPatients",
 			Handler:    _PatientService_ListPatients_Handler,
 		},
+		{
+			MethodName: "SearchPatients",
+			Handler:    _PatientService_SearchPatients_Handler,
+		},
 		{
 			MethodName: "UpdatePatientDetails",
 			Handler:    _PatientService_UpdatePatientDetails_Handler,
@@ -306,7 +559,33 @@ var PatientService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPatientMedicalHistory",
 			Handler:    _PatientService_GetPatientMedicalHistory_Handler,
 		},
+		{
+			MethodName: "DeletePatient",
+			Handler:    _PatientService_DeletePatient_Handler,
+		},
+		{
+			MethodName: "RestorePatient",
+			Handler:    _PatientService_RestorePatient_Handler,
+		},
+		{
+			MethodName: "ListDeletedPatients",
+			Handler:    _PatientService_ListDeletedPatients_Handler,
+		},
+		{
+			MethodName: "PurgeDeletedPatients",
+			Handler:    _PatientService_PurgeDeletedPatients_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _PatientService_ListAuditEvents_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportPatients",
+			Handler:       _PatientService_ExportPatients_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/patient-service/patient.proto",
 }
@@ -8,10 +8,12 @@ package staff_service
 
 import (
 	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	core "golang-microservices-boilerplate/proto/core"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -193,6 +195,7 @@ type TaskProto struct {
 	StatusId      string                 `protobuf:"bytes,7,opt,name=status_id,json=statusId,proto3" json:"status_id,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version       int64                  `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -290,6 +293,13 @@ func (x *TaskProto) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *TaskProto) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 // Corresponds to entity.ScheduleEntry (Join Table)
 type ScheduleEntryProto struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -359,6 +369,8 @@ type Staff struct {
 	Schedule       []*ScheduleEntryProto  `protobuf:"bytes,11,rep,name=schedule,proto3" json:"schedule,omitempty"`
 	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version        int64                  `protobuf:"varint,14,opt,name=version,proto3" json:"version,omitempty"`
+	DeletedAt      *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=deleted_at,json=deletedAt,proto3,oneof" json:"deleted_at,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -484,6 +496,20 @@ func (x *Staff) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Staff) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Staff) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
 // Staff Operations
 type AddStaffRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -726,9 +752,8 @@ func (x *GetStaffDetailsResponse) GetStaff() *Staff {
 }
 
 type UpdateStaffDetailsRequest struct {
-	state   protoimpl.MessageState `protogen:"open.v1"`
-	StaffId string                 `protobuf:"bytes,1,opt,name=staff_id,json=staffId,proto3" json:"staff_id,omitempty"`
-	// Fields below are optional for update (use wrappers or field masks in real app)
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	StaffId        string                 `protobuf:"bytes,1,opt,name=staff_id,json=staffId,proto3" json:"staff_id,omitempty"`
 	FirstName      string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
 	LastName       string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
 	DateOfBirth    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=date_of_birth,json=dateOfBirth,proto3" json:"date_of_birth,omitempty"`
@@ -736,6 +761,8 @@ type UpdateStaffDetailsRequest struct {
 	Address        string                 `protobuf:"bytes,6,opt,name=address,proto3" json:"address,omitempty"`
 	Specialization string                 `protobuf:"bytes,7,opt,name=specialization,proto3" json:"specialization,omitempty"`
 	NurseType      string                 `protobuf:"bytes,8,opt,name=nurse_type,json=nurseType,proto3" json:"nurse_type,omitempty"`
+	Version        *int64                 `protobuf:"varint,9,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	UpdateMask     *fieldmaskpb.FieldMask `protobuf:"bytes,10,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -826,6 +853,20 @@ func (x *UpdateStaffDetailsRequest) GetNurseType() string {
 	return ""
 }
 
+func (x *UpdateStaffDetailsRequest) GetVersion() int64 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+func (x *UpdateStaffDetailsRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 type UpdateStaffDetailsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Staff         *Staff                 `protobuf:"bytes,1,opt,name=staff,proto3" json:"staff,omitempty"`
@@ -874,8 +915,9 @@ func (x *UpdateStaffDetailsResponse) GetStaff() *Staff {
 type ListStaffRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Optional filtering parameters
-	RoleId        string `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
-	StatusId      string `protobuf:"bytes,2,opt,name=status_id,json=statusId,proto3" json:"status_id,omitempty"` // Add pagination fields like page_size, page_token if needed
+	RoleId        string              `protobuf:"bytes,1,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	StatusId      string              `protobuf:"bytes,2,opt,name=status_id,json=statusId,proto3" json:"status_id,omitempty"`
+	Options       *core.FilterOptions `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -924,12 +966,20 @@ func (x *ListStaffRequest) GetStatusId() string {
 	return ""
 }
 
+func (x *ListStaffRequest) GetOptions() *core.FilterOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
 // Response for ListStaff
 type ListStaffResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	StaffMembers  []*Staff               `protobuf:"bytes,1,rep,name=staff_members,json=staffMembers,proto3" json:"staff_members,omitempty"` // Renamed from 'staff' to avoid conflict
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	StaffMembers   []*Staff               `protobuf:"bytes,1,rep,name=staff_members,json=staffMembers,proto3" json:"staff_members,omitempty"` // Renamed from 'staff' to avoid conflict
+	PaginationInfo *core.PaginationInfo   `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ListStaffResponse) Reset() {
@@ -969,6 +1019,13 @@ func (x *ListStaffResponse) GetStaffMembers() []*Staff {
 	return nil
 }
 
+func (x *ListStaffResponse) GetPaginationInfo() *core.PaginationInfo {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
 type UpdateStaffScheduleRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	StaffId         string                 `protobuf:"bytes,1,opt,name=staff_id,json=staffId,proto3" json:"staff_id,omitempty"`
@@ -1361,7 +1418,8 @@ func (x *TrackWorkloadResponse) GetWorkload() []*TaskProto {
 type ListTasksRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Optional filtering parameters (examples)
-	StatusId      string `protobuf:"bytes,1,opt,name=status_id,json=statusId,proto3" json:"status_id,omitempty"`
+	StatusId      string              `protobuf:"bytes,1,opt,name=status_id,json=statusId,proto3" json:"status_id,omitempty"`
+	Options       *core.FilterOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1403,12 +1461,20 @@ func (x *ListTasksRequest) GetStatusId() string {
 	return ""
 }
 
+func (x *ListTasksRequest) GetOptions() *core.FilterOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
 // Response for ListTasks
 type ListTasksResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tasks         []*TaskProto           `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"` // Add pagination fields like next_page_token if needed
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Tasks          []*TaskProto           `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	PaginationInfo *core.PaginationInfo   `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ListTasksResponse) Reset() {
@@ -1448,6 +1514,13 @@ func (x *ListTasksResponse) GetTasks() []*TaskProto {
 	return nil
 }
 
+func (x *ListTasksResponse) GetPaginationInfo() *core.PaginationInfo {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
 // Role Operations
 type AddStaffRoleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1979,6 +2052,557 @@ func (x *ListTaskStatusesResponse) GetStatuses() []*TaskStatusProto {
 	return nil
 }
 
+// Request for DeleteStaff
+type DeleteStaffRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StaffId       string                 `protobuf:"bytes,1,opt,name=staff_id,json=staffId,proto3" json:"staff_id,omitempty"`
+	HardDelete    bool                   `protobuf:"varint,2,opt,name=hard_delete,json=hardDelete,proto3" json:"hard_delete,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteStaffRequest) Reset() {
+	*x = DeleteStaffRequest{}
+	mi := &file_proto_staff_service_staff_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteStaffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteStaffRequest) ProtoMessage() {}
+
+func (x *DeleteStaffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_staff_service_staff_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteStaffRequest.ProtoReflect.Descriptor instead.
+func (*DeleteStaffRequest) Descriptor() ([]byte, []int) {
+	return file_proto_staff_service_staff_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeleteStaffRequest) GetStaffId() string {
+	if x != nil {
+		return x.StaffId
+	}
+	return ""
+}
+
+func (x *DeleteStaffRequest) GetHardDelete() bool {
+	if x != nil {
+		return x.HardDelete
+	}
+	return false
+}
+
+// Request for RestoreStaff
+type RestoreStaffRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StaffId       string                 `protobuf:"bytes,1,opt,name=staff_id,json=staffId,proto3" json:"staff_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreStaffRequest) Reset() {
+	*x = RestoreStaffRequest{}
+	mi := &file_proto_staff_service_staff_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreStaffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreStaffRequest) ProtoMessage() {}
+
+func (x *RestoreStaffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_staff_service_staff_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreStaffRequest.ProtoReflect.Descriptor instead.
+func (*RestoreStaffRequest) Descriptor() ([]byte, []int) {
+	return file_proto_staff_service_staff_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RestoreStaffRequest) GetStaffId() string {
+	if x != nil {
+		return x.StaffId
+	}
+	return ""
+}
+
+// Response for RestoreStaff
+type RestoreStaffResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Staff         *Staff                 `protobuf:"bytes,1,opt,name=staff,proto3" json:"staff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreStaffResponse) Reset() {
+	*x = RestoreStaffResponse{}
+	mi := &file_proto_staff_service_staff_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreStaffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreStaffResponse) ProtoMessage() {}
+
+func (x *RestoreStaffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_staff_service_staff_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreStaffResponse.ProtoReflect.Descriptor instead.
+func (*RestoreStaffResponse) Descriptor() ([]byte, []int) {
+	return file_proto_staff_service_staff_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RestoreStaffResponse) GetStaff() *Staff {
+	if x != nil {
+		return x.Staff
+	}
+	return nil
+}
+
+// Request for ExportStaff
+type ExportStaffRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *core.FilterOptions    `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportStaffRequest) Reset() {
+	*x = ExportStaffRequest{}
+	mi := &file_proto_staff_service_staff_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportStaffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportStaffRequest) ProtoMessage() {}
+
+func (x *ExportStaffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_staff_service_staff_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportStaffRequest.ProtoReflect.Descriptor instead.
+func (*ExportStaffRequest) Descriptor() ([]byte, []int) {
+	return file_proto_staff_service_staff_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ExportStaffRequest) GetOptions() *core.FilterOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// Request for SearchStaff
+type SearchStaffRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchStaffRequest) Reset() {
+	*x = SearchStaffRequest{}
+	mi := &file_proto_staff_service_staff_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchStaffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchStaffRequest) ProtoMessage() {}
+
+func (x *SearchStaffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_staff_service_staff_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchStaffRequest.ProtoReflect.Descriptor instead.
+func (*SearchStaffRequest) Descriptor() ([]byte, []int) {
+	return file_proto_staff_service_staff_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SearchStaffRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchStaffRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchStaffRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// One hit of SearchStaff
+type StaffSearchHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Staff         *Staff                 `protobuf:"bytes,1,opt,name=staff,proto3" json:"staff,omitempty"`
+	Rank          float64                `protobuf:"fixed64,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	Highlights    map[string]string      `protobuf:"bytes,3,rep,name=highlights,proto3" json:"highlights,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StaffSearchHit) Reset() {
+	*x = StaffSearchHit{}
+	mi := &file_proto_staff_service_staff_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StaffSearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StaffSearchHit) ProtoMessage() {}
+
+func (x *StaffSearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_staff_service_staff_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StaffSearchHit.ProtoReflect.Descriptor instead.
+func (*StaffSearchHit) Descriptor() ([]byte, []int) {
+	return file_proto_staff_service_staff_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *StaffSearchHit) GetStaff() *Staff {
+	if x != nil {
+		return x.Staff
+	}
+	return nil
+}
+
+func (x *StaffSearchHit) GetRank() float64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *StaffSearchHit) GetHighlights() map[string]string {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+// Response for SearchStaff
+type SearchStaffResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Hits           []*StaffSearchHit      `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	PaginationInfo *core.PaginationInfo   `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SearchStaffResponse) Reset() {
+	*x = SearchStaffResponse{}
+	mi := &file_proto_staff_service_staff_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchStaffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchStaffResponse) ProtoMessage() {}
+
+func (x *SearchStaffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_staff_service_staff_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchStaffResponse.ProtoReflect.Descriptor instead.
+func (*SearchStaffResponse) Descriptor() ([]byte, []int) {
+	return file_proto_staff_service_staff_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SearchStaffResponse) GetHits() []*StaffSearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *SearchStaffResponse) GetPaginationInfo() *core.PaginationInfo {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
+// Request for ListDeletedStaff
+type ListDeletedStaffRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *core.FilterOptions    `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedStaffRequest) Reset() {
+	*x = ListDeletedStaffRequest{}
+	mi := &file_proto_staff_service_staff_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedStaffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedStaffRequest) ProtoMessage() {}
+
+func (x *ListDeletedStaffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_staff_service_staff_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedStaffRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedStaffRequest) Descriptor() ([]byte, []int) {
+	return file_proto_staff_service_staff_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListDeletedStaffRequest) GetOptions() *core.FilterOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// Response for ListDeletedStaff
+type ListDeletedStaffResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	StaffMembers   []*Staff               `protobuf:"bytes,1,rep,name=staff_members,json=staffMembers,proto3" json:"staff_members,omitempty"`
+	PaginationInfo *core.PaginationInfo   `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListDeletedStaffResponse) Reset() {
+	*x = ListDeletedStaffResponse{}
+	mi := &file_proto_staff_service_staff_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedStaffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedStaffResponse) ProtoMessage() {}
+
+func (x *ListDeletedStaffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_staff_service_staff_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedStaffResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedStaffResponse) Descriptor() ([]byte, []int) {
+	return file_proto_staff_service_staff_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListDeletedStaffResponse) GetStaffMembers() []*Staff {
+	if x != nil {
+		return x.StaffMembers
+	}
+	return nil
+}
+
+func (x *ListDeletedStaffResponse) GetPaginationInfo() *core.PaginationInfo {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
+// Request for PurgeDeletedStaff
+type PurgeDeletedStaffRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OlderThanDays int32                  `protobuf:"varint,1,opt,name=older_than_days,json=olderThanDays,proto3" json:"older_than_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeletedStaffRequest) Reset() {
+	*x = PurgeDeletedStaffRequest{}
+	mi := &file_proto_staff_service_staff_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeletedStaffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeletedStaffRequest) ProtoMessage() {}
+
+func (x *PurgeDeletedStaffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_staff_service_staff_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeletedStaffRequest.ProtoReflect.Descriptor instead.
+func (*PurgeDeletedStaffRequest) Descriptor() ([]byte, []int) {
+	return file_proto_staff_service_staff_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *PurgeDeletedStaffRequest) GetOlderThanDays() int32 {
+	if x != nil {
+		return x.OlderThanDays
+	}
+	return 0
+}
+
+// Response for PurgeDeletedStaff
+type PurgeDeletedStaffResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PurgedCount   int64                  `protobuf:"varint,1,opt,name=purged_count,json=purgedCount,proto3" json:"purged_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeletedStaffResponse) Reset() {
+	*x = PurgeDeletedStaffResponse{}
+	mi := &file_proto_staff_service_staff_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeletedStaffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeletedStaffResponse) ProtoMessage() {}
+
+func (x *PurgeDeletedStaffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_staff_service_staff_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeletedStaffResponse.ProtoReflect.Descriptor instead.
+func (*PurgeDeletedStaffResponse) Descriptor() ([]byte, []int) {
+	return file_proto_staff_service_staff_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *PurgeDeletedStaffResponse) GetPurgedCount() int64 {
+	if x != nil {
+		return x.PurgedCount
+	}
+	return 0
+}
+
 type GetDoctorAvailabilityResponse_TimeSlot struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	StartTime     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
@@ -1989,7 +2613,7 @@ type GetDoctorAvailabilityResponse_TimeSlot struct {
 
 func (x *GetDoctorAvailabilityResponse_TimeSlot) Reset() {
 	*x = GetDoctorAvailabilityResponse_TimeSlot{}
-	mi := &file_proto_staff_service_staff_proto_msgTypes[35]
+	mi := &file_proto_staff_service_staff_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2001,7 +2625,7 @@ func (x *GetDoctorAvailabilityResponse_TimeSlot) String() string {
 func (*GetDoctorAvailabilityResponse_TimeSlot) ProtoMessage() {}
 
 func (x *GetDoctorAvailabilityResponse_TimeSlot) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_staff_service_staff_proto_msgTypes[35]
+	mi := &file_proto_staff_service_staff_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2035,7 +2659,7 @@ var File_proto_staff_service_staff_proto protoreflect.FileDescriptor
 
 const file_proto_staff_service_staff_proto_rawDesc = "" +
 	"\n" +
-	"\x1fproto/staff-service/staff.proto\x12\fstaffservice\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xc6\x02\n" +
+	"\x1fproto/staff-service/staff.proto\x12\fstaffservice\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x17proto/core/common.proto\x1a\x16proto/core/audit.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xc6\x02\n" +
 	"\x0eStaffRoleProto\x12J\n" +
 	"\x04name\x18\x01 \x01(\tB6\x92A32'Unique name for the role (Primary Key).J\b\"Doctor\"R\x04name\x12\x8b\x01\n" +
 	"\vdescription\x18\x02 \x01(\tBi\x92Af2!Optional description of the role.JA\"Medical doctor responsible for patient diagnosis and treatment.\"R\vdescription:Z\x92AW\n" +
@@ -2048,7 +2672,7 @@ const file_proto_staff_service_staff_proto_rawDesc = "" +
 	"\x0fTaskStatusProto\x12R\n" +
 	"\x04name\x18\x01 \x01(\tB>\x92A;2.Unique name for the task status (Primary Key).J\t\"Pending\"R\x04name\x12x\n" +
 	"\vdescription\x18\x02 \x01(\tBV\x92AS2(Optional description of the task status.J'\"Task is assigned but not yet started.\"R\vdescription:`\x92A]\n" +
-	"[*\vTask Status2ERepresents the status of an assigned task (e.g., Pending, Completed).\xd2\x01\x04name\"\x98\n" +
+	"[*\vTask Status2ERepresents the status of an assigned task (e.g., Pending, Completed).\xd2\x01\x04name\"\xe3\n" +
 	"\n" +
 	"\tTaskProto\x12o\n" +
 	"\x02id\x18\x01 \x01(\tB_\x92A\\2-Unique identifier for the task (UUID format).J+\"task-a1b2c3d4-e5f6-7890-1234-567890abcdef\"R\x02id\x12M\n" +
@@ -2062,7 +2686,9 @@ const file_proto_staff_service_staff_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampBV\x92AS29Timestamp when the task was created (RFC3339 UTC format).J\x16\"2023-03-30T11:00:00Z\"R\tcreatedAt\x12\x96\x01\n" +
 	"\n" +
-	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampB[\x92AX2>Timestamp when the task was last updated (RFC3339 UTC format).J\x16\"2023-03-30T11:00:00Z\"R\tupdatedAt:\xa5\x01\x92A\xa1\x01\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampB[\x92AX2>Timestamp when the task was last updated (RFC3339 UTC format).J\x16\"2023-03-30T11:00:00Z\"R\tupdatedAt\x12I\n" +
+	"\aversion\x18\n" +
+	" \x01(\x03B/\x92A,2'Optimistic locking version of the task.J\x011R\aversion:\xa5\x01\x92A\xa1\x01\n" +
 	"\x9e\x01*\x04Task2@Represents a task assigned to a staff member via their schedule.\xd2\x01\x02id\xd2\x01\x05title\xd2\x01\bpriority\xd2\x01\n" +
 	"start_time\xd2\x01\bend_time\xd2\x01\tstatus_id\xd2\x01\n" +
 	"created_at\xd2\x01\n" +
@@ -2070,7 +2696,7 @@ const file_proto_staff_service_staff_proto_rawDesc = "" +
 	"\x12ScheduleEntryProto\x12u\n" +
 	"\bstaff_id\x18\x01 \x01(\tBZ\x92AW2-Identifier of the staff member (UUID format).J&\"s1a2b3c4-e5f6-7890-1234-567890abcdef\"R\astaffId\x12_\n" +
 	"\x04task\x18\x02 \x01(\v2\x17.staffservice.TaskProtoB2\x92A/2-The task associated with this schedule entry.R\x04task:c\x92A`\n" +
-	"^*\x0eSchedule Entry2:Links a staff member to a specific task in their schedule.\xd2\x01\bstaff_id\xd2\x01\x04task\"\xbd\r\n" +
+	"^*\x0eSchedule Entry2:Links a staff member to a specific task in their schedule.\xd2\x01\bstaff_id\xd2\x01\x04task\"\x96\x10\n" +
 	"\x05Staff\x12r\n" +
 	"\x02id\x18\x01 \x01(\tBb\x92A_25Unique identifier for the staff member (UUID format).J&\"s1a2b3c4-e5f6-7890-1234-567890abcdef\"R\x02id\x12L\n" +
 	"\n" +
@@ -2089,11 +2715,15 @@ const file_proto_staff_service_staff_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampB^\x92A[2ATimestamp when the staff record was created (RFC3339 UTC format).J\x16\"2022-11-01T10:00:00Z\"R\tcreatedAt\x12\x9e\x01\n" +
 	"\n" +
-	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampBc\x92A`2FTimestamp when the staff record was last updated (RFC3339 UTC format).J\x16\"2023-03-15T16:00:00Z\"R\tupdatedAt:\xaa\x01\x92A\xa6\x01\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampBc\x92A`2FTimestamp when the staff record was last updated (RFC3339 UTC format).J\x16\"2023-03-15T16:00:00Z\"R\tupdatedAt\x12\x8c\x01\n" +
+	"\aversion\x18\x0e \x01(\x03Br\x92Ao2jOptimistic locking version of the staff record. Send it back on update to detect concurrent modifications.J\x014R\aversion\x12\xb8\x01\n" +
+	"\n" +
+	"deleted_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampBx\x92Au2[Timestamp when the staff record was soft-deleted (RFC3339 UTC format). Null if not deleted.J\x16\"2023-04-01T12:00:00Z\"H\x00R\tdeletedAt\x88\x01\x01:\xaa\x01\x92A\xa6\x01\n" +
 	"\xa3\x01*\x05Staff2#Represents a hospital staff member.\xd2\x01\x02id\xd2\x01\n" +
 	"first_name\xd2\x01\tlast_name\xd2\x01\rdate_of_birth\xd2\x01\fphone_number\xd2\x01\aaddress\xd2\x01\arole_id\xd2\x01\tstatus_id\xd2\x01\n" +
 	"created_at\xd2\x01\n" +
-	"updated_at\"\xb5\b\n" +
+	"updated_atB\r\n" +
+	"\v_deleted_at\"\xb5\b\n" +
 	"\x0fAddStaffRequest\x12K\n" +
 	"\n" +
 	"first_name\x18\x01 \x01(\tB,\x92A)2\x1aStaff member's first name.J\v\"Nurse Ben\"R\tfirstName\x12E\n" +
@@ -2116,7 +2746,7 @@ const file_proto_staff_service_staff_proto_rawDesc = "" +
 	"N*\x19Get Staff Details Request21Specifies the ID of the staff member to retrieve.\"\xbc\x01\n" +
 	"\x17GetStaffDetailsResponse\x12)\n" +
 	"\x05staff\x18\x01 \x01(\v2\x13.staffservice.StaffR\x05staff:v\x92As\n" +
-	"q*\x1aGet Staff Details Response2SContains the details of the requested staff member, including their schedule/tasks.\"\xbd\a\n" +
+	"q*\x1aGet Staff Details Response2SContains the details of the requested staff member, including their schedule/tasks.\"\xb8\v\n" +
 	"\x19UpdateStaffDetailsRequest\x12o\n" +
 	"\bstaff_id\x18\x01 \x01(\tBT\x92AQ2'The UUID of the staff member to update.J&\"s1a2b3c4-e5f6-7890-1234-567890abcdef\"R\astaffId\x12J\n" +
 	"\n" +
@@ -2128,17 +2758,25 @@ const file_proto_staff_service_staff_proto_rawDesc = "" +
 	"\aaddress\x18\x06 \x01(\tB:\x92A72\x17New address (optional).J\x1c\"102 Nurse Station, Medtown\"R\aaddress\x12q\n" +
 	"\x0especialization\x18\a \x01(\tBI\x92AF26Updated specialization (optional, mainly for Doctors).J\f\"Cardiology\"R\x0especialization\x12[\n" +
 	"\n" +
-	"nurse_type\x18\b \x01(\tB<\x92A921Updated nurse type (optional, mainly for Nurses).J\x04\"RN\"R\tnurseType:\x8e\x01\x92A\x8a\x01\n" +
-	"\x87\x01*\x1cUpdate Staff Details Request2\\Data for updating an existing staff member. Only include fields to change (PATCH semantics).\xd2\x01\bstaff_id\"\x99\x01\n" +
+	"nurse_type\x18\b \x01(\tB<\x92A921Updated nurse type (optional, mainly for Nurses).J\x04\"RN\"R\tnurseType\x12\xa8\x01\n" +
+	"\aversion\x18\t \x01(\x03B\x88\x01\x92A\x84\x012\x7fExpected current version of the staff record (optional). If the record was modified since, the update is rejected with ABORTED.J\x014H\x00R\aversion\x88\x01\x01\x12\x96\x02\n" +
+	"\vupdate_mask\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.FieldMaskB\xd8\x01\x92A\xd4\x012\xb3\x01Fields to update (optional). Masked fields are set even when empty, which clears them; fields outside the mask are left unchanged. Without a mask, empty fields are left unchanged.J\x1c\"specialization,phoneNumber\"R\n" +
+	"updateMask:\xb9\x01\x92A\xb5\x01\n" +
+	"\xb2\x01*\x1cUpdate Staff Details Request2\x86\x01Data for updating an existing staff member. Only include fields to change, or list the fields to set in update_mask (PATCH semantics).\xd2\x01\bstaff_idB\n" +
+	"\n" +
+	"\b_version\"\x99\x01\n" +
 	"\x1aUpdateStaffDetailsResponse\x12)\n" +
 	"\x05staff\x18\x01 \x01(\v2\x13.staffservice.StaffR\x05staff:P\x92AM\n" +
-	"K*\x1dUpdate Staff Details Response2*Contains the updated staff member details.\"\xb5\x02\n" +
+	"K*\x1dUpdate Staff Details Response2*Contains the updated staff member details.\"\x9d\x03\n" +
 	"\x10ListStaffRequest\x12U\n" +
 	"\arole_id\x18\x01 \x01(\tB<\x92A92-Optional: Filter by role ID (e.g., \"Doctor\").J\b\"Doctor\"R\x06roleId\x12[\n" +
-	"\tstatus_id\x18\x02 \x01(\tB>\x92A;2/Optional: Filter by status ID (e.g., \"Active\").J\b\"Active\"R\bstatusId:m\x92Aj\n" +
-	"h*\x12List Staff Request2RRequest to list all staff members (add pagination/filtering parameters if needed).\"\xa2\x01\n" +
+	"\tstatus_id\x18\x02 \x01(\tB>\x92A;2/Optional: Filter by status ID (e.g., \"Active\").J\b\"Active\"R\bstatusId\x12-\n" +
+	"\aoptions\x18\x03 \x01(\v2\x13.core.FilterOptionsR\aoptions:\xa5\x01\x92A\xa1\x01\n" +
+	"\x9e\x01*\x12List Staff Request2\x87\x01Filters, sorting and pagination for listing staff members. role_id and status_id take precedence over the same keys in options.filters.\"\xe1\x01\n" +
 	"\x11ListStaffResponse\x128\n" +
-	"\rstaff_members\x18\x01 \x03(\v2\x13.staffservice.StaffR\fstaffMembers:S\x92AP\n" +
+	"\rstaff_members\x18\x01 \x03(\v2\x13.staffservice.StaffR\fstaffMembers\x12=\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x14.core.PaginationInfoR\x0epaginationInfo:S\x92AP\n" +
 	"N*\x13List Staff Response27Contains a list of staff members matching the criteria.\"\xa0\x03\n" +
 	"\x1aUpdateStaffScheduleRequest\x12\x85\x01\n" +
 	"\bstaff_id\x18\x01 \x01(\tBj\x92Ag2=The UUID of the staff member whose schedule is being updated.J&\"s1a2b3c4-e5f6-7890-1234-567890abcdef\"R\astaffId\x12\x86\x01\n" +
@@ -2179,12 +2817,14 @@ const file_proto_staff_service_staff_proto_rawDesc = "" +
 	"[*\x16Track Workload Request2ASpecifies the ID of the staff member whose workload is requested.\"\xe0\x01\n" +
 	"\x15TrackWorkloadResponse\x12e\n" +
 	"\bworkload\x18\x01 \x03(\v2\x17.staffservice.TaskProtoB0\x92A-2+List of tasks assigned to the staff member.R\bworkload:`\x92A]\n" +
-	"[*\x17Track Workload Response2@Contains a list of tasks assigned to the specified staff member.\"\xdd\x01\n" +
+	"[*\x17Track Workload Response2@Contains a list of tasks assigned to the specified staff member.\"\xb8\x02\n" +
 	"\x10ListTasksRequest\x12b\n" +
-	"\tstatus_id\x18\x01 \x01(\tBE\x92AB25Optional: Filter by task status ID (e.g., \"Pending\").J\t\"Pending\"R\bstatusId:e\x92Ab\n" +
-	"`*\x12List Tasks Request2JRequest to list all tasks (add pagination/filtering parameters if needed).\"\x8f\x01\n" +
+	"\tstatus_id\x18\x01 \x01(\tBE\x92AB25Optional: Filter by task status ID (e.g., \"Pending\").J\t\"Pending\"R\bstatusId\x12-\n" +
+	"\aoptions\x18\x02 \x01(\v2\x13.core.FilterOptionsR\aoptions:\x90\x01\x92A\x8c\x01\n" +
+	"\x89\x01*\x12List Tasks Request2sFilters, sorting and pagination for listing tasks. status_id takes precedence over the same key in options.filters.\"\xce\x01\n" +
 	"\x11ListTasksResponse\x12-\n" +
-	"\x05tasks\x18\x01 \x03(\v2\x17.staffservice.TaskProtoR\x05tasks:K\x92AH\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x17.staffservice.TaskProtoR\x05tasks\x12=\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x14.core.PaginationInfoR\x0epaginationInfo:K\x92AH\n" +
 	"F*\x13List Tasks Response2/Contains a list of tasks matching the criteria.\"\xfe\x01\n" +
 	"\x13AddStaffRoleRequest\x12D\n" +
 	"\x04name\x18\x01 \x01(\tB0\x92A-2\x1dUnique name for the new role.J\f\"Pharmacist\"R\x04name\x12V\n" +
@@ -2221,7 +2861,53 @@ const file_proto_staff_service_staff_proto_rawDesc = "" +
 	"d*\x1aList Task Statuses Request2FRequest to list all available task statuses (no parameters currently).\"\xa1\x01\n" +
 	"\x18ListTaskStatusesResponse\x129\n" +
 	"\bstatuses\x18\x01 \x03(\v2\x1d.staffservice.TaskStatusProtoR\bstatuses:J\x92AG\n" +
-	"E*\x1bList Task Statuses Response2&A list of all available task statuses.2\xa2\x1c\n" +
+	"E*\x1bList Task Statuses Response2&A list of all available task statuses.\"\x8c\x03\n" +
+	"\x12DeleteStaffRequest\x12o\n" +
+	"\bstaff_id\x18\x01 \x01(\tBT\x92AQ2'The UUID of the staff member to delete.J&\"s1a2b3c4-e5f6-7890-1234-567890abcdef\"R\astaffId\x12\x8d\x01\n" +
+	"\vhard_delete\x18\x02 \x01(\bBl\x92Ai2YIf true, performs a permanent (hard) delete. If false or omitted, performs a soft delete.:\x05falseJ\x05falseR\n" +
+	"hardDelete:u\x92Ar\n" +
+	"p*\x14Delete Staff Request2XSpecifies the staff member to delete and whether it should be a permanent (hard) delete.\"\xdc\x01\n" +
+	"\x13RestoreStaffRequest\x12r\n" +
+	"\bstaff_id\x18\x01 \x01(\tBW\x92AT2*The UUID of the soft-deleted staff member.J&\"s1a2b3c4-e5f6-7890-1234-567890abcdef\"R\astaffId:Q\x92AN\n" +
+	"L*\x15Restore Staff Request23Specifies the soft-deleted staff member to restore.\"\x94\x01\n" +
+	"\x14RestoreStaffResponse\x12)\n" +
+	"\x05staff\x18\x01 \x01(\v2\x13.staffservice.StaffR\x05staff:Q\x92AN\n" +
+	"L*\x16Restore Staff Response22Contains the details of the restored staff member.\"\x8b\x02\n" +
+	"\x12ExportStaffRequest\x12-\n" +
+	"\aoptions\x18\x01 \x01(\v2\x13.core.FilterOptionsR\aoptions:\xc5\x01\x92A\xc1\x01\n" +
+	"\xbe\x01*\x14Export Staff Request2\xa5\x01Filters and sort order of the staff members to export. Every matching staff member is streamed; limit sets how many are read per page, offset and cursor are ignored.\"\xc7\x03\n" +
+	"\x12SearchStaffRequest\x12\xb2\x01\n" +
+	"\x05query\x18\x01 \x01(\tB\x9b\x01\x92A\x97\x012\x86\x01Words to search for. Each word matches as a prefix of a name, phone or address word; misspellings and partial phone numbers match too.J\f\"ben carter\"R\x05query\x12D\n" +
+	"\x05limit\x18\x02 \x01(\x05B.\x92A+2!Maximum number of hits to return.:\x0220J\x0220R\x05limit\x12:\n" +
+	"\x06offset\x18\x03 \x01(\x05B\"\x92A\x1f2\x17Number of hits to skip.:\x010J\x010R\x06offset:z\x92Aw\n" +
+	"u*\x14Search Staff Request2UFree-text query and pagination for searching staff members by name, phone or address.\xd2\x01\x05query\"\x9a\x04\n" +
+	"\x0eStaffSearchHit\x12)\n" +
+	"\x05staff\x18\x01 \x01(\v2\x13.staffservice.StaffR\x05staff\x12Y\n" +
+	"\x04rank\x18\x02 \x01(\x01BE\x92AB2:Relevance of the hit; hits are ordered by descending rank.J\x040.87R\x04rank\x12\xeb\x01\n" +
+	"\n" +
+	"highlights\x18\x03 \x03(\v2,.staffservice.StaffSearchHit.HighlightsEntryB\x9c\x01\x92A\x98\x012oMatched fields (first_name, last_name, phone_number, address) with the matching words wrapped in <mark></mark>.J%{\"first_name\": \"<mark>Alicia</mark>\"}R\n" +
+	"highlights\x1a=\n" +
+	"\x0fHighlightsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01:U\x92AR\n" +
+	"P*\x10Staff Search Hit2<A matching staff with its relevance and highlighted matches.\"\xd9\x01\n" +
+	"\x13SearchStaffResponse\x120\n" +
+	"\x04hits\x18\x01 \x03(\v2\x1c.staffservice.StaffSearchHitR\x04hits\x12=\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x14.core.PaginationInfoR\x0epaginationInfo:Q\x92AN\n" +
+	"L*\x15Search Staff Response23A ranked, paginated page of matching staff members.\"\xb7\x01\n" +
+	"\x17ListDeletedStaffRequest\x12-\n" +
+	"\aoptions\x18\x01 \x01(\v2\x13.core.FilterOptionsR\aoptions:m\x92Aj\n" +
+	"h*\x1aList Deleted Staff Request2JOptions for filtering, sorting, and paginating soft-deleted staff records.\"\xe8\x01\n" +
+	"\x18ListDeletedStaffResponse\x128\n" +
+	"\rstaff_members\x18\x01 \x03(\v2\x13.staffservice.StaffR\fstaffMembers\x12=\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x14.core.PaginationInfoR\x0epaginationInfo:S\x92AP\n" +
+	"N*\x1bList Deleted Staff Response2/A paginated list of soft-deleted staff records.\"\xb4\x02\n" +
+	"\x18PurgeDeletedStaffRequest\x12\x9d\x01\n" +
+	"\x0folder_than_days\x18\x01 \x01(\x05Bu\x92Ar2hPermanently remove records soft-deleted more than this many days ago. 0 purges all soft-deleted records.:\x0230J\x0230R\rolderThanDays:x\x92Au\n" +
+	"s*\x1bPurge Deleted Staff Request2TRetention window for soft-deleted staff records; older ones are permanently removed.\"\xc2\x01\n" +
+	"\x19PurgeDeletedStaffResponse\x12R\n" +
+	"\fpurged_count\x18\x01 \x01(\x03B/\x92A,2&Number of records permanently removed.J\x0212R\vpurgedCount:Q\x92AN\n" +
+	"L*\x1cPurge Deleted Staff Response2,Number of staff records permanently removed.2\xc6(\n" +
 	"\fStaffService\x12\xa7\x01\n" +
 	"\bAddStaff\x12\x1d.staffservice.AddStaffRequest\x1a\x1e.staffservice.AddStaffResponse\"\\\x92AA\n" +
 	"\x05Staff\x12\x10Add Staff Member\x1a&Adds a new staff member to the system.\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/api/v1/staff\x12\xf4\x01\n" +
@@ -2229,7 +2915,10 @@ const file_proto_staff_service_staff_proto_rawDesc = "" +
 	"\x05Staff\x12\x11Get Staff Details\x1aTRetrieves details for a specific staff member by their ID, including schedule/tasks.\x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/staff/{staff_id}\x12\xc4\x01\n" +
 	"\tListStaff\x12\x1e.staffservice.ListStaffRequest\x1a\x1f.staffservice.ListStaffResponse\"v\x92A^\n" +
 	"\x05Staff\x12\n" +
-	"List Staff\x1aIRetrieves a list of staff members, optionally filtered by role or status.\x82\xd3\xe4\x93\x02\x0f\x12\r/api/v1/staff\x12\xe4\x01\n" +
+	"List Staff\x1aIRetrieves a list of staff members, optionally filtered by role or status.\x82\xd3\xe4\x93\x02\x0f\x12\r/api/v1/staff\x12\x81\x02\n" +
+	"\vSearchStaff\x12 .staffservice.SearchStaffRequest\x1a!.staffservice.SearchStaffResponse\"\xac\x01\x92A\x8c\x01\n" +
+	"\x05Staff\x12\fSearch Staff\x1auFull-text and fuzzy search of staff members by name, phone or address. Results are ranked, paginated and highlighted.\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/staff:search\x12F\n" +
+	"\vExportStaff\x12 .staffservice.ExportStaffRequest\x1a\x13.staffservice.Staff0\x01\x12\xe4\x01\n" +
 	"\x12UpdateStaffDetails\x12'.staffservice.UpdateStaffDetailsRequest\x1a(.staffservice.UpdateStaffDetailsResponse\"{\x92AU\n" +
 	"\x05Staff\x12\x14Update Staff Details\x1a6Updates specific details for an existing staff member.\x82\xd3\xe4\x93\x02\x1d:\x01*2\x18/api/v1/staff/{staff_id}\x12\xe8\x01\n" +
 	"\x13UpdateStaffSchedule\x12(.staffservice.UpdateStaffScheduleRequest\x1a\x16.google.protobuf.Empty\"\x8e\x01\x92A_\n" +
@@ -2263,7 +2952,17 @@ const file_proto_staff_service_staff_proto_rawDesc = "" +
 	"\rTask Statuses\x12\x0fAdd Task Status\x1a%Creates a new task status definition.\x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/api/v1/task-statuses\x12\xe1\x01\n" +
 	"\x10ListTaskStatuses\x12%.staffservice.ListTaskStatusesRequest\x1a&.staffservice.ListTaskStatusesResponse\"~\x92A^\n" +
 	"\aLookups\n" +
-	"\rTask Statuses\x12\x12List Task Statuses\x1a0Retrieves a list of all available task statuses.\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/task-statuses\x1a0\x92A-\x12+Manage hospital staff, schedules, and tasksB\xcb\x01\x92A\x91\x01\x12g\n" +
+	"\rTask Statuses\x12\x12List Task Statuses\x1a0Retrieves a list of all available task statuses.\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/task-statuses\x12\x80\x02\n" +
+	"\vDeleteStaff\x12 .staffservice.DeleteStaffRequest\x1a\x16.google.protobuf.Empty\"\xb6\x01\x92A\x92\x01\n" +
+	"\x05Staff\x12\x18Delete Staff (Soft/Hard)\x1aoDeletes a staff member. Defaults to soft delete. Set 'hard_delete=true' query parameter for permanent deletion.\x82\xd3\xe4\x93\x02\x1a*\x18/api/v1/staff/{staff_id}\x12\xc2\x01\n" +
+	"\fRestoreStaff\x12!.staffservice.RestoreStaffRequest\x1a\".staffservice.RestoreStaffResponse\"k\x92A=\n" +
+	"\x05Staff\x12\rRestore Staff\x1a%Restores a soft-deleted staff member.\x82\xd3\xe4\x93\x02%:\x01*\" /api/v1/staff/{staff_id}:restore\x12\xd9\x01\n" +
+	"\x10ListDeletedStaff\x12%.staffservice.ListDeletedStaffRequest\x1a&.staffservice.ListDeletedStaffResponse\"v\x92AV\n" +
+	"\x05Staff\x12\x12List Deleted Staff\x1a9Retrieves a paginated list of soft-deleted staff records.\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/staff:deleted\x12\xfa\x01\n" +
+	"\x11PurgeDeletedStaff\x12&.staffservice.PurgeDeletedStaffRequest\x1a'.staffservice.PurgeDeletedStaffResponse\"\x93\x01\x92Ar\n" +
+	"\x05Staff\x12\x13Purge Deleted Staff\x1aTPermanently removes staff records soft-deleted more than 'older_than_days' days ago.\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/api/v1/staff:purge\x12\xb4\x02\n" +
+	"\x0fListAuditEvents\x12\x1c.core.ListAuditEventsRequest\x1a\x1d.core.ListAuditEventsResponse\"\xe3\x01\x92A\xbe\x01\n" +
+	"\x05Staff\x12\x11List Audit Events\x1a\xa1\x01Retrieves the recorded changes of staff members, tasks and lookup tables, newest first. Filter by 'entity_type' and 'entity_id' to get the history of one entity.\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/staff:auditEvents\x1a0\x92A-\x12+Manage hospital staff, schedules, and tasksB\xcb\x01\x92A\x91\x01\x12g\n" +
 	"\x11Staff Service API\x12MAPI for managing hospital staff, their roles, statuses, schedules, and tasks.2\x031.0*\x02\x01\x022\x10application/json:\x10application/jsonZ4golang-microservices-boilerplate/proto/staff-serviceb\x06proto3"
 
 var (
@@ -2278,7 +2977,7 @@ func file_proto_staff_service_staff_proto_rawDescGZIP() []byte {
 	return file_proto_staff_service_staff_proto_rawDescData
 }
 
-var file_proto_staff_service_staff_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
+var file_proto_staff_service_staff_proto_msgTypes = make([]protoimpl.MessageInfo, 48)
 var file_proto_staff_service_staff_proto_goTypes = []any{
 	(*StaffRoleProto)(nil),                         // 0: staffservice.StaffRoleProto
 	(*StaffStatusProto)(nil),                       // 1: staffservice.StaffStatusProto
@@ -2315,79 +3014,125 @@ var file_proto_staff_service_staff_proto_goTypes = []any{
 	(*AddTaskStatusResponse)(nil),                  // 32: staffservice.AddTaskStatusResponse
 	(*ListTaskStatusesRequest)(nil),                // 33: staffservice.ListTaskStatusesRequest
 	(*ListTaskStatusesResponse)(nil),               // 34: staffservice.ListTaskStatusesResponse
-	(*GetDoctorAvailabilityResponse_TimeSlot)(nil), // 35: staffservice.GetDoctorAvailabilityResponse.TimeSlot
-	(*timestamppb.Timestamp)(nil),                  // 36: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),                          // 37: google.protobuf.Empty
+	(*DeleteStaffRequest)(nil),                     // 35: staffservice.DeleteStaffRequest
+	(*RestoreStaffRequest)(nil),                    // 36: staffservice.RestoreStaffRequest
+	(*RestoreStaffResponse)(nil),                   // 37: staffservice.RestoreStaffResponse
+	(*ExportStaffRequest)(nil),                     // 38: staffservice.ExportStaffRequest
+	(*SearchStaffRequest)(nil),                     // 39: staffservice.SearchStaffRequest
+	(*StaffSearchHit)(nil),                         // 40: staffservice.StaffSearchHit
+	(*SearchStaffResponse)(nil),                    // 41: staffservice.SearchStaffResponse
+	(*ListDeletedStaffRequest)(nil),                // 42: staffservice.ListDeletedStaffRequest
+	(*ListDeletedStaffResponse)(nil),               // 43: staffservice.ListDeletedStaffResponse
+	(*PurgeDeletedStaffRequest)(nil),               // 44: staffservice.PurgeDeletedStaffRequest
+	(*PurgeDeletedStaffResponse)(nil),              // 45: staffservice.PurgeDeletedStaffResponse
+	(*GetDoctorAvailabilityResponse_TimeSlot)(nil), // 46: staffservice.GetDoctorAvailabilityResponse.TimeSlot
+	nil,                                  // 47: staffservice.StaffSearchHit.HighlightsEntry
+	(*timestamppb.Timestamp)(nil),        // 48: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),        // 49: google.protobuf.FieldMask
+	(*core.FilterOptions)(nil),           // 50: core.FilterOptions
+	(*core.PaginationInfo)(nil),          // 51: core.PaginationInfo
+	(*core.ListAuditEventsRequest)(nil),  // 52: core.ListAuditEventsRequest
+	(*emptypb.Empty)(nil),                // 53: google.protobuf.Empty
+	(*core.ListAuditEventsResponse)(nil), // 54: core.ListAuditEventsResponse
 }
 var file_proto_staff_service_staff_proto_depIdxs = []int32{
-	36, // 0: staffservice.TaskProto.start_time:type_name -> google.protobuf.Timestamp
-	36, // 1: staffservice.TaskProto.end_time:type_name -> google.protobuf.Timestamp
-	36, // 2: staffservice.TaskProto.created_at:type_name -> google.protobuf.Timestamp
-	36, // 3: staffservice.TaskProto.updated_at:type_name -> google.protobuf.Timestamp
+	48, // 0: staffservice.TaskProto.start_time:type_name -> google.protobuf.Timestamp
+	48, // 1: staffservice.TaskProto.end_time:type_name -> google.protobuf.Timestamp
+	48, // 2: staffservice.TaskProto.created_at:type_name -> google.protobuf.Timestamp
+	48, // 3: staffservice.TaskProto.updated_at:type_name -> google.protobuf.Timestamp
 	3,  // 4: staffservice.ScheduleEntryProto.task:type_name -> staffservice.TaskProto
-	36, // 5: staffservice.Staff.date_of_birth:type_name -> google.protobuf.Timestamp
+	48, // 5: staffservice.Staff.date_of_birth:type_name -> google.protobuf.Timestamp
 	4,  // 6: staffservice.Staff.schedule:type_name -> staffservice.ScheduleEntryProto
-	36, // 7: staffservice.Staff.created_at:type_name -> google.protobuf.Timestamp
-	36, // 8: staffservice.Staff.updated_at:type_name -> google.protobuf.Timestamp
-	36, // 9: staffservice.AddStaffRequest.date_of_birth:type_name -> google.protobuf.Timestamp
-	5,  // 10: staffservice.AddStaffResponse.staff:type_name -> staffservice.Staff
-	5,  // 11: staffservice.GetStaffDetailsResponse.staff:type_name -> staffservice.Staff
-	36, // 12: staffservice.UpdateStaffDetailsRequest.date_of_birth:type_name -> google.protobuf.Timestamp
-	5,  // 13: staffservice.UpdateStaffDetailsResponse.staff:type_name -> staffservice.Staff
-	5,  // 14: staffservice.ListStaffResponse.staff_members:type_name -> staffservice.Staff
-	3,  // 15: staffservice.UpdateStaffScheduleRequest.tasks_to_schedule:type_name -> staffservice.TaskProto
-	36, // 16: staffservice.GetDoctorAvailabilityRequest.start_time:type_name -> google.protobuf.Timestamp
-	36, // 17: staffservice.GetDoctorAvailabilityRequest.end_time:type_name -> google.protobuf.Timestamp
-	35, // 18: staffservice.GetDoctorAvailabilityResponse.available_slots:type_name -> staffservice.GetDoctorAvailabilityResponse.TimeSlot
-	36, // 19: staffservice.AssignTaskRequest.start_time:type_name -> google.protobuf.Timestamp
-	36, // 20: staffservice.AssignTaskRequest.end_time:type_name -> google.protobuf.Timestamp
-	3,  // 21: staffservice.TrackWorkloadResponse.workload:type_name -> staffservice.TaskProto
-	3,  // 22: staffservice.ListTasksResponse.tasks:type_name -> staffservice.TaskProto
-	0,  // 23: staffservice.AddStaffRoleResponse.role:type_name -> staffservice.StaffRoleProto
-	0,  // 24: staffservice.ListStaffRolesResponse.roles:type_name -> staffservice.StaffRoleProto
-	1,  // 25: staffservice.AddStaffStatusResponse.status:type_name -> staffservice.StaffStatusProto
-	1,  // 26: staffservice.ListStaffStatusesResponse.statuses:type_name -> staffservice.StaffStatusProto
-	2,  // 27: staffservice.AddTaskStatusResponse.status:type_name -> staffservice.TaskStatusProto
-	2,  // 28: staffservice.ListTaskStatusesResponse.statuses:type_name -> staffservice.TaskStatusProto
-	36, // 29: staffservice.GetDoctorAvailabilityResponse.TimeSlot.start_time:type_name -> google.protobuf.Timestamp
-	36, // 30: staffservice.GetDoctorAvailabilityResponse.TimeSlot.end_time:type_name -> google.protobuf.Timestamp
-	6,  // 31: staffservice.StaffService.AddStaff:input_type -> staffservice.AddStaffRequest
-	8,  // 32: staffservice.StaffService.GetStaffDetails:input_type -> staffservice.GetStaffDetailsRequest
-	12, // 33: staffservice.StaffService.ListStaff:input_type -> staffservice.ListStaffRequest
-	10, // 34: staffservice.StaffService.UpdateStaffDetails:input_type -> staffservice.UpdateStaffDetailsRequest
-	14, // 35: staffservice.StaffService.UpdateStaffSchedule:input_type -> staffservice.UpdateStaffScheduleRequest
-	15, // 36: staffservice.StaffService.SetStaffAvailability:input_type -> staffservice.SetStaffAvailabilityRequest
-	16, // 37: staffservice.StaffService.GetDoctorAvailability:input_type -> staffservice.GetDoctorAvailabilityRequest
-	18, // 38: staffservice.StaffService.AssignTask:input_type -> staffservice.AssignTaskRequest
-	19, // 39: staffservice.StaffService.TrackWorkload:input_type -> staffservice.TrackWorkloadRequest
-	21, // 40: staffservice.StaffService.ListTasks:input_type -> staffservice.ListTasksRequest
-	23, // 41: staffservice.StaffService.AddStaffRole:input_type -> staffservice.AddStaffRoleRequest
-	25, // 42: staffservice.StaffService.ListStaffRoles:input_type -> staffservice.ListStaffRolesRequest
-	27, // 43: staffservice.StaffService.AddStaffStatus:input_type -> staffservice.AddStaffStatusRequest
-	29, // 44: staffservice.StaffService.ListStaffStatuses:input_type -> staffservice.ListStaffStatusesRequest
-	31, // 45: staffservice.StaffService.AddTaskStatus:input_type -> staffservice.AddTaskStatusRequest
-	33, // 46: staffservice.StaffService.ListTaskStatuses:input_type -> staffservice.ListTaskStatusesRequest
-	7,  // 47: staffservice.StaffService.AddStaff:output_type -> staffservice.AddStaffResponse
-	9,  // 48: staffservice.StaffService.GetStaffDetails:output_type -> staffservice.GetStaffDetailsResponse
-	13, // 49: staffservice.StaffService.ListStaff:output_type -> staffservice.ListStaffResponse
-	11, // 50: staffservice.StaffService.UpdateStaffDetails:output_type -> staffservice.UpdateStaffDetailsResponse
-	37, // 51: staffservice.StaffService.UpdateStaffSchedule:output_type -> google.protobuf.Empty
-	37, // 52: staffservice.StaffService.SetStaffAvailability:output_type -> google.protobuf.Empty
-	17, // 53: staffservice.StaffService.GetDoctorAvailability:output_type -> staffservice.GetDoctorAvailabilityResponse
-	37, // 54: staffservice.StaffService.AssignTask:output_type -> google.protobuf.Empty
-	20, // 55: staffservice.StaffService.TrackWorkload:output_type -> staffservice.TrackWorkloadResponse
-	22, // 56: staffservice.StaffService.ListTasks:output_type -> staffservice.ListTasksResponse
-	24, // 57: staffservice.StaffService.AddStaffRole:output_type -> staffservice.AddStaffRoleResponse
-	26, // 58: staffservice.StaffService.ListStaffRoles:output_type -> staffservice.ListStaffRolesResponse
-	28, // 59: staffservice.StaffService.AddStaffStatus:output_type -> staffservice.AddStaffStatusResponse
-	30, // 60: staffservice.StaffService.ListStaffStatuses:output_type -> staffservice.ListStaffStatusesResponse
-	32, // 61: staffservice.StaffService.AddTaskStatus:output_type -> staffservice.AddTaskStatusResponse
-	34, // 62: staffservice.StaffService.ListTaskStatuses:output_type -> staffservice.ListTaskStatusesResponse
-	47, // [47:63] is the sub-list for method output_type
-	31, // [31:47] is the sub-list for method input_type
-	31, // [31:31] is the sub-list for extension type_name
-	31, // [31:31] is the sub-list for extension extendee
-	0,  // [0:31] is the sub-list for field type_name
+	48, // 7: staffservice.Staff.created_at:type_name -> google.protobuf.Timestamp
+	48, // 8: staffservice.Staff.updated_at:type_name -> google.protobuf.Timestamp
+	48, // 9: staffservice.Staff.deleted_at:type_name -> google.protobuf.Timestamp
+	48, // 10: staffservice.AddStaffRequest.date_of_birth:type_name -> google.protobuf.Timestamp
+	5,  // 11: staffservice.AddStaffResponse.staff:type_name -> staffservice.Staff
+	5,  // 12: staffservice.GetStaffDetailsResponse.staff:type_name -> staffservice.Staff
+	48, // 13: staffservice.UpdateStaffDetailsRequest.date_of_birth:type_name -> google.protobuf.Timestamp
+	49, // 14: staffservice.UpdateStaffDetailsRequest.update_mask:type_name -> google.protobuf.FieldMask
+	5,  // 15: staffservice.UpdateStaffDetailsResponse.staff:type_name -> staffservice.Staff
+	50, // 16: staffservice.ListStaffRequest.options:type_name -> core.FilterOptions
+	5,  // 17: staffservice.ListStaffResponse.staff_members:type_name -> staffservice.Staff
+	51, // 18: staffservice.ListStaffResponse.pagination_info:type_name -> core.PaginationInfo
+	3,  // 19: staffservice.UpdateStaffScheduleRequest.tasks_to_schedule:type_name -> staffservice.TaskProto
+	48, // 20: staffservice.GetDoctorAvailabilityRequest.start_time:type_name -> google.protobuf.Timestamp
+	48, // 21: staffservice.GetDoctorAvailabilityRequest.end_time:type_name -> google.protobuf.Timestamp
+	46, // 22: staffservice.GetDoctorAvailabilityResponse.available_slots:type_name -> staffservice.GetDoctorAvailabilityResponse.TimeSlot
+	48, // 23: staffservice.AssignTaskRequest.start_time:type_name -> google.protobuf.Timestamp
+	48, // 24: staffservice.AssignTaskRequest.end_time:type_name -> google.protobuf.Timestamp
+	3,  // 25: staffservice.TrackWorkloadResponse.workload:type_name -> staffservice.TaskProto
+	50, // 26: staffservice.ListTasksRequest.options:type_name -> core.FilterOptions
+	3,  // 27: staffservice.ListTasksResponse.tasks:type_name -> staffservice.TaskProto
+	51, // 28: staffservice.ListTasksResponse.pagination_info:type_name -> core.PaginationInfo
+	0,  // 29: staffservice.AddStaffRoleResponse.role:type_name -> staffservice.StaffRoleProto
+	0,  // 30: staffservice.ListStaffRolesResponse.roles:type_name -> staffservice.StaffRoleProto
+	1,  // 31: staffservice.AddStaffStatusResponse.status:type_name -> staffservice.StaffStatusProto
+	1,  // 32: staffservice.ListStaffStatusesResponse.statuses:type_name -> staffservice.StaffStatusProto
+	2,  // 33: staffservice.AddTaskStatusResponse.status:type_name -> staffservice.TaskStatusProto
+	2,  // 34: staffservice.ListTaskStatusesResponse.statuses:type_name -> staffservice.TaskStatusProto
+	5,  // 35: staffservice.RestoreStaffResponse.staff:type_name -> staffservice.Staff
+	50, // 36: staffservice.ExportStaffRequest.options:type_name -> core.FilterOptions
+	5,  // 37: staffservice.StaffSearchHit.staff:type_name -> staffservice.Staff
+	47, // 38: staffservice.StaffSearchHit.highlights:type_name -> staffservice.StaffSearchHit.HighlightsEntry
+	40, // 39: staffservice.SearchStaffResponse.hits:type_name -> staffservice.StaffSearchHit
+	51, // 40: staffservice.SearchStaffResponse.pagination_info:type_name -> core.PaginationInfo
+	50, // 41: staffservice.ListDeletedStaffRequest.options:type_name -> core.FilterOptions
+	5,  // 42: staffservice.ListDeletedStaffResponse.staff_members:type_name -> staffservice.Staff
+	51, // 43: staffservice.ListDeletedStaffResponse.pagination_info:type_name -> core.PaginationInfo
+	48, // 44: staffservice.GetDoctorAvailabilityResponse.TimeSlot.start_time:type_name -> google.protobuf.Timestamp
+	48, // 45: staffservice.GetDoctorAvailabilityResponse.TimeSlot.end_time:type_name -> google.protobuf.Timestamp
+	6,  // 46: staffservice.StaffService.AddStaff:input_type -> staffservice.AddStaffRequest
+	8,  // 47: staffservice.StaffService.GetStaffDetails:input_type -> staffservice.GetStaffDetailsRequest
+	12, // 48: staffservice.StaffService.ListStaff:input_type -> staffservice.ListStaffRequest
+	39, // 49: staffservice.StaffService.SearchStaff:input_type -> staffservice.SearchStaffRequest
+	38, // 50: staffservice.StaffService.ExportStaff:input_type -> staffservice.ExportStaffRequest
+	10, // 51: staffservice.StaffService.UpdateStaffDetails:input_type -> staffservice.UpdateStaffDetailsRequest
+	14, // 52: staffservice.StaffService.UpdateStaffSchedule:input_type -> staffservice.UpdateStaffScheduleRequest
+	15, // 53: staffservice.StaffService.SetStaffAvailability:input_type -> staffservice.SetStaffAvailabilityRequest
+	16, // 54: staffservice.StaffService.GetDoctorAvailability:input_type -> staffservice.GetDoctorAvailabilityRequest
+	18, // 55: staffservice.StaffService.AssignTask:input_type -> staffservice.AssignTaskRequest
+	19, // 56: staffservice.StaffService.TrackWorkload:input_type -> staffservice.TrackWorkloadRequest
+	21, // 57: staffservice.StaffService.ListTasks:input_type -> staffservice.ListTasksRequest
+	23, // 58: staffservice.StaffService.AddStaffRole:input_type -> staffservice.AddStaffRoleRequest
+	25, // 59: staffservice.StaffService.ListStaffRoles:input_type -> staffservice.ListStaffRolesRequest
+	27, // 60: staffservice.StaffService.AddStaffStatus:input_type -> staffservice.AddStaffStatusRequest
+	29, // 61: staffservice.StaffService.ListStaffStatuses:input_type -> staffservice.ListStaffStatusesRequest
+	31, // 62: staffservice.StaffService.AddTaskStatus:input_type -> staffservice.AddTaskStatusRequest
+	33, // 63: staffservice.StaffService.ListTaskStatuses:input_type -> staffservice.ListTaskStatusesRequest
+	35, // 64: staffservice.StaffService.DeleteStaff:input_type -> staffservice.DeleteStaffRequest
+	36, // 65: staffservice.StaffService.RestoreStaff:input_type -> staffservice.RestoreStaffRequest
+	42, // 66: staffservice.StaffService.ListDeletedStaff:input_type -> staffservice.ListDeletedStaffRequest
+	44, // 67: staffservice.StaffService.PurgeDeletedStaff:input_type -> staffservice.PurgeDeletedStaffRequest
+	52, // 68: staffservice.StaffService.ListAuditEvents:input_type -> core.ListAuditEventsRequest
+	7,  // 69: staffservice.StaffService.AddStaff:output_type -> staffservice.AddStaffResponse
+	9,  // 70: staffservice.StaffService.GetStaffDetails:output_type -> staffservice.GetStaffDetailsResponse
+	13, // 71: staffservice.StaffService.ListStaff:output_type -> staffservice.ListStaffResponse
+	41, // 72: staffservice.StaffService.SearchStaff:output_type -> staffservice.SearchStaffResponse
+	5,  // 73: staffservice.StaffService.ExportStaff:output_type -> staffservice.Staff
+	11, // 74: staffservice.StaffService.UpdateStaffDetails:output_type -> staffservice.UpdateStaffDetailsResponse
+	53, // 75: staffservice.StaffService.UpdateStaffSchedule:output_type -> google.protobuf.Empty
+	53, // 76: staffservice.StaffService.SetStaffAvailability:output_type -> google.protobuf.Empty
+	17, // 77: staffservice.StaffService.GetDoctorAvailability:output_type -> staffservice.GetDoctorAvailabilityResponse
+	53, // 78: staffservice.StaffService.AssignTask:output_type -> google.protobuf.Empty
+	20, // 79: staffservice.StaffService.TrackWorkload:output_type -> staffservice.TrackWorkloadResponse
+	22, // 80: staffservice.StaffService.ListTasks:output_type -> staffservice.ListTasksResponse
+	24, // 81: staffservice.StaffService.AddStaffRole:output_type -> staffservice.AddStaffRoleResponse
+	26, // 82: staffservice.StaffService.ListStaffRoles:output_type -> staffservice.ListStaffRolesResponse
+	28, // 83: staffservice.StaffService.AddStaffStatus:output_type -> staffservice.AddStaffStatusResponse
+	30, // 84: staffservice.StaffService.ListStaffStatuses:output_type -> staffservice.ListStaffStatusesResponse
+	32, // 85: staffservice.StaffService.AddTaskStatus:output_type -> staffservice.AddTaskStatusResponse
+	34, // 86: staffservice.StaffService.ListTaskStatuses:output_type -> staffservice.ListTaskStatusesResponse
+	53, // 87: staffservice.StaffService.DeleteStaff:output_type -> google.protobuf.Empty
+	37, // 88: staffservice.StaffService.RestoreStaff:output_type -> staffservice.RestoreStaffResponse
+	43, // 89: staffservice.StaffService.ListDeletedStaff:output_type -> staffservice.ListDeletedStaffResponse
+	45, // 90: staffservice.StaffService.PurgeDeletedStaff:output_type -> staffservice.PurgeDeletedStaffResponse
+	54, // 91: staffservice.StaffService.ListAuditEvents:output_type -> core.ListAuditEventsResponse
+	69, // [69:92] is the sub-list for method output_type
+	46, // [46:69] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
 }
 
 func init() { file_proto_staff_service_staff_proto_init() }
@@ -2395,13 +3140,15 @@ func file_proto_staff_service_staff_proto_init() {
 	if File_proto_staff_service_staff_proto != nil {
 		return
 	}
+	file_proto_staff_service_staff_proto_msgTypes[5].OneofWrappers = []any{}
+	file_proto_staff_service_staff_proto_msgTypes[10].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_staff_service_staff_proto_rawDesc), len(file_proto_staff_service_staff_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   36,
+			NumMessages:   48,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -8,6 +8,7 @@ package staff_service
 
 import (
 	context "context"
+	core "golang-microservices-boilerplate/proto/core"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -23,6 +24,8 @@ const (
 	StaffService_AddStaff_FullMethodName              = "/staffservice.StaffService/AddStaff"
 	StaffService_GetStaffDetails_FullMethodName       = "/staffservice.StaffService/GetStaffDetails"
 	StaffService_ListStaff_FullMethodName             = "/staffservice.StaffService/ListStaff"
+	StaffService_SearchStaff_FullMethodName           = "/staffservice.StaffService/SearchStaff"
+	StaffService_ExportStaff_FullMethodName           = "/staffservice.StaffService/ExportStaff"
 	StaffService_UpdateStaffDetails_FullMethodName    = "/staffservice.StaffService/UpdateStaffDetails"
 	StaffService_UpdateStaffSchedule_FullMethodName   = "/staffservice.StaffService/UpdateStaffSchedule"
 	StaffService_SetStaffAvailability_FullMethodName  = "/staffservice.StaffService/SetStaffAvailability"
@@ -36,6 +39,11 @@ const (
 	StaffService_ListStaffStatuses_FullMethodName     = "/staffservice.StaffService/ListStaffStatuses"
 	StaffService_AddTaskStatus_FullMethodName         = "/staffservice.StaffService/AddTaskStatus"
 	StaffService_ListTaskStatuses_FullMethodName      = "/staffservice.StaffService/ListTaskStatuses"
+	StaffService_DeleteStaff_FullMethodName           = "/staffservice.StaffService/DeleteStaff"
+	StaffService_RestoreStaff_FullMethodName          = "/staffservice.StaffService/RestoreStaff"
+	StaffService_ListDeletedStaff_FullMethodName      = "/staffservice.StaffService/ListDeletedStaff"
+	StaffService_PurgeDeletedStaff_FullMethodName     = "/staffservice.StaffService/PurgeDeletedStaff"
+	StaffService_ListAuditEvents_FullMethodName       = "/staffservice.StaffService/ListAuditEvents"
 )
 
 // StaffServiceClient is the client API for StaffService service.
@@ -46,6 +54,10 @@ type StaffServiceClient interface {
 	AddStaff(ctx context.Context, in *AddStaffRequest, opts ...grpc.CallOption) (*AddStaffResponse, error)
 	GetStaffDetails(ctx context.Context, in *GetStaffDetailsRequest, opts ...grpc.CallOption) (*GetStaffDetailsResponse, error)
 	ListStaff(ctx context.Context, in *ListStaffRequest, opts ...grpc.CallOption) (*ListStaffResponse, error)
+	SearchStaff(ctx context.Context, in *SearchStaffRequest, opts ...grpc.CallOption) (*SearchStaffResponse, error)
+	// Streams every matching staff member. Not mapped by grpc-gateway: the API
+	// gateway serves it as an NDJSON or CSV download at GET /api/v1/staff:export.
+	ExportStaff(ctx context.Context, in *ExportStaffRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Staff], error)
 	UpdateStaffDetails(ctx context.Context, in *UpdateStaffDetailsRequest, opts ...grpc.CallOption) (*UpdateStaffDetailsResponse, error)
 	// Restored APIs (Implementation needs careful review based on new entities)
 	UpdateStaffSchedule(ctx context.Context, in *UpdateStaffScheduleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
@@ -62,6 +74,13 @@ type StaffServiceClient interface {
 	ListStaffStatuses(ctx context.Context, in *ListStaffStatusesRequest, opts ...grpc.CallOption) (*ListStaffStatusesResponse, error)
 	AddTaskStatus(ctx context.Context, in *AddTaskStatusRequest, opts ...grpc.CallOption) (*AddTaskStatusResponse, error)
 	ListTaskStatuses(ctx context.Context, in *ListTaskStatusesRequest, opts ...grpc.CallOption) (*ListTaskStatusesResponse, error)
+	// --- Soft-delete Lifecycle ---
+	DeleteStaff(ctx context.Context, in *DeleteStaffRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreStaff(ctx context.Context, in *RestoreStaffRequest, opts ...grpc.CallOption) (*RestoreStaffResponse, error)
+	ListDeletedStaff(ctx context.Context, in *ListDeletedStaffRequest, opts ...grpc.CallOption) (*ListDeletedStaffResponse, error)
+	PurgeDeletedStaff(ctx context.Context, in *PurgeDeletedStaffRequest, opts ...grpc.CallOption) (*PurgeDeletedStaffResponse, error)
+	// --- Audit Trail ---
+	ListAuditEvents(ctx context.Context, in *core.ListAuditEventsRequest, opts ...grpc.CallOption) (*core.ListAuditEventsResponse, error)
 }
 
 type staffServiceClient struct {
@@ -102,6 +121,35 @@ func (c *staffServiceClient) ListStaff(ctx context.Context, in *ListStaffRequest
 	return out, nil
 }
 
+func (c *staffServiceClient) SearchStaff(ctx context.Context, in *SearchStaffRequest, opts ...grpc.CallOption) (*SearchStaffResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchStaffResponse)
+	err := c.cc.Invoke(ctx, StaffService_SearchStaff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *staffServiceClient) ExportStaff(ctx context.Context, in *ExportStaffRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Staff], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &StaffService_ServiceDesc.Streams[0], StaffService_ExportStaff_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportStaffRequest, Staff]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StaffService_ExportStaffClient = grpc.ServerStreamingClient[Staff]
+
 func (c *staffServiceClient) UpdateStaffDetails(ctx context.Context, in *UpdateStaffDetailsRequest, opts ...grpc.CallOption) (*UpdateStaffDetailsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateStaffDetailsResponse)
@@ -232,6 +280,56 @@ func (c *staffServiceClient) ListTaskStatuses(ctx context.Context, in *ListTaskS
 	return out, nil
 }
 
+func (c *staffServiceClient) DeleteStaff(ctx context.Context, in *DeleteStaffRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, StaffService_DeleteStaff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *staffServiceClient) RestoreStaff(ctx context.Context, in *RestoreStaffRequest, opts ...grpc.CallOption) (*RestoreStaffResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreStaffResponse)
+	err := c.cc.Invoke(ctx, StaffService_RestoreStaff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *staffServiceClient) ListDeletedStaff(ctx context.Context, in *ListDeletedStaffRequest, opts ...grpc.CallOption) (*ListDeletedStaffResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeletedStaffResponse)
+	err := c.cc.Invoke(ctx, StaffService_ListDeletedStaff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *staffServiceClient) PurgeDeletedStaff(ctx context.Context, in *PurgeDeletedStaffRequest, opts ...grpc.CallOption) (*PurgeDeletedStaffResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeDeletedStaffResponse)
+	err := c.cc.Invoke(ctx, StaffService_PurgeDeletedStaff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *staffServiceClient) ListAuditEvents(ctx context.Context, in *core.ListAuditEventsRequest, opts ...grpc.CallOption) (*core.ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(core.ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, StaffService_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StaffServiceServer is the server API for StaffService service.
 // All implementations must embed UnimplementedStaffServiceServer
 // for forward compatibility.
@@ -240,6 +338,10 @@ type StaffServiceServer interface {
 	AddStaff(context.Context, *AddStaffRequest) (*AddStaffResponse, error)
 	GetStaffDetails(context.Context, *GetStaffDetailsRequest) (*GetStaffDetailsResponse, error)
 	ListStaff(context.Context, *ListStaffRequest) (*ListStaffResponse, error)
+	SearchStaff(context.Context, *SearchStaffRequest) (*SearchStaffResponse, error)
+	// Streams every matching staff member. Not mapped by grpc-gateway: the API
+	// gateway serves it as an NDJSON or CSV download at GET /api/v1/staff:export.
+	ExportStaff(*ExportStaffRequest, grpc.ServerStreamingServer[Staff]) error
 	UpdateStaffDetails(context.Context, *UpdateStaffDetailsRequest) (*UpdateStaffDetailsResponse, error)
 	// Restored APIs (Implementation needs careful review based on new entities)
 	UpdateStaffSchedule(context.Context, *UpdateStaffScheduleRequest) (*emptypb.Empty, error)
@@ -256,6 +358,13 @@ type StaffServiceServer interface {
 	ListStaffStatuses(context.Context, *ListStaffStatusesRequest) (*ListStaffStatusesResponse, error)
 	AddTaskStatus(context.Context, *AddTaskStatusRequest) (*AddTaskStatusResponse, error)
 	ListTaskStatuses(context.Context, *ListTaskStatusesRequest) (*ListTaskStatusesResponse, error)
+	// --- Soft-delete Lifecycle ---
+	DeleteStaff(context.Context, *DeleteStaffRequest) (*emptypb.Empty, error)
+	RestoreStaff(context.Context, *RestoreStaffRequest) (*RestoreStaffResponse, error)
+	ListDeletedStaff(context.Context, *ListDeletedStaffRequest) (*ListDeletedStaffResponse, error)
+	PurgeDeletedStaff(context.Context, *PurgeDeletedStaffRequest) (*PurgeDeletedStaffResponse, error)
+	// --- Audit Trail ---
+	ListAuditEvents(context.Context, *core.ListAuditEventsRequest) (*core.ListAuditEventsResponse, error)
 	mustEmbedUnimplementedStaffServiceServer()
 }
 
@@ -275,6 +384,12 @@ func (UnimplementedStaffServiceServer) GetStaffDetails(context.Context, *GetStaf
 func (UnimplementedStaffServiceServer) ListStaff(context.Context, *ListStaffRequest) (*ListStaffResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListStaff not implemented")
 }
+func (UnimplementedStaffServiceServer) SearchStaff(context.Context, *SearchStaffRequest) (*SearchStaffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchStaff not implemented")
+}
+func (UnimplementedStaffServiceServer) ExportStaff(*ExportStaffRequest, grpc.ServerStreamingServer[Staff]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportStaff not implemented")
+}
 func (UnimplementedStaffServiceServer) UpdateStaffDetails(context.Context, *UpdateStaffDetailsRequest) (*UpdateStaffDetailsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateStaffDetails not implemented")
 }
@@ -314,6 +429,21 @@ func (UnimplementedStaffServiceServer) AddTaskStatus(context.Context, *AddTaskSt
 func (UnimplementedStaffServiceServer) ListTaskStatuses(context.Context, *ListTaskStatusesRequest) (*ListTaskStatusesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTaskStatuses not implemented")
 }
+func (UnimplementedStaffServiceServer) DeleteStaff(context.Context, *DeleteStaffRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteStaff not implemented")
+}
+func (UnimplementedStaffServiceServer) RestoreStaff(context.Context, *RestoreStaffRequest) (*RestoreStaffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreStaff not implemented")
+}
+func (UnimplementedStaffServiceServer) ListDeletedStaff(context.Context, *ListDeletedStaffRequest) (*ListDeletedStaffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedStaff not implemented")
+}
+func (UnimplementedStaffServiceServer) PurgeDeletedStaff(context.Context, *PurgeDeletedStaffRequest) (*PurgeDeletedStaffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeDeletedStaff not implemented")
+}
+func (UnimplementedStaffServiceServer) ListAuditEvents(context.Context, *core.ListAuditEventsRequest) (*core.ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
 func (UnimplementedStaffServiceServer) mustEmbedUnimplementedStaffServiceServer() {}
 func (UnimplementedStaffServiceServer) testEmbeddedByValue()                      {}
 
@@ -389,6 +519,35 @@ func _StaffService_ListStaff_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StaffService_SearchStaff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchStaffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).SearchStaff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_SearchStaff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).SearchStaff(ctx, req.(*SearchStaffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StaffService_ExportStaff_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportStaffRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StaffServiceServer).ExportStaff(m, &grpc.GenericServerStream[ExportStaffRequest, Staff]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type StaffService_ExportStaffServer = grpc.ServerStreamingServer[Staff]
+
 func _StaffService_UpdateStaffDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateStaffDetailsRequest)
 	if err := dec(in); err != nil {
@@ -623,6 +782,96 @@ func _StaffService_ListTaskStatuses_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StaffService_DeleteStaff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteStaffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).DeleteStaff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_DeleteStaff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).DeleteStaff(ctx, req.(*DeleteStaffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StaffService_RestoreStaff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreStaffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).RestoreStaff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_RestoreStaff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).RestoreStaff(ctx, req.(*RestoreStaffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StaffService_ListDeletedStaff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedStaffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).ListDeletedStaff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_ListDeletedStaff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).ListDeletedStaff(ctx, req.(*ListDeletedStaffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StaffService_PurgeDeletedStaff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeDeletedStaffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).PurgeDeletedStaff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_PurgeDeletedStaff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).PurgeDeletedStaff(ctx, req.(*PurgeDeletedStaffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StaffService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(core.ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StaffServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StaffService_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StaffServiceServer).ListAuditEvents(ctx, req.(*core.ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StaffService_ServiceDesc is the grpc.ServiceDesc for StaffService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -642,6 +891,10 @@ var StaffService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListStaff",
 			Handler:    _StaffService_ListStaff_Handler,
 		},
+		{
+			MethodName: "SearchStaff",
+			Handler:    _StaffService_SearchStaff_Handler,
+		},
 		{
 			MethodName: "UpdateStaffDetails",
 			Handler:    _StaffService_UpdateStaffDetails_Handler,
@@ -694,7 +947,33 @@ var StaffService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListTaskStatuses",
 			Handler:    _StaffService_ListTaskStatuses_Handler,
 		},
+		{
+			MethodName: "DeleteStaff",
+			Handler:    _StaffService_DeleteStaff_Handler,
+		},
+		{
+			MethodName: "RestoreStaff",
+			Handler:    _StaffService_RestoreStaff_Handler,
+		},
+		{
+			MethodName: "ListDeletedStaff",
+			Handler:    _StaffService_ListDeletedStaff_Handler,
+		},
+		{
+			MethodName: "PurgeDeletedStaff",
+			Handler:    _StaffService_PurgeDeletedStaff_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _StaffService_ListAuditEvents_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportStaff",
+			Handler:       _StaffService_ExportStaff_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/staff-service/staff.proto",
 }
@@ -13,6 +13,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	_ "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
@@ -47,6 +48,7 @@ type User struct {
 	Address       string                 `protobuf:"bytes,13,opt,name=address,proto3" json:"address,omitempty"`
 	Age           int32                  `protobuf:"varint,14,opt,name=age,proto3" json:"age,omitempty"`
 	ProfilePic    string                 `protobuf:"bytes,15,opt,name=profile_pic,json=profilePic,proto3" json:"profile_pic,omitempty"`
+	Version       int64                  `protobuf:"varint,16,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -186,6 +188,13 @@ func (x *User) GetProfilePic() string {
 	return ""
 }
 
+func (x *User) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 // Request for creating a single user
 type CreateUserRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -564,6 +573,8 @@ type UpdateUserRequest struct {
 	Address       *wrapperspb.StringValue `protobuf:"bytes,9,opt,name=address,proto3,oneof" json:"address,omitempty"`
 	Age           *wrapperspb.Int32Value  `protobuf:"bytes,10,opt,name=age,proto3,oneof" json:"age,omitempty"`
 	ProfilePic    *wrapperspb.StringValue `protobuf:"bytes,11,opt,name=profile_pic,json=profilePic,proto3,oneof" json:"profile_pic,omitempty"`
+	Version       *int64                  `protobuf:"varint,12,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask  `protobuf:"bytes,13,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -675,6 +686,20 @@ func (x *UpdateUserRequest) GetProfilePic() *wrapperspb.StringValue {
 	return nil
 }
 
+func (x *UpdateUserRequest) GetVersion() int64 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+func (x *UpdateUserRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 // Response for updating a user
 type UpdateUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -874,10 +899,12 @@ func (x *FindUsersWithFilterResponse) GetPaginationInfo() *core.PaginationInfo {
 
 // Request for creating multiple users
 type CreateUsersRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Users         []*CreateUserRequest   `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Users []*CreateUserRequest   `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	// Process items independently instead of all-or-nothing
+	PartialSuccess bool `protobuf:"varint,2,opt,name=partial_success,json=partialSuccess,proto3" json:"partial_success,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CreateUsersRequest) Reset() {
@@ -917,10 +944,19 @@ func (x *CreateUsersRequest) GetUsers() []*CreateUserRequest {
 	return nil
 }
 
+func (x *CreateUsersRequest) GetPartialSuccess() bool {
+	if x != nil {
+		return x.PartialSuccess
+	}
+	return false
+}
+
 // Response for creating multiple users
 type CreateUsersResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"` // Example defined in User message
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Users []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"` // Example defined in User message
+	// One result per requested item, in request order
+	Results       []*core.BulkItemResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -962,6 +998,13 @@ func (x *CreateUsersResponse) GetUsers() []*User {
 	return nil
 }
 
+func (x *CreateUsersResponse) GetResults() []*core.BulkItemResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
 // Defines a single item for the bulk update request
 type UpdateUserItem struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -977,6 +1020,8 @@ type UpdateUserItem struct {
 	Address       *wrapperspb.StringValue `protobuf:"bytes,9,opt,name=address,proto3,oneof" json:"address,omitempty"`                          // Corrected escaping
 	Age           *wrapperspb.Int32Value  `protobuf:"bytes,10,opt,name=age,proto3,oneof" json:"age,omitempty"`                                 // Corrected escaping (number doesn't need quotes)
 	ProfilePic    *wrapperspb.StringValue `protobuf:"bytes,11,opt,name=profile_pic,json=profilePic,proto3,oneof" json:"profile_pic,omitempty"` // Corrected escaping
+	Version       *int64                  `protobuf:"varint,12,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask  `protobuf:"bytes,13,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1088,13 +1133,29 @@ func (x *UpdateUserItem) GetProfilePic() *wrapperspb.StringValue {
 	return nil
 }
 
+func (x *UpdateUserItem) GetVersion() int64 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+func (x *UpdateUserItem) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 // Request for updating multiple users based on a list of items
 type UpdateUsersRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Replace filter and individual fields with a list of items
-	Items         []*UpdateUserItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Items []*UpdateUserItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// Process items independently instead of all-or-nothing
+	PartialSuccess bool `protobuf:"varint,2,opt,name=partial_success,json=partialSuccess,proto3" json:"partial_success,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *UpdateUsersRequest) Reset() {
@@ -1134,9 +1195,18 @@ func (x *UpdateUsersRequest) GetItems() []*UpdateUserItem {
 	return nil
 }
 
-// Response for updating multiple users (now empty)
+func (x *UpdateUsersRequest) GetPartialSuccess() bool {
+	if x != nil {
+		return x.PartialSuccess
+	}
+	return false
+}
+
+// Response for updating multiple users
 type UpdateUsersResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One result per requested item, in request order
+	Results       []*core.BulkItemResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1171,15 +1241,24 @@ func (*UpdateUsersResponse) Descriptor() ([]byte, []int) {
 	return file_proto_user_service_user_proto_rawDescGZIP(), []int{16}
 }
 
+func (x *UpdateUsersResponse) GetResults() []*core.BulkItemResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
 // Request for deleting multiple users by IDs (soft or hard delete)
 type DeleteUsersRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Remove filter, add IDs
 	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
 	// Add hard_delete flag
-	HardDelete    bool `protobuf:"varint,2,opt,name=hard_delete,json=hardDelete,proto3" json:"hard_delete,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	HardDelete bool `protobuf:"varint,2,opt,name=hard_delete,json=hardDelete,proto3" json:"hard_delete,omitempty"`
+	// Process items independently instead of all-or-nothing
+	PartialSuccess bool `protobuf:"varint,3,opt,name=partial_success,json=partialSuccess,proto3" json:"partial_success,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *DeleteUsersRequest) Reset() {
@@ -1226,9 +1305,18 @@ func (x *DeleteUsersRequest) GetHardDelete() bool {
 	return false
 }
 
-// Response for deleting multiple users (now empty)
+func (x *DeleteUsersRequest) GetPartialSuccess() bool {
+	if x != nil {
+		return x.PartialSuccess
+	}
+	return false
+}
+
+// Response for deleting multiple users
 type DeleteUsersResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One result per requested item, in request order
+	Results       []*core.BulkItemResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1263,6 +1351,291 @@ func (*DeleteUsersResponse) Descriptor() ([]byte, []int) {
 	return file_proto_user_service_user_proto_rawDescGZIP(), []int{18}
 }
 
+func (x *DeleteUsersResponse) GetResults() []*core.BulkItemResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// Request for restoring a soft-deleted user
+type RestoreUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreUserRequest) Reset() {
+	*x = RestoreUserRequest{}
+	mi := &file_proto_user_service_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreUserRequest) ProtoMessage() {}
+
+func (x *RestoreUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreUserRequest.ProtoReflect.Descriptor instead.
+func (*RestoreUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RestoreUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Response for restoring a soft-deleted user
+type RestoreUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"` // Example defined in User message
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreUserResponse) Reset() {
+	*x = RestoreUserResponse{}
+	mi := &file_proto_user_service_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreUserResponse) ProtoMessage() {}
+
+func (x *RestoreUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreUserResponse.ProtoReflect.Descriptor instead.
+func (*RestoreUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RestoreUserResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+// Request for listing soft-deleted users
+type ListDeletedUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       *core.FilterOptions    `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedUsersRequest) Reset() {
+	*x = ListDeletedUsersRequest{}
+	mi := &file_proto_user_service_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedUsersRequest) ProtoMessage() {}
+
+func (x *ListDeletedUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedUsersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListDeletedUsersRequest) GetOptions() *core.FilterOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// Response for listing soft-deleted users
+type ListDeletedUsersResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Users          []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"` // Example defined in User message
+	PaginationInfo *core.PaginationInfo   `protobuf:"bytes,2,opt,name=pagination_info,json=paginationInfo,proto3" json:"pagination_info,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListDeletedUsersResponse) Reset() {
+	*x = ListDeletedUsersResponse{}
+	mi := &file_proto_user_service_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedUsersResponse) ProtoMessage() {}
+
+func (x *ListDeletedUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedUsersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListDeletedUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListDeletedUsersResponse) GetPaginationInfo() *core.PaginationInfo {
+	if x != nil {
+		return x.PaginationInfo
+	}
+	return nil
+}
+
+// Request for permanently removing users soft-deleted before the retention window
+type PurgeDeletedUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OlderThanDays int32                  `protobuf:"varint,1,opt,name=older_than_days,json=olderThanDays,proto3" json:"older_than_days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeletedUsersRequest) Reset() {
+	*x = PurgeDeletedUsersRequest{}
+	mi := &file_proto_user_service_user_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeletedUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeletedUsersRequest) ProtoMessage() {}
+
+func (x *PurgeDeletedUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_user_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeletedUsersRequest.ProtoReflect.Descriptor instead.
+func (*PurgeDeletedUsersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_user_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PurgeDeletedUsersRequest) GetOlderThanDays() int32 {
+	if x != nil {
+		return x.OlderThanDays
+	}
+	return 0
+}
+
+// Response for purging soft-deleted users
+type PurgeDeletedUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PurgedCount   int64                  `protobuf:"varint,1,opt,name=purged_count,json=purgedCount,proto3" json:"purged_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeletedUsersResponse) Reset() {
+	*x = PurgeDeletedUsersResponse{}
+	mi := &file_proto_user_service_user_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeletedUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeletedUsersResponse) ProtoMessage() {}
+
+func (x *PurgeDeletedUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_user_service_user_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeletedUsersResponse.ProtoReflect.Descriptor instead.
+func (*PurgeDeletedUsersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_user_service_user_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PurgeDeletedUsersResponse) GetPurgedCount() int64 {
+	if x != nil {
+		return x.PurgedCount
+	}
+	return 0
+}
+
 // Request for user login
 type LoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1274,7 +1647,7 @@ type LoginRequest struct {
 
 func (x *LoginRequest) Reset() {
 	*x = LoginRequest{}
-	mi := &file_proto_user_service_user_proto_msgTypes[19]
+	mi := &file_proto_user_service_user_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1286,7 +1659,7 @@ func (x *LoginRequest) String() string {
 func (*LoginRequest) ProtoMessage() {}
 
 func (x *LoginRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_service_user_proto_msgTypes[19]
+	mi := &file_proto_user_service_user_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1299,7 +1672,7 @@ func (x *LoginRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
 func (*LoginRequest) Descriptor() ([]byte, []int) {
-	return file_proto_user_service_user_proto_rawDescGZIP(), []int{19}
+	return file_proto_user_service_user_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *LoginRequest) GetEmail() string {
@@ -1329,7 +1702,7 @@ type LoginResponse struct {
 
 func (x *LoginResponse) Reset() {
 	*x = LoginResponse{}
-	mi := &file_proto_user_service_user_proto_msgTypes[20]
+	mi := &file_proto_user_service_user_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1341,7 +1714,7 @@ func (x *LoginResponse) String() string {
 func (*LoginResponse) ProtoMessage() {}
 
 func (x *LoginResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_service_user_proto_msgTypes[20]
+	mi := &file_proto_user_service_user_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1354,7 +1727,7 @@ func (x *LoginResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
 func (*LoginResponse) Descriptor() ([]byte, []int) {
-	return file_proto_user_service_user_proto_rawDescGZIP(), []int{20}
+	return file_proto_user_service_user_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *LoginResponse) GetUser() *User {
@@ -1395,7 +1768,7 @@ type RefreshRequest struct {
 
 func (x *RefreshRequest) Reset() {
 	*x = RefreshRequest{}
-	mi := &file_proto_user_service_user_proto_msgTypes[21]
+	mi := &file_proto_user_service_user_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1407,7 +1780,7 @@ func (x *RefreshRequest) String() string {
 func (*RefreshRequest) ProtoMessage() {}
 
 func (x *RefreshRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_service_user_proto_msgTypes[21]
+	mi := &file_proto_user_service_user_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1420,7 +1793,7 @@ func (x *RefreshRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshRequest.ProtoReflect.Descriptor instead.
 func (*RefreshRequest) Descriptor() ([]byte, []int) {
-	return file_proto_user_service_user_proto_rawDescGZIP(), []int{21}
+	return file_proto_user_service_user_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *RefreshRequest) GetRefreshToken() string {
@@ -1442,7 +1815,7 @@ type RefreshResponse struct {
 
 func (x *RefreshResponse) Reset() {
 	*x = RefreshResponse{}
-	mi := &file_proto_user_service_user_proto_msgTypes[22]
+	mi := &file_proto_user_service_user_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1454,7 +1827,7 @@ func (x *RefreshResponse) String() string {
 func (*RefreshResponse) ProtoMessage() {}
 
 func (x *RefreshResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_user_service_user_proto_msgTypes[22]
+	mi := &file_proto_user_service_user_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1467,7 +1840,7 @@ func (x *RefreshResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshResponse.ProtoReflect.Descriptor instead.
 func (*RefreshResponse) Descriptor() ([]byte, []int) {
-	return file_proto_user_service_user_proto_rawDescGZIP(), []int{22}
+	return file_proto_user_service_user_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *RefreshResponse) GetAccessToken() string {
@@ -1495,7 +1868,7 @@ var File_proto_user_service_user_proto protoreflect.FileDescriptor
 
 const file_proto_user_service_user_proto_rawDesc = "" +
 	"\n" +
-	"\x1dproto/user-service/user.proto\x12\vuserservice\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a\x17proto/core/common.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xda\r\n" +
+	"\x1dproto/user-service/user.proto\x12\vuserservice\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1egoogle/protobuf/wrappers.proto\x1a google/protobuf/field_mask.proto\x1a\x17proto/core/common.proto\x1a\x16proto/core/audit.proto\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xd4\x0e\n" +
 	"\x04User\x12j\n" +
 	"\x02id\x18\x01 \x01(\tBZ\x92AW2-Unique identifier for the user (UUID format).J&\"a1b2c3d4-e5f6-7890-1234-567890abcdef\"R\x02id\x12\x91\x01\n" +
 	"\n" +
@@ -1517,7 +1890,8 @@ const file_proto_user_service_user_proto_rawDesc = "" +
 	"\aaddress\x18\r \x01(\tB7\x92A42\x1aUser's address (optional).J\x16\"123 Main St, Anytown\"R\aaddress\x121\n" +
 	"\x03age\x18\x0e \x01(\x05B\x1f\x92A\x1c2\x16User's age (optional).J\x0230R\x03age\x12\x7f\n" +
 	"\vprofile_pic\x18\x0f \x01(\tB^\x92A[2-URL to the user's profile picture (optional).J*\"https://example.com/profiles/johndoe.jpg\"R\n" +
-	"profilePic:\x8d\x01\x92A\x89\x01\n" +
+	"profilePic\x12x\n" +
+	"\aversion\x18\x10 \x01(\x03B^\x92A[2VOptimistic locking version. Send it back on update to detect concurrent modifications.J\x013R\aversion:\x8d\x01\x92A\x89\x01\n" +
 	"\x86\x01*\x04User2 Represents a user in the system.\xd2\x01\x02id\xd2\x01\n" +
 	"created_at\xd2\x01\n" +
 	"updated_at\xd2\x01\busername\xd2\x01\x05email\xd2\x01\n" +
@@ -1563,8 +1937,7 @@ const file_proto_user_service_user_proto_rawDesc = "" +
 	"\x11ListUsersResponse\x12'\n" +
 	"\x05users\x18\x01 \x03(\v2\x11.userservice.UserR\x05users\x12=\n" +
 	"\x0fpagination_info\x18\x02 \x01(\v2\x14.core.PaginationInfoR\x0epaginationInfo:L\x92AI\n" +
-	"G*\x13List Users Response20A paginated list of users matching the criteria.\"\xc6\n" +
-	"\n" +
+	"G*\x13List Users Response20A paginated list of users matching the criteria.\"\x92\x0e\n" +
 	"\x11UpdateUserRequest\x12\\\n" +
 	"\x02id\x18\x01 \x01(\tBL\x92AI2\x1fThe UUID of the user to update.J&\"a1b2c3d4-e5f6-7890-1234-567890abcdef\"R\x02id\x12c\n" +
 	"\busername\x18\x02 \x01(\v2\x1c.google.protobuf.StringValueB$\x92A!2\rNew username.J\x10\"johndoeupdated\"H\x00R\busername\x88\x01\x01\x12p\n" +
@@ -1580,8 +1953,12 @@ const file_proto_user_service_user_proto_rawDesc = "" +
 	"\x03age\x18\n" +
 	" \x01(\v2\x1b.google.protobuf.Int32ValueB\x11\x92A\x0e2\bNew age.J\x0231H\bR\x03age\x88\x01\x01\x12\x90\x01\n" +
 	"\vprofile_pic\x18\v \x01(\v2\x1c.google.protobuf.StringValueBL\x92AI2\x18New profile picture URL.J-\"https://example.com/profiles/johndoe_v2.jpg\"H\tR\n" +
-	"profilePic\x88\x01\x01:k\x92Ah\n" +
-	"f*\x13Update User Request2JData for updating an existing user. Include only the fields to be changed.\xd2\x01\x02idB\v\n" +
+	"profilePic\x88\x01\x01\x12\x99\x01\n" +
+	"\aversion\x18\f \x01(\x03Bz\x92Aw2rExpected current version of the user. If set and the user was modified since, the update is rejected with ABORTED.J\x013H\n" +
+	"R\aversion\x88\x01\x01\x12\xf4\x01\n" +
+	"\vupdate_mask\x18\r \x01(\v2\x1a.google.protobuf.FieldMaskB\xb6\x01\x92A\xb2\x012\x9e\x01Fields to update (optional). A masked field that is absent from the request is cleared. Without a mask, exactly the fields present in the request are updated.J\x0f\"phone,address\"R\n" +
+	"updateMask:\x97\x01\x92A\x93\x01\n" +
+	"\x90\x01*\x13Update User Request2tData for updating an existing user. Include only the fields to be changed, or list the fields to set in update_mask.\xd2\x01\x02idB\v\n" +
 	"\t_usernameB\b\n" +
 	"\x06_emailB\r\n" +
 	"\v_first_nameB\f\n" +
@@ -1594,7 +1971,9 @@ const file_proto_user_service_user_proto_rawDesc = "" +
 	"\n" +
 	"\b_addressB\x06\n" +
 	"\x04_ageB\x0e\n" +
-	"\f_profile_pic\"|\n" +
+	"\f_profile_picB\n" +
+	"\n" +
+	"\b_version\"|\n" +
 	"\x12UpdateUserResponse\x12%\n" +
 	"\x04user\x18\x01 \x01(\v2\x11.userservice.UserR\x04user:?\x92A<\n" +
 	":*\x14Update User Response2\"Contains the updated user details.\"\xf9\x02\n" +
@@ -1609,14 +1988,15 @@ const file_proto_user_service_user_proto_rawDesc = "" +
 	"\x1bFindUsersWithFilterResponse\x12'\n" +
 	"\x05users\x18\x01 \x03(\v2\x11.userservice.UserR\x05users\x12=\n" +
 	"\x0fpagination_info\x18\x02 \x01(\v2\x14.core.PaginationInfoR\x0epaginationInfo:h\x92Ae\n" +
-	"c*\x1fFind Users With Filter Response2@A paginated list of users matching the advanced search criteria.\"\xa4\x01\n" +
+	"c*\x1fFind Users With Filter Response2@A paginated list of users matching the advanced search criteria.\"\x97\x03\n" +
 	"\x12CreateUsersRequest\x124\n" +
-	"\x05users\x18\x01 \x03(\v2\x1e.userservice.CreateUserRequestR\x05users:X\x92AU\n" +
-	"S*\x1bCreate Users Request (Bulk)24A list of user creation requests for bulk insertion.\"\x9e\x01\n" +
+	"\x05users\x18\x01 \x03(\v2\x1e.userservice.CreateUserRequestR\x05users\x12\xf0\x01\n" +
+	"\x0fpartial_success\x18\x02 \x01(\bB\xc6\x01\x92A\xc2\x012\xb1\x01If true, every item is created on its own and failures are reported per item in results instead of failing the whole request. If false or omitted, the request is all-or-nothing.:\x05falseJ\x05falseR\x0epartialSuccess:X\x92AU\n" +
+	"S*\x1bCreate Users Request (Bulk)24A list of user creation requests for bulk insertion.\"\xd5\x01\n" +
 	"\x13CreateUsersResponse\x12'\n" +
-	"\x05users\x18\x01 \x03(\v2\x11.userservice.UserR\x05users:^\x92A[\n" +
-	"Y*\x1cCreate Users Response (Bulk)29A list containing the details of the newly created users.\"\xcb\n" +
-	"\n" +
+	"\x05users\x18\x01 \x03(\v2\x11.userservice.UserR\x05users\x12.\n" +
+	"\aresults\x18\x02 \x03(\v2\x14.core.BulkItemResultR\aresults:e\x92Ab\n" +
+	"`*\x1cCreate Users Response (Bulk)2@The newly created users and the outcome of every requested item.\"\xe9\r\n" +
 	"\x0eUpdateUserItem\x12\\\n" +
 	"\x02id\x18\x01 \x01(\tBL\x92AI2\x1fThe UUID of the user to update.J&\"a1b2c3d4-e5f6-7890-1234-567890abcdef\"R\x02id\x12d\n" +
 	"\busername\x18\x02 \x01(\v2\x1c.google.protobuf.StringValueB%\x92A\"2\rNew username.J\x11\"updatedusername\"H\x00R\busername\x88\x01\x01\x12m\n" +
@@ -1631,7 +2011,11 @@ const file_proto_user_service_user_proto_rawDesc = "" +
 	"\x03age\x18\n" +
 	" \x01(\v2\x1b.google.protobuf.Int32ValueB\x11\x92A\x0e2\bNew age.J\x0240H\bR\x03age\x88\x01\x01\x12\x8d\x01\n" +
 	"\vprofile_pic\x18\v \x01(\v2\x1c.google.protobuf.StringValueBI\x92AF2\x18New profile picture URL.J*\"https://example.com/profiles/updated.jpg\"H\tR\n" +
-	"profilePic\x88\x01\x01:n\x92Ak\n" +
+	"profilePic\x88\x01\x01\x12\x9e\x01\n" +
+	"\aversion\x18\f \x01(\x03B\x7f\x92A|2wExpected current version of the user. If set and the user was modified since, the bulk update is rejected with ABORTED.J\x013H\n" +
+	"R\aversion\x88\x01\x01\x12\xee\x01\n" +
+	"\vupdate_mask\x18\r \x01(\v2\x1a.google.protobuf.FieldMaskB\xb0\x01\x92A\xac\x012\x98\x01Fields to update (optional). A masked field that is absent from the item is cleared. Without a mask, exactly the fields present in the item are updated.J\x0f\"phone,address\"R\n" +
+	"updateMask:n\x92Ak\n" +
 	"i*\x10Update User Item2PSpecifies the ID and the fields to update for a single user in a bulk operation.\xd2\x01\x02idB\v\n" +
 	"\t_usernameB\b\n" +
 	"\x06_emailB\r\n" +
@@ -1645,19 +2029,44 @@ const file_proto_user_service_user_proto_rawDesc = "" +
 	"\n" +
 	"\b_addressB\x06\n" +
 	"\x04_ageB\x0e\n" +
-	"\f_profile_pic\"\x89\x02\n" +
+	"\f_profile_picB\n" +
+	"\n" +
+	"\b_version\"\xfc\x03\n" +
 	"\x12UpdateUsersRequest\x12\x84\x01\n" +
-	"\x05items\x18\x01 \x03(\v2\x1b.userservice.UpdateUserItemBQ\x92AN2LList of user updates. Each item must contain an ID and the fields to modify.R\x05items:l\x92Ai\n" +
-	"g*\x1bUpdate Users Request (Bulk)2HA list of users to update, each specifying an ID and the data to change.\"|\n" +
-	"\x13UpdateUsersResponse:e\x92Ab\n" +
-	"`*\x1cUpdate Users Response (Bulk)2@Indicates success of the bulk update operation (empty response).\"\xa9\x03\n" +
+	"\x05items\x18\x01 \x03(\v2\x1b.userservice.UpdateUserItemBQ\x92AN2LList of user updates. Each item must contain an ID and the fields to modify.R\x05items\x12\xf0\x01\n" +
+	"\x0fpartial_success\x18\x02 \x01(\bB\xc6\x01\x92A\xc2\x012\xb1\x01If true, every item is updated on its own and failures are reported per item in results instead of failing the whole request. If false or omitted, the request is all-or-nothing.:\x05falseJ\x05falseR\x0epartialSuccess:l\x92Ai\n" +
+	"g*\x1bUpdate Users Request (Bulk)2HA list of users to update, each specifying an ID and the data to change.\"\x92\x01\n" +
+	"\x13UpdateUsersResponse\x12.\n" +
+	"\aresults\x18\x01 \x03(\v2\x14.core.BulkItemResultR\aresults:K\x92AH\n" +
+	"F*\x1cUpdate Users Response (Bulk)2&The outcome of every requested update.\"\x9c\x05\n" +
 	"\x12DeleteUsersRequest\x12\x86\x01\n" +
 	"\x03ids\x18\x01 \x03(\tBt\x92Aq2\x1dList of user UUIDs to delete.JP[\"a1b2c3d4-e5f6-7890-1234-567890abcdef\", \"b2c3d4e5-f6a7-8901-2345-67890abcdef0\"]R\x03ids\x12\x8d\x01\n" +
 	"\vhard_delete\x18\x02 \x01(\bBl\x92Ai2YIf true, performs a permanent (hard) delete. If false or omitted, performs a soft delete.:\x05falseJ\x05falseR\n" +
-	"hardDelete:z\x92Aw\n" +
-	"u*\x1bDelete Users Request (Bulk)2PA list of user IDs to delete and whether it should be a permanent (hard) delete.\xd2\x01\x03ids\"|\n" +
-	"\x13DeleteUsersResponse:e\x92Ab\n" +
-	"`*\x1cDelete Users Response (Bulk)2@Indicates success of the bulk delete operation (empty response).\"\xfd\x01\n" +
+	"hardDelete\x12\xf0\x01\n" +
+	"\x0fpartial_success\x18\x03 \x01(\bB\xc6\x01\x92A\xc2\x012\xb1\x01If true, every item is deleted on its own and failures are reported per item in results instead of failing the whole request. If false or omitted, the request is all-or-nothing.:\x05falseJ\x05falseR\x0epartialSuccess:z\x92Aw\n" +
+	"u*\x1bDelete Users Request (Bulk)2PA list of user IDs to delete and whether it should be a permanent (hard) delete.\xd2\x01\x03ids\"\x92\x01\n" +
+	"\x13DeleteUsersResponse\x12.\n" +
+	"\aresults\x18\x01 \x03(\v2\x14.core.BulkItemResultR\aresults:K\x92AH\n" +
+	"F*\x1cDelete Users Response (Bulk)2&The outcome of every requested delete.\"\xd4\x01\n" +
+	"\x12RestoreUserRequest\x12j\n" +
+	"\x02id\x18\x01 \x01(\tBZ\x92AW2-The UUID of the soft-deleted user to restore.J&\"a1b2c3d4-e5f6-7890-1234-567890abcdef\"R\x02id:R\x92AO\n" +
+	"M*\x14Restore User Request25Specifies the ID of the soft-deleted user to restore.\"\x86\x01\n" +
+	"\x13RestoreUserResponse\x12%\n" +
+	"\x04user\x18\x01 \x01(\v2\x11.userservice.UserR\x04user:H\x92AE\n" +
+	"C*\x15Restore User Response2*Contains the details of the restored user.\"\xbb\x01\n" +
+	"\x17ListDeletedUsersRequest\x12-\n" +
+	"\aoptions\x18\x01 \x01(\v2\x13.core.FilterOptionsR\aoptions:q\x92An\n" +
+	"l*\x1aList Deleted Users Request2NOptions for filtering, sorting, and paginating the list of soft-deleted users.\"\xcf\x01\n" +
+	"\x18ListDeletedUsersResponse\x12'\n" +
+	"\x05users\x18\x01 \x03(\v2\x11.userservice.UserR\x05users\x12=\n" +
+	"\x0fpagination_info\x18\x02 \x01(\v2\x14.core.PaginationInfoR\x0epaginationInfo:K\x92AH\n" +
+	"F*\x1bList Deleted Users Response2'A paginated list of soft-deleted users.\"\xa8\x02\n" +
+	"\x18PurgeDeletedUsersRequest\x12\x99\x01\n" +
+	"\x0folder_than_days\x18\x01 \x01(\x05Bq\x92An2dPermanently remove users soft-deleted more than this many days ago. 0 purges all soft-deleted users.:\x0230J\x0230R\rolderThanDays:p\x92Am\n" +
+	"k*\x1bPurge Deleted Users Request2LRetention window for soft-deleted users; older ones are permanently removed.\"\xb8\x01\n" +
+	"\x19PurgeDeletedUsersResponse\x12P\n" +
+	"\fpurged_count\x18\x01 \x01(\x03B-\x92A*2$Number of users permanently removed.J\x0212R\vpurgedCount:I\x92AF\n" +
+	"D*\x1cPurge Deleted Users Response2$Number of users permanently removed.\"\xfd\x01\n" +
 	"\fLoginRequest\x12H\n" +
 	"\x05email\x18\x01 \x01(\tB2\x92A/2\x15User's email address.J\x16\"john.doe@example.com\"R\x05email\x12K\n" +
 	"\bpassword\x18\x02 \x01(\tB/\x92A,2\x10User's password.J\r\"password123\"\xa2\x02\bpasswordR\bpassword:V\x92AS\n" +
@@ -1679,7 +2088,7 @@ const file_proto_user_service_user_proto_rawDesc = "" +
 	"\n" +
 	"expires_at\x18\x03 \x01(\x03BL\x92AI2;Unix timestamp (seconds) when the new access token expires.J\n" +
 	"1678889400R\texpiresAt:\\\x92AY\n" +
-	"W*\x10Refresh Response2CContains a new access token and potentially the same refresh token.2\xe9\x12\n" +
+	"W*\x10Refresh Response2CContains a new access token and potentially the same refresh token.2\xb6\x1c\n" +
 	"\vUserService\x12\x97\x01\n" +
 	"\x06Create\x12\x1e.userservice.CreateUserRequest\x1a\x1f.userservice.CreateUserResponse\"L\x92A1\n" +
 	"\x05Users\x12\vCreate User\x1a\x1bCreates a new user account.\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/api/v1/users\x12\xb5\x01\n" +
@@ -1693,16 +2102,24 @@ const file_proto_user_service_user_proto_rawDesc = "" +
 	"\x06Delete\x12\x1e.userservice.DeleteUserRequest\x1a\x16.google.protobuf.Empty\"\xa7\x01\x92A\x89\x01\n" +
 	"\x05Users\x12\x17Delete User (Soft/Hard)\x1agDeletes a user. Defaults to soft delete. Set 'hard_delete=true' query parameter for permanent deletion.\x82\xd3\xe4\x93\x02\x14*\x12/api/v1/users/{id}\x12\x82\x02\n" +
 	"\x0eFindWithFilter\x12'.userservice.FindUsersWithFilterRequest\x1a(.userservice.FindUsersWithFilterResponse\"\x9c\x01\x92Az\n" +
-	"\x05Users\x12\x16Find Users with Filter\x1aYPerforms an advanced search for users using complex filters provided in the request body.\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/api/v1/users/search\x12\xda\x01\n" +
+	"\x05Users\x12\x16Find Users with Filter\x1aYPerforms an advanced search for users using complex filters provided in the request body.\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/api/v1/users/search\x12\xd0\x02\n" +
 	"\n" +
-	"CreateMany\x12\x1f.userservice.CreateUsersRequest\x1a .userservice.CreateUsersResponse\"\x88\x01\x92Aa\n" +
-	"\fUsers (Bulk)\x12\x1cCreate Multiple Users (Bulk)\x1a3Creates multiple user accounts in a single request.\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/v1/users/bulk/create\x12\xe9\x01\n" +
+	"CreateMany\x12\x1f.userservice.CreateUsersRequest\x1a .userservice.CreateUsersResponse\"\xfe\x01\x92A\xd6\x01\n" +
+	"\fUsers (Bulk)\x12\x1cCreate Multiple Users (Bulk)\x1a\xa7\x01Creates multiple user accounts in a single request. By default all users are created or none; set 'partial_success' to create the valid ones and get a result per item.\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/v1/users/bulk/create\x12\xea\x02\n" +
 	"\n" +
-	"UpdateMany\x12\x1f.userservice.UpdateUsersRequest\x1a\x16.google.protobuf.Empty\"\xa1\x01\x92Az\n" +
-	"\fUsers (Bulk)\x12\x1cUpdate Multiple Users (Bulk)\x1aLUpdates multiple users based on a list of IDs and corresponding update data.\x82\xd3\xe4\x93\x02\x1e:\x01*2\x19/api/v1/users/bulk/update\x12\xa3\x02\n" +
+	"UpdateMany\x12\x1f.userservice.UpdateUsersRequest\x1a .userservice.UpdateUsersResponse\"\x98\x02\x92A\xf0\x01\n" +
+	"\fUsers (Bulk)\x12\x1cUpdate Multiple Users (Bulk)\x1a\xc1\x01Updates multiple users based on a list of IDs and corresponding update data. By default all updates are applied or none; set 'partial_success' to apply the valid ones and get a result per item.\x82\xd3\xe4\x93\x02\x1e:\x01*2\x19/api/v1/users/bulk/update\x12\xfb\x02\n" +
 	"\n" +
-	"DeleteMany\x12\x1f.userservice.DeleteUsersRequest\x1a\x16.google.protobuf.Empty\"\xdb\x01\x92A\xb3\x01\n" +
-	"\fUsers (Bulk)\x12'Delete Multiple Users (Bulk, Soft/Hard)\x1azDeletes multiple users by ID. Defaults to soft delete. Set 'hard_delete' field in the request body for permanent deletion.\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/v1/users/bulk/delete\x12\xb5\x01\n" +
+	"DeleteMany\x12\x1f.userservice.DeleteUsersRequest\x1a .userservice.DeleteUsersResponse\"\xa9\x02\x92A\x81\x02\n" +
+	"\fUsers (Bulk)\x12'Delete Multiple Users (Bulk, Soft/Hard)\x1a\xc7\x01Deletes multiple users by ID. Defaults to soft delete. Set 'hard_delete' field in the request body for permanent deletion, and 'partial_success' to delete the existing ones and get a result per item.\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/v1/users/bulk/delete\x12\xaa\x01\n" +
+	"\aRestore\x12\x1f.userservice.RestoreUserRequest\x1a .userservice.RestoreUserResponse\"\\\x92A4\n" +
+	"\x05Users\x12\fRestore User\x1a\x1dRestores a soft-deleted user.\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/api/v1/users/{id}:restore\x12\xca\x01\n" +
+	"\vListDeleted\x12$.userservice.ListDeletedUsersRequest\x1a%.userservice.ListDeletedUsersResponse\"n\x92AN\n" +
+	"\x05Users\x12\x12List Deleted Users\x1a1Retrieves a paginated list of soft-deleted users.\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/users:deleted\x12\xeb\x01\n" +
+	"\fPurgeDeleted\x12%.userservice.PurgeDeletedUsersRequest\x1a&.userservice.PurgeDeletedUsersResponse\"\x8b\x01\x92Aj\n" +
+	"\x05Users\x12\x13Purge Deleted Users\x1aLPermanently removes users soft-deleted more than 'older_than_days' days ago.\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/api/v1/users:purge\x12\x93\x02\n" +
+	"\x0fListAuditEvents\x12\x1c.core.ListAuditEventsRequest\x1a\x1d.core.ListAuditEventsResponse\"\xc2\x01\x92A\x9d\x01\n" +
+	"\x05Users\x12\x11List Audit Events\x1a\x80\x01Retrieves the recorded changes of users, newest first. Filter by 'entity_type' and 'entity_id' to get the history of one entity.\x82\xd3\xe4\x93\x02\x1b\x12\x19/api/v1/users:auditEvents\x12\xb5\x01\n" +
 	"\x05Login\x12\x19.userservice.LoginRequest\x1a\x1a.userservice.LoginResponse\"u\x92AU\n" +
 	"\x0eAuthentication\x12\n" +
 	"User Login\x1a7Authenticates a user and returns access/refresh tokens.\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/api/v1/auth/login\x12\xc0\x01\n" +
@@ -1728,104 +2145,131 @@ func file_proto_user_service_user_proto_rawDescGZIP() []byte {
 	return file_proto_user_service_user_proto_rawDescData
 }
 
-var file_proto_user_service_user_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_proto_user_service_user_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_proto_user_service_user_proto_goTypes = []any{
-	(*User)(nil),                        // 0: userservice.User
-	(*CreateUserRequest)(nil),           // 1: userservice.CreateUserRequest
-	(*CreateUserResponse)(nil),          // 2: userservice.CreateUserResponse
-	(*GetUserByIDRequest)(nil),          // 3: userservice.GetUserByIDRequest
-	(*GetUserByIDResponse)(nil),         // 4: userservice.GetUserByIDResponse
-	(*ListUsersRequest)(nil),            // 5: userservice.ListUsersRequest
-	(*ListUsersResponse)(nil),           // 6: userservice.ListUsersResponse
-	(*UpdateUserRequest)(nil),           // 7: userservice.UpdateUserRequest
-	(*UpdateUserResponse)(nil),          // 8: userservice.UpdateUserResponse
-	(*DeleteUserRequest)(nil),           // 9: userservice.DeleteUserRequest
-	(*FindUsersWithFilterRequest)(nil),  // 10: userservice.FindUsersWithFilterRequest
-	(*FindUsersWithFilterResponse)(nil), // 11: userservice.FindUsersWithFilterResponse
-	(*CreateUsersRequest)(nil),          // 12: userservice.CreateUsersRequest
-	(*CreateUsersResponse)(nil),         // 13: userservice.CreateUsersResponse
-	(*UpdateUserItem)(nil),              // 14: userservice.UpdateUserItem
-	(*UpdateUsersRequest)(nil),          // 15: userservice.UpdateUsersRequest
-	(*UpdateUsersResponse)(nil),         // 16: userservice.UpdateUsersResponse
-	(*DeleteUsersRequest)(nil),          // 17: userservice.DeleteUsersRequest
-	(*DeleteUsersResponse)(nil),         // 18: userservice.DeleteUsersResponse
-	(*LoginRequest)(nil),                // 19: userservice.LoginRequest
-	(*LoginResponse)(nil),               // 20: userservice.LoginResponse
-	(*RefreshRequest)(nil),              // 21: userservice.RefreshRequest
-	(*RefreshResponse)(nil),             // 22: userservice.RefreshResponse
-	(*timestamppb.Timestamp)(nil),       // 23: google.protobuf.Timestamp
-	(*core.FilterOptions)(nil),          // 24: core.FilterOptions
-	(*core.PaginationInfo)(nil),         // 25: core.PaginationInfo
-	(*wrapperspb.StringValue)(nil),      // 26: google.protobuf.StringValue
-	(*wrapperspb.BoolValue)(nil),        // 27: google.protobuf.BoolValue
-	(*wrapperspb.Int32Value)(nil),       // 28: google.protobuf.Int32Value
-	(*emptypb.Empty)(nil),               // 29: google.protobuf.Empty
+	(*User)(nil),                         // 0: userservice.User
+	(*CreateUserRequest)(nil),            // 1: userservice.CreateUserRequest
+	(*CreateUserResponse)(nil),           // 2: userservice.CreateUserResponse
+	(*GetUserByIDRequest)(nil),           // 3: userservice.GetUserByIDRequest
+	(*GetUserByIDResponse)(nil),          // 4: userservice.GetUserByIDResponse
+	(*ListUsersRequest)(nil),             // 5: userservice.ListUsersRequest
+	(*ListUsersResponse)(nil),            // 6: userservice.ListUsersResponse
+	(*UpdateUserRequest)(nil),            // 7: userservice.UpdateUserRequest
+	(*UpdateUserResponse)(nil),           // 8: userservice.UpdateUserResponse
+	(*DeleteUserRequest)(nil),            // 9: userservice.DeleteUserRequest
+	(*FindUsersWithFilterRequest)(nil),   // 10: userservice.FindUsersWithFilterRequest
+	(*FindUsersWithFilterResponse)(nil),  // 11: userservice.FindUsersWithFilterResponse
+	(*CreateUsersRequest)(nil),           // 12: userservice.CreateUsersRequest
+	(*CreateUsersResponse)(nil),          // 13: userservice.CreateUsersResponse
+	(*UpdateUserItem)(nil),               // 14: userservice.UpdateUserItem
+	(*UpdateUsersRequest)(nil),           // 15: userservice.UpdateUsersRequest
+	(*UpdateUsersResponse)(nil),          // 16: userservice.UpdateUsersResponse
+	(*DeleteUsersRequest)(nil),           // 17: userservice.DeleteUsersRequest
+	(*DeleteUsersResponse)(nil),          // 18: userservice.DeleteUsersResponse
+	(*RestoreUserRequest)(nil),           // 19: userservice.RestoreUserRequest
+	(*RestoreUserResponse)(nil),          // 20: userservice.RestoreUserResponse
+	(*ListDeletedUsersRequest)(nil),      // 21: userservice.ListDeletedUsersRequest
+	(*ListDeletedUsersResponse)(nil),     // 22: userservice.ListDeletedUsersResponse
+	(*PurgeDeletedUsersRequest)(nil),     // 23: userservice.PurgeDeletedUsersRequest
+	(*PurgeDeletedUsersResponse)(nil),    // 24: userservice.PurgeDeletedUsersResponse
+	(*LoginRequest)(nil),                 // 25: userservice.LoginRequest
+	(*LoginResponse)(nil),                // 26: userservice.LoginResponse
+	(*RefreshRequest)(nil),               // 27: userservice.RefreshRequest
+	(*RefreshResponse)(nil),              // 28: userservice.RefreshResponse
+	(*timestamppb.Timestamp)(nil),        // 29: google.protobuf.Timestamp
+	(*core.FilterOptions)(nil),           // 30: core.FilterOptions
+	(*core.PaginationInfo)(nil),          // 31: core.PaginationInfo
+	(*wrapperspb.StringValue)(nil),       // 32: google.protobuf.StringValue
+	(*wrapperspb.BoolValue)(nil),         // 33: google.protobuf.BoolValue
+	(*wrapperspb.Int32Value)(nil),        // 34: google.protobuf.Int32Value
+	(*fieldmaskpb.FieldMask)(nil),        // 35: google.protobuf.FieldMask
+	(*core.BulkItemResult)(nil),          // 36: core.BulkItemResult
+	(*core.ListAuditEventsRequest)(nil),  // 37: core.ListAuditEventsRequest
+	(*emptypb.Empty)(nil),                // 38: google.protobuf.Empty
+	(*core.ListAuditEventsResponse)(nil), // 39: core.ListAuditEventsResponse
 }
 var file_proto_user_service_user_proto_depIdxs = []int32{
-	23, // 0: userservice.User.created_at:type_name -> google.protobuf.Timestamp
-	23, // 1: userservice.User.updated_at:type_name -> google.protobuf.Timestamp
-	23, // 2: userservice.User.deleted_at:type_name -> google.protobuf.Timestamp
-	23, // 3: userservice.User.last_login_at:type_name -> google.protobuf.Timestamp
+	29, // 0: userservice.User.created_at:type_name -> google.protobuf.Timestamp
+	29, // 1: userservice.User.updated_at:type_name -> google.protobuf.Timestamp
+	29, // 2: userservice.User.deleted_at:type_name -> google.protobuf.Timestamp
+	29, // 3: userservice.User.last_login_at:type_name -> google.protobuf.Timestamp
 	0,  // 4: userservice.CreateUserResponse.user:type_name -> userservice.User
 	0,  // 5: userservice.GetUserByIDResponse.user:type_name -> userservice.User
-	24, // 6: userservice.ListUsersRequest.options:type_name -> core.FilterOptions
+	30, // 6: userservice.ListUsersRequest.options:type_name -> core.FilterOptions
 	0,  // 7: userservice.ListUsersResponse.users:type_name -> userservice.User
-	25, // 8: userservice.ListUsersResponse.pagination_info:type_name -> core.PaginationInfo
-	26, // 9: userservice.UpdateUserRequest.username:type_name -> google.protobuf.StringValue
-	26, // 10: userservice.UpdateUserRequest.email:type_name -> google.protobuf.StringValue
-	26, // 11: userservice.UpdateUserRequest.first_name:type_name -> google.protobuf.StringValue
-	26, // 12: userservice.UpdateUserRequest.last_name:type_name -> google.protobuf.StringValue
-	26, // 13: userservice.UpdateUserRequest.role:type_name -> google.protobuf.StringValue
-	27, // 14: userservice.UpdateUserRequest.is_active:type_name -> google.protobuf.BoolValue
-	26, // 15: userservice.UpdateUserRequest.phone:type_name -> google.protobuf.StringValue
-	26, // 16: userservice.UpdateUserRequest.address:type_name -> google.protobuf.StringValue
-	28, // 17: userservice.UpdateUserRequest.age:type_name -> google.protobuf.Int32Value
-	26, // 18: userservice.UpdateUserRequest.profile_pic:type_name -> google.protobuf.StringValue
-	0,  // 19: userservice.UpdateUserResponse.user:type_name -> userservice.User
-	24, // 20: userservice.FindUsersWithFilterRequest.options:type_name -> core.FilterOptions
-	0,  // 21: userservice.FindUsersWithFilterResponse.users:type_name -> userservice.User
-	25, // 22: userservice.FindUsersWithFilterResponse.pagination_info:type_name -> core.PaginationInfo
-	1,  // 23: userservice.CreateUsersRequest.users:type_name -> userservice.CreateUserRequest
-	0,  // 24: userservice.CreateUsersResponse.users:type_name -> userservice.User
-	26, // 25: userservice.UpdateUserItem.username:type_name -> google.protobuf.StringValue
-	26, // 26: userservice.UpdateUserItem.email:type_name -> google.protobuf.StringValue
-	26, // 27: userservice.UpdateUserItem.first_name:type_name -> google.protobuf.StringValue
-	26, // 28: userservice.UpdateUserItem.last_name:type_name -> google.protobuf.StringValue
-	26, // 29: userservice.UpdateUserItem.role:type_name -> google.protobuf.StringValue
-	27, // 30: userservice.UpdateUserItem.is_active:type_name -> google.protobuf.BoolValue
-	26, // 31: userservice.UpdateUserItem.phone:type_name -> google.protobuf.StringValue
-	26, // 32: userservice.UpdateUserItem.address:type_name -> google.protobuf.StringValue
-	28, // 33: userservice.UpdateUserItem.age:type_name -> google.protobuf.Int32Value
-	26, // 34: userservice.UpdateUserItem.profile_pic:type_name -> google.protobuf.StringValue
-	14, // 35: userservice.UpdateUsersRequest.items:type_name -> userservice.UpdateUserItem
-	0,  // 36: userservice.LoginResponse.user:type_name -> userservice.User
-	1,  // 37: userservice.UserService.Create:input_type -> userservice.CreateUserRequest
-	3,  // 38: userservice.UserService.GetByID:input_type -> userservice.GetUserByIDRequest
-	5,  // 39: userservice.UserService.List:input_type -> userservice.ListUsersRequest
-	7,  // 40: userservice.UserService.Update:input_type -> userservice.UpdateUserRequest
-	9,  // 41: userservice.UserService.Delete:input_type -> userservice.DeleteUserRequest
-	10, // 42: userservice.UserService.FindWithFilter:input_type -> userservice.FindUsersWithFilterRequest
-	12, // 43: userservice.UserService.CreateMany:input_type -> userservice.CreateUsersRequest
-	15, // 44: userservice.UserService.UpdateMany:input_type -> userservice.UpdateUsersRequest
-	17, // 45: userservice.UserService.DeleteMany:input_type -> userservice.DeleteUsersRequest
-	19, // 46: userservice.UserService.Login:input_type -> userservice.LoginRequest
-	21, // 47: userservice.UserService.Refresh:input_type -> userservice.RefreshRequest
-	2,  // 48: userservice.UserService.Create:output_type -> userservice.CreateUserResponse
-	4,  // 49: userservice.UserService.GetByID:output_type -> userservice.GetUserByIDResponse
-	6,  // 50: userservice.UserService.List:output_type -> userservice.ListUsersResponse
-	8,  // 51: userservice.UserService.Update:output_type -> userservice.UpdateUserResponse
-	29, // 52: userservice.UserService.Delete:output_type -> google.protobuf.Empty
-	11, // 53: userservice.UserService.FindWithFilter:output_type -> userservice.FindUsersWithFilterResponse
-	13, // 54: userservice.UserService.CreateMany:output_type -> userservice.CreateUsersResponse
-	29, // 55: userservice.UserService.UpdateMany:output_type -> google.protobuf.Empty
-	29, // 56: userservice.UserService.DeleteMany:output_type -> google.protobuf.Empty
-	20, // 57: userservice.UserService.Login:output_type -> userservice.LoginResponse
-	22, // 58: userservice.UserService.Refresh:output_type -> userservice.RefreshResponse
-	48, // [48:59] is the sub-list for method output_type
-	37, // [37:48] is the sub-list for method input_type
-	37, // [37:37] is the sub-list for extension type_name
-	37, // [37:37] is the sub-list for extension extendee
-	0,  // [0:37] is the sub-list for field type_name
+	31, // 8: userservice.ListUsersResponse.pagination_info:type_name -> core.PaginationInfo
+	32, // 9: userservice.UpdateUserRequest.username:type_name -> google.protobuf.StringValue
+	32, // 10: userservice.UpdateUserRequest.email:type_name -> google.protobuf.StringValue
+	32, // 11: userservice.UpdateUserRequest.first_name:type_name -> google.protobuf.StringValue
+	32, // 12: userservice.UpdateUserRequest.last_name:type_name -> google.protobuf.StringValue
+	32, // 13: userservice.UpdateUserRequest.role:type_name -> google.protobuf.StringValue
+	33, // 14: userservice.UpdateUserRequest.is_active:type_name -> google.protobuf.BoolValue
+	32, // 15: userservice.UpdateUserRequest.phone:type_name -> google.protobuf.StringValue
+	32, // 16: userservice.UpdateUserRequest.address:type_name -> google.protobuf.StringValue
+	34, // 17: userservice.UpdateUserRequest.age:type_name -> google.protobuf.Int32Value
+	32, // 18: userservice.UpdateUserRequest.profile_pic:type_name -> google.protobuf.StringValue
+	35, // 19: userservice.UpdateUserRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 20: userservice.UpdateUserResponse.user:type_name -> userservice.User
+	30, // 21: userservice.FindUsersWithFilterRequest.options:type_name -> core.FilterOptions
+	0,  // 22: userservice.FindUsersWithFilterResponse.users:type_name -> userservice.User
+	31, // 23: userservice.FindUsersWithFilterResponse.pagination_info:type_name -> core.PaginationInfo
+	1,  // 24: userservice.CreateUsersRequest.users:type_name -> userservice.CreateUserRequest
+	0,  // 25: userservice.CreateUsersResponse.users:type_name -> userservice.User
+	36, // 26: userservice.CreateUsersResponse.results:type_name -> core.BulkItemResult
+	32, // 27: userservice.UpdateUserItem.username:type_name -> google.protobuf.StringValue
+	32, // 28: userservice.UpdateUserItem.email:type_name -> google.protobuf.StringValue
+	32, // 29: userservice.UpdateUserItem.first_name:type_name -> google.protobuf.StringValue
+	32, // 30: userservice.UpdateUserItem.last_name:type_name -> google.protobuf.StringValue
+	32, // 31: userservice.UpdateUserItem.role:type_name -> google.protobuf.StringValue
+	33, // 32: userservice.UpdateUserItem.is_active:type_name -> google.protobuf.BoolValue
+	32, // 33: userservice.UpdateUserItem.phone:type_name -> google.protobuf.StringValue
+	32, // 34: userservice.UpdateUserItem.address:type_name -> google.protobuf.StringValue
+	34, // 35: userservice.UpdateUserItem.age:type_name -> google.protobuf.Int32Value
+	32, // 36: userservice.UpdateUserItem.profile_pic:type_name -> google.protobuf.StringValue
+	35, // 37: userservice.UpdateUserItem.update_mask:type_name -> google.protobuf.FieldMask
+	14, // 38: userservice.UpdateUsersRequest.items:type_name -> userservice.UpdateUserItem
+	36, // 39: userservice.UpdateUsersResponse.results:type_name -> core.BulkItemResult
+	36, // 40: userservice.DeleteUsersResponse.results:type_name -> core.BulkItemResult
+	0,  // 41: userservice.RestoreUserResponse.user:type_name -> userservice.User
+	30, // 42: userservice.ListDeletedUsersRequest.options:type_name -> core.FilterOptions
+	0,  // 43: userservice.ListDeletedUsersResponse.users:type_name -> userservice.User
+	31, // 44: userservice.ListDeletedUsersResponse.pagination_info:type_name -> core.PaginationInfo
+	0,  // 45: userservice.LoginResponse.user:type_name -> userservice.User
+	1,  // 46: userservice.UserService.Create:input_type -> userservice.CreateUserRequest
+	3,  // 47: userservice.UserService.GetByID:input_type -> userservice.GetUserByIDRequest
+	5,  // 48: userservice.UserService.List:input_type -> userservice.ListUsersRequest
+	7,  // 49: userservice.UserService.Update:input_type -> userservice.UpdateUserRequest
+	9,  // 50: userservice.UserService.Delete:input_type -> userservice.DeleteUserRequest
+	10, // 51: userservice.UserService.FindWithFilter:input_type -> userservice.FindUsersWithFilterRequest
+	12, // 52: userservice.UserService.CreateMany:input_type -> userservice.CreateUsersRequest
+	15, // 53: userservice.UserService.UpdateMany:input_type -> userservice.UpdateUsersRequest
+	17, // 54: userservice.UserService.DeleteMany:input_type -> userservice.DeleteUsersRequest
+	19, // 55: userservice.UserService.Restore:input_type -> userservice.RestoreUserRequest
+	21, // 56: userservice.UserService.ListDeleted:input_type -> userservice.ListDeletedUsersRequest
+	23, // 57: userservice.UserService.PurgeDeleted:input_type -> userservice.PurgeDeletedUsersRequest
+	37, // 58: userservice.UserService.ListAuditEvents:input_type -> core.ListAuditEventsRequest
+	25, // 59: userservice.UserService.Login:input_type -> userservice.LoginRequest
+	27, // 60: userservice.UserService.Refresh:input_type -> userservice.RefreshRequest
+	2,  // 61: userservice.UserService.Create:output_type -> userservice.CreateUserResponse
+	4,  // 62: userservice.UserService.GetByID:output_type -> userservice.GetUserByIDResponse
+	6,  // 63: userservice.UserService.List:output_type -> userservice.ListUsersResponse
+	8,  // 64: userservice.UserService.Update:output_type -> userservice.UpdateUserResponse
+	38, // 65: userservice.UserService.Delete:output_type -> google.protobuf.Empty
+	11, // 66: userservice.UserService.FindWithFilter:output_type -> userservice.FindUsersWithFilterResponse
+	13, // 67: userservice.UserService.CreateMany:output_type -> userservice.CreateUsersResponse
+	16, // 68: userservice.UserService.UpdateMany:output_type -> userservice.UpdateUsersResponse
+	18, // 69: userservice.UserService.DeleteMany:output_type -> userservice.DeleteUsersResponse
+	20, // 70: userservice.UserService.Restore:output_type -> userservice.RestoreUserResponse
+	22, // 71: userservice.UserService.ListDeleted:output_type -> userservice.ListDeletedUsersResponse
+	24, // 72: userservice.UserService.PurgeDeleted:output_type -> userservice.PurgeDeletedUsersResponse
+	39, // 73: userservice.UserService.ListAuditEvents:output_type -> core.ListAuditEventsResponse
+	26, // 74: userservice.UserService.Login:output_type -> userservice.LoginResponse
+	28, // 75: userservice.UserService.Refresh:output_type -> userservice.RefreshResponse
+	61, // [61:76] is the sub-list for method output_type
+	46, // [46:61] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
 }
 
 func init() { file_proto_user_service_user_proto_init() }
@@ -1843,7 +2287,7 @@ func file_proto_user_service_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_user_service_user_proto_rawDesc), len(file_proto_user_service_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   23,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
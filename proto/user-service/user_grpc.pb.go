@@ -8,6 +8,7 @@ package user_service
 
 import (
 	context "context"
+	core "golang-microservices-boilerplate/proto/core"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -20,17 +21,21 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_Create_FullMethodName         = "/userservice.UserService/Create"
-	UserService_GetByID_FullMethodName        = "/userservice.UserService/GetByID"
-	UserService_List_FullMethodName           = "/userservice.UserService/List"
-	UserService_Update_FullMethodName         = "/userservice.UserService/Update"
-	UserService_Delete_FullMethodName         = "/userservice.UserService/Delete"
-	UserService_FindWithFilter_FullMethodName = "/userservice.UserService/FindWithFilter"
-	UserService_CreateMany_FullMethodName     = "/userservice.UserService/CreateMany"
-	UserService_UpdateMany_FullMethodName     = "/userservice.UserService/UpdateMany"
-	UserService_DeleteMany_FullMethodName     = "/userservice.UserService/DeleteMany"
-	UserService_Login_FullMethodName          = "/userservice.UserService/Login"
-	UserService_Refresh_FullMethodName        = "/userservice.UserService/Refresh"
+	UserService_Create_FullMethodName          = "/userservice.UserService/Create"
+	UserService_GetByID_FullMethodName         = "/userservice.UserService/GetByID"
+	UserService_List_FullMethodName            = "/userservice.UserService/List"
+	UserService_Update_FullMethodName          = "/userservice.UserService/Update"
+	UserService_Delete_FullMethodName          = "/userservice.UserService/Delete"
+	UserService_FindWithFilter_FullMethodName  = "/userservice.UserService/FindWithFilter"
+	UserService_CreateMany_FullMethodName      = "/userservice.UserService/CreateMany"
+	UserService_UpdateMany_FullMethodName      = "/userservice.UserService/UpdateMany"
+	UserService_DeleteMany_FullMethodName      = "/userservice.UserService/DeleteMany"
+	UserService_Restore_FullMethodName         = "/userservice.UserService/Restore"
+	UserService_ListDeleted_FullMethodName     = "/userservice.UserService/ListDeleted"
+	UserService_PurgeDeleted_FullMethodName    = "/userservice.UserService/PurgeDeleted"
+	UserService_ListAuditEvents_FullMethodName = "/userservice.UserService/ListAuditEvents"
+	UserService_Login_FullMethodName           = "/userservice.UserService/Login"
+	UserService_Refresh_FullMethodName         = "/userservice.UserService/Refresh"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -51,9 +56,15 @@ type UserServiceClient interface {
 	// Bulk operations
 	CreateMany(ctx context.Context, in *CreateUsersRequest, opts ...grpc.CallOption) (*CreateUsersResponse, error)
 	// Refactored UpdateMany RPC
-	UpdateMany(ctx context.Context, in *UpdateUsersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateMany(ctx context.Context, in *UpdateUsersRequest, opts ...grpc.CallOption) (*UpdateUsersResponse, error)
 	// Consolidated DeleteMany RPC
-	DeleteMany(ctx context.Context, in *DeleteUsersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteMany(ctx context.Context, in *DeleteUsersRequest, opts ...grpc.CallOption) (*DeleteUsersResponse, error)
+	// Soft-delete lifecycle
+	Restore(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*RestoreUserResponse, error)
+	ListDeleted(ctx context.Context, in *ListDeletedUsersRequest, opts ...grpc.CallOption) (*ListDeletedUsersResponse, error)
+	PurgeDeleted(ctx context.Context, in *PurgeDeletedUsersRequest, opts ...grpc.CallOption) (*PurgeDeletedUsersResponse, error)
+	// --- Audit Trail ---
+	ListAuditEvents(ctx context.Context, in *core.ListAuditEventsRequest, opts ...grpc.CallOption) (*core.ListAuditEventsResponse, error)
 	// Authentication
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	Refresh(ctx context.Context, in *RefreshRequest, opts ...grpc.CallOption) (*RefreshResponse, error)
@@ -137,9 +148,9 @@ func (c *userServiceClient) CreateMany(ctx context.Context, in *CreateUsersReque
 	return out, nil
 }
 
-func (c *userServiceClient) UpdateMany(ctx context.Context, in *UpdateUsersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *userServiceClient) UpdateMany(ctx context.Context, in *UpdateUsersRequest, opts ...grpc.CallOption) (*UpdateUsersResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(emptypb.Empty)
+	out := new(UpdateUsersResponse)
 	err := c.cc.Invoke(ctx, UserService_UpdateMany_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -147,9 +158,9 @@ func (c *userServiceClient) UpdateMany(ctx context.Context, in *UpdateUsersReque
 	return out, nil
 }
 
-func (c *userServiceClient) DeleteMany(ctx context.Context, in *DeleteUsersRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *userServiceClient) DeleteMany(ctx context.Context, in *DeleteUsersRequest, opts ...grpc.CallOption) (*DeleteUsersResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(emptypb.Empty)
+	out := new(DeleteUsersResponse)
 	err := c.cc.Invoke(ctx, UserService_DeleteMany_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -157,6 +168,46 @@ func (c *userServiceClient) DeleteMany(ctx context.Context, in *DeleteUsersReque
 	return out, nil
 }
 
+func (c *userServiceClient) Restore(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*RestoreUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreUserResponse)
+	err := c.cc.Invoke(ctx, UserService_Restore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListDeleted(ctx context.Context, in *ListDeletedUsersRequest, opts ...grpc.CallOption) (*ListDeletedUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeletedUsersResponse)
+	err := c.cc.Invoke(ctx, UserService_ListDeleted_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) PurgeDeleted(ctx context.Context, in *PurgeDeletedUsersRequest, opts ...grpc.CallOption) (*PurgeDeletedUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeDeletedUsersResponse)
+	err := c.cc.Invoke(ctx, UserService_PurgeDeleted_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListAuditEvents(ctx context.Context, in *core.ListAuditEventsRequest, opts ...grpc.CallOption) (*core.ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(core.ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, UserService_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(LoginResponse)
@@ -195,9 +246,15 @@ type UserServiceServer interface {
 	// Bulk operations
 	CreateMany(context.Context, *CreateUsersRequest) (*CreateUsersResponse, error)
 	// Refactored UpdateMany RPC
-	UpdateMany(context.Context, *UpdateUsersRequest) (*emptypb.Empty, error)
+	UpdateMany(context.Context, *UpdateUsersRequest) (*UpdateUsersResponse, error)
 	// Consolidated DeleteMany RPC
-	DeleteMany(context.Context, *DeleteUsersRequest) (*emptypb.Empty, error)
+	DeleteMany(context.Context, *DeleteUsersRequest) (*DeleteUsersResponse, error)
+	// Soft-delete lifecycle
+	Restore(context.Context, *RestoreUserRequest) (*RestoreUserResponse, error)
+	ListDeleted(context.Context, *ListDeletedUsersRequest) (*ListDeletedUsersResponse, error)
+	PurgeDeleted(context.Context, *PurgeDeletedUsersRequest) (*PurgeDeletedUsersResponse, error)
+	// --- Audit Trail ---
+	ListAuditEvents(context.Context, *core.ListAuditEventsRequest) (*core.ListAuditEventsResponse, error)
 	// Authentication
 	Login(context.Context, *LoginRequest) (*LoginResponse, error)
 	Refresh(context.Context, *RefreshRequest) (*RefreshResponse, error)
@@ -232,12 +289,24 @@ func (UnimplementedUserServiceServer) FindWithFilter(context.Context, *FindUsers
 func (UnimplementedUserServiceServer) CreateMany(context.Context, *CreateUsersRequest) (*CreateUsersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateMany not implemented")
 }
-func (UnimplementedUserServiceServer) UpdateMany(context.Context, *UpdateUsersRequest) (*emptypb.Empty, error) {
+func (UnimplementedUserServiceServer) UpdateMany(context.Context, *UpdateUsersRequest) (*UpdateUsersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateMany not implemented")
 }
-func (UnimplementedUserServiceServer) DeleteMany(context.Context, *DeleteUsersRequest) (*emptypb.Empty, error) {
+func (UnimplementedUserServiceServer) DeleteMany(context.Context, *DeleteUsersRequest) (*DeleteUsersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteMany not implemented")
 }
+func (UnimplementedUserServiceServer) Restore(context.Context, *RestoreUserRequest) (*RestoreUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedUserServiceServer) ListDeleted(context.Context, *ListDeletedUsersRequest) (*ListDeletedUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeleted not implemented")
+}
+func (UnimplementedUserServiceServer) PurgeDeleted(context.Context, *PurgeDeletedUsersRequest) (*PurgeDeletedUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeDeleted not implemented")
+}
+func (UnimplementedUserServiceServer) ListAuditEvents(context.Context, *core.ListAuditEventsRequest) (*core.ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
 func (UnimplementedUserServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
 }
@@ -427,6 +496,78 @@ func _UserService_DeleteMany_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_Restore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).Restore(ctx, req.(*RestoreUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListDeleted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListDeleted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListDeleted_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListDeleted(ctx, req.(*ListDeletedUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_PurgeDeleted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeDeletedUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).PurgeDeleted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_PurgeDeleted_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).PurgeDeleted(ctx, req.(*PurgeDeletedUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(core.ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListAuditEvents(ctx, req.(*core.ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(LoginRequest)
 	if err := dec(in); err != nil {
@@ -506,6 +647,22 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteMany",
 			Handler:    _UserService_DeleteMany_Handler,
 		},
+		{
+			MethodName: "Restore",
+			Handler:    _UserService_Restore_Handler,
+		},
+		{
+			MethodName: "ListDeleted",
+			Handler:    _UserService_ListDeleted_Handler,
+		},
+		{
+			MethodName: "PurgeDeleted",
+			Handler:    _UserService_PurgeDeleted_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _UserService_ListAuditEvents_Handler,
+		},
 		{
 			MethodName: "Login",
 			Handler:    _UserService_Login_Handler,
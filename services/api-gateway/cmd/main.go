@@ -5,10 +5,19 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+
+	"golang-microservices-boilerplate/pkg/core/database"
+	coreGrpc "golang-microservices-boilerplate/pkg/core/grpc"
 	"golang-microservices-boilerplate/pkg/core/logger"
+	coreMigrations "golang-microservices-boilerplate/pkg/core/migrations"
+	"golang-microservices-boilerplate/pkg/core/ratelimit"
+	"golang-microservices-boilerplate/pkg/core/tracing"
 	"golang-microservices-boilerplate/pkg/utils"
 	"golang-microservices-boilerplate/services/api-gateway/internal/gateway"
 	"golang-microservices-boilerplate/services/api-gateway/internal/infrastructure/adapter"
@@ -39,6 +48,17 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Trace requests with the exporter selected by TRACING_EXPORTER
+	tracer, err := tracing.Setup(ctx, tracing.ConfigFromEnv("api-gateway"))
+	if err != nil {
+		appLogger.Fatal("Failed to set up tracing", "error", err)
+	}
+	defer func() {
+		if err := tracer.Shutdown(context.Background()); err != nil {
+			appLogger.Error("Error flushing traces", "error", err)
+		}
+	}()
+
 	// Initialize Kubernetes service discovery
 	namespace := utils.GetEnv("K8S_NAMESPACE", "ride-sharing")
 
@@ -51,12 +71,27 @@ func main() {
 	}
 	defer discovery.Close()
 
+	// Dial the services with TLS (mutual TLS with a client certificate) when TLS_* is configured
+	gatewayOpts := []gateway.GatewayOption{gateway.WithLogger(logger.Named("gateway"))}
+	if tlsConfig := coreGrpc.TLSConfigFromEnv(); tlsConfig != nil {
+		creds, err := coreGrpc.NewClientCredentials(tlsConfig, logger.Named("grpc.tls"))
+		if err != nil {
+			appLogger.Fatal("Failed to load TLS credentials", "error", err)
+		}
+		gatewayOpts = append(gatewayOpts, gateway.WithDialOptions(grpc.WithTransportCredentials(creds)))
+	}
+
+	// Rate limit signing in and the bulk endpoints
+	limiter, closeLimiter := setupRateLimiter(ctx, appLogger)
+	defer closeLimiter()
+	gatewayOpts = append(gatewayOpts, gateway.WithRateLimiter(limiter, utils.GetEnv("ACCESS_TOKEN_SECRET", "")))
+
+	// Report unhealthy services on /health/ready without failing it, unless required
+	requireServices, _ := strconv.ParseBool(utils.GetEnv("READINESS_REQUIRE_SERVICES", "false"))
+	gatewayOpts = append(gatewayOpts, gateway.WithReadinessRequiringServices(requireServices))
+
 	// Initialize gateway
-	gw := gateway.NewGateway(
-		ctx,
-		discovery,
-		gateway.WithLogger(logger.Named("gateway")),
-	)
+	gw := gateway.NewGateway(ctx, discovery, gatewayOpts...)
 
 	// Start server in a goroutine
 	port := utils.GetEnv("PORT", "8081")
@@ -86,3 +121,36 @@ func main() {
 
 	appLogger.Info("API Gateway stopped")
 }
+
+// setupRateLimiter creates the limiter of gateway.DefaultRateLimitRules. With
+// RATE_LIMIT_STORE=postgres the buckets are shared by the gateway replicas
+// through the rate_limit_buckets table of the DB_* database, created by the
+// core migrations; the returned func closes that connection.
+func setupRateLimiter(ctx context.Context, log logger.Logger) (*ratelimit.Limiter, func()) {
+	config := ratelimit.DefaultConfig()
+	var gormDB *gorm.DB
+	closeDB := func() {}
+	if config.Store == ratelimit.StorePostgres {
+		db, err := database.NewDatabaseConnection(database.DefaultDBConfig())
+		if err != nil {
+			log.Fatal("Failed to connect to the rate limit database", "error", err)
+		}
+		if db.Config.MigrateOnStart {
+			if _, err := database.NewMigrator(db.DB, coreMigrations.Source).Up(ctx); err != nil {
+				log.Fatal("Failed to migrate the rate limit database", "error", err)
+			}
+		}
+		gormDB, closeDB = db.DB, func() { _ = db.Close() }
+	}
+
+	store, err := ratelimit.NewStore(config, gormDB)
+	if err != nil {
+		log.Fatal("Failed to create the rate limit store", "error", err)
+	}
+	limiter, err := ratelimit.NewLimiter(store, gateway.DefaultRateLimitRules()...)
+	if err != nil {
+		log.Fatal("Invalid rate limit rules", "error", err)
+	}
+	log.Info("Rate limiting enabled", "store", config.Store)
+	return limiter, closeDB
+}
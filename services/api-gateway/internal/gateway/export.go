@@ -0,0 +1,294 @@
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	appointment_pb "golang-microservices-boilerplate/proto/appointment-service"
+	patient_pb "golang-microservices-boilerplate/proto/patient-service"
+	staff_pb "golang-microservices-boilerplate/proto/staff-service"
+)
+
+// exportMarshaler encodes exported messages like the gRPC-Gateway encodes responses.
+var exportMarshaler = &runtime.JSONPb{MarshalOptions: protojson.MarshalOptions{EmitUnpopulated: true}}
+
+// recvFunc returns the next message of an export stream, or io.EOF after the last one.
+type recvFunc func() (proto.Message, error)
+
+// exportRoute is a server-streaming export RPC served as a file download.
+type exportRoute struct {
+	path     string // Fiber route; colons of custom verbs are escaped
+	filename string // Download name without extension
+	service  string // Key of the service connection in Gateway.serviceConns
+	open     func(ctx context.Context, conn *grpc.ClientConn, query url.Values) (recvFunc, error)
+}
+
+// exportRoutes lists the export downloads. They bypass the gRPC-Gateway mux,
+// whose responses are buffered by the Fiber adaptor, so that rows reach the
+// client while the export is still being read.
+var exportRoutes = []exportRoute{
+	{
+		path: "/api/v1/patients\\:export", filename: "patients", service: "patient",
+		open: func(ctx context.Context, conn *grpc.ClientConn, query url.Values) (recvFunc, error) {
+			req := &patient_pb.ExportPatientsRequest{}
+			if err := populateExportRequest(req, query); err != nil {
+				return nil, err
+			}
+			stream, err := patient_pb.NewPatientServiceClient(conn).ExportPatients(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+			return func() (proto.Message, error) { return recvMessage(stream.Recv()) }, nil
+		},
+	},
+	{
+		path: "/api/v1/staff\\:export", filename: "staff", service: "staff",
+		open: func(ctx context.Context, conn *grpc.ClientConn, query url.Values) (recvFunc, error) {
+			req := &staff_pb.ExportStaffRequest{}
+			if err := populateExportRequest(req, query); err != nil {
+				return nil, err
+			}
+			stream, err := staff_pb.NewStaffServiceClient(conn).ExportStaff(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+			return func() (proto.Message, error) { return recvMessage(stream.Recv()) }, nil
+		},
+	},
+	{
+		path: "/api/v1/appointments\\:export", filename: "appointments", service: "appointment",
+		open: func(ctx context.Context, conn *grpc.ClientConn, query url.Values) (recvFunc, error) {
+			req := &appointment_pb.ExportAppointmentsRequest{}
+			if err := populateExportRequest(req, query); err != nil {
+				return nil, err
+			}
+			stream, err := appointment_pb.NewAppointmentServiceClient(conn).ExportAppointments(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+			return func() (proto.Message, error) { return recvMessage(stream.Recv()) }, nil
+		},
+	},
+}
+
+// recvMessage converts the result of a typed Recv, keeping a nil message nil.
+func recvMessage[M proto.Message](msg M, err error) (proto.Message, error) {
+	if err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// populateExportRequest fills req from the query string like the gRPC-Gateway
+// does, e.g. ?options.sort_by=last_name&start_time=2024-01-01T00:00:00Z.
+func populateExportRequest(req proto.Message, query url.Values) error {
+	if err := runtime.PopulateQueryParameters(req, query, utilities.NewDoubleArray(nil)); err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return nil
+}
+
+// registerExportRoutes serves the export RPCs as downloads. Clients pick the
+// format with ?format=ndjson|csv or the Accept header; NDJSON is the default.
+func (g *Gateway) registerExportRoutes() {
+	for _, route := range exportRoutes {
+		g.app.Get(route.path, func(c *fiber.Ctx) error {
+			return g.serveExport(c, route)
+		})
+	}
+}
+
+// serveExport streams one export. Errors before the first row become regular
+// JSON error responses; later ones end an NDJSON download with an
+// {"error": ...} line and truncate a CSV download.
+func (g *Gateway) serveExport(c *fiber.Ctx, route exportRoute) error {
+	g.mu.Lock()
+	conn := g.serviceConns[route.service]
+	g.mu.Unlock()
+	if conn == nil {
+		return writeStatus(c, status.Newf(codes.Unavailable, "%s service is not available", route.service))
+	}
+
+	query, err := url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return writeStatus(c, status.Newf(codes.InvalidArgument, "invalid query string: %v", err))
+	}
+	format := strings.ToLower(query.Get("format"))
+	query.Del("format")
+	if format == "" {
+		format = "ndjson"
+		if strings.Contains(c.Get(fiber.HeaderAccept), "text/csv") {
+			format = "csv"
+		}
+	}
+	var writer exportWriter
+	switch format {
+	case "ndjson":
+		writer = &ndjsonWriter{}
+	case "csv":
+		writer = &csvWriter{}
+	default:
+		return writeStatus(c, status.Newf(codes.InvalidArgument, "unsupported export format %q, use ndjson or csv", format))
+	}
+
+	// The stream outlives the handler, so it is bound to the gateway instead of the request
+	ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(g.ctx, exportMetadata(c)))
+	recv, err := route.open(ctx, conn, query)
+	var first proto.Message
+	if err == nil {
+		first, err = recv()
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		cancel()
+		return writeStatus(c, status.Convert(err))
+	}
+
+	c.Set(fiber.HeaderContentType, writer.contentType())
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", route.filename+"."+format))
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		msg := first
+		for msg != nil {
+			if err := writer.write(w, msg); err != nil {
+				g.logger.Error("Failed to encode export row", "export", route.filename, "error", err)
+				return
+			}
+			if err := w.Flush(); err != nil {
+				return // Client went away; cancel stops the export upstream
+			}
+			next, err := recv()
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					g.logger.Error("Export stream failed", "export", route.filename, "error", err)
+					writer.fail(w, status.Convert(err))
+				}
+				break
+			}
+			msg = next
+		}
+		_ = w.Flush()
+	})
+	return nil
+}
+
+// exportMetadata forwards the request headers the gRPC-Gateway would forward.
+func exportMetadata(c *fiber.Ctx) metadata.MD {
+	md := metadata.MD{}
+	c.Request().Header.VisitAll(func(key, value []byte) {
+		if name, ok := headerMatcher(string(key)); ok {
+			md.Append(name, string(value))
+		}
+	})
+	return md
+}
+
+// writeStatus writes st as a JSON error response in the gRPC-Gateway format.
+func writeStatus(c *fiber.Ctx, st *status.Status) error {
+	body, err := exportMarshaler.Marshal(st.Proto())
+	if err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Status(runtime.HTTPStatusFromCode(st.Code())).Send(body)
+}
+
+// exportWriter encodes the rows of an export download.
+type exportWriter interface {
+	contentType() string
+	write(w io.Writer, msg proto.Message) error
+	fail(w io.Writer, st *status.Status)
+}
+
+// ndjsonWriter writes one JSON object per line.
+type ndjsonWriter struct{}
+
+func (ndjsonWriter) contentType() string { return "application/x-ndjson" }
+
+func (ndjsonWriter) write(w io.Writer, msg proto.Message) error {
+	b, err := exportMarshaler.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+func (ndjsonWriter) fail(w io.Writer, st *status.Status) {
+	b, err := exportMarshaler.Marshal(st.Proto())
+	if err != nil {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "{\"error\":%s}\n", b)
+}
+
+// csvWriter writes a header row of the top-level JSON field names followed by
+// one row per message. Strings and timestamps are written as is, nested
+// messages and lists as JSON.
+type csvWriter struct {
+	columns []string
+}
+
+func (*csvWriter) contentType() string { return "text/csv; charset=utf-8" }
+
+func (cw *csvWriter) write(w io.Writer, msg proto.Message) error {
+	out := csv.NewWriter(w)
+	if cw.columns == nil {
+		fields := msg.ProtoReflect().Descriptor().Fields()
+		for i := 0; i < fields.Len(); i++ {
+			cw.columns = append(cw.columns, fields.Get(i).JSONName())
+		}
+		if err := out.Write(cw.columns); err != nil {
+			return err
+		}
+	}
+
+	b, err := exportMarshaler.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(b, &values); err != nil {
+		return err
+	}
+	row := make([]string, len(cw.columns))
+	for i, col := range cw.columns {
+		row[i] = csvCell(values[col])
+	}
+	if err := out.Write(row); err != nil {
+		return err
+	}
+	out.Flush()
+	return out.Error()
+}
+
+func (*csvWriter) fail(io.Writer, *status.Status) {}
+
+// csvCell renders a JSON value as a CSV cell: strings unquoted, null empty and
+// everything else as JSON text.
+func csvCell(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return string(raw)
+}
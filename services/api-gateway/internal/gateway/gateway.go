@@ -16,8 +16,12 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/status"
 
+	coreGrpc "golang-microservices-boilerplate/pkg/core/grpc"
 	"golang-microservices-boilerplate/pkg/core/logger"
+	"golang-microservices-boilerplate/pkg/core/metrics"
+	"golang-microservices-boilerplate/pkg/core/ratelimit"
 	"golang-microservices-boilerplate/pkg/middleware"
 	"golang-microservices-boilerplate/services/api-gateway/internal/domain"
 )
@@ -32,7 +36,13 @@ type Gateway struct {
 	discovery    domain.ServiceDiscovery
 	serviceConns map[string]*grpc.ClientConn
 	opts         []grpc.DialOption
+	metrics      *metrics.Server // Prometheus /metrics endpoint; nil when METRICS_PORT is empty
 	mu           sync.Mutex
+
+	limiter           *ratelimit.Limiter // Nil disables rate limiting
+	accessTokenSecret string             // Verifies the tokens identifying rate limited callers
+
+	readinessRequiresServices bool // /health/ready fails unless every service is serving
 }
 
 // GatewayOption configures the Gateway
@@ -47,6 +57,14 @@ func WithLogger(l logger.Logger) GatewayOption {
 	}
 }
 
+// WithDialOptions adds options to the connections made to the services, after
+// the plaintext default; pass grpc.WithTransportCredentials to dial with TLS.
+func WithDialOptions(opts ...grpc.DialOption) GatewayOption {
+	return func(g *Gateway) {
+		g.opts = append(g.opts, opts...)
+	}
+}
+
 // stdLogAdapter adapts logger.Logger to io.Writer for standard logger
 type stdLogAdapter struct {
 	logger logger.Logger
@@ -105,18 +123,50 @@ func NewGateway(
 	// --- Configure components that depend on the FINAL logger ---
 
 	// Configure Fiber App with the final logger in the error handler
-	g.app = fiber.New(fiber.Config{
+	// Behind an ingress, the client IP comes from the X-Forwarded-For header it
+	// sets, which is trusted from TRUSTED_PROXIES only
+	fiberConfig := fiber.Config{
 		ErrorHandler: g.fiberErrorHandler, // Assign the method reference
-	})
+	}
+	if proxies := trustedProxies(); len(proxies) > 0 {
+		fiberConfig.EnableTrustedProxyCheck = true
+		fiberConfig.TrustedProxies = proxies
+		fiberConfig.ProxyHeader = fiber.HeaderXForwardedFor
+	}
+	g.app = fiber.New(fiberConfig)
 
 	// Configure gRPC global logger
 	grpcLoggerWriter := &stdLogAdapter{logger: g.logger.Named("grpc")}
 	grpcStdLogger := log.New(grpcLoggerWriter, "", 0)
 	grpclog.SetLoggerV2(grpclog.NewLoggerV2(grpcStdLogger.Writer(), grpcStdLogger.Writer(), grpcStdLogger.Writer()))
 
+	// Trace, log and measure the calls made to the services
+	clientLogger := g.logger.Named("grpc.client")
+	g.opts = append(g.opts,
+		grpc.WithChainUnaryInterceptor(
+			coreGrpc.TracingUnaryClientInterceptor(),
+			coreGrpc.ObservabilityUnaryClientInterceptor(clientLogger),
+		),
+		grpc.WithChainStreamInterceptor(
+			coreGrpc.TracingStreamClientInterceptor(),
+			coreGrpc.ObservabilityStreamClientInterceptor(clientLogger),
+		),
+	)
+	if addr := metrics.DefaultAddr(); addr != "" {
+		g.metrics = metrics.NewServer(addr, g.logger)
+	}
+
 	// Add Fiber middleware
-	g.app.Use(cors.New())                    // CORS
-	g.app.Use(middleware.LoggerMiddleware()) // Call middleware without logger arg
+	g.app.Use(cors.New())                                                 // CORS
+	g.app.Use(middleware.TracingMiddleware())                             // Server spans, continued by the services
+	g.app.Use(middleware.LoggerMiddleware(g.logger.Named("http.access"))) // Access logs and HTTP metrics
+	g.app.Use(forwardClientIP)                                            // Services see the client IP
+	if g.limiter != nil {
+		g.app.Use(g.rateLimitMiddleware()) // 429 for callers over their rate limit
+	}
+
+	// Export downloads are streamed by Fiber itself, so they must match before the mux
+	g.registerExportRoutes()
 
 	// Mount the gRPC-Gateway mux
 	g.app.Use("/api", adaptor.HTTPHandler(g.gwMux))
@@ -157,9 +207,13 @@ func (g *Gateway) Start(port string) error {
 		g.RegisterSwaggerUI(swaggerDir)
 	}
 
-	g.app.Get("/health", func(c *fiber.Ctx) error {
-		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "healthy"})
-	})
+	g.registerHealthRoutes()
+
+	if g.metrics != nil {
+		if err := g.metrics.Start(); err != nil {
+			return fmt.Errorf("failed to serve metrics: %w", err)
+		}
+	}
 
 	g.logger.Info("Starting Fiber HTTP server", "port", port)
 	return g.app.Listen(fmt.Sprintf(":%s", port))
@@ -170,8 +224,22 @@ func (g *Gateway) Shutdown(ctx context.Context) error {
 	g.logger.Info("Shutting down Fiber server...")
 	serverErr := g.app.Shutdown()
 
-	// Removed closing of gRPC connections previously managed by discovery
-	// The connections used by Register...FromEndpoint are managed internally by grpc-gateway/grpc
+	// The connections used by Register...FromEndpoint are managed internally by grpc-gateway/grpc;
+	// only the export and health check connections are ours to close
+	g.mu.Lock()
+	for name, conn := range g.serviceConns {
+		if err := conn.Close(); err != nil {
+			g.logger.Warn("Failed to close service connection", "service", name, "error", err)
+		}
+	}
+	g.serviceConns = make(map[string]*grpc.ClientConn)
+	g.mu.Unlock()
+
+	if g.metrics != nil {
+		if err := g.metrics.Shutdown(ctx); err != nil {
+			g.logger.Warn("Failed to shutdown metrics server", "error", err)
+		}
+	}
 
 	if serverErr != nil {
 		g.logger.Error("Failed to shutdown Fiber server", "error", serverErr)
@@ -185,13 +253,18 @@ func (g *Gateway) Shutdown(ctx context.Context) error {
 // defaultErrorHandler is the default gRPC-Gateway error handler.
 func defaultErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
 	grpclog.Errorf("gRPC-Gateway Error: %v", err)
+	if retryAfter := retryAfterHeader(status.Convert(err)); retryAfter != "" {
+		w.Header().Set(fiber.HeaderRetryAfter, retryAfter) // Rate limited by the service
+	}
 	runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
 }
 
-// headerMatcher remains the same.
+// headerMatcher selects the HTTP headers forwarded to the services as gRPC
+// metadata: credentials, idempotency keys, X- headers and the W3C trace context.
 func headerMatcher(key string) (string, bool) {
 	key = strings.ToLower(key)
-	if key == "authorization" {
+	switch key {
+	case "authorization", "idempotency-key", "traceparent", "tracestate", "baggage":
 		return key, true
 	}
 	if strings.HasPrefix(key, "x-") {
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 
+	"google.golang.org/grpc"
+
 	appointment_pb "golang-microservices-boilerplate/proto/appointment-service"
 	patient_pb "golang-microservices-boilerplate/proto/patient-service"
 	staff_pb "golang-microservices-boilerplate/proto/staff-service"
@@ -71,7 +73,7 @@ func (g *Gateway) setupUserServiceHandlers(service domain.Service) error {
 	}
 
 	g.logger.Info("Registered gRPC-Gateway handlers via endpoint", "service", "user-service", "endpoint", service.Endpoint)
-	return nil
+	return g.dialService("user", service)
 }
 
 func (g *Gateway) setupPatientServiceHandlers(service domain.Service) error {
@@ -81,7 +83,7 @@ func (g *Gateway) setupPatientServiceHandlers(service domain.Service) error {
 		return fmt.Errorf("failed to register patient service handler from endpoint %s: %w", service.Endpoint, err)
 	}
 	g.logger.Info("Registered gRPC-Gateway handlers via endpoint", "service", "patient-service", "endpoint", service.Endpoint)
-	return nil
+	return g.dialService("patient", service)
 }
 
 func (g *Gateway) setupAppointmentServiceHandlers(service domain.Service) error {
@@ -91,7 +93,7 @@ func (g *Gateway) setupAppointmentServiceHandlers(service domain.Service) error
 		return fmt.Errorf("failed to register appointment service handler from endpoint %s: %w", service.Endpoint, err)
 	}
 	g.logger.Info("Registered gRPC-Gateway handlers via endpoint", "service", "appointment-service", "endpoint", service.Endpoint)
-	return nil
+	return g.dialService("appointment", service)
 }
 
 func (g *Gateway) setupStaffServiceHandlers(service domain.Service) error {
@@ -101,5 +103,25 @@ func (g *Gateway) setupStaffServiceHandlers(service domain.Service) error {
 		return fmt.Errorf("failed to register staff service handler from endpoint %s: %w", service.Endpoint, err)
 	}
 	g.logger.Info("Registered gRPC-Gateway handlers via endpoint", "service", "staff-service", "endpoint", service.Endpoint)
+	return g.dialService("staff", service)
+}
+
+// dialService connects to a service for the routes the gateway serves itself
+// (see registerExportRoutes and registerHealthRoutes), replacing any previous
+// connection to it.
+func (g *Gateway) dialService(name string, service domain.Service) error {
+	conn, err := grpc.NewClient(service.Endpoint, g.opts...)
+	if err != nil {
+		g.logger.Error("Failed to connect to service", "service", name, "endpoint", service.Endpoint, "error", err)
+		return fmt.Errorf("failed to connect to %s service at %s: %w", name, service.Endpoint, err)
+	}
+
+	g.mu.Lock()
+	previous := g.serviceConns[name]
+	g.serviceConns[name] = conn
+	g.mu.Unlock()
+	if previous != nil {
+		_ = previous.Close()
+	}
 	return nil
 }
@@ -0,0 +1,95 @@
+package gateway
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	coreGrpc "golang-microservices-boilerplate/pkg/core/grpc"
+)
+
+// readinessTimeout bounds the health check of each service on /health/ready.
+const readinessTimeout = 2 * time.Second
+
+// serviceHealth is the readiness of one service in the /health/ready response.
+type serviceHealth struct {
+	Status string `json:"status"`          // SERVING or NOT_SERVING
+	Error  string `json:"error,omitempty"` // Why the check failed
+}
+
+// WithReadinessRequiringServices makes /health/ready fail with 503 unless
+// every discovered service is serving. By default it reports their health
+// without failing, so one unhealthy service does not take the gateway, and
+// with it every other service, out of rotation.
+func WithReadinessRequiringServices(require bool) GatewayOption {
+	return func(g *Gateway) {
+		g.readinessRequiresServices = require
+	}
+}
+
+// registerHealthRoutes adds the probe endpoints. /health/live reports that the
+// gateway process is up; /health/ready lists the health of every discovered
+// service from its grpc.health.v1.Health service. /health is kept as an alias
+// of /health/live.
+func (g *Gateway) registerHealthRoutes() {
+	live := func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "healthy"})
+	}
+	g.app.Get("/health", live)
+	g.app.Get("/health/live", live)
+	g.app.Get("/health/ready", g.handleReady)
+}
+
+// handleReady aggregates the health of the discovered services. The status is
+// "degraded" while some of them are not serving, which fails the probe only
+// with WithReadinessRequiringServices.
+func (g *Gateway) handleReady(c *fiber.Ctx) error {
+	services := g.checkServices(c.UserContext())
+
+	serving := len(services) > 0
+	for _, health := range services {
+		serving = serving && health.Status == "SERVING"
+	}
+	switch {
+	case serving:
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "ready", "services": services})
+	case g.readinessRequiresServices:
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"status": "unavailable", "services": services})
+	}
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "degraded", "services": services})
+}
+
+// checkServices runs the health check of every connected service concurrently.
+func (g *Gateway) checkServices(ctx context.Context) map[string]serviceHealth {
+	g.mu.Lock()
+	checks := make(map[string]coreGrpc.HealthChecker, len(g.serviceConns))
+	for name, conn := range g.serviceConns {
+		checks[name] = coreGrpc.ClientHealthCheck(conn, "")
+	}
+	g.mu.Unlock()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		services = make(map[string]serviceHealth, len(checks))
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check coreGrpc.HealthChecker) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, readinessTimeout)
+			defer cancel()
+			health := serviceHealth{Status: "SERVING"}
+			if err := check(checkCtx); err != nil {
+				health = serviceHealth{Status: "NOT_SERVING", Error: err.Error()}
+			}
+			mu.Lock()
+			services[name] = health
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+	return services
+}
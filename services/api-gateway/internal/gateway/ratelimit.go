@@ -0,0 +1,103 @@
+package gateway
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+
+	coreGrpc "golang-microservices-boilerplate/pkg/core/grpc"
+	"golang-microservices-boilerplate/pkg/core/metrics"
+	"golang-microservices-boilerplate/pkg/core/ratelimit"
+	"golang-microservices-boilerplate/pkg/utils"
+)
+
+// DefaultRateLimitRules throttle signing in per client IP, against password
+// guessing, and the bulk user endpoints per signed-in user.
+func DefaultRateLimitRules() []ratelimit.Rule {
+	return []ratelimit.Rule{
+		{Pattern: "POST /api/v1/auth/login", Key: ratelimit.KeyIP, Limit: ratelimit.Limit{Requests: 5, Period: time.Minute}},
+		{Pattern: "/api/v1/users/bulk/*", Key: ratelimit.KeySubject, Limit: ratelimit.Limit{Requests: 10, Period: time.Minute}},
+	}
+}
+
+// WithRateLimiter rejects the requests exceeding the rules of limiter with 429
+// Too Many Requests. accessTokenSecret verifies the bearer tokens identifying
+// callers of ratelimit.KeySubject rules; callers without a valid one are
+// limited by IP.
+func WithRateLimiter(limiter *ratelimit.Limiter, accessTokenSecret string) GatewayOption {
+	return func(g *Gateway) {
+		g.limiter = limiter
+		g.accessTokenSecret = accessTokenSecret
+	}
+}
+
+// forwardClientIP replaces X-Forwarded-For with the client IP, as resolved by
+// Fiber from the trusted proxies, so that services limiting by IP see the
+// client rather than a spoofable header or the gateway itself.
+func forwardClientIP(c *fiber.Ctx) error {
+	c.Request().Header.Set(fiber.HeaderXForwardedFor, c.IP())
+	return c.Next()
+}
+
+// trustedProxies returns the comma separated IPs and CIDR ranges of
+// TRUSTED_PROXIES, the proxies whose X-Forwarded-For header is believed.
+func trustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(utils.GetEnv("TRUSTED_PROXIES", ""), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
+// rateLimitMiddleware takes a token for each request matching a rule of the
+// limiter. When the store fails, requests are let through and the failure is
+// logged.
+func (g *Gateway) rateLimitMiddleware() fiber.Handler {
+	log := g.logger.Named("http.ratelimit")
+	return func(c *fiber.Ctx) error {
+		rule := g.limiter.Match(c.Method(), c.Path())
+		if rule == nil {
+			return c.Next()
+		}
+		var subject string
+		if rule.Key == ratelimit.KeySubject {
+			subject = coreGrpc.BearerSubject(c.Get(fiber.HeaderAuthorization), g.accessTokenSecret)
+		}
+		caller := ratelimit.Caller(rule.Key, c.IP(), subject, c.Get("X-API-Key"))
+
+		result, err := g.limiter.Allow(c.UserContext(), rule, caller)
+		if err != nil {
+			log.WithContext(c.UserContext()).Error("Rate limit check failed, letting the request through", "path", c.Path(), "error", err)
+			return c.Next()
+		}
+		if !result.Allowed {
+			metrics.RateLimited.WithLabelValues("http", rule.Pattern).Inc()
+			c.Set(fiber.HeaderRetryAfter, retryAfterSeconds(result.RetryAfter))
+			return writeStatus(c, coreGrpc.RateLimitStatus(rule, result))
+		}
+		return c.Next()
+	}
+}
+
+// retryAfterHeader returns the Retry-After value of a status carrying a
+// google.rpc.RetryInfo detail, such as the services' rate limit errors, or "".
+func retryAfterHeader(st *status.Status) string {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return retryAfterSeconds(info.GetRetryDelay().AsDuration())
+		}
+	}
+	return ""
+}
+
+// retryAfterSeconds formats d as a Retry-After value: whole seconds, at least 1.
+func retryAfterSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Max(1, math.Ceil(d.Seconds()))))
+}
@@ -1,6 +1,7 @@
 package adapter
 
 import (
+	"context"
 	"golang-microservices-boilerplate/pkg/core/logger"
 	"log"
 	"os"
@@ -48,6 +49,11 @@ func (a *StdLoggerAdapter) With(args ...interface{}) logger.Logger {
 	return a // No-op implementation for simplicity
 }
 
+// WithContext returns a logger for the span in ctx
+func (a *StdLoggerAdapter) WithContext(ctx context.Context) logger.Logger {
+	return a // No-op implementation for simplicity
+}
+
 // Named returns a logger with the given name
 func (a *StdLoggerAdapter) Named(name string) logger.Logger {
 	newLogger := log.New(os.Stdout, "["+name+"] ", log.LstdFlags|log.Lshortfile)
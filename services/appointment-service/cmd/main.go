@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"os/signal"
@@ -8,8 +9,10 @@ import (
 
 	// Core packages
 
+	coreAudit "golang-microservices-boilerplate/pkg/core/audit"
 	coreGrpc "golang-microservices-boilerplate/pkg/core/grpc"
 	"golang-microservices-boilerplate/pkg/utils"
+	staff_pb "golang-microservices-boilerplate/proto/staff-service"
 )
 
 func main() {
@@ -24,6 +27,24 @@ func main() {
 	logger := setupLogger(appName)
 	logger.Info("Appointment service starting...")
 
+	// --- Subcommands ---
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrateCommand(logger, os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		runSeedCommand(logger, os.Args[2:])
+		return
+	}
+
+	// --- Tracing (buffered spans are flushed on shutdown) ---
+	tracer := setupTracing(logger)
+	defer func() {
+		if err := tracer.Shutdown(context.Background()); err != nil {
+			logger.Error("Error flushing traces", "error", err)
+		}
+	}()
+
 	db := setupDatabase(logger)
 	defer func() {
 		if err := db.Close(); err != nil {
@@ -31,18 +52,25 @@ func main() {
 		}
 	}()
 
+	// --- Domain Events (outbox dispatcher runs until shutdown) ---
+	eventsCtx, stopEvents := context.WithCancel(context.Background())
+	defer stopEvents()
+	setupEvents(eventsCtx, db, logger)
+
 	// Note: setupDependencies now handles staff client creation
-	uc, mapper := setupDependencies(db, logger, staffServiceAddress)
+	uc, mapper, staffClient := setupDependencies(db, logger, staffServiceAddress)
+	defer staffClient.Close()
 
-	// --- Setup gRPC Server (using coreGrpc helper) ---
-	grpcServer := coreGrpc.NewBaseGrpcServer(logger)
+	// --- Setup gRPC Server (idempotency-key aware) ---
+	grpcServer := setupGrpcServer(db, logger)
+	// Scheduling needs the staff service, but reads and cancellations do not, so
+	// a staff outage only degrades the server
+	grpcServer.AddOptionalHealthCheck("staff-service", coreGrpc.ClientHealthCheck(staffClient.Conn, staff_pb.StaffService_ServiceDesc.ServiceName))
 
 	// Register Services using function from setup.go
-	registerServices(grpcServer.Server(), uc, mapper) // Pass mapper
+	registerServices(grpcServer.Server(), uc, mapper, coreAudit.NewStore(db.DB)) // Pass mapper
 	logger.Info("Appointment gRPC service registered")
 
-	// Health check and Reflection are typically handled within NewBaseGrpcServer or its Start method
-
 	// --- Start Server ---
 	if err := grpcServer.Start(); err != nil {
 		logger.Fatal("Failed to start gRPC server", "error", err)
@@ -1,18 +1,28 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
+	"time"
 
 	"google.golang.org/grpc"
 
+	coreAudit "golang-microservices-boilerplate/pkg/core/audit"
 	coreDatabase "golang-microservices-boilerplate/pkg/core/database"
+	coreEvents "golang-microservices-boilerplate/pkg/core/events"
 	coreGrpc "golang-microservices-boilerplate/pkg/core/grpc"
+	coreIdempotency "golang-microservices-boilerplate/pkg/core/idempotency"
 	coreLogger "golang-microservices-boilerplate/pkg/core/logger"
+	coreMigrations "golang-microservices-boilerplate/pkg/core/migrations"
+	coreTracing "golang-microservices-boilerplate/pkg/core/tracing"
+	"golang-microservices-boilerplate/pkg/utils"
+	appointment_pb "golang-microservices-boilerplate/proto/appointment-service"
 	staff_pb "golang-microservices-boilerplate/proto/staff-service"
 	"golang-microservices-boilerplate/services/appointment-service/internal/controller"
-	"golang-microservices-boilerplate/services/appointment-service/internal/entity"
 	appointmentRepoGorm "golang-microservices-boilerplate/services/appointment-service/internal/repository"
 	appointmentUseCase "golang-microservices-boilerplate/services/appointment-service/internal/usecase"
+	"golang-microservices-boilerplate/services/appointment-service/migrations"
 )
 
 // setupLogger initializes the logger based on environment configuration.
@@ -27,30 +37,121 @@ func setupLogger(appName string) coreLogger.Logger {
 	return logger
 }
 
-// setupDatabase initializes the database connection and performs migrations.
-func setupDatabase(logger coreLogger.Logger) *coreDatabase.DatabaseConnection {
-	dbConfig := coreDatabase.DefaultDBConfig()
+// setupTracing installs the tracer provider selected by TRACING_EXPORTER.
+func setupTracing(logger coreLogger.Logger) *coreTracing.Provider {
+	tracer, err := coreTracing.Setup(context.Background(), coreTracing.ConfigFromEnv("appointment-service"))
+	if err != nil {
+		logger.Fatal("Failed to set up tracing", "error", err)
+	}
+	return tracer
+}
+
+// connectDatabase opens the database connection.
+func connectDatabase(logger coreLogger.Logger) *coreDatabase.DatabaseConnection {
+	dbConfig := coreDatabase.DefaultDBConfig() // Load config from env or defaults
 	db, err := coreDatabase.NewDatabaseConnection(dbConfig)
 	if err != nil {
 		logger.Fatal("Failed to connect to database", "error", err)
 	}
 	logger.Info("Database connection established")
+	return db
+}
+
+// newMigrator returns the migrator for the service tables and the shared core tables.
+func newMigrator(db *coreDatabase.DatabaseConnection) *coreDatabase.Migrator {
+	return coreDatabase.NewMigrator(db.DB, migrations.Source, coreMigrations.Source)
+}
 
-	// Auto-migrate schema
-	if err := db.MigrateModels(&entity.Appointment{}); err != nil {
+// newSeeder returns the seeder for the reference data of the service tables.
+// The appointment-service has no reference tables yet; give it a seeds package like
+// the staff-service's when it gets one.
+func newSeeder(db *coreDatabase.DatabaseConnection) *coreDatabase.Seeder {
+	return coreDatabase.NewSeeder(db.DB)
+}
+
+// runMigrateCommand runs the "migrate up|down|status" subcommand.
+func runMigrateCommand(logger coreLogger.Logger, args []string) {
+	db := connectDatabase(logger)
+	err := coreDatabase.RunMigrateCommand(context.Background(), newMigrator(db), args, os.Stdout)
+	_ = db.Close()
+	if err != nil {
+		logger.Fatal("Migration command failed", "error", err)
+	}
+}
+
+// runSeedCommand runs the "seed [apply]|list" subcommand.
+func runSeedCommand(logger coreLogger.Logger, args []string) {
+	db := connectDatabase(logger)
+	err := coreDatabase.RunSeedCommand(context.Background(), newSeeder(db), args, os.Stdout)
+	_ = db.Close()
+	if err != nil {
+		logger.Fatal("Seed command failed", "error", err)
+	}
+}
+
+// setupDatabase initializes the database connection and applies pending migrations.
+func setupDatabase(logger coreLogger.Logger) *coreDatabase.DatabaseConnection {
+	db := connectDatabase(logger)
+
+	// Record an audit trail of every entity change
+	if err := coreAudit.Register(db.DB); err != nil {
 		_ = db.Close()
-		logger.Fatal("Failed to auto-migrate database schema", "error", err)
+		logger.Fatal("Failed to register audit callbacks", "error", err)
+	}
+
+	// Write recorded domain events to the outbox in the transaction of each change
+	if err := coreEvents.Register(db.DB); err != nil {
+		_ = db.Close()
+		logger.Fatal("Failed to register outbox callbacks", "error", err)
+	}
+
+	// Apply pending versioned migrations unless deployments run "migrate up" themselves
+	if db.Config.MigrateOnStart {
+		applied, err := newMigrator(db).Up(context.Background())
+		if err != nil {
+			_ = db.Close()
+			logger.Fatal("Failed to migrate database schema", "error", err)
+		}
+		logger.Info("Database schema migrated", "applied", len(applied))
+	}
+
+	// Upsert reference data such as lookup tables unless deployments run "seed" themselves
+	if db.Config.SeedOnStart {
+		results, err := newSeeder(db).Seed(context.Background())
+		if err != nil {
+			_ = db.Close()
+			logger.Fatal("Failed to seed reference data", "error", err)
+		}
+		logger.Info("Reference data seeded", "files", len(results))
 	}
-	logger.Info("Database schema migrated")
 	return db
 }
 
-// setupDependencies initializes and returns the core dependencies: repository, use case, mapper, and staff client adapter.
-func setupDependencies(db *coreDatabase.DatabaseConnection, logger coreLogger.Logger, staffServiceAddress string) (appointmentUseCase.AppointmentUseCase, controller.Mapper) {
-	// Staff Service gRPC Client
+// setupEvents starts dispatching the outbox until ctx is cancelled and returns
+// the bus delivering domain events to this service's handlers.
+func setupEvents(ctx context.Context, db *coreDatabase.DatabaseConnection, logger coreLogger.Logger) *coreEvents.Bus {
+	bus := coreEvents.NewBus()
+	if err := coreEvents.Start(ctx, db.DB, db.Config.URI, bus, logger, coreEvents.DefaultConfig()); err != nil {
+		logger.Fatal("Failed to start event dispatcher", "error", err)
+	}
+	return bus
+}
+
+// setupDependencies initializes and returns the core dependencies: use case, mapper, and the
+// staff service client behind the use case's adapter.
+func setupDependencies(db *coreDatabase.DatabaseConnection, logger coreLogger.Logger, staffServiceAddress string) (appointmentUseCase.AppointmentUseCase, controller.Mapper, *coreGrpc.BaseGrpcClient) {
+	// Staff Service gRPC Client. Availability lookups are read-only, so they are
+	// retried; a slow or failing staff-service trips the breaker instead of
+	// holding up scheduling.
+	resilience := coreGrpc.DefaultResilienceConfig()
+	resilience.Timeout = utils.GetEnvDuration("STAFF_SERVICE_TIMEOUT", 3*time.Second)
+	resilience.IdempotentMethods = []string{staff_pb.StaffService_GetDoctorAvailability_FullMethodName}
 	staffClientConn, err := coreGrpc.NewBaseGrpcClient(logger, &coreGrpc.GrpcClientConfig{
+		ServiceName:            "staff-service",
 		ServiceHost:            staffServiceAddress,
-		AllowInsecureTransport: true,
+		AllowInsecureTransport: true, // Used only when TLS_* is not configured
+		TLS:                    coreGrpc.TLSConfigFromEnv(),
+		Resilience:             resilience,
 	})
 	if err != nil {
 		logger.Fatal("Failed to connect to staff service", "address", staffServiceAddress, "error", err)
@@ -66,10 +167,39 @@ func setupDependencies(db *coreDatabase.DatabaseConnection, logger coreLogger.Lo
 	uc := appointmentUseCase.NewAppointmentUseCase(repo, staffAdapter, logger)
 	mapper := controller.NewAppointmentMapper() // Instantiate the correct mapper
 
-	return uc, mapper
+	return uc, mapper, staffClientConn
 }
 
 // registerServices registers all gRPC services with the server.
-func registerServices(s *grpc.Server, uc appointmentUseCase.AppointmentUseCase, mapper controller.Mapper) {
-	controller.RegisterAppointmentServiceServer(s, uc, mapper)
+func registerServices(s *grpc.Server, uc appointmentUseCase.AppointmentUseCase, mapper controller.Mapper, auditEvents coreAudit.Reader) {
+	controller.RegisterAppointmentServiceServer(s, uc, mapper, auditEvents)
+}
+
+// authPolicy lets every signed-in user work with the service, while deleting,
+// restoring and purging, bulk exports, and reading the audit trail need
+// elevated roles.
+var authPolicy = &coreGrpc.AuthPolicy{
+	Roles: map[string][]string{
+		appointment_pb.AppointmentService_DeleteAppointment_FullMethodName:        {"admin", "manager"},
+		appointment_pb.AppointmentService_RestoreAppointment_FullMethodName:       {"admin", "manager"},
+		appointment_pb.AppointmentService_ListDeletedAppointments_FullMethodName:  {"admin", "manager"},
+		appointment_pb.AppointmentService_ExportAppointments_FullMethodName:       {"admin", "manager"},
+		appointment_pb.AppointmentService_PurgeDeletedAppointments_FullMethodName: {"admin"},
+		appointment_pb.AppointmentService_ListAuditEvents_FullMethodName:          {"admin"},
+	},
+}
+
+// setupGrpcServer creates the gRPC server. Repeated ScheduleAppointment calls carrying the
+// same Idempotency-Key get the response of the first call. The server reports
+// NOT_SERVING while the database is unreachable.
+func setupGrpcServer(db *coreDatabase.DatabaseConnection, logger coreLogger.Logger) *coreGrpc.BaseGrpcServer {
+	store := coreIdempotency.NewStore(db.DB, coreIdempotency.DefaultConfig())
+	config := coreGrpc.DefaultGrpcServerConfig()
+	config.AuthPolicy = authPolicy
+	config.UnaryInterceptors = append(config.UnaryInterceptors,
+		coreGrpc.IdempotencyUnaryServerInterceptor(store, appointment_pb.AppointmentService_ScheduleAppointment_FullMethodName),
+	)
+	server := coreGrpc.NewBaseGrpcServerWithConfig(logger, config)
+	server.AddHealthCheck("database", db.Ping)
+	return server
 }
@@ -2,8 +2,6 @@ package controller
 
 import (
 	"context"
-	"errors"
-	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,8 +10,12 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
-	core_usecase "golang-microservices-boilerplate/pkg/core/usecase"
+	"golang-microservices-boilerplate/pkg/core/audit"
+	coreGrpc "golang-microservices-boilerplate/pkg/core/grpc"
+	coreTypes "golang-microservices-boilerplate/pkg/core/types"
 	pb "golang-microservices-boilerplate/proto/appointment-service" // Used indirectly
+	corepb "golang-microservices-boilerplate/proto/core"
+	"golang-microservices-boilerplate/services/appointment-service/internal/entity"
 	"golang-microservices-boilerplate/services/appointment-service/internal/usecase"
 )
 
@@ -31,21 +33,23 @@ type appointmentServer struct {
 	pb.UnimplementedAppointmentServiceServer // Embed for forward compatibility
 	uc                                       usecase.AppointmentUseCase
 	mapper                                   Mapper // Use interface
+	audit                                    *coreGrpc.AuditEventServer
 }
 
 // NewAppointmentServer creates a new gRPC server instance.
 // Accepts Mapper interface and returns AppointmentServer interface.
-func NewAppointmentServer(uc usecase.AppointmentUseCase, mapper Mapper) AppointmentServer {
+func NewAppointmentServer(uc usecase.AppointmentUseCase, mapper Mapper, auditEvents audit.Reader) AppointmentServer {
 	return &appointmentServer{
 		uc:     uc,
 		mapper: mapper,
+		audit:  coreGrpc.NewAuditEventServer(auditEvents),
 	}
 }
 
 // RegisterAppointmentServiceServer registers the appointment service implementation with the gRPC server.
 // Accepts use case and mapper.
-func RegisterAppointmentServiceServer(s *grpc.Server, uc usecase.AppointmentUseCase, mapper Mapper) {
-	server := NewAppointmentServer(uc, mapper)
+func RegisterAppointmentServiceServer(s *grpc.Server, uc usecase.AppointmentUseCase, mapper Mapper, auditEvents audit.Reader) {
+	server := NewAppointmentServer(uc, mapper, auditEvents)
 	pb.RegisterAppointmentServiceServer(s, server)
 }
 
@@ -54,7 +58,7 @@ func (s *appointmentServer) ScheduleAppointment(ctx context.Context, req *pb.Sch
 	// Use case already accepts the proto request, no mapping needed here.
 	aptEntity, err := s.uc.ScheduleAppointment(ctx, req)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	aptProto, err := s.mapper.EntityToProto(aptEntity)
@@ -75,7 +79,7 @@ func (s *appointmentServer) GetAppointmentDetails(ctx context.Context, req *pb.G
 
 	aptEntity, err := s.uc.GetAppointmentDetails(ctx, appointmentID)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	aptProto, err := s.mapper.EntityToProto(aptEntity)
@@ -96,7 +100,7 @@ func (s *appointmentServer) UpdateAppointmentStatus(ctx context.Context, req *pb
 	// Use case already accepts the proto request.
 	aptEntity, err := s.uc.UpdateAppointmentStatus(ctx, appointmentID, req)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	aptProto, err := s.mapper.EntityToProto(aptEntity)
@@ -117,7 +121,7 @@ func (s *appointmentServer) RescheduleAppointment(ctx context.Context, req *pb.R
 	// Use case already accepts the proto request.
 	aptEntity, err := s.uc.RescheduleAppointment(ctx, appointmentID, req)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	aptProto, err := s.mapper.EntityToProto(aptEntity)
@@ -137,7 +141,7 @@ func (s *appointmentServer) CancelAppointment(ctx context.Context, req *pb.Cance
 
 	err = s.uc.CancelAppointment(ctx, appointmentID)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	return &emptypb.Empty{}, nil
@@ -150,18 +154,21 @@ func (s *appointmentServer) GetAppointmentsForPatient(ctx context.Context, req *
 		return nil, status.Errorf(codes.InvalidArgument, "invalid patient ID format: %v", err)
 	}
 
-	aptEntities, err := s.uc.GetAppointmentsForPatient(ctx, patientID)
+	result, err := s.uc.GetAppointmentsForPatient(ctx, patientID, appointmentListOptions(req.Options, true))
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
-	aptProtos, err := s.mapper.EntitiesToProto(aptEntities)
+	aptProtos, err := s.mapper.EntitiesToProto(result.Items)
 	if err != nil {
 		// This indicates an internal issue with mapping potentially valid data
 		return nil, status.Errorf(codes.Internal, "failed to map results to proto: %v", err)
 	}
 
-	return &pb.GetAppointmentsForPatientResponse{Appointments: aptProtos}, nil
+	return &pb.GetAppointmentsForPatientResponse{
+		Appointments:   aptProtos,
+		PaginationInfo: coreGrpc.PaginationInfoToProto(result),
+	}, nil
 }
 
 // GetAppointmentsForDoctor implements the corresponding gRPC method.
@@ -183,40 +190,134 @@ func (s *appointmentServer) GetAppointmentsForDoctor(ctx context.Context, req *p
 		return nil, status.Errorf(codes.InvalidArgument, "invalid time range provided")
 	}
 
-	aptEntities, err := s.uc.GetAppointmentsForDoctor(ctx, doctorID, startTime, endTime)
+	result, err := s.uc.GetAppointmentsForDoctor(ctx, doctorID, startTime, endTime, appointmentListOptions(req.Options, false))
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
-	aptProtos, err := s.mapper.EntitiesToProto(aptEntities)
+	aptProtos, err := s.mapper.EntitiesToProto(result.Items)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to map results to proto: %v", err)
 	}
 
-	return &pb.GetAppointmentsForDoctorResponse{Appointments: aptProtos}, nil
-}
-
-// mapUseCaseErrorToGrpcStatus converts use case errors to gRPC status errors.
-func mapUseCaseErrorToGrpcStatus(err error) error {
-	var ucErr *core_usecase.UseCaseError
-	if errors.As(err, &ucErr) {
-		switch ucErr.Type {
-		case core_usecase.ErrNotFound:
-			return status.Error(codes.NotFound, ucErr.Message)
-		case core_usecase.ErrInvalidInput:
-			return status.Error(codes.InvalidArgument, ucErr.Message)
-		case core_usecase.ErrConflict:
-			return status.Error(codes.AlreadyExists, ucErr.Message) // Or codes.FailedPrecondition / codes.Aborted
-		case core_usecase.ErrInternal:
-			return status.Error(codes.Internal, ucErr.Message)
-		default:
-			// Log the unknown type for debugging
-			// log.Printf("Unknown use case error type: %v, message: %s", ucErr.Type, ucErr.Message)
-			return status.Error(codes.Unknown, fmt.Sprintf("unknown use case error type: %v", ucErr.Type))
+	return &pb.GetAppointmentsForDoctorResponse{
+		Appointments:   aptProtos,
+		PaginationInfo: coreGrpc.PaginationInfoToProto(result),
+	}, nil
+}
+
+// appointmentListOptions converts the options of a list request. Unless the
+// client chose a sort_by, appointments are sorted by appointment_time,
+// descending when desc is set and sort_desc is not.
+func appointmentListOptions(in *corepb.FilterOptions, desc bool) coreTypes.FilterOptions {
+	opts := coreGrpc.FilterOptionsFromProto(in)
+	if in.GetSortBy() == "" {
+		opts.SortBy = "appointment_time"
+		if in == nil || in.SortDesc == nil {
+			opts.SortDesc = desc
+		}
+	}
+	return opts
+}
+
+// ExportAppointments implements the corresponding gRPC method, streaming
+// appointments as they are read instead of collecting them first.
+func (s *appointmentServer) ExportAppointments(req *pb.ExportAppointmentsRequest, stream grpc.ServerStreamingServer[pb.Appointment]) error {
+	var doctorID, patientID uuid.UUID
+	var err error
+	if req.DoctorId != "" {
+		if doctorID, err = uuid.Parse(req.DoctorId); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid doctor ID format: %v", err)
+		}
+	}
+	if req.PatientId != "" {
+		if patientID, err = uuid.Parse(req.PatientId); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid patient ID format: %v", err)
 		}
 	}
-	// If it's not a UseCaseError, treat it as an internal server error.
-	// Log the original error for debugging.
-	// log.Printf("Unhandled internal error: %v", err) // Replace with proper logging
-	return status.Error(codes.Internal, "an unexpected error occurred")
+	startTime := time.Time{}
+	if req.StartTime != nil {
+		startTime = req.StartTime.AsTime()
+	}
+	endTime := time.Time{}
+	if req.EndTime != nil {
+		endTime = req.EndTime.AsTime()
+	}
+
+	err = s.uc.ExportAppointments(stream.Context(), doctorID, patientID, startTime, endTime, coreGrpc.FilterOptionsFromProto(req.Options),
+		func(apt *entity.Appointment) error {
+			aptProto, err := s.mapper.EntityToProto(apt)
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to map appointment to proto: %v", err)
+			}
+			return stream.Send(aptProto)
+		})
+	return coreGrpc.ErrorToStatus(err)
+}
+
+// DeleteAppointment implements the corresponding gRPC method.
+func (s *appointmentServer) DeleteAppointment(ctx context.Context, req *pb.DeleteAppointmentRequest) (*emptypb.Empty, error) {
+	appointmentID, err := uuid.Parse(req.AppointmentId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid appointment ID format: %v", err)
+	}
+
+	if err := s.uc.Delete(ctx, appointmentID, req.HardDelete); err != nil {
+		return nil, coreGrpc.ErrorToStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// RestoreAppointment implements the corresponding gRPC method.
+func (s *appointmentServer) RestoreAppointment(ctx context.Context, req *pb.RestoreAppointmentRequest) (*pb.RestoreAppointmentResponse, error) {
+	appointmentID, err := uuid.Parse(req.AppointmentId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid appointment ID format: %v", err)
+	}
+
+	aptEntity, err := s.uc.Restore(ctx, appointmentID)
+	if err != nil {
+		return nil, coreGrpc.ErrorToStatus(err)
+	}
+
+	aptProto, err := s.mapper.EntityToProto(aptEntity)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to map result to proto: %v", err)
+	}
+
+	return &pb.RestoreAppointmentResponse{Appointment: aptProto}, nil
+}
+
+// ListDeletedAppointments implements the corresponding gRPC method.
+func (s *appointmentServer) ListDeletedAppointments(ctx context.Context, req *pb.ListDeletedAppointmentsRequest) (*pb.ListDeletedAppointmentsResponse, error) {
+	result, err := s.uc.ListDeleted(ctx, coreGrpc.FilterOptionsFromProto(req.Options))
+	if err != nil {
+		return nil, coreGrpc.ErrorToStatus(err)
+	}
+
+	aptProtos, err := s.mapper.EntitiesToProto(result.Items)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to map results to proto: %v", err)
+	}
+
+	return &pb.ListDeletedAppointmentsResponse{
+		Appointments:   aptProtos,
+		PaginationInfo: coreGrpc.PaginationInfoToProto(result),
+	}, nil
+}
+
+// PurgeDeletedAppointments implements the corresponding gRPC method.
+func (s *appointmentServer) PurgeDeletedAppointments(ctx context.Context, req *pb.PurgeDeletedAppointmentsRequest) (*pb.PurgeDeletedAppointmentsResponse, error) {
+	purged, err := s.uc.PurgeDeleted(ctx, int(req.OlderThanDays))
+	if err != nil {
+		return nil, coreGrpc.ErrorToStatus(err)
+	}
+
+	return &pb.PurgeDeletedAppointmentsResponse{PurgedCount: purged}, nil
+}
+
+// ListAuditEvents implements the corresponding gRPC method.
+func (s *appointmentServer) ListAuditEvents(ctx context.Context, req *corepb.ListAuditEventsRequest) (*corepb.ListAuditEventsResponse, error) {
+	return s.audit.ListAuditEvents(ctx, req)
 }
@@ -40,6 +40,11 @@ func (m *AppointmentMapper) EntityToProto(apt *entity.Appointment) (*pb.Appointm
 		return nil, fmt.Errorf("failed to map status: %w", err)
 	}
 
+	var deletedAt *timestamppb.Timestamp
+	if apt.DeletedAt.Valid {
+		deletedAt = timestamppb.New(apt.DeletedAt.Time)
+	}
+
 	return &pb.Appointment{
 		Id:              apt.ID.String(),
 		PatientId:       apt.PatientID.String(),
@@ -51,6 +56,8 @@ func (m *AppointmentMapper) EntityToProto(apt *entity.Appointment) (*pb.Appointm
 		Notes:           apt.Notes,
 		CreatedAt:       timestamppb.New(apt.CreatedAt),
 		UpdatedAt:       timestamppb.New(apt.UpdatedAt),
+		Version:         apt.Version,
+		DeletedAt:       deletedAt,
 	}, nil
 }
 
@@ -39,6 +39,17 @@ type Appointment struct {
 	// Doctor  *staffEntity.Staff    `json:"doctor,omitempty" gorm:"foreignKey:DoctorID"`   // Define staffEntity alias if needed
 }
 
+// FilterableColumns lists the columns clients may filter appointments by.
+func (a Appointment) FilterableColumns() []string {
+	return append(a.BaseEntity.FilterableColumns(),
+		"patient_id", "doctor_id", "appointment_time", "duration", "status", "place")
+}
+
+// SortableColumns lists the columns clients may sort appointments by.
+func (a Appointment) SortableColumns() []string {
+	return append(a.BaseEntity.SortableColumns(), "appointment_time", "duration", "status", "place")
+}
+
 // DoctorAvailability struct might not be needed here if availability is primarily managed
 // by the Staff service and checked via the client interface.
 // If kept, it should also embed BaseEntity and use UUIDs.
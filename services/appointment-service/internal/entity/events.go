@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Names of the domain events published by the appointment service. Other
+// services subscribe to these names.
+const (
+	AppointmentScheduledEvent     = "appointment.scheduled"
+	AppointmentStatusChangedEvent = "appointment.status_changed"
+	AppointmentRescheduledEvent   = "appointment.rescheduled"
+)
+
+// AppointmentScheduled is published when a new appointment was booked. It holds
+// the appointment itself so the payload carries the ID assigned on insert.
+type AppointmentScheduled struct {
+	Appointment *Appointment
+}
+
+// EventName implements events.Event.
+func (e AppointmentScheduled) EventName() string { return AppointmentScheduledEvent }
+
+// AggregateID implements events.Event.
+func (e AppointmentScheduled) AggregateID() string { return e.Appointment.ID.String() }
+
+// MarshalJSON encodes the event payload.
+func (e AppointmentScheduled) MarshalJSON() ([]byte, error) {
+	a := e.Appointment
+	return json.Marshal(struct {
+		AppointmentID   uuid.UUID `json:"appointment_id"`
+		PatientID       uuid.UUID `json:"patient_id"`
+		DoctorID        uuid.UUID `json:"doctor_id"`
+		AppointmentTime time.Time `json:"appointment_time"`
+		DurationSeconds int64     `json:"duration_seconds"`
+		Reason          string    `json:"reason"`
+		Place           string    `json:"place,omitempty"`
+	}{a.ID, a.PatientID, a.DoctorID, a.AppointmentTime, int64(a.Duration / time.Second), a.Reason, a.Place})
+}
+
+// AppointmentStatusChanged is published when the status of an appointment
+// changed, including cancellations.
+type AppointmentStatusChanged struct {
+	AppointmentID uuid.UUID         `json:"appointment_id"`
+	PatientID     uuid.UUID         `json:"patient_id"`
+	DoctorID      uuid.UUID         `json:"doctor_id"`
+	From          AppointmentStatus `json:"from"`
+	To            AppointmentStatus `json:"to"`
+}
+
+// EventName implements events.Event.
+func (e AppointmentStatusChanged) EventName() string { return AppointmentStatusChangedEvent }
+
+// AggregateID implements events.Event.
+func (e AppointmentStatusChanged) AggregateID() string { return e.AppointmentID.String() }
+
+// AppointmentRescheduled is published when an appointment was moved to a new
+// time, duration or place.
+type AppointmentRescheduled struct {
+	AppointmentID   uuid.UUID `json:"appointment_id"`
+	PatientID       uuid.UUID `json:"patient_id"`
+	DoctorID        uuid.UUID `json:"doctor_id"`
+	AppointmentTime time.Time `json:"appointment_time"`
+	DurationSeconds int64     `json:"duration_seconds"`
+	Place           string    `json:"place,omitempty"`
+}
+
+// EventName implements events.Event.
+func (e AppointmentRescheduled) EventName() string { return AppointmentRescheduledEvent }
+
+// AggregateID implements events.Event.
+func (e AppointmentRescheduled) AggregateID() string { return e.AppointmentID.String() }
@@ -34,10 +34,10 @@ func NewGormAppointmentRepository(db *gorm.DB) *GormAppointmentRepository {
 func (r *GormAppointmentRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Appointment, error) {
     // Example: Preload patient and doctor info if needed often
     appointment := &entity.Appointment{}
-    err := r.DB.WithContext(ctx).Preload("Patient").Preload("Doctor").First(appointment, "id = ?", id).Error
+    err := r.ReadConn(ctx).Preload("Patient").Preload("Doctor").First(appointment, "id = ?", id).Error
     if err != nil {
         if errors.Is(err, gorm.ErrRecordNotFound) {
-            return nil, errors.New("entity not found")
+            return nil, coreRepository.NewNotFoundError(r.ModelType.Name(), id)
         }
         return nil, err
     }
@@ -48,14 +48,14 @@ func (r *GormAppointmentRepository) FindByID(ctx context.Context, id uuid.UUID)
 // FindByPatientID retrieves appointments for a specific patient.
 func (r *GormAppointmentRepository) FindByPatientID(ctx context.Context, patientID uuid.UUID) ([]*entity.Appointment, error) {
 	var appointments []*entity.Appointment
-	err := r.DB.WithContext(ctx).Where("patient_id = ?", patientID).Order("appointment_time DESC").Find(&appointments).Error
+	err := r.Conn(ctx).Where("patient_id = ?", patientID).Order("appointment_time DESC").Find(&appointments).Error
 	return appointments, err
 }
 
 // FindByDoctorID retrieves appointments for a specific doctor within a time range.
 func (r *GormAppointmentRepository) FindByDoctorID(ctx context.Context, doctorID uuid.UUID, startTime time.Time, endTime time.Time) ([]*entity.Appointment, error) {
 	var appointments []*entity.Appointment
-	err := r.DB.WithContext(ctx).
+	err := r.Conn(ctx).
 		Where("doctor_id = ?", doctorID).
 		Where("appointment_time >= ?", startTime).
 		Where("appointment_time < ?", endTime).
@@ -67,7 +67,7 @@ func (r *GormAppointmentRepository) FindByDoctorID(ctx context.Context, doctorID
 // FindByDateRange retrieves all appointments within a specific date range.
 func (r *GormAppointmentRepository) FindByDateRange(ctx context.Context, startTime time.Time, endTime time.Time) ([]*entity.Appointment, error) {
 	var appointments []*entity.Appointment
-	err := r.DB.WithContext(ctx).
+	err := r.Conn(ctx).
 		Where("appointment_time >= ?", startTime).
 		Where("appointment_time < ?", endTime).
 		Order("appointment_time ASC").
@@ -79,7 +79,7 @@ func (r *GormAppointmentRepository) FindByDateRange(ctx context.Context, startTi
 // Returns true if NO conflicting appointments are found (slot is free), false otherwise.
 func (r *GormAppointmentRepository) CheckDoctorAvailability(ctx context.Context, doctorID uuid.UUID, startTime time.Time, endTime time.Time) (bool, error) {
 	var count int64
-	err := r.DB.WithContext(ctx).Model(&entity.Appointment{}).
+	err := r.Conn(ctx).Model(&entity.Appointment{}).
 		Where("doctor_id = ?", doctorID).
 		Where("status != ?", entity.Cancelled).                                                // Don't count cancelled appointments as conflicts
 		Where("appointment_time < ?", endTime).                                                // Existing appointment starts before the new one ends
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	coreTypes "golang-microservices-boilerplate/pkg/core/types"
 	coreUseCase "golang-microservices-boilerplate/pkg/core/usecase"
 	pb "golang-microservices-boilerplate/proto/appointment-service" // Import proto
 	"golang-microservices-boilerplate/services/appointment-service/internal/entity"
@@ -33,11 +34,16 @@ type AppointmentUseCase interface {
 	// CancelAppointment cancels an existing appointment.
 	CancelAppointment(ctx context.Context, appointmentID uuid.UUID) error
 
-	// GetAppointmentsForPatient retrieves all appointments for a given patient.
-	GetAppointmentsForPatient(ctx context.Context, patientID uuid.UUID) ([]*entity.Appointment, error)
+	// GetAppointmentsForPatient retrieves the appointments of a patient matching opts with pagination.
+	GetAppointmentsForPatient(ctx context.Context, patientID uuid.UUID, opts coreTypes.FilterOptions) (*coreTypes.PaginationResult[entity.Appointment], error)
 
-	// GetAppointmentsForDoctor retrieves appointments for a doctor within a time range.
-	GetAppointmentsForDoctor(ctx context.Context, doctorID uuid.UUID, startTime time.Time, endTime time.Time) ([]*entity.Appointment, error)
+	// GetAppointmentsForDoctor retrieves the appointments of a doctor within a time range
+	// matching opts with pagination.
+	GetAppointmentsForDoctor(ctx context.Context, doctorID uuid.UUID, startTime time.Time, endTime time.Time, opts coreTypes.FilterOptions) (*coreTypes.PaginationResult[entity.Appointment], error)
+
+	// ExportAppointments calls fn for every appointment matching opts, reading them page by page.
+	// A non-nil doctorID or patientID and non-zero startTime or endTime narrow the export down.
+	ExportAppointments(ctx context.Context, doctorID, patientID uuid.UUID, startTime, endTime time.Time, opts coreTypes.FilterOptions, fn func(*entity.Appointment) error) error
 
 	// CheckDoctorAvailability checks if a specific time slot is free for a doctor.
 	// Returns true if available, false otherwise.
@@ -9,8 +9,11 @@ import (
 	pb "golang-microservices-boilerplate/proto/appointment-service"
 	staff_pb "golang-microservices-boilerplate/proto/staff-service"
 
+	coreDatabase "golang-microservices-boilerplate/pkg/core/database"
+	coreEvents "golang-microservices-boilerplate/pkg/core/events"
 	coreLogger "golang-microservices-boilerplate/pkg/core/logger"
 	coreRepository "golang-microservices-boilerplate/pkg/core/repository"
+	coreTypes "golang-microservices-boilerplate/pkg/core/types"
 	coreUseCase "golang-microservices-boilerplate/pkg/core/usecase"
 
 	// coreDTO "golang-microservices-boilerplate/pkg/core/dto"
@@ -61,7 +64,7 @@ func NewGrpcStaffServiceClientAdapter(client staff_pb.StaffServiceClient, logger
 // GetDoctorAvailability implements the StaffServiceClient interface.
 // Updated to return []AvailableTimeSlot
 func (a *grpcStaffServiceClientAdapter) GetDoctorAvailability(ctx context.Context, doctorID uuid.UUID, startTime time.Time, endTime time.Time) ([]AvailableTimeSlot, error) {
-	a.logger.Debug("Calling StaffService.GetDoctorAvailability via gRPC adapter", "doctorID", doctorID, "start", startTime, "end", endTime)
+	a.logger.WithContext(ctx).Debug("Calling StaffService.GetDoctorAvailability via gRPC adapter", "doctorID", doctorID, "start", startTime, "end", endTime)
 
 	protoReq := &staff_pb.GetDoctorAvailabilityRequest{
 		DoctorId:  doctorID.String(),
@@ -74,7 +77,7 @@ func (a *grpcStaffServiceClientAdapter) GetDoctorAvailability(ctx context.Contex
 		// Basic gRPC error handling
 		st, ok := status.FromError(err)
 		if ok {
-			a.logger.Error("StaffService.GetDoctorAvailability gRPC error", "code", st.Code(), "message", st.Message())
+			a.logger.WithContext(ctx).Error("StaffService.GetDoctorAvailability gRPC error", "code", st.Code(), "message", st.Message())
 			// Map gRPC status codes to potential coreUseCase errors if needed
 			if st.Code() == codes.NotFound {
 				return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrNotFound, "doctor not found or not available in staff service")
@@ -83,7 +86,7 @@ func (a *grpcStaffServiceClientAdapter) GetDoctorAvailability(ctx context.Contex
 			return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, fmt.Sprintf("staff service communication error: %s", st.Message()))
 		}
 		// Handle non-gRPC errors
-		a.logger.Error("StaffService.GetDoctorAvailability non-gRPC error", "error", err)
+		a.logger.WithContext(ctx).Error("StaffService.GetDoctorAvailability non-gRPC error", "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, fmt.Sprintf("failed to call staff service: %v", err))
 	}
 
@@ -99,7 +102,7 @@ func (a *grpcStaffServiceClientAdapter) GetDoctorAvailability(ctx context.Contex
 		}
 		// Basic validation on response data
 		if protoSlot.StartTime == nil || protoSlot.EndTime == nil || !protoSlot.StartTime.IsValid() || !protoSlot.EndTime.IsValid() || protoSlot.StartTime.AsTime().After(protoSlot.EndTime.AsTime()) {
-			a.logger.Warn("Received invalid time slot from StaffService", "start", protoSlot.StartTime, "end", protoSlot.EndTime)
+			a.logger.WithContext(ctx).Warn("Received invalid time slot from StaffService", "start", protoSlot.StartTime, "end", protoSlot.EndTime)
 			continue
 		}
 
@@ -110,7 +113,7 @@ func (a *grpcStaffServiceClientAdapter) GetDoctorAvailability(ctx context.Contex
 		})
 	}
 
-	a.logger.Debug("Received available time slots from StaffService", "count", len(entitySlots))
+	a.logger.WithContext(ctx).Debug("Received available time slots from StaffService", "count", len(entitySlots))
 	return entitySlots, nil
 }
 
@@ -149,7 +152,7 @@ func NewAppointmentUseCase(
 
 // CheckDoctorAvailability checks if a doctor is available.
 func (uc *appointmentUseCase) CheckDoctorAvailability(ctx context.Context, doctorID uuid.UUID, startTime time.Time, endTime time.Time) (bool, error) {
-	uc.logger.Debug("Checking doctor availability", "doctorID", doctorID, "start", startTime, "end", endTime)
+	uc.logger.WithContext(ctx).Debug("Checking doctor availability", "doctorID", doctorID, "start", startTime, "end", endTime)
 	if doctorID == uuid.Nil {
 		return false, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid doctor ID")
 	}
@@ -163,16 +166,16 @@ func (uc *appointmentUseCase) CheckDoctorAvailability(ctx context.Context, docto
 		// Check if the error is a UseCaseError indicating NotFound
 		var ucErr *coreUseCase.UseCaseError
 		if errors.As(err, &ucErr) && ucErr.Type == coreUseCase.ErrNotFound {
-			uc.logger.Info("Doctor not found or unavailable via StaffService", "doctorID", doctorID)
+			uc.logger.WithContext(ctx).Info("Doctor not found or unavailable via StaffService", "doctorID", doctorID)
 			return false, nil // Doctor doesn't exist or has no availability reported by staff service
 		}
 		// For other errors from the client, log and return them
-		uc.logger.Error("Failed to check doctor availability via staff service client", "doctorID", doctorID, "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to check doctor availability via staff service client", "doctorID", doctorID, "error", err)
 		return false, err // Don't wrap internal error here, let the original propagate
 	}
 
 	if len(availableTimeSlots) == 0 {
-		uc.logger.Info("Doctor has no available time slots reported by StaffService client", "doctorID", doctorID)
+		uc.logger.WithContext(ctx).Info("Doctor has no available time slots reported by StaffService client", "doctorID", doctorID)
 		return false, nil // Staff service reports no availability in this window
 	}
 
@@ -186,7 +189,7 @@ func (uc *appointmentUseCase) CheckDoctorAvailability(ctx context.Context, docto
 		}
 	}
 	if !isCovered {
-		uc.logger.Info("Requested time slot does not fit within any available slot from StaffService", "doctorID", doctorID)
+		uc.logger.WithContext(ctx).Info("Requested time slot does not fit within any available slot from StaffService", "doctorID", doctorID)
 		return false, nil // Requested time doesn't fit within the general availability blocks
 	}
 
@@ -194,11 +197,11 @@ func (uc *appointmentUseCase) CheckDoctorAvailability(ctx context.Context, docto
 	// This confirms the specific requested slot is free, even if the broader window was available.
 	isLocallyFree, err := uc.appointmentRepo.CheckDoctorAvailability(ctx, doctorID, startTime, endTime)
 	if err != nil {
-		uc.logger.Error("Failed to check appointment repository for conflicts", "doctorID", doctorID, "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to check appointment repository for conflicts", "doctorID", doctorID, "error", err)
 		return false, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to confirm appointment availability locally")
 	}
 	if !isLocallyFree {
-		uc.logger.Info("Doctor has conflicting appointments in local repository for the specific slot", "doctorID", doctorID)
+		uc.logger.WithContext(ctx).Info("Doctor has conflicting appointments in local repository for the specific slot", "doctorID", doctorID)
 	}
 
 	return isLocallyFree, nil
@@ -206,7 +209,7 @@ func (uc *appointmentUseCase) CheckDoctorAvailability(ctx context.Context, docto
 
 // ScheduleAppointment creates a new appointment.
 func (uc *appointmentUseCase) ScheduleAppointment(ctx context.Context, req *pb.ScheduleAppointmentRequest) (*entity.Appointment, error) {
-	uc.logger.Info("Scheduling appointment", "patientID", req.PatientId, "doctorID", req.DoctorId, "place", req.Place)
+	uc.logger.WithContext(ctx).Info("Scheduling appointment", "patientID", req.PatientId, "doctorID", req.DoctorId, "place", req.Place)
 	// Validate IDs
 	patientID, errP := uuid.Parse(req.PatientId)
 	doctorID, errD := uuid.Parse(req.DoctorId)
@@ -227,39 +230,44 @@ func (uc *appointmentUseCase) ScheduleAppointment(ctx context.Context, req *pb.S
 		return nil, err
 	}
 	if !available {
-		uc.logger.Warn("Doctor not available for requested slot", "doctorID", req.DoctorId, "time", appointmentTime)
+		uc.logger.WithContext(ctx).Warn("Doctor not available for requested slot", "doctorID", req.DoctorId, "time", appointmentTime)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrConflict, "doctor is not available at the requested time")
 	}
 
 	// 2. Create Appointment Entity - Pass Place
 	appointment := entity.NewAppointment(patientID, doctorID, req.Reason, req.Place, appointmentTime, duration)
 
-	// 3. Save Appointment locally using embedded base repo's CREATE method
+	// 3. Save Appointment locally using embedded base repo's CREATE method;
+	// AppointmentScheduled is written to the outbox in the same transaction
+	ctx = coreEvents.Record(ctx, entity.AppointmentScheduled{Appointment: appointment})
 	err = uc.BaseUseCaseImpl.Repository.Create(ctx, appointment)
 	if err != nil {
-		uc.logger.Error("Failed to create appointment", "error", err)
+		if ucErr := coreUseCase.TranslateRepositoryError(err); ucErr != nil {
+			uc.logger.WithContext(ctx).Warn("Appointment creation rejected", "error", err)
+			return nil, ucErr
+		}
+		uc.logger.WithContext(ctx).Error("Failed to create appointment", "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to schedule appointment")
 	}
 
-	// 4. Optional: Notify Staff Service (Saga/Outbox pattern recommended)
-	uc.logger.Info("Appointment scheduled successfully", "appointmentID", appointment.ID.String())
+	uc.logger.WithContext(ctx).Info("Appointment scheduled successfully", "appointmentID", appointment.ID.String())
 	return appointment, nil
 }
 
 // GetAppointmentDetails retrieves appointment details.
 func (uc *appointmentUseCase) GetAppointmentDetails(ctx context.Context, appointmentID uuid.UUID) (*entity.Appointment, error) {
-	uc.logger.Info("Getting appointment details", "appointmentID", appointmentID.String())
+	uc.logger.WithContext(ctx).Info("Getting appointment details", "appointmentID", appointmentID.String())
 	if appointmentID == uuid.Nil {
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid appointment ID")
 	}
 	// Use embedded base GetByID which uses repo FindByID
 	appointment, err := uc.BaseUseCaseImpl.Repository.FindByID(ctx, appointmentID)
 	if err != nil {
-		if errors.Is(err, errors.New("entity not found")) {
-			uc.logger.Warn("Appointment not found", "appointmentID", appointmentID.String())
+		if errors.Is(err, coreRepository.ErrNotFound) {
+			uc.logger.WithContext(ctx).Warn("Appointment not found", "appointmentID", appointmentID.String())
 			return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrNotFound, "appointment not found")
 		}
-		uc.logger.Error("Failed to get appointment details", "appointmentID", appointmentID.String(), "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to get appointment details", "appointmentID", appointmentID.String(), "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to retrieve appointment details")
 	}
 	return appointment, nil
@@ -267,7 +275,7 @@ func (uc *appointmentUseCase) GetAppointmentDetails(ctx context.Context, appoint
 
 // UpdateAppointmentStatus updates the status.
 func (uc *appointmentUseCase) UpdateAppointmentStatus(ctx context.Context, appointmentID uuid.UUID, req *pb.UpdateAppointmentStatusRequest) (*entity.Appointment, error) {
-	uc.logger.Info("Updating appointment status", "appointmentID", appointmentID.String(), "newStatus", req.Status)
+	uc.logger.WithContext(ctx).Info("Updating appointment status", "appointmentID", appointmentID.String(), "newStatus", req.Status)
 	if appointmentID == uuid.Nil {
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid appointment ID")
 	}
@@ -278,39 +286,56 @@ func (uc *appointmentUseCase) UpdateAppointmentStatus(ctx context.Context, appoi
 	}
 
 	// Fetch appointment using base repo method
-	apt, err := uc.BaseUseCaseImpl.Repository.FindByID(ctx, appointmentID)
+	apt, err := uc.BaseUseCaseImpl.Repository.FindByID(coreDatabase.WithPrimary(ctx), appointmentID)
 	if err != nil {
-		if errors.Is(err, errors.New("entity not found")) {
-			uc.logger.Warn("Appointment not found for status update", "appointmentID", appointmentID.String())
+		if errors.Is(err, coreRepository.ErrNotFound) {
+			uc.logger.WithContext(ctx).Warn("Appointment not found for status update", "appointmentID", appointmentID.String())
 			return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrNotFound, "appointment not found")
 		}
-		uc.logger.Error("Failed to find appointment for status update", "appointmentID", appointmentID.String(), "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to find appointment for status update", "appointmentID", appointmentID.String(), "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to find appointment")
 	}
 
 	// Apply status change using entity method and handle error
+	oldStatus := apt.Status
 	if err := apt.SetStatus(newStatus); err != nil {
 		// Handle potential invalid status transition from entity logic
-		uc.logger.Warn("Invalid status transition attempted", "appointmentID", appointmentID.String(), "from", apt.Status, "to", newStatus, "error", err)
+		uc.logger.WithContext(ctx).Warn("Invalid status transition attempted", "appointmentID", appointmentID.String(), "from", apt.Status, "to", newStatus, "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrConflict, err.Error())
 	}
 
+	if req.Version != nil {
+		apt.Version = *req.Version // Repository rejects the write if the client's copy is stale
+	}
+
+	if apt.Status != oldStatus {
+		ctx = coreEvents.Record(ctx, entity.AppointmentStatusChanged{
+			AppointmentID: apt.ID,
+			PatientID:     apt.PatientID,
+			DoctorID:      apt.DoctorID,
+			From:          oldStatus,
+			To:            apt.Status,
+		})
+	}
+
 	// Save using base repo method's UPDATE
 	err = uc.BaseUseCaseImpl.Repository.Update(ctx, apt)
 	if err != nil {
-		uc.logger.Error("Failed to update appointment status", "appointmentID", appointmentID.String(), "error", err)
+		if errors.Is(err, coreRepository.ErrVersionConflict) {
+			uc.logger.WithContext(ctx).Warn("Stale appointment status update rejected", "appointmentID", appointmentID.String(), "error", err)
+			return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrConflict, "appointment was modified by another request; reload and retry")
+		}
+		uc.logger.WithContext(ctx).Error("Failed to update appointment status", "appointmentID", appointmentID.String(), "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to update appointment status")
 	}
 
-	// Optional: Notify Staff Service if cancelled
-
-	uc.logger.Info("Appointment status updated successfully", "appointmentID", appointmentID.String())
+	uc.logger.WithContext(ctx).Info("Appointment status updated successfully", "appointmentID", appointmentID.String())
 	return apt, nil
 }
 
 // RescheduleAppointment changes the time/duration/place.
 func (uc *appointmentUseCase) RescheduleAppointment(ctx context.Context, appointmentID uuid.UUID, req *pb.RescheduleAppointmentRequest) (*entity.Appointment, error) {
-	uc.logger.Info("Rescheduling appointment", "appointmentID", appointmentID.String(), "newPlace", req.Place)
+	uc.logger.WithContext(ctx).Info("Rescheduling appointment", "appointmentID", appointmentID.String(), "newPlace", req.Place)
 	if appointmentID == uuid.Nil {
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid appointment ID")
 	}
@@ -323,13 +348,13 @@ func (uc *appointmentUseCase) RescheduleAppointment(ctx context.Context, appoint
 	}
 
 	// Fetch existing appointment using base repo method
-	apt, err := uc.BaseUseCaseImpl.Repository.FindByID(ctx, appointmentID)
+	apt, err := uc.BaseUseCaseImpl.Repository.FindByID(coreDatabase.WithPrimary(ctx), appointmentID)
 	if err != nil {
-		if errors.Is(err, errors.New("entity not found")) {
-			uc.logger.Warn("Appointment not found for reschedule", "appointmentID", appointmentID.String())
+		if errors.Is(err, coreRepository.ErrNotFound) {
+			uc.logger.WithContext(ctx).Warn("Appointment not found for reschedule", "appointmentID", appointmentID.String())
 			return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrNotFound, "appointment not found")
 		}
-		uc.logger.Error("Failed to find appointment for reschedule", "appointmentID", appointmentID.String(), "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to find appointment for reschedule", "appointmentID", appointmentID.String(), "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to find appointment for reschedule")
 	}
 
@@ -356,32 +381,50 @@ func (uc *appointmentUseCase) RescheduleAppointment(ctx context.Context, appoint
 	// Check availability for the new time slot
 	available, err := uc.CheckDoctorAvailability(ctx, apt.DoctorID, newTime, newEndTime)
 	if err != nil {
-		uc.logger.Error("Failed availability check during reschedule", "appointmentID", appointmentID.String(), "error", err)
+		uc.logger.WithContext(ctx).Error("Failed availability check during reschedule", "appointmentID", appointmentID.String(), "error", err)
 		// Don't wrap the error again if it's already a UseCaseError
-		if _, ok := err.(*coreUseCase.UseCaseError); ok {
+		var ucErr *coreUseCase.UseCaseError
+		if errors.As(err, &ucErr) {
 			return nil, err
 		}
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to check doctor availability for reschedule")
 	}
 	if !available {
-		uc.logger.Warn("Doctor not available for requested reschedule slot", "appointmentID", appointmentID.String(), "doctorID", apt.DoctorID, "newTime", newTime)
+		uc.logger.WithContext(ctx).Warn("Doctor not available for requested reschedule slot", "appointmentID", appointmentID.String(), "doctorID", apt.DoctorID, "newTime", newTime)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrConflict, "doctor is not available at the requested new time")
 	}
 
 	// Apply the changes using the entity method, including place
 	if err := apt.Reschedule(newTime, newDurationPtr, newPlacePtr); err != nil {
-		uc.logger.Warn("Failed to apply reschedule to entity", "appointmentID", appointmentID.String(), "error", err)
+		uc.logger.WithContext(ctx).Warn("Failed to apply reschedule to entity", "appointmentID", appointmentID.String(), "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrConflict, err.Error())
 	}
 
+	if req.Version != nil {
+		apt.Version = *req.Version // Repository rejects the write if the client's copy is stale
+	}
+
+	ctx = coreEvents.Record(ctx, entity.AppointmentRescheduled{
+		AppointmentID:   apt.ID,
+		PatientID:       apt.PatientID,
+		DoctorID:        apt.DoctorID,
+		AppointmentTime: apt.AppointmentTime,
+		DurationSeconds: int64(apt.Duration / time.Second),
+		Place:           apt.Place,
+	})
+
 	// Save changes using base repo Update
 	err = uc.BaseUseCaseImpl.Repository.Update(ctx, apt)
 	if err != nil {
-		uc.logger.Error("Failed to update appointment after reschedule", "appointmentID", appointmentID.String(), "error", err)
+		if errors.Is(err, coreRepository.ErrVersionConflict) {
+			uc.logger.WithContext(ctx).Warn("Stale appointment reschedule rejected", "appointmentID", appointmentID.String(), "error", err)
+			return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrConflict, "appointment was modified by another request; reload and retry")
+		}
+		uc.logger.WithContext(ctx).Error("Failed to update appointment after reschedule", "appointmentID", appointmentID.String(), "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to save rescheduled appointment")
 	}
 
-	uc.logger.Info("Appointment rescheduled successfully", "appointmentID", apt.ID.String(), "newTime", newTime)
+	uc.logger.WithContext(ctx).Info("Appointment rescheduled successfully", "appointmentID", apt.ID.String(), "newTime", newTime)
 	return apt, nil
 }
 
@@ -397,45 +440,81 @@ func (uc *appointmentUseCase) CancelAppointment(ctx context.Context, appointment
 	// Assuming UpdateAppointmentStatus takes (ctx, uuid, *protoReq)
 	_, err := uc.UpdateAppointmentStatus(ctx, appointmentID, updateReq)
 	if err != nil {
-		// Map internal errors to potentially simpler user-facing ones if needed
-		if errors.Is(err, errors.New("appointment not found")) {
-			return errors.New("appointment not found")
-		}
-		if errors.Is(err, errors.New("cannot update status of a completed or cancelled appointment")) {
-			return errors.New("appointment already completed or cancelled")
-		}
-		return errors.New("failed to cancel appointment") // Generic fallback
+		return err // Already a UseCaseError (not found, conflict or internal)
 	}
 	return nil
 }
 
-// GetAppointmentsForPatient retrieves appointments by patient ID.
-func (uc *appointmentUseCase) GetAppointmentsForPatient(ctx context.Context, patientID uuid.UUID) ([]*entity.Appointment, error) {
+// GetAppointmentsForPatient retrieves the appointments of a patient matching opts with pagination.
+func (uc *appointmentUseCase) GetAppointmentsForPatient(ctx context.Context, patientID uuid.UUID, opts coreTypes.FilterOptions) (*coreTypes.PaginationResult[entity.Appointment], error) {
 	if patientID == uuid.Nil {
-		return nil, errors.New("invalid patient ID")
+		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid patient ID")
 	}
-	apts, err := uc.appointmentRepo.FindByPatientID(ctx, patientID)
+	result, err := uc.appointmentRepo.FindWithFilter(ctx, map[string]interface{}{"patient_id": patientID.String()}, opts)
 	if err != nil {
-		// uc.logger.Error("Failed to get appointments for patient", "patientID", patientID, "error", err)
-		return nil, errors.New("failed to retrieve patient appointments")
+		if ucErr := coreUseCase.TranslateRepositoryError(err); ucErr != nil {
+			return nil, ucErr
+		}
+		uc.logger.WithContext(ctx).Error("Failed to get appointments for patient", "patientID", patientID, "error", err)
+		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to retrieve patient appointments")
 	}
-	return apts, nil
+	return result, nil
 }
 
-// GetAppointmentsForDoctor retrieves appointments by doctor ID and time range.
-func (uc *appointmentUseCase) GetAppointmentsForDoctor(ctx context.Context, doctorID uuid.UUID, startTime time.Time, endTime time.Time) ([]*entity.Appointment, error) {
+// GetAppointmentsForDoctor retrieves the appointments of a doctor in [startTime, endTime)
+// matching opts with pagination.
+func (uc *appointmentUseCase) GetAppointmentsForDoctor(ctx context.Context, doctorID uuid.UUID, startTime time.Time, endTime time.Time, opts coreTypes.FilterOptions) (*coreTypes.PaginationResult[entity.Appointment], error) {
 	if doctorID == uuid.Nil {
-		return nil, errors.New("invalid doctor ID")
+		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid doctor ID")
 	}
 	if startTime.IsZero() || endTime.IsZero() || endTime.Before(startTime) {
-		return nil, errors.New("invalid time range")
+		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid time range")
 	}
-	apts, err := uc.appointmentRepo.FindByDoctorID(ctx, doctorID, startTime, endTime)
+	filter := map[string]interface{}{
+		"doctor_id":        doctorID.String(),
+		"appointment_time": map[string]interface{}{"gte": startTime, "lt": endTime},
+	}
+	result, err := uc.appointmentRepo.FindWithFilter(ctx, filter, opts)
 	if err != nil {
-		// uc.logger.Error("Failed to get appointments for doctor", "doctorID", doctorID, "error", err)
-		return nil, errors.New("failed to retrieve doctor appointments")
+		if ucErr := coreUseCase.TranslateRepositoryError(err); ucErr != nil {
+			return nil, ucErr
+		}
+		uc.logger.WithContext(ctx).Error("Failed to get appointments for doctor", "doctorID", doctorID, "error", err)
+		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to retrieve doctor appointments")
+	}
+	return result, nil
+}
+
+// ExportAppointments streams the appointments of a doctor, a patient and/or a time range.
+func (uc *appointmentUseCase) ExportAppointments(ctx context.Context, doctorID, patientID uuid.UUID, startTime, endTime time.Time, opts coreTypes.FilterOptions, fn func(*entity.Appointment) error) error {
+	if !startTime.IsZero() && !endTime.IsZero() && endTime.Before(startTime) {
+		return coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid time range")
+	}
+	filters := make(map[string]interface{}, len(opts.Filters)+3)
+	for k, v := range opts.Filters {
+		filters[k] = v
+	}
+	if doctorID != uuid.Nil {
+		filters["doctor_id"] = doctorID.String()
+	}
+	if patientID != uuid.Nil {
+		filters["patient_id"] = patientID.String()
+	}
+	timeRange := map[string]interface{}{}
+	if !startTime.IsZero() {
+		timeRange["gte"] = startTime
+	}
+	if !endTime.IsZero() {
+		timeRange["lt"] = endTime
+	}
+	if len(timeRange) > 0 {
+		filters["appointment_time"] = timeRange
+	}
+	opts.Filters = filters
+	if opts.SortBy == "" {
+		opts.SortBy = "appointment_time"
 	}
-	return apts, nil
+	return uc.Export(ctx, opts, fn)
 }
 
 // --- Helper Functions ---
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS "appointments" (
+    "id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 1,
+    "patient_id" uuid,
+    "doctor_id" uuid,
+    "appointment_time" timestamptz,
+    "duration" bigint,
+    "reason" text,
+    "status" text,
+    "notes" text,
+    "place" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_appointments_patient_id" ON "appointments" ("patient_id");
+CREATE INDEX IF NOT EXISTS "idx_appointments_doctor_id" ON "appointments" ("doctor_id");
+CREATE INDEX IF NOT EXISTS "idx_appointments_appointment_time" ON "appointments" ("appointment_time");
+CREATE INDEX IF NOT EXISTS "idx_appointments_place" ON "appointments" ("place");
+CREATE INDEX IF NOT EXISTS "idx_appointments_deleted_at" ON "appointments" ("deleted_at");
@@ -40,6 +40,11 @@ func (m *PatientMapper) EntityToProto(patient *entity.Patient) (*pb.Patient, err
 		return nil, fmt.Errorf("failed to map medical history: %w", err)
 	}
 
+	var deletedAt *timestamppb.Timestamp
+	if patient.DeletedAt.Valid {
+		deletedAt = timestamppb.New(patient.DeletedAt.Time)
+	}
+
 	return &pb.Patient{
 		Id:             patient.ID.String(),
 		FirstName:      patient.FirstName,
@@ -51,6 +56,8 @@ func (m *PatientMapper) EntityToProto(patient *entity.Patient) (*pb.Patient, err
 		MedicalHistory: medicalHistoryProto,
 		CreatedAt:      timestamppb.New(patient.CreatedAt),
 		UpdatedAt:      timestamppb.New(patient.UpdatedAt),
+		Version:        patient.Version,
+		DeletedAt:      deletedAt,
 	}, nil
 }
 
@@ -2,8 +2,6 @@ package controller
 
 import (
 	"context"
-	"errors"
-	"fmt"
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
@@ -11,8 +9,12 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
-	core_usecase "golang-microservices-boilerplate/pkg/core/usecase"
+	"golang-microservices-boilerplate/pkg/core/audit"
+	coreGrpc "golang-microservices-boilerplate/pkg/core/grpc"
+	coreTypes "golang-microservices-boilerplate/pkg/core/types"
+	corepb "golang-microservices-boilerplate/proto/core"
 	pb "golang-microservices-boilerplate/proto/patient-service"
+	"golang-microservices-boilerplate/services/patient-service/internal/entity"
 	"golang-microservices-boilerplate/services/patient-service/internal/usecase"
 )
 
@@ -32,21 +34,23 @@ type patientServer struct {
 	pb.UnimplementedPatientServiceServer
 	uc     usecase.PatientUseCase
 	mapper Mapper // Use the Mapper interface
+	audit  *coreGrpc.AuditEventServer
 }
 
 // NewPatientServer creates a new gRPC server instance.
 // Accepts Mapper interface and returns PatientServer interface.
-func NewPatientServer(uc usecase.PatientUseCase, mapper Mapper) PatientServer {
+func NewPatientServer(uc usecase.PatientUseCase, mapper Mapper, auditEvents audit.Reader) PatientServer {
 	return &patientServer{
 		uc:     uc,
 		mapper: mapper, // Inject mapper
+		audit:  coreGrpc.NewAuditEventServer(auditEvents),
 	}
 }
 
 // RegisterPatientServiceServer registers the patient service implementation with the gRPC server.
 // Accepts use case and mapper to create the server.
-func RegisterPatientServiceServer(s *grpc.Server, uc usecase.PatientUseCase, mapper Mapper) {
-	server := NewPatientServer(uc, mapper) // Pass mapper
+func RegisterPatientServiceServer(s *grpc.Server, uc usecase.PatientUseCase, mapper Mapper, auditEvents audit.Reader) {
+	server := NewPatientServer(uc, mapper, auditEvents) // Pass mapper
 	pb.RegisterPatientServiceServer(s, server)
 }
 
@@ -55,7 +59,7 @@ func (s *patientServer) RegisterPatient(ctx context.Context, req *pb.RegisterPat
 	// Use case already accepts the proto request.
 	patientEntity, err := s.uc.RegisterPatient(ctx, req)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	patientProto, err := s.mapper.EntityToProto(patientEntity)
@@ -75,7 +79,7 @@ func (s *patientServer) GetPatientDetails(ctx context.Context, req *pb.GetPatien
 
 	patientEntity, err := s.uc.GetPatientDetails(ctx, patientID)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	patientProto, err := s.mapper.EntityToProto(patientEntity)
@@ -96,7 +100,7 @@ func (s *patientServer) UpdatePatientDetails(ctx context.Context, req *pb.Update
 	// Use case already accepts the proto request.
 	patientEntity, err := s.uc.UpdatePatientDetails(ctx, patientID, req)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	patientProto, err := s.mapper.EntityToProto(patientEntity)
@@ -117,7 +121,7 @@ func (s *patientServer) AddMedicalRecord(ctx context.Context, req *pb.AddMedical
 	// Use case already accepts the proto request.
 	err = s.uc.AddMedicalRecord(ctx, patientID, req)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	return &emptypb.Empty{}, nil
@@ -132,7 +136,7 @@ func (s *patientServer) GetPatientMedicalHistory(ctx context.Context, req *pb.Ge
 
 	medicalHistoryEntities, err := s.uc.GetPatientMedicalHistory(ctx, patientID)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	medicalHistoryProto, err := s.mapper.MedicalRecordsToProto(medicalHistoryEntities)
@@ -145,41 +149,123 @@ func (s *patientServer) GetPatientMedicalHistory(ctx context.Context, req *pb.Ge
 
 // ListPatients implements the corresponding gRPC method.
 func (s *patientServer) ListPatients(ctx context.Context, req *pb.ListPatientsRequest) (*pb.ListPatientsResponse, error) {
-	// Call the use case method to get the list of patient entities (pointers).
-	patientEntities, err := s.uc.ListPatients(ctx)
+	result, err := s.uc.ListPatients(ctx, coreGrpc.FilterOptionsFromProto(req.Options))
 	if err != nil {
-		// Map use case errors (e.g., internal DB error) to gRPC status codes.
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		// Map use case errors (e.g., invalid filter, internal DB error) to gRPC status codes.
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	// Map the slice of entity pointers to a slice of proto messages.
-	patientProtos, err := s.mapper.PatientsToProto(patientEntities)
+	patientProtos, err := s.mapper.PatientsToProto(result.Items)
 	if err != nil {
 		// This error comes from the mapping function itself (e.g., if it decided to return an error).
 		return nil, status.Errorf(codes.Internal, "failed to map patient list to proto: %v", err)
 	}
 
-	// Return the response containing the list of patient protos.
-	return &pb.ListPatientsResponse{Patients: patientProtos}, nil
-}
-
-// mapUseCaseErrorToGrpcStatus converts use case errors to gRPC status errors.
-// (This is the same helper function as in appointment controller)
-func mapUseCaseErrorToGrpcStatus(err error) error {
-	var ucErr *core_usecase.UseCaseError
-	if errors.As(err, &ucErr) {
-		switch ucErr.Type {
-		case core_usecase.ErrNotFound:
-			return status.Error(codes.NotFound, ucErr.Message)
-		case core_usecase.ErrInvalidInput:
-			return status.Error(codes.InvalidArgument, ucErr.Message)
-		case core_usecase.ErrConflict:
-			return status.Error(codes.AlreadyExists, ucErr.Message) // Or FailedPrecondition
-		case core_usecase.ErrInternal:
-			return status.Error(codes.Internal, ucErr.Message)
-		default:
-			return status.Error(codes.Unknown, fmt.Sprintf("unknown use case error type: %v", ucErr.Type))
+	return &pb.ListPatientsResponse{
+		Patients:       patientProtos,
+		PaginationInfo: coreGrpc.PaginationInfoToProto(result),
+	}, nil
+}
+
+// SearchPatients implements the corresponding gRPC method.
+func (s *patientServer) SearchPatients(ctx context.Context, req *pb.SearchPatientsRequest) (*pb.SearchPatientsResponse, error) {
+	result, err := s.uc.SearchPatients(ctx, coreTypes.SearchOptions{Query: req.Query, Limit: int(req.Limit), Offset: int(req.Offset)})
+	if err != nil {
+		return nil, coreGrpc.ErrorToStatus(err)
+	}
+
+	hits := make([]*pb.PatientSearchHit, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		patientProto, err := s.mapper.EntityToProto(hit.Item)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to map search hit to proto: %v", err)
 		}
+		hits = append(hits, &pb.PatientSearchHit{Patient: patientProto, Rank: hit.Rank, Highlights: hit.Highlights})
+	}
+
+	return &pb.SearchPatientsResponse{
+		Hits:           hits,
+		PaginationInfo: coreGrpc.SearchPaginationInfoToProto(result),
+	}, nil
+}
+
+// ExportPatients implements the corresponding gRPC method, streaming patients
+// as they are read instead of collecting them first.
+func (s *patientServer) ExportPatients(req *pb.ExportPatientsRequest, stream grpc.ServerStreamingServer[pb.Patient]) error {
+	err := s.uc.Export(stream.Context(), coreGrpc.FilterOptionsFromProto(req.Options), func(patient *entity.Patient) error {
+		patientProto, err := s.mapper.EntityToProto(patient)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to map patient to proto: %v", err)
+		}
+		return stream.Send(patientProto)
+	})
+	return coreGrpc.ErrorToStatus(err)
+}
+
+// DeletePatient implements the corresponding gRPC method.
+func (s *patientServer) DeletePatient(ctx context.Context, req *pb.DeletePatientRequest) (*emptypb.Empty, error) {
+	patientID, err := uuid.Parse(req.PatientId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid patient ID format: %v", err)
+	}
+
+	if err := s.uc.Delete(ctx, patientID, req.HardDelete); err != nil {
+		return nil, coreGrpc.ErrorToStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// RestorePatient implements the corresponding gRPC method.
+func (s *patientServer) RestorePatient(ctx context.Context, req *pb.RestorePatientRequest) (*pb.RestorePatientResponse, error) {
+	patientID, err := uuid.Parse(req.PatientId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid patient ID format: %v", err)
 	}
-	return status.Error(codes.Internal, "an unexpected error occurred")
+
+	patientEntity, err := s.uc.Restore(ctx, patientID)
+	if err != nil {
+		return nil, coreGrpc.ErrorToStatus(err)
+	}
+
+	patientProto, err := s.mapper.EntityToProto(patientEntity)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to map result to proto: %v", err)
+	}
+
+	return &pb.RestorePatientResponse{Patient: patientProto}, nil
+}
+
+// ListDeletedPatients implements the corresponding gRPC method.
+func (s *patientServer) ListDeletedPatients(ctx context.Context, req *pb.ListDeletedPatientsRequest) (*pb.ListDeletedPatientsResponse, error) {
+	result, err := s.uc.ListDeleted(ctx, coreGrpc.FilterOptionsFromProto(req.Options))
+	if err != nil {
+		return nil, coreGrpc.ErrorToStatus(err)
+	}
+
+	patientProtos, err := s.mapper.PatientsToProto(result.Items)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to map patient list to proto: %v", err)
+	}
+
+	return &pb.ListDeletedPatientsResponse{
+		Patients:       patientProtos,
+		PaginationInfo: coreGrpc.PaginationInfoToProto(result),
+	}, nil
+}
+
+// PurgeDeletedPatients implements the corresponding gRPC method.
+func (s *patientServer) PurgeDeletedPatients(ctx context.Context, req *pb.PurgeDeletedPatientsRequest) (*pb.PurgeDeletedPatientsResponse, error) {
+	purged, err := s.uc.PurgeDeleted(ctx, int(req.OlderThanDays))
+	if err != nil {
+		return nil, coreGrpc.ErrorToStatus(err)
+	}
+
+	return &pb.PurgeDeletedPatientsResponse{PurgedCount: purged}, nil
+}
+
+// ListAuditEvents implements the corresponding gRPC method.
+func (s *patientServer) ListAuditEvents(ctx context.Context, req *corepb.ListAuditEventsRequest) (*corepb.ListAuditEventsResponse, error) {
+	return s.audit.ListAuditEvents(ctx, req)
 }
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Names of the domain events published by the patient service. Other services
+// subscribe to these names.
+const (
+	PatientRegisteredEvent  = "patient.registered"
+	MedicalRecordAddedEvent = "patient.medical_record_added"
+)
+
+// PatientRegistered is published when a new patient was registered. It holds
+// the patient itself so the payload carries the ID assigned on insert.
+type PatientRegistered struct {
+	Patient *Patient
+}
+
+// EventName implements events.Event.
+func (e PatientRegistered) EventName() string { return PatientRegisteredEvent }
+
+// AggregateID implements events.Event.
+func (e PatientRegistered) AggregateID() string { return e.Patient.ID.String() }
+
+// MarshalJSON encodes the event payload. Contact details stay in the patient
+// service; subscribers look them up when they need them.
+func (e PatientRegistered) MarshalJSON() ([]byte, error) {
+	p := e.Patient
+	return json.Marshal(struct {
+		PatientID uuid.UUID `json:"patient_id"`
+		FirstName string    `json:"first_name"`
+		LastName  string    `json:"last_name"`
+	}{p.ID, p.FirstName, p.LastName})
+}
+
+// MedicalRecordAdded is published when a record was added to the medical
+// history of a patient. The diagnosis itself is not part of the payload.
+type MedicalRecordAdded struct {
+	Record *MedicalRecord
+}
+
+// EventName implements events.Event.
+func (e MedicalRecordAdded) EventName() string { return MedicalRecordAddedEvent }
+
+// AggregateID implements events.Event. Records belong to the patient aggregate.
+func (e MedicalRecordAdded) AggregateID() string { return e.Record.PatientID.String() }
+
+// MarshalJSON encodes the event payload.
+func (e MedicalRecordAdded) MarshalJSON() ([]byte, error) {
+	r := e.Record
+	return json.Marshal(struct {
+		RecordID  uuid.UUID `json:"record_id"`
+		PatientID uuid.UUID `json:"patient_id"`
+		StaffID   uuid.UUID `json:"staff_id"`
+		Date      time.Time `json:"date"`
+	}{r.ID, r.PatientID, r.StaffID, r.Date})
+}
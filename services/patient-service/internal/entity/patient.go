@@ -15,7 +15,7 @@ type Patient struct {
 	LastName              string          `json:"last_name" gorm:"not null"`                                                                  // Patient's last name
 	DateOfBirth           time.Time       `json:"date_of_birth" gorm:"type:date"`                                                             // Patient's date of birth
 	Gender                string          `json:"gender"`                                                                                     // Patient's gender
-	PhoneNumber           string          `json:"phone_number" gorm:"uniqueIndex"`                                                            // Phone number, email, etc.
+	PhoneNumber           string          `json:"phone_number" gorm:"uniqueIndex:idx_patients_phone_number,where:phone_number <> ''"`         // Phone number, email, etc.
 	Address               string          `json:"address"`                                                                                    // Patient's address
 	MedicalHistory        []MedicalRecord `json:"medical_history" gorm:"foreignKey:PatientID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"` // List of medical records (GORM relation)
 	// Removed ID, CreatedAt, UpdatedAt as they are in BaseEntity
@@ -26,6 +26,22 @@ func (Patient) TableName() string {
 	return "patients"
 }
 
+// FilterableColumns lists the columns clients may filter patients by.
+func (p Patient) FilterableColumns() []string {
+	return append(p.BaseEntity.FilterableColumns(),
+		"first_name", "last_name", "date_of_birth", "gender", "phone_number", "address")
+}
+
+// SortableColumns lists the columns clients may sort patients by.
+func (p Patient) SortableColumns() []string {
+	return append(p.BaseEntity.SortableColumns(), "first_name", "last_name", "date_of_birth")
+}
+
+// SearchColumns lists the columns patients are searched and highlighted in.
+func (p Patient) SearchColumns() []string {
+	return []string{"first_name", "last_name", "phone_number", "address"}
+}
+
 // MedicalRecord represents a single entry in a patient's medical history.
 type MedicalRecord struct {
 	coreEntity.BaseEntity           // Embedded base entity for MedicalRecord
@@ -32,13 +32,13 @@ func (r *GormPatientRepository) FindByID(ctx context.Context, id uuid.UUID) (*en
 	patient := &entity.Patient{}
 	// Use Preload to automatically load the MedicalHistory association
 	// Use Clauses(clause.Associations) to load all defined associations
-	err := r.DB.WithContext(ctx).Preload("MedicalHistory").First(patient, "id = ?", id).Error
+	err := r.ReadConn(ctx).Preload("MedicalHistory").First(patient, "id = ?", id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			// Use the error message expected by the BaseRepository for consistency
-			return nil, errors.New("entity not found")
+			// Report the same typed error as the BaseRepository
+			return nil, repository.NewNotFoundError(r.ModelType.Name(), id)
 		}
-		return nil, err
+		return nil, repository.TranslateError(err)
 	}
 	return patient, nil
 }
@@ -55,7 +55,7 @@ func (r *GormPatientRepository) AddMedicalRecord(ctx context.Context, patientID
 	// Create the medical record. GORM's BeforeCreate hook on MedicalRecord's BaseEntity
 	// should handle setting its own UUID.
 	// This operation should ideally be within a transaction managed by the use case if multiple steps are involved.
-	return r.DB.WithContext(ctx).Create(record).Error
+	return repository.TranslateError(r.Conn(ctx).Create(record).Error)
 }
 
 // You can override other base methods like FindAll if specific preloading or filtering is always needed for Patients
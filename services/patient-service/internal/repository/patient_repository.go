@@ -17,6 +17,7 @@ import (
 // to provide standard CRUD methods (Save, Update, Delete, FindAll, etc.).
 type PatientRepository interface {
 	coreRepository.BaseRepository[entity.Patient]
+	coreRepository.Searcher[entity.Patient] // Full-text and fuzzy search over names, phone and address
 
 	// AddMedicalRecord adds a medical record to a specific patient.
 	AddMedicalRecord(ctx context.Context, patientID uuid.UUID, record *entity.MedicalRecord) error
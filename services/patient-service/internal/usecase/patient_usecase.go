@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 
+	coreTypes "golang-microservices-boilerplate/pkg/core/types"
 	pb "golang-microservices-boilerplate/proto/patient-service" // Import proto
 	"golang-microservices-boilerplate/services/patient-service/internal/entity"
 
@@ -26,7 +27,27 @@ type PatientUseCase interface {
 	// GetPatientMedicalHistory retrieves the medical history for a specific patient.
 	GetPatientMedicalHistory(ctx context.Context, patientID uuid.UUID) ([]entity.MedicalRecord, error)
 
-	// ListPatients retrieves a list of all patients.
-	// Returns a slice of pointers to patients.
-	ListPatients(ctx context.Context) ([]*entity.Patient, error)
+	// ListPatients retrieves patients with filtering, sorting and pagination.
+	ListPatients(ctx context.Context, opts coreTypes.FilterOptions) (*coreTypes.PaginationResult[entity.Patient], error)
+
+	// SearchPatients finds patients by partial name, phone or address, ranked by relevance.
+	SearchPatients(ctx context.Context, opts coreTypes.SearchOptions) (*coreTypes.SearchResult[entity.Patient], error)
+
+	// Export calls fn for every patient matching opts, reading them page by page
+	// (provided by the embedded BaseUseCaseImpl).
+	Export(ctx context.Context, opts coreTypes.FilterOptions, fn func(*entity.Patient) error) error
+
+	// --- Soft-delete lifecycle (provided by the embedded BaseUseCaseImpl) ---
+
+	// Delete soft-deletes a patient, or permanently removes it when hardDelete is set.
+	Delete(ctx context.Context, id uuid.UUID, hardDelete bool) error
+
+	// Restore undeletes a soft-deleted patient.
+	Restore(ctx context.Context, id uuid.UUID) (*entity.Patient, error)
+
+	// ListDeleted retrieves soft-deleted patients with pagination.
+	ListDeleted(ctx context.Context, opts coreTypes.FilterOptions) (*coreTypes.PaginationResult[entity.Patient], error)
+
+	// PurgeDeleted permanently removes patients soft-deleted more than olderThanDays days ago.
+	PurgeDeleted(ctx context.Context, olderThanDays int) (int64, error)
 }
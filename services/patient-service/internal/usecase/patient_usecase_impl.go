@@ -7,13 +7,14 @@ import (
 
 	pb "golang-microservices-boilerplate/proto/patient-service" // Alias for generated proto types
 
+	coreDatabase "golang-microservices-boilerplate/pkg/core/database"
+	coreDTO "golang-microservices-boilerplate/pkg/core/dto"
+	coreEvents "golang-microservices-boilerplate/pkg/core/events"
 	coreLogger "golang-microservices-boilerplate/pkg/core/logger"
 	coreRepository "golang-microservices-boilerplate/pkg/core/repository" // Added alias for core repo
 	coreTypes "golang-microservices-boilerplate/pkg/core/types"           // Import coreTypes
 	coreUseCase "golang-microservices-boilerplate/pkg/core/usecase"
 
-	// coreDTO "golang-microservices-boilerplate/pkg/core/dto" // Not used currently
-
 	"golang-microservices-boilerplate/services/patient-service/internal/entity"
 	"golang-microservices-boilerplate/services/patient-service/internal/repository"
 
@@ -54,7 +55,7 @@ func NewPatientUseCase(repo repository.PatientRepository, logger coreLogger.Logg
 
 // RegisterPatient handles the registration logic, potentially bypassing the generic BaseUseCase.Create.
 func (uc *patientUseCase) RegisterPatient(ctx context.Context, req *pb.RegisterPatientRequest) (*entity.Patient, error) {
-	uc.logger.Info("Registering new patient", "firstName", req.FirstName, "lastName", req.LastName)
+	uc.logger.WithContext(ctx).Info("Registering new patient", "firstName", req.FirstName, "lastName", req.LastName)
 
 	// Manual validation (or use coreDTO.Validate if applicable to proto messages)
 	if req.FirstName == "" || req.LastName == "" || req.PhoneNumber == "" {
@@ -69,13 +70,18 @@ func (uc *patientUseCase) RegisterPatient(ctx context.Context, req *pb.RegisterP
 	patient := entity.NewPatient(req.FirstName, req.LastName, req.Gender, req.PhoneNumber, req.Address, dob)
 
 	// Use the Save method from the embedded BaseUseCaseImpl's Repository (Explicit access)
+	ctx = coreEvents.Record(ctx, entity.PatientRegistered{Patient: patient})
 	err := uc.BaseUseCaseImpl.Repository.Create(ctx, patient)
 	if err != nil {
-		uc.logger.Error("Failed to save patient", "error", err)
+		if ucErr := coreUseCase.TranslateRepositoryError(err); ucErr != nil {
+			uc.logger.WithContext(ctx).Warn("Patient registration rejected", "error", err)
+			return nil, ucErr
+		}
+		uc.logger.WithContext(ctx).Error("Failed to save patient", "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to register patient")
 	}
 
-	uc.logger.Info("Patient registered successfully", "patientID", patient.ID.String())
+	uc.logger.WithContext(ctx).Info("Patient registered successfully", "patientID", patient.ID.String())
 	// The patient object now has the ID assigned by BeforeCreate/Save
 	return patient, nil
 }
@@ -83,7 +89,7 @@ func (uc *patientUseCase) RegisterPatient(ctx context.Context, req *pb.RegisterP
 // GetPatientDetails retrieves patient details by ID.
 // Can potentially leverage the embedded BaseUseCase.GetByID if no custom logic is needed.
 func (uc *patientUseCase) GetPatientDetails(ctx context.Context, patientID uuid.UUID) (*entity.Patient, error) {
-	uc.logger.Info("Getting patient details", "patientID", patientID.String())
+	uc.logger.WithContext(ctx).Info("Getting patient details", "patientID", patientID.String())
 	if patientID == uuid.Nil {
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid patient ID")
 	}
@@ -91,11 +97,11 @@ func (uc *patientUseCase) GetPatientDetails(ctx context.Context, patientID uuid.
 	// Use the specific repository's FindByID (which might have custom preloading)
 	patient, err := uc.patientRepo.FindByID(ctx, patientID)
 	if err != nil {
-		if errors.Is(err, errors.New("entity not found")) { // Match repo error
-			uc.logger.Warn("Patient not found", "patientID", patientID.String())
+		if errors.Is(err, coreRepository.ErrNotFound) {
+			uc.logger.WithContext(ctx).Warn("Patient not found", "patientID", patientID.String())
 			return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrNotFound, "patient not found")
 		}
-		uc.logger.Error("Failed to get patient details", "patientID", patientID.String(), "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to get patient details", "patientID", patientID.String(), "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to retrieve patient details")
 	}
 	return patient, nil
@@ -105,7 +111,7 @@ func (uc *patientUseCase) GetPatientDetails(ctx context.Context, patientID uuid.
 // Could use BaseUseCase.Update if mapping from pb.UpdatePatientDetailsRequest works directly.
 // Here, we implement custom logic using the entity method.
 func (uc *patientUseCase) UpdatePatientDetails(ctx context.Context, patientID uuid.UUID, req *pb.UpdatePatientDetailsRequest) (*entity.Patient, error) {
-	uc.logger.Info("Updating patient details", "patientID", patientID.String())
+	uc.logger.WithContext(ctx).Info("Updating patient details", "patientID", patientID.String())
 	if patientID == uuid.Nil {
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid patient ID")
 	}
@@ -114,37 +120,55 @@ func (uc *patientUseCase) UpdatePatientDetails(ctx context.Context, patientID uu
 	// Use coreDTO.Validate(req) if validation tags are added to proto messages?
 
 	// Fetch existing patient
-	patient, err := uc.patientRepo.FindByID(ctx, patientID)
+	patient, err := uc.patientRepo.FindByID(coreDatabase.WithPrimary(ctx), patientID)
 	if err != nil {
-		if errors.Is(err, errors.New("entity not found")) {
-			uc.logger.Warn("Patient not found for update", "patientID", patientID.String())
+		if errors.Is(err, coreRepository.ErrNotFound) {
+			uc.logger.WithContext(ctx).Warn("Patient not found for update", "patientID", patientID.String())
 			return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrNotFound, "patient not found")
 		}
-		uc.logger.Error("Failed to find patient for update", "patientID", patientID.String(), "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to find patient for update", "patientID", patientID.String(), "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to find patient for update")
 	}
 
-	// Apply updates using the entity method
-	var dob time.Time
-	if req.DateOfBirth != nil {
-		dob = req.DateOfBirth.AsTime()
+	// Apply exactly the masked fields, which may clear them; without a mask
+	// the entity method skips empty fields
+	if len(req.GetUpdateMask().GetPaths()) > 0 {
+		if err := coreDTO.ApplyMask(req, patient, req.UpdateMask.GetPaths()); err != nil {
+			if ucErr := coreUseCase.TranslateMaskError(err); ucErr != nil {
+				return nil, ucErr
+			}
+			uc.logger.WithContext(ctx).Error("Failed to apply patient update mask", "patientID", patientID.String(), "error", err)
+			return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to update patient details")
+		}
+	} else {
+		var dob time.Time
+		if req.DateOfBirth != nil {
+			dob = req.DateOfBirth.AsTime()
+		}
+		patient.UpdateDetails(req.FirstName, req.LastName, req.Gender, req.PhoneNumber, req.Address, dob)
+	}
+	if req.Version != nil {
+		patient.Version = *req.Version // Let the repository reject the write if the client's copy is stale
 	}
-	patient.UpdateDetails(req.FirstName, req.LastName, req.Gender, req.PhoneNumber, req.Address, dob)
 
 	// Save the updated entity using the embedded repository's Update (Explicit access)
 	err = uc.BaseUseCaseImpl.Repository.Update(ctx, patient)
 	if err != nil {
-		uc.logger.Error("Failed to update patient", "patientID", patientID.String(), "error", err)
+		if errors.Is(err, coreRepository.ErrVersionConflict) {
+			uc.logger.WithContext(ctx).Warn("Stale patient update rejected", "patientID", patientID.String(), "error", err)
+			return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrConflict, "patient was modified by another request; reload and retry")
+		}
+		uc.logger.WithContext(ctx).Error("Failed to update patient", "patientID", patientID.String(), "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to update patient details")
 	}
 
-	uc.logger.Info("Patient details updated successfully", "patientID", patientID.String())
+	uc.logger.WithContext(ctx).Info("Patient details updated successfully", "patientID", patientID.String())
 	return patient, nil
 }
 
 // AddMedicalRecord adds a medical record.
 func (uc *patientUseCase) AddMedicalRecord(ctx context.Context, patientID uuid.UUID, req *pb.AddMedicalRecordRequest) error {
-	uc.logger.Info("Adding medical record", "patientID", patientID.String())
+	uc.logger.WithContext(ctx).Info("Adding medical record", "patientID", patientID.String())
 	if patientID == uuid.Nil {
 		return coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid patient ID")
 	}
@@ -156,7 +180,7 @@ func (uc *patientUseCase) AddMedicalRecord(ctx context.Context, patientID uuid.U
 	// Parse StaffID string to UUID
 	staffID, err := uuid.Parse(req.StaffId)
 	if err != nil {
-		uc.logger.Error("Invalid StaffID format in AddMedicalRecord request", "staffIdString", req.StaffId, "error", err)
+		uc.logger.WithContext(ctx).Error("Invalid StaffID format in AddMedicalRecord request", "staffIdString", req.StaffId, "error", err)
 		return coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid staff ID format")
 	}
 
@@ -172,19 +196,23 @@ func (uc *patientUseCase) AddMedicalRecord(ctx context.Context, patientID uuid.U
 	}
 
 	// Use the dedicated method from the specific repository interface
+	ctx = coreEvents.Record(ctx, entity.MedicalRecordAdded{Record: record})
 	err = uc.patientRepo.AddMedicalRecord(ctx, patientID, record)
 	if err != nil {
-		uc.logger.Error("Failed to add medical record", "patientID", patientID.String(), "error", err)
-		// Check for specific repo errors?
+		if ucErr := coreUseCase.TranslateRepositoryError(err); ucErr != nil {
+			uc.logger.WithContext(ctx).Warn("Medical record rejected", "patientID", patientID.String(), "error", err)
+			return ucErr // e.g. unknown patient (foreign key violation)
+		}
+		uc.logger.WithContext(ctx).Error("Failed to add medical record", "patientID", patientID.String(), "error", err)
 		return coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to add medical record")
 	}
-	uc.logger.Info("Medical record added successfully", "patientID", patientID.String(), "recordID", record.ID.String())
+	uc.logger.WithContext(ctx).Info("Medical record added successfully", "patientID", patientID.String(), "recordID", record.ID.String())
 	return nil
 }
 
 // GetPatientMedicalHistory retrieves medical history.
 func (uc *patientUseCase) GetPatientMedicalHistory(ctx context.Context, patientID uuid.UUID) ([]entity.MedicalRecord, error) {
-	uc.logger.Info("Getting medical history", "patientID", patientID.String())
+	uc.logger.WithContext(ctx).Info("Getting medical history", "patientID", patientID.String())
 	if patientID == uuid.Nil {
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid patient ID")
 	}
@@ -202,26 +230,32 @@ func (uc *patientUseCase) GetPatientMedicalHistory(ctx context.Context, patientI
 	return patient.MedicalHistory, nil
 }
 
-// ListPatients retrieves a list of all patients.
-// Returns a slice of pointers to patients.
-func (uc *patientUseCase) ListPatients(ctx context.Context) ([]*entity.Patient, error) {
-	uc.logger.Info("Listing all patients")
-
-	// Call the embedded repository's FindAll method.
-	// Use coreTypes.FilterOptions.
-	filterOpts := coreTypes.FilterOptions{} // Use coreTypes
-	paginationResult, err := uc.patientRepo.FindAll(ctx, filterOpts)
+// ListPatients retrieves patients with filtering, sorting and pagination.
+func (uc *patientUseCase) ListPatients(ctx context.Context, opts coreTypes.FilterOptions) (*coreTypes.PaginationResult[entity.Patient], error) {
+	uc.logger.WithContext(ctx).Info("Listing patients")
 
+	result, err := uc.patientRepo.FindAll(ctx, opts)
 	if err != nil {
-		uc.logger.Error("Failed to list patients", "error", err)
+		if ucErr := coreUseCase.TranslateRepositoryError(err); ucErr != nil {
+			return nil, ucErr
+		}
+		uc.logger.WithContext(ctx).Error("Failed to list patients", "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to retrieve patients")
 	}
+	return result, nil
+}
 
-	// Check if the result or Items field is nil.
-	if paginationResult == nil || paginationResult.Items == nil {
-		return []*entity.Patient{}, nil // Return empty slice of pointers
-	}
+// SearchPatients finds patients by partial name, phone or address, ranked by relevance.
+func (uc *patientUseCase) SearchPatients(ctx context.Context, opts coreTypes.SearchOptions) (*coreTypes.SearchResult[entity.Patient], error) {
+	uc.logger.WithContext(ctx).Info("Searching patients", "query", opts.Query)
 
-	// Return the slice of pointers directly.
-	return paginationResult.Items, nil
+	result, err := uc.patientRepo.Search(ctx, opts)
+	if err != nil {
+		if ucErr := coreUseCase.TranslateRepositoryError(err); ucErr != nil {
+			return nil, ucErr
+		}
+		uc.logger.WithContext(ctx).Error("Failed to search patients", "error", err)
+		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to search patients")
+	}
+	return result, nil
 }
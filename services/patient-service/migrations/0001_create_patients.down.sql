@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "medical_records";
+DROP TABLE IF EXISTS "patients";
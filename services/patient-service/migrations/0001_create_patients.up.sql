@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS "patients" (
+    "id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 1,
+    "first_name" text NOT NULL,
+    "last_name" text NOT NULL,
+    "date_of_birth" date,
+    "gender" text,
+    "phone_number" text,
+    "address" text,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_patients_phone_number" ON "patients" ("phone_number");
+CREATE INDEX IF NOT EXISTS "idx_patients_deleted_at" ON "patients" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "medical_records" (
+    "id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 1,
+    "patient_id" uuid NOT NULL,
+    "date" timestamptz NOT NULL,
+    "staff_id" uuid NOT NULL,
+    "diagnosis" text NOT NULL,
+    "treatment" text NOT NULL,
+    "notes" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_patients_medical_history" FOREIGN KEY ("patient_id") REFERENCES "patients" ("id") ON DELETE SET NULL ON UPDATE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_medical_records_patient_id" ON "medical_records" ("patient_id");
+CREATE INDEX IF NOT EXISTS "idx_medical_records_staff_id" ON "medical_records" ("staff_id");
+CREATE INDEX IF NOT EXISTS "idx_medical_records_deleted_at" ON "medical_records" ("deleted_at");
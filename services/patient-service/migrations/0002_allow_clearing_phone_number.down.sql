@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_patients_phone_number";
+CREATE UNIQUE INDEX "idx_patients_phone_number" ON "patients" ("phone_number");
@@ -0,0 +1,3 @@
+-- Phone numbers can be cleared through update masks; several cleared numbers must not collide
+DROP INDEX IF EXISTS "idx_patients_phone_number";
+CREATE UNIQUE INDEX "idx_patients_phone_number" ON "patients" ("phone_number") WHERE "phone_number" <> '';
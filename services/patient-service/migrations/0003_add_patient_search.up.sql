@@ -0,0 +1,18 @@
+-- Full-text (search_vector) and trigram (search_text) search over names, phone and address
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE "patients"
+    ADD COLUMN IF NOT EXISTS "search_vector" tsvector GENERATED ALWAYS AS (
+        to_tsvector('simple',
+            coalesce("first_name", '') || ' ' || coalesce("last_name", '') || ' ' ||
+            coalesce("phone_number", '') || ' ' || coalesce("address", ''))
+    ) STORED,
+    ADD COLUMN IF NOT EXISTS "search_text" text GENERATED ALWAYS AS (
+        lower(
+            coalesce("first_name", '') || ' ' || coalesce("last_name", '') || ' ' ||
+            coalesce("phone_number", '') || ' ' || coalesce("address", '') || ' ' ||
+            regexp_replace(coalesce("phone_number", ''), '[^0-9]', '', 'g'))
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS "idx_patients_search_vector" ON "patients" USING gin ("search_vector");
+CREATE INDEX IF NOT EXISTS "idx_patients_search_text" ON "patients" USING gin ("search_text" gin_trgm_ops);
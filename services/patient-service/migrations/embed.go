@@ -0,0 +1,14 @@
+// Package migrations holds the versioned schema of the patient-service tables.
+package migrations
+
+import (
+	"embed"
+
+	"golang-microservices-boilerplate/pkg/core/database"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// Source is the migration source of the patient-service.
+var Source = database.Source{Name: "patient-service", FS: files}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"os/signal"
@@ -8,9 +9,10 @@ import (
 
 	// Core packages
 
-	coreGrpc "golang-microservices-boilerplate/pkg/core/grpc"
+	coreAudit "golang-microservices-boilerplate/pkg/core/audit"
 	"golang-microservices-boilerplate/pkg/utils"
 	// Staff service internal packages
+	"golang-microservices-boilerplate/services/staff-service/internal/controller"
 	// For AutoMigrate
 	// Assuming GORM implementation
 )
@@ -26,6 +28,24 @@ func main() {
 	logger := setupLogger(appName)
 	logger.Info("Staff service starting...")
 
+	// --- Subcommands ---
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrateCommand(logger, os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		runSeedCommand(logger, os.Args[2:])
+		return
+	}
+
+	// --- Tracing (buffered spans are flushed on shutdown) ---
+	tracer := setupTracing(logger)
+	defer func() {
+		if err := tracer.Shutdown(context.Background()); err != nil {
+			logger.Error("Error flushing traces", "error", err)
+		}
+	}()
+
 	db := setupDatabase(logger)
 	defer func() {
 		if err := db.Close(); err != nil {
@@ -33,17 +53,21 @@ func main() {
 		}
 	}()
 
+	// --- Domain Events (outbox dispatcher runs until shutdown) ---
+	eventsCtx, stopEvents := context.WithCancel(context.Background())
+	defer stopEvents()
+	bus := setupEvents(eventsCtx, db, logger)
+
 	uc, mapper := setupDependencies(db, logger)
+	controller.RegisterEventHandlers(bus, uc, logger)
 
-	// --- Setup gRPC Server (using coreGrpc helper) ---
-	grpcServer := coreGrpc.NewBaseGrpcServer(logger)
+	// --- Setup gRPC Server (idempotency-key aware) ---
+	grpcServer := setupGrpcServer(db, logger)
 
 	// Register Services using function from setup.go
-	registerServices(grpcServer.Server(), uc, mapper)
+	registerServices(grpcServer.Server(), uc, mapper, coreAudit.NewStore(db.DB))
 	logger.Info("Staff gRPC service registered")
 
-	// Health check and Reflection are typically handled within NewBaseGrpcServer or its Start method
-
 	// --- Start Server ---
 	if err := grpcServer.Start(); err != nil {
 		logger.Fatal("Failed to start gRPC server", "error", err)
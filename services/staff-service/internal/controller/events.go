@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"golang-microservices-boilerplate/pkg/core/events"
+	"golang-microservices-boilerplate/pkg/core/logger"
+	coreUseCase "golang-microservices-boilerplate/pkg/core/usecase"
+	"golang-microservices-boilerplate/services/staff-service/internal/usecase"
+)
+
+// appointmentScheduledEvent is the name the appointment service publishes new
+// bookings under.
+const appointmentScheduledEvent = "appointment.scheduled"
+
+// appointmentScheduled is the payload of appointmentScheduledEvent. Only the
+// fields the staff service needs are decoded.
+type appointmentScheduled struct {
+	AppointmentID   uuid.UUID `json:"appointment_id"`
+	DoctorID        uuid.UUID `json:"doctor_id"`
+	AppointmentTime time.Time `json:"appointment_time"`
+	DurationSeconds int64     `json:"duration_seconds"`
+	Place           string    `json:"place"`
+}
+
+// RegisterEventHandlers subscribes the staff service to the domain events of
+// other services: booked appointments are put on the doctor's schedule.
+func RegisterEventHandlers(bus *events.Bus, uc usecase.StaffUseCase, logger logger.Logger) {
+	bus.Subscribe(appointmentScheduledEvent, func(ctx context.Context, msg *events.Message) error {
+		var evt appointmentScheduled
+		if err := msg.Decode(&evt); err != nil {
+			return fmt.Errorf("invalid %s payload: %w", msg.Name, err)
+		}
+		end := evt.AppointmentTime.Add(time.Duration(evt.DurationSeconds) * time.Second)
+		err := uc.ScheduleAppointment(ctx, evt.AppointmentID, evt.DoctorID, evt.AppointmentTime, end, evt.Place)
+		var ucErr *coreUseCase.UseCaseError
+		if errors.As(err, &ucErr) && (ucErr.Type == coreUseCase.ErrNotFound || ucErr.Type == coreUseCase.ErrInvalidInput) {
+			// Redelivering cannot fix these, so the event is dropped
+			logger.WithContext(ctx).Warn("Ignoring appointment that cannot be scheduled",
+				"doctorID", evt.DoctorID.String(),
+				"appointmentID", evt.AppointmentID.String(),
+				"requestID", msg.RequestID,
+				"error", err)
+			return nil
+		}
+		return err
+	})
+}
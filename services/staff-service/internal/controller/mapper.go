@@ -65,6 +65,11 @@ func (m *StaffMapper) EntityToProto(staff *entity.Staff) (*pb.Staff, error) {
 		dobProto = timestamppb.New(staff.DateOfBirth)
 	}
 
+	var deletedAt *timestamppb.Timestamp
+	if staff.DeletedAt.Valid {
+		deletedAt = timestamppb.New(staff.DeletedAt.Time)
+	}
+
 	return &pb.Staff{
 		Id:             staff.ID.String(),
 		FirstName:      staff.FirstName,
@@ -79,6 +84,8 @@ func (m *StaffMapper) EntityToProto(staff *entity.Staff) (*pb.Staff, error) {
 		Schedule:       scheduleProto, // Updated field type
 		CreatedAt:      timestamppb.New(staff.CreatedAt),
 		UpdatedAt:      timestamppb.New(staff.UpdatedAt),
+		Version:        staff.Version,
+		DeletedAt:      deletedAt,
 	}, nil
 }
 
@@ -152,6 +159,7 @@ func (m *StaffMapper) TaskToProto(task *entity.Task) (*pb.TaskProto, error) {
 		StatusId:    task.StatusID, // String FK
 		CreatedAt:   timestamppb.New(task.CreatedAt),
 		UpdatedAt:   timestamppb.New(task.UpdatedAt),
+		Version:     task.Version,
 	}, nil
 }
 
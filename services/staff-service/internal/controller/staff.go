@@ -2,7 +2,6 @@ package controller
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
@@ -12,8 +11,12 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 
-	coreUseCase "golang-microservices-boilerplate/pkg/core/usecase"
+	"golang-microservices-boilerplate/pkg/core/audit"
+	coreGrpc "golang-microservices-boilerplate/pkg/core/grpc"
+	coreTypes "golang-microservices-boilerplate/pkg/core/types"
+	corepb "golang-microservices-boilerplate/proto/core"
 	pb "golang-microservices-boilerplate/proto/staff-service"
+	"golang-microservices-boilerplate/services/staff-service/internal/entity"
 	"golang-microservices-boilerplate/services/staff-service/internal/usecase"
 )
 
@@ -31,19 +34,21 @@ type staffServer struct {
 	pb.UnimplementedStaffServiceServer
 	uc     usecase.StaffUseCase
 	mapper Mapper // Use interface
+	audit  *coreGrpc.AuditEventServer
 }
 
 // NewStaffServer creates a new gRPC server instance.
-func NewStaffServer(uc usecase.StaffUseCase, mapper Mapper) StaffServer {
+func NewStaffServer(uc usecase.StaffUseCase, mapper Mapper, auditEvents audit.Reader) StaffServer {
 	return &staffServer{
 		uc:     uc,
 		mapper: mapper,
+		audit:  coreGrpc.NewAuditEventServer(auditEvents),
 	}
 }
 
 // RegisterStaffServiceServer registers the staff service implementation with the gRPC server.
-func RegisterStaffServiceServer(s *grpc.Server, uc usecase.StaffUseCase, mapper Mapper) {
-	server := NewStaffServer(uc, mapper)
+func RegisterStaffServiceServer(s *grpc.Server, uc usecase.StaffUseCase, mapper Mapper, auditEvents audit.Reader) {
+	server := NewStaffServer(uc, mapper, auditEvents)
 	pb.RegisterStaffServiceServer(s, server)
 }
 
@@ -59,7 +64,7 @@ func (s *staffServer) AddStaff(ctx context.Context, req *pb.AddStaffRequest) (*p
 
 	staffEntity, err := s.uc.AddStaff(ctx, req.FirstName, req.LastName, dob, req.PhoneNumber, req.Address, req.RoleId, req.StatusId, req.Specialization, req.NurseType)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	staffProto, err := s.mapper.EntityToProto(staffEntity)
@@ -81,7 +86,7 @@ func (s *staffServer) GetStaffDetails(ctx context.Context, req *pb.GetStaffDetai
 
 	staffEntity, err := s.uc.GetStaffDetails(ctx, staffID)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	staffProto, err := s.mapper.EntityToProto(staffEntity)
@@ -106,9 +111,9 @@ func (s *staffServer) UpdateStaffDetails(ctx context.Context, req *pb.UpdateStaf
 		dob = &dobValue
 	}
 
-	staffEntity, err := s.uc.UpdateStaffDetails(ctx, staffID, req.FirstName, req.LastName, dob, req.PhoneNumber, req.Address, req.Specialization, req.NurseType)
+	staffEntity, err := s.uc.UpdateStaffDetails(ctx, staffID, req.FirstName, req.LastName, dob, req.PhoneNumber, req.Address, req.Specialization, req.NurseType, req.Version, req.GetUpdateMask().GetPaths())
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	staffProto, err := s.mapper.EntityToProto(staffEntity)
@@ -123,21 +128,58 @@ func (s *staffServer) UpdateStaffDetails(ctx context.Context, req *pb.UpdateStaf
 // ListStaff implements the corresponding gRPC method.
 func (s *staffServer) ListStaff(ctx context.Context, req *pb.ListStaffRequest) (*pb.ListStaffResponse, error) {
 	// Call the use case method, passing the request which contains filters.
-	staffEntities, err := s.uc.ListStaff(ctx, req)
+	result, err := s.uc.ListStaff(ctx, req, coreGrpc.FilterOptionsFromProto(req.Options))
 	if err != nil {
 		// Map use case errors to gRPC status codes.
-		return nil, mapUseCaseErrorToGrpcStatus(err) // Assuming helper exists
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	// Map the slice of entity pointers to a slice of proto messages.
-	staffProtos, err := s.mapper.StaffListToProto(staffEntities)
+	staffProtos, err := s.mapper.StaffListToProto(result.Items)
 	if err != nil {
 		// This error comes from the mapping function itself.
 		return nil, status.Errorf(codes.Internal, "failed to map staff list to proto: %v", err)
 	}
 
-	// Return the response containing the list of staff protos.
-	return &pb.ListStaffResponse{StaffMembers: staffProtos}, nil
+	return &pb.ListStaffResponse{
+		StaffMembers:   staffProtos,
+		PaginationInfo: coreGrpc.PaginationInfoToProto(result),
+	}, nil
+}
+
+// SearchStaff implements the corresponding gRPC method.
+func (s *staffServer) SearchStaff(ctx context.Context, req *pb.SearchStaffRequest) (*pb.SearchStaffResponse, error) {
+	result, err := s.uc.SearchStaff(ctx, coreTypes.SearchOptions{Query: req.Query, Limit: int(req.Limit), Offset: int(req.Offset)})
+	if err != nil {
+		return nil, coreGrpc.ErrorToStatus(err)
+	}
+
+	hits := make([]*pb.StaffSearchHit, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		staffProto, err := s.mapper.EntityToProto(hit.Item)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to map search hit to proto: %v", err)
+		}
+		hits = append(hits, &pb.StaffSearchHit{Staff: staffProto, Rank: hit.Rank, Highlights: hit.Highlights})
+	}
+
+	return &pb.SearchStaffResponse{
+		Hits:           hits,
+		PaginationInfo: coreGrpc.SearchPaginationInfoToProto(result),
+	}, nil
+}
+
+// ExportStaff implements the corresponding gRPC method, streaming staff members
+// as they are read instead of collecting them first.
+func (s *staffServer) ExportStaff(req *pb.ExportStaffRequest, stream grpc.ServerStreamingServer[pb.Staff]) error {
+	err := s.uc.ExportStaff(stream.Context(), coreGrpc.FilterOptionsFromProto(req.Options), func(staff *entity.Staff) error {
+		staffProto, err := s.mapper.EntityToProto(staff)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to map staff to proto: %v", err)
+		}
+		return stream.Send(staffProto)
+	})
+	return coreGrpc.ErrorToStatus(err)
 }
 
 // --- Restored RPC Implementations (Needing Review) ---
@@ -152,7 +194,7 @@ func (s *staffServer) UpdateStaffSchedule(ctx context.Context, req *pb.UpdateSta
 	// Pass the TaskProto slice directly to the use case
 	err = s.uc.UpdateStaffSchedule(ctx, staffID, req.TasksToSchedule)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	return &emptypb.Empty{}, nil
@@ -168,7 +210,7 @@ func (s *staffServer) SetStaffAvailability(ctx context.Context, req *pb.SetStaff
 	// Call the renamed use case method
 	err = s.uc.SetStaffStatus(ctx, staffID, req.StatusId)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	return &emptypb.Empty{}, nil
@@ -201,7 +243,7 @@ func (s *staffServer) GetDoctorAvailability(ctx context.Context, req *pb.GetDoct
 	// Use case now returns []*pb.GetDoctorAvailabilityResponse_TimeSlot directly
 	availableSlotsProto, err := s.uc.GetDoctorAvailability(ctx, doctorIDPtr, startTime, endTime)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	// No further mapping needed as use case returns the correct proto type
@@ -227,7 +269,7 @@ func (s *staffServer) AssignTask(ctx context.Context, req *pb.AssignTaskRequest)
 
 	_, err = s.uc.AssignTask(ctx, staffID, req.Title, req.Description, req.Priority, startTime, endTime, req.StatusId)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	// Returning Empty as per proto definition, could return created task if needed
@@ -243,7 +285,7 @@ func (s *staffServer) TrackWorkload(ctx context.Context, req *pb.TrackWorkloadRe
 
 	workloadEntities, err := s.uc.TrackWorkload(ctx, staffID)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	workloadProto, err := s.mapper.TasksToProto(workloadEntities)
@@ -258,22 +300,24 @@ func (s *staffServer) TrackWorkload(ctx context.Context, req *pb.TrackWorkloadRe
 // ListTasks implements the corresponding gRPC method.
 func (s *staffServer) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
 	// Call the use case method, passing the request which contains filters.
-	taskEntities, err := s.uc.ListTasks(ctx, req)
+	result, err := s.uc.ListTasks(ctx, req, coreGrpc.FilterOptionsFromProto(req.Options))
 	if err != nil {
 		// Map use case errors to gRPC status codes.
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	// Map the slice of entity pointers to a slice of proto messages.
 	// Use the existing TasksToProto mapper function.
-	taskProtos, err := s.mapper.TasksToProto(taskEntities)
+	taskProtos, err := s.mapper.TasksToProto(result.Items)
 	if err != nil {
 		// This error comes from the mapping function itself.
 		return nil, status.Errorf(codes.Internal, "failed to map task list to proto: %v", err)
 	}
 
-	// Return the response containing the list of task protos.
-	return &pb.ListTasksResponse{Tasks: taskProtos}, nil
+	return &pb.ListTasksResponse{
+		Tasks:          taskProtos,
+		PaginationInfo: coreGrpc.PaginationInfoToProto(result),
+	}, nil
 }
 
 // --- Lookup Table RPCs ---
@@ -282,7 +326,7 @@ func (s *staffServer) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (
 func (s *staffServer) AddStaffRole(ctx context.Context, req *pb.AddStaffRoleRequest) (*pb.AddStaffRoleResponse, error) {
 	roleEntity, err := s.uc.AddStaffRole(ctx, req.Name, req.Description)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 	return &pb.AddStaffRoleResponse{Role: s.mapper.RoleToProto(roleEntity)}, nil
 }
@@ -291,7 +335,7 @@ func (s *staffServer) AddStaffRole(ctx context.Context, req *pb.AddStaffRoleRequ
 func (s *staffServer) ListStaffRoles(ctx context.Context, req *pb.ListStaffRolesRequest) (*pb.ListStaffRolesResponse, error) {
 	roleEntities, err := s.uc.ListStaffRoles(ctx)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 	return &pb.ListStaffRolesResponse{Roles: s.mapper.RolesToProto(roleEntities)}, nil
 }
@@ -300,7 +344,7 @@ func (s *staffServer) ListStaffRoles(ctx context.Context, req *pb.ListStaffRoles
 func (s *staffServer) AddStaffStatus(ctx context.Context, req *pb.AddStaffStatusRequest) (*pb.AddStaffStatusResponse, error) {
 	statusEntity, err := s.uc.AddStaffStatus(ctx, req.Name, req.Description)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 	return &pb.AddStaffStatusResponse{Status: s.mapper.StatusToProto(statusEntity)}, nil
 }
@@ -309,7 +353,7 @@ func (s *staffServer) AddStaffStatus(ctx context.Context, req *pb.AddStaffStatus
 func (s *staffServer) ListStaffStatuses(ctx context.Context, req *pb.ListStaffStatusesRequest) (*pb.ListStaffStatusesResponse, error) {
 	statusEntities, err := s.uc.ListStaffStatuses(ctx)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 	return &pb.ListStaffStatusesResponse{Statuses: s.mapper.StatusesToProto(statusEntities)}, nil
 }
@@ -318,7 +362,7 @@ func (s *staffServer) ListStaffStatuses(ctx context.Context, req *pb.ListStaffSt
 func (s *staffServer) AddTaskStatus(ctx context.Context, req *pb.AddTaskStatusRequest) (*pb.AddTaskStatusResponse, error) {
 	statusEntity, err := s.uc.AddTaskStatus(ctx, req.Name, req.Description)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 	return &pb.AddTaskStatusResponse{Status: s.mapper.TaskStatusToProto(statusEntity)}, nil
 }
@@ -327,31 +371,72 @@ func (s *staffServer) AddTaskStatus(ctx context.Context, req *pb.AddTaskStatusRe
 func (s *staffServer) ListTaskStatuses(ctx context.Context, req *pb.ListTaskStatusesRequest) (*pb.ListTaskStatusesResponse, error) {
 	statusEntities, err := s.uc.ListTaskStatuses(ctx)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 	return &pb.ListTaskStatusesResponse{Statuses: s.mapper.TaskStatusesToProto(statusEntities)}, nil
 }
 
-// --- Helper Functions ---
-
-// mapUseCaseErrorToGrpcStatus converts use case errors to gRPC status errors.
-func mapUseCaseErrorToGrpcStatus(err error) error {
-	var ucErr *coreUseCase.UseCaseError // Use aliased import
-	if errors.As(err, &ucErr) {
-		switch ucErr.Type {
-		case coreUseCase.ErrNotFound:
-			return status.Error(codes.NotFound, ucErr.Message)
-		case coreUseCase.ErrInvalidInput:
-			return status.Error(codes.InvalidArgument, ucErr.Message)
-		case coreUseCase.ErrConflict:
-			return status.Error(codes.AlreadyExists, ucErr.Message)
-		case coreUseCase.ErrInternal:
-			return status.Error(codes.Internal, ucErr.Message)
-		default:
-			return status.Error(codes.Unknown, fmt.Sprintf("unknown use case error type: %v", ucErr.Type))
-		}
+// --- Soft-delete Lifecycle RPC Implementations ---
+
+// DeleteStaff implements the gRPC method.
+func (s *staffServer) DeleteStaff(ctx context.Context, req *pb.DeleteStaffRequest) (*emptypb.Empty, error) {
+	staffID, err := uuid.Parse(req.StaffId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid staff ID format: %v", err)
+	}
+
+	if err := s.uc.DeleteStaff(ctx, staffID, req.HardDelete); err != nil {
+		return nil, coreGrpc.ErrorToStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// RestoreStaff implements the gRPC method.
+func (s *staffServer) RestoreStaff(ctx context.Context, req *pb.RestoreStaffRequest) (*pb.RestoreStaffResponse, error) {
+	staffID, err := uuid.Parse(req.StaffId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid staff ID format: %v", err)
+	}
+
+	staffEntity, err := s.uc.RestoreStaff(ctx, staffID)
+	if err != nil {
+		return nil, coreGrpc.ErrorToStatus(err)
+	}
+
+	staffProto, err := s.mapper.EntityToProto(staffEntity)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to map staff to proto: %v", err)
+	}
+	return &pb.RestoreStaffResponse{Staff: staffProto}, nil
+}
+
+// ListDeletedStaff implements the gRPC method.
+func (s *staffServer) ListDeletedStaff(ctx context.Context, req *pb.ListDeletedStaffRequest) (*pb.ListDeletedStaffResponse, error) {
+	result, err := s.uc.ListDeletedStaff(ctx, coreGrpc.FilterOptionsFromProto(req.Options))
+	if err != nil {
+		return nil, coreGrpc.ErrorToStatus(err)
+	}
+
+	staffProtos, err := s.mapper.StaffListToProto(result.Items)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to map staff list to proto: %v", err)
 	}
-	// Log unexpected non-UseCaseError errors
-	fmt.Printf("Unexpected error type encountered: %T - %v\n", err, err) // Replace with proper logging
-	return status.Error(codes.Internal, "an unexpected internal error occurred")
+	return &pb.ListDeletedStaffResponse{
+		StaffMembers:   staffProtos,
+		PaginationInfo: coreGrpc.PaginationInfoToProto(result),
+	}, nil
+}
+
+// PurgeDeletedStaff implements the gRPC method.
+func (s *staffServer) PurgeDeletedStaff(ctx context.Context, req *pb.PurgeDeletedStaffRequest) (*pb.PurgeDeletedStaffResponse, error) {
+	purged, err := s.uc.PurgeDeletedStaff(ctx, int(req.OlderThanDays))
+	if err != nil {
+		return nil, coreGrpc.ErrorToStatus(err)
+	}
+	return &pb.PurgeDeletedStaffResponse{PurgedCount: purged}, nil
+}
+
+// ListAuditEvents implements the corresponding gRPC method.
+func (s *staffServer) ListAuditEvents(ctx context.Context, req *corepb.ListAuditEventsRequest) (*corepb.ListAuditEventsResponse, error) {
+	return s.audit.ListAuditEvents(ctx, req)
 }
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Names of the domain events published by the staff service. Other services
+// subscribe to these names.
+const (
+	StaffAddedEvent   = "staff.added"
+	TaskAssignedEvent = "staff.task_assigned"
+)
+
+// StaffAdded is published when a new staff member was added. It holds the
+// staff member itself so the payload carries the ID assigned on insert.
+type StaffAdded struct {
+	Staff *Staff
+}
+
+// EventName implements events.Event.
+func (e StaffAdded) EventName() string { return StaffAddedEvent }
+
+// AggregateID implements events.Event.
+func (e StaffAdded) AggregateID() string { return e.Staff.ID.String() }
+
+// MarshalJSON encodes the event payload.
+func (e StaffAdded) MarshalJSON() ([]byte, error) {
+	s := e.Staff
+	return json.Marshal(struct {
+		StaffID   uuid.UUID `json:"staff_id"`
+		FirstName string    `json:"first_name"`
+		LastName  string    `json:"last_name"`
+		RoleID    string    `json:"role_id"`
+		StatusID  string    `json:"status_id"`
+	}{s.ID, s.FirstName, s.LastName, s.RoleID, s.StatusID})
+}
+
+// TaskAssigned is published when a task was assigned to a staff member.
+type TaskAssigned struct {
+	StaffID uuid.UUID
+	Task    *Task
+}
+
+// EventName implements events.Event.
+func (e TaskAssigned) EventName() string { return TaskAssignedEvent }
+
+// AggregateID implements events.Event. Tasks belong to the staff aggregate.
+func (e TaskAssigned) AggregateID() string { return e.StaffID.String() }
+
+// MarshalJSON encodes the event payload.
+func (e TaskAssigned) MarshalJSON() ([]byte, error) {
+	t := e.Task
+	return json.Marshal(struct {
+		StaffID   uuid.UUID `json:"staff_id"`
+		TaskID    uuid.UUID `json:"task_id"`
+		Title     string    `json:"title"`
+		Priority  int       `json:"priority"`
+		StartTime time.Time `json:"start_time"`
+		EndTime   time.Time `json:"end_time"`
+		StatusID  string    `json:"status_id"`
+	}{e.StaffID, t.ID, t.Title, t.Priority, t.StartTime, t.EndTime, t.StatusID})
+}
@@ -51,10 +51,10 @@ func (TaskStatus) TableName() string {
 // Staff represents a hospital staff member.
 type Staff struct {
 	coreEntity.BaseEntity
-	FirstName   string    `gorm:"not null"`    // Staff member's first name
-	LastName    string    `gorm:"not null"`    // Staff member's last name
-	DateOfBirth time.Time `gorm:"type:date"`   // Staff member's date of birth
-	PhoneNumber string    `gorm:"uniqueIndex"` // Contact phone number
+	FirstName   string    `gorm:"not null"`                                                    // Staff member's first name
+	LastName    string    `gorm:"not null"`                                                    // Staff member's last name
+	DateOfBirth time.Time `gorm:"type:date"`                                                   // Staff member's date of birth
+	PhoneNumber string    `gorm:"uniqueIndex:idx_staff_phone_number,where:phone_number <> ''"` // Contact phone number, unique unless empty
 	Address     string    // Staff member's address
 
 	// Foreign keys are now strings referencing the Name field of lookup tables
@@ -81,6 +81,22 @@ func (Staff) TableName() string {
 	return "staff"
 }
 
+// FilterableColumns lists the columns clients may filter staff by.
+func (s Staff) FilterableColumns() []string {
+	return append(s.BaseEntity.FilterableColumns(),
+		"first_name", "last_name", "date_of_birth", "phone_number", "role_id", "status_id", "specialization", "nurse_type")
+}
+
+// SortableColumns lists the columns clients may sort staff by.
+func (s Staff) SortableColumns() []string {
+	return append(s.BaseEntity.SortableColumns(), "first_name", "last_name", "date_of_birth", "role_id", "status_id")
+}
+
+// SearchColumns lists the columns staff are searched and highlighted in.
+func (s Staff) SearchColumns() []string {
+	return []string{"first_name", "last_name", "phone_number", "address"}
+}
+
 // --- Related Entities (Schedule, Task) ---
 
 // ScheduleEntry represents the link between a staff member and a specific task in their schedule.
@@ -123,6 +139,16 @@ func (Task) TableName() string {
 	return "staff_tasks"
 }
 
+// FilterableColumns lists the columns clients may filter tasks by.
+func (t Task) FilterableColumns() []string {
+	return append(t.BaseEntity.FilterableColumns(), "title", "priority", "start_time", "end_time", "status_id")
+}
+
+// SortableColumns lists the columns clients may sort tasks by.
+func (t Task) SortableColumns() []string {
+	return append(t.BaseEntity.SortableColumns(), "title", "priority", "start_time", "end_time", "status_id")
+}
+
 // --- Constructors and Methods ---
 
 // NewStaff creates a new Staff instance.
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	coreDatabase "golang-microservices-boilerplate/pkg/core/database"
+	coreRepository "golang-microservices-boilerplate/pkg/core/repository"
+	coreTypes "golang-microservices-boilerplate/pkg/core/types"
+	"golang-microservices-boilerplate/pkg/utils/cache"
+	"golang-microservices-boilerplate/services/staff-service/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+// --- Cached Staff Repository ---
+
+// Ensure CachedStaffRepository implements StaffRepository
+var _ StaffRepository = (*CachedStaffRepository)(nil)
+
+// CachedStaffRepository caches FindByID (staff with their role, status and
+// schedule) on top of a StaffRepository. Schedule and status changes made
+// through it invalidate the staff member like base updates do.
+type CachedStaffRepository struct {
+	*coreRepository.CachedRepository[entity.Staff]
+	repo StaffRepository
+}
+
+// NewCachedStaffRepository wraps repo with a FindByID cache configured by opts.
+func NewCachedStaffRepository(repo StaffRepository, opts cache.Options) *CachedStaffRepository {
+	return &CachedStaffRepository{
+		CachedRepository: coreRepository.NewCachedRepository[entity.Staff](repo, opts),
+		repo:             repo,
+	}
+}
+
+// Search is not cached.
+func (r *CachedStaffRepository) Search(ctx context.Context, opts coreTypes.SearchOptions) (*coreTypes.SearchResult[entity.Staff], error) {
+	return r.repo.Search(ctx, opts)
+}
+
+// FindAvailableDoctors is not cached.
+func (r *CachedStaffRepository) FindAvailableDoctors(ctx context.Context, startTime time.Time, endTime time.Time) ([]*entity.Staff, error) {
+	return r.repo.FindAvailableDoctors(ctx, startTime, endTime)
+}
+
+// AddScheduleEntries invalidates the staff member, whose schedule changes.
+func (r *CachedStaffRepository) AddScheduleEntries(ctx context.Context, staffID uuid.UUID, tasks []*entity.Task) error {
+	defer r.Invalidate(ctx, staffID)
+	return r.repo.AddScheduleEntries(ctx, staffID, tasks)
+}
+
+// UpdateStatus invalidates the staff member.
+func (r *CachedStaffRepository) UpdateStatus(ctx context.Context, staffID uuid.UUID, statusID string) error {
+	defer r.Invalidate(ctx, staffID)
+	return r.repo.UpdateStatus(ctx, staffID, statusID)
+}
+
+// AssignTaskToStaff invalidates the staff member, whose schedule changes.
+func (r *CachedStaffRepository) AssignTaskToStaff(ctx context.Context, staffID uuid.UUID, task *entity.Task) error {
+	defer r.Invalidate(ctx, staffID)
+	return r.repo.AssignTaskToStaff(ctx, staffID, task)
+}
+
+// FindTasksByStaffID is not cached.
+func (r *CachedStaffRepository) FindTasksByStaffID(ctx context.Context, staffID uuid.UUID) ([]*entity.Task, error) {
+	return r.repo.FindTasksByStaffID(ctx, staffID)
+}
+
+// --- Cached Lookup Repositories --- //
+
+// lookupRepository is the shape shared by the lookup table repositories.
+type lookupRepository[E any] interface {
+	Create(ctx context.Context, e *E) error
+	FindByName(ctx context.Context, name string) (*E, error)
+	ListAll(ctx context.Context) ([]*E, error)
+}
+
+// Ensure cachedLookupRepository implements the lookup table repositories
+var (
+	_ StaffRoleRepository   = (*cachedLookupRepository[entity.StaffRole])(nil)
+	_ StaffStatusRepository = (*cachedLookupRepository[entity.StaffStatus])(nil)
+	_ TaskStatusRepository  = (*cachedLookupRepository[entity.TaskStatus])(nil)
+)
+
+// cachedLookupRepository caches FindByName and ListAll of a lookup table,
+// which is read on most staff and task writes but rarely changes. Create
+// invalidates the whole table. Like CachedRepository, it bypasses the cache
+// inside a unit of work and returns copies of the cached rows.
+type cachedLookupRepository[E any] struct {
+	repo   lookupRepository[E]
+	byName *cache.Cache[string, E]
+	all    *cache.Cache[struct{}, []E]
+}
+
+// newCachedLookupRepository wraps repo with caches configured by opts.
+func newCachedLookupRepository[E any](repo lookupRepository[E], opts cache.Options) *cachedLookupRepository[E] {
+	return &cachedLookupRepository[E]{
+		repo:   repo,
+		byName: cache.New[string, E](opts),
+		all:    cache.New[struct{}, []E](opts),
+	}
+}
+
+// NewCachedStaffRoleRepository wraps repo with caches of roles by name and of the role list.
+func NewCachedStaffRoleRepository(repo StaffRoleRepository, opts cache.Options) StaffRoleRepository {
+	return newCachedLookupRepository[entity.StaffRole](repo, opts)
+}
+
+// NewCachedStaffStatusRepository wraps repo with caches of statuses by name and of the status list.
+func NewCachedStaffStatusRepository(repo StaffStatusRepository, opts cache.Options) StaffStatusRepository {
+	return newCachedLookupRepository[entity.StaffStatus](repo, opts)
+}
+
+// NewCachedTaskStatusRepository wraps repo with caches of task statuses by name and of the status list.
+func NewCachedTaskStatusRepository(repo TaskStatusRepository, opts cache.Options) TaskStatusRepository {
+	return newCachedLookupRepository[entity.TaskStatus](repo, opts)
+}
+
+func (r *cachedLookupRepository[E]) Create(ctx context.Context, e *E) error {
+	defer func() {
+		r.invalidate()
+		coreDatabase.AfterCommit(ctx, r.invalidate)
+	}()
+	return r.repo.Create(ctx, e)
+}
+
+func (r *cachedLookupRepository[E]) FindByName(ctx context.Context, name string) (*E, error) {
+	if _, inTx := coreDatabase.TxFromContext(ctx); inTx {
+		return r.repo.FindByName(ctx, name)
+	}
+	value, err := r.byName.GetOrLoad(name, func() (E, error) {
+		e, err := r.repo.FindByName(ctx, name)
+		if err != nil {
+			var zero E
+			return zero, err
+		}
+		return *e, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+func (r *cachedLookupRepository[E]) ListAll(ctx context.Context) ([]*E, error) {
+	if _, inTx := coreDatabase.TxFromContext(ctx); inTx {
+		return r.repo.ListAll(ctx)
+	}
+	values, err := r.all.GetOrLoad(struct{}{}, func() ([]E, error) {
+		rows, err := r.repo.ListAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]E, len(rows))
+		for i, row := range rows {
+			values[i] = *row
+		}
+		return values, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]*E, len(values))
+	for i := range values {
+		row := values[i]
+		rows[i] = &row
+	}
+	return rows, nil
+}
+
+// invalidate drops every cached row of the table.
+func (r *cachedLookupRepository[E]) invalidate() {
+	r.byName.Clear()
+	r.all.Clear()
+}
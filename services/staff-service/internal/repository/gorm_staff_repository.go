@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	coreDatabase "golang-microservices-boilerplate/pkg/core/database"
 	coreRepository "golang-microservices-boilerplate/pkg/core/repository"
 	"golang-microservices-boilerplate/services/staff-service/internal/entity"
 
@@ -33,7 +34,7 @@ func NewGormStaffRepository(db *gorm.DB) *GormStaffRepository {
 // FindByID overrides base FindByID to preload associations
 func (r *GormStaffRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Staff, error) {
 	staff := &entity.Staff{}
-	err := r.DB.WithContext(ctx).
+	err := r.ReadConn(ctx).
 		Preload("Role").                 // Preload StaffRole
 		Preload("Status").               // Preload StaffStatus
 		Preload("Schedule").             // Preload ScheduleEntries
@@ -43,8 +44,7 @@ func (r *GormStaffRepository) FindByID(ctx context.Context, id uuid.UUID) (*enti
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			// Use a standard error or define custom errors
-			return nil, errors.New("staff not found")
+			return nil, coreRepository.NewNotFoundError(r.ModelType.Name(), id)
 		}
 		return nil, err
 	}
@@ -58,7 +58,7 @@ func (r *GormStaffRepository) FindAvailableDoctors(ctx context.Context, startTim
 
 	// Placeholder query - MUST BE REVISED
 	// This only finds active doctors, but doesn't analyze their schedule for actual availability gaps.
-	err := r.DB.WithContext(ctx).
+	err := r.Conn(ctx).
 		Preload("Role").
 		Preload("Status").
 		Preload("Schedule.Task.Status").
@@ -82,16 +82,17 @@ func (r *GormStaffRepository) FindAvailableDoctors(ctx context.Context, startTim
 	return availableDoctors, nil
 }
 
-// AddScheduleEntries creates tasks and links them via ScheduleEntry within a transaction.
+// AddScheduleEntries creates tasks and links them via ScheduleEntry atomically,
+// within the caller's unit of work if there is one.
 func (r *GormStaffRepository) AddScheduleEntries(ctx context.Context, staffID uuid.UUID, tasks []*entity.Task) error {
 	if len(tasks) == 0 {
 		return nil // Nothing to add
 	}
 
-	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return coreDatabase.NewUnitOfWork(r.DB).Do(ctx, func(ctx context.Context) error {
 		// 1. Create all the tasks first (ensure IDs are generated)
-		if err := tx.Create(tasks).Error; err != nil {
-			return err // Rollback transaction
+		if err := r.Conn(ctx).Create(tasks).Error; err != nil {
+			return coreRepository.TranslateError(err) // Rollback transaction
 		}
 
 		// 2. Create the ScheduleEntry links
@@ -103,8 +104,8 @@ func (r *GormStaffRepository) AddScheduleEntries(ctx context.Context, staffID uu
 			}
 		}
 
-		if err := tx.Create(&scheduleEntries).Error; err != nil {
-			return err // Rollback transaction
+		if err := r.Conn(ctx).Create(&scheduleEntries).Error; err != nil {
+			return coreRepository.TranslateError(err) // Rollback transaction
 		}
 
 		return nil // Commit transaction
@@ -113,29 +114,31 @@ func (r *GormStaffRepository) AddScheduleEntries(ctx context.Context, staffID uu
 
 // UpdateStatus updates only the StatusID for a staff member.
 func (r *GormStaffRepository) UpdateStatus(ctx context.Context, staffID uuid.UUID, statusID string) error {
-	result := r.DB.WithContext(ctx).
+	result := r.Conn(ctx).
 		Model(&entity.Staff{}).
 		Where("id = ?", staffID).
 		Updates(map[string]interface{}{
 			"status_id":  statusID,
 			"updated_at": time.Now(), // Ensure updated_at is set
+			"version":    gorm.Expr("version + 1"),
 		})
 
 	if result.Error != nil {
-		return result.Error
+		return coreRepository.TranslateError(result.Error)
 	}
 	if result.RowsAffected == 0 {
-		return errors.New("staff not found or status not changed")
+		return coreRepository.NewNotFoundError(r.ModelType.Name(), staffID)
 	}
 	return nil
 }
 
-// AssignTaskToStaff creates a Task and links it via ScheduleEntry in a transaction.
+// AssignTaskToStaff creates a Task and links it via ScheduleEntry atomically,
+// within the caller's unit of work if there is one.
 func (r *GormStaffRepository) AssignTaskToStaff(ctx context.Context, staffID uuid.UUID, task *entity.Task) error {
-	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return coreDatabase.NewUnitOfWork(r.DB).Do(ctx, func(ctx context.Context) error {
 		// 1. Create the task
-		if err := tx.Create(task).Error; err != nil {
-			return err
+		if err := r.Conn(ctx).Create(task).Error; err != nil {
+			return coreRepository.TranslateError(err)
 		}
 
 		// 2. Create the ScheduleEntry link
@@ -143,8 +146,8 @@ func (r *GormStaffRepository) AssignTaskToStaff(ctx context.Context, staffID uui
 			StaffID: staffID,
 			TaskID:  task.ID,
 		}
-		if err := tx.Create(&scheduleEntry).Error; err != nil {
-			return err
+		if err := r.Conn(ctx).Create(&scheduleEntry).Error; err != nil {
+			return coreRepository.TranslateError(err)
 		}
 		return nil
 	})
@@ -153,7 +156,7 @@ func (r *GormStaffRepository) AssignTaskToStaff(ctx context.Context, staffID uui
 // FindTasksByStaffID retrieves tasks associated with a staff member.
 func (r *GormStaffRepository) FindTasksByStaffID(ctx context.Context, staffID uuid.UUID) ([]*entity.Task, error) {
 	var tasks []*entity.Task
-	err := r.DB.WithContext(ctx).
+	err := r.Conn(ctx).
 		Preload("Status"). // Preload TaskStatus
 		Joins("JOIN "+entity.ScheduleEntry{}.TableName()+" sse ON sse.task_id = "+entity.Task{}.TableName()+".id").
 		Where("sse.staff_id = ?", staffID).
@@ -178,15 +181,15 @@ func NewGormStaffRoleRepository(db *gorm.DB) *GormStaffRoleRepository {
 }
 
 func (r *GormStaffRoleRepository) Create(ctx context.Context, role *entity.StaffRole) error {
-	return r.db.WithContext(ctx).Create(role).Error
+	return coreDatabase.Conn(ctx, r.db).Create(role).Error
 }
 
 func (r *GormStaffRoleRepository) FindByName(ctx context.Context, name string) (*entity.StaffRole, error) {
 	var role entity.StaffRole
-	err := r.db.WithContext(ctx).First(&role, "name = ?", name).Error
+	err := coreDatabase.Conn(ctx, r.db).First(&role, "name = ?", name).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("staff role not found") // Consistent error
+			return nil, coreRepository.NewNotFoundError("StaffRole", name)
 		}
 		return nil, err
 	}
@@ -195,7 +198,7 @@ func (r *GormStaffRoleRepository) FindByName(ctx context.Context, name string) (
 
 func (r *GormStaffRoleRepository) ListAll(ctx context.Context) ([]*entity.StaffRole, error) {
 	var roles []*entity.StaffRole
-	err := r.db.WithContext(ctx).Find(&roles).Error
+	err := coreDatabase.Conn(ctx, r.db).Find(&roles).Error
 	if err != nil {
 		return nil, err
 	}
@@ -216,15 +219,15 @@ func NewGormStaffStatusRepository(db *gorm.DB) *GormStaffStatusRepository {
 }
 
 func (r *GormStaffStatusRepository) Create(ctx context.Context, status *entity.StaffStatus) error {
-	return r.db.WithContext(ctx).Create(status).Error
+	return coreDatabase.Conn(ctx, r.db).Create(status).Error
 }
 
 func (r *GormStaffStatusRepository) FindByName(ctx context.Context, name string) (*entity.StaffStatus, error) {
 	var status entity.StaffStatus
-	err := r.db.WithContext(ctx).First(&status, "name = ?", name).Error
+	err := coreDatabase.Conn(ctx, r.db).First(&status, "name = ?", name).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("staff status not found")
+			return nil, coreRepository.NewNotFoundError("StaffStatus", name)
 		}
 		return nil, err
 	}
@@ -233,7 +236,7 @@ func (r *GormStaffStatusRepository) FindByName(ctx context.Context, name string)
 
 func (r *GormStaffStatusRepository) ListAll(ctx context.Context) ([]*entity.StaffStatus, error) {
 	var statuses []*entity.StaffStatus
-	err := r.db.WithContext(ctx).Find(&statuses).Error
+	err := coreDatabase.Conn(ctx, r.db).Find(&statuses).Error
 	if err != nil {
 		return nil, err
 	}
@@ -254,15 +257,15 @@ func NewGormTaskStatusRepository(db *gorm.DB) *GormTaskStatusRepository {
 }
 
 func (r *GormTaskStatusRepository) Create(ctx context.Context, status *entity.TaskStatus) error {
-	return r.db.WithContext(ctx).Create(status).Error
+	return coreDatabase.Conn(ctx, r.db).Create(status).Error
 }
 
 func (r *GormTaskStatusRepository) FindByName(ctx context.Context, name string) (*entity.TaskStatus, error) {
 	var status entity.TaskStatus
-	err := r.db.WithContext(ctx).First(&status, "name = ?", name).Error
+	err := coreDatabase.Conn(ctx, r.db).First(&status, "name = ?", name).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("task status not found")
+			return nil, coreRepository.NewNotFoundError("TaskStatus", name)
 		}
 		return nil, err
 	}
@@ -271,7 +274,7 @@ func (r *GormTaskStatusRepository) FindByName(ctx context.Context, name string)
 
 func (r *GormTaskStatusRepository) ListAll(ctx context.Context) ([]*entity.TaskStatus, error) {
 	var statuses []*entity.TaskStatus
-	err := r.db.WithContext(ctx).Find(&statuses).Error
+	err := coreDatabase.Conn(ctx, r.db).Find(&statuses).Error
 	if err != nil {
 		return nil, err
 	}
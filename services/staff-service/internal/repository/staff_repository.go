@@ -15,6 +15,7 @@ import (
 // Implementations should handle preloading of related entities (Role, Status, Schedule.Task) where appropriate.
 type StaffRepository interface {
 	coreRepository.BaseRepository[entity.Staff]
+	coreRepository.Searcher[entity.Staff] // Full-text and fuzzy search over names, phone and address
 
 	// FindAvailableDoctors retrieves staff (typically doctors by role) who are considered available
 	// within a given time range. Implementation needs to consider Staff.StatusID and existing Task start/end times.
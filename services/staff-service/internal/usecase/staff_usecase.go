@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	coreTypes "golang-microservices-boilerplate/pkg/core/types"
 	pb "golang-microservices-boilerplate/proto/staff-service"
 	"golang-microservices-boilerplate/services/staff-service/internal/entity"
 
@@ -22,8 +23,9 @@ type StaffUseCase interface {
 	GetStaffDetails(ctx context.Context, staffID uuid.UUID) (*entity.Staff, error)
 
 	// UpdateStaffDetails updates information for an existing staff member.
-	// Takes parameters aligned with pb.UpdateStaffDetailsRequest.
-	UpdateStaffDetails(ctx context.Context, staffID uuid.UUID, firstName, lastName string, dob *time.Time, phone, address, specialization, nurseType string) (*entity.Staff, error)
+	// Takes parameters aligned with pb.UpdateStaffDetailsRequest. With a non-empty updateMask only the
+	// named fields (proto field names) are set, even to empty values; otherwise empty values are skipped.
+	UpdateStaffDetails(ctx context.Context, staffID uuid.UUID, firstName, lastName string, dob *time.Time, phone, address, specialization, nurseType string, expectedVersion *int64, updateMask []string) (*entity.Staff, error)
 
 	// UpdateStaffSchedule updates the schedule for a staff member by creating new tasks and schedule entries.
 	// Input tasks are expected to be DTOs or similar, not raw entities.
@@ -41,14 +43,41 @@ type StaffUseCase interface {
 	// Input parameters align with pb.AssignTaskRequest.
 	AssignTask(ctx context.Context, staffID uuid.UUID, title, description string, priority int32, startTime, endTime *time.Time, statusID string) (*entity.Task, error)
 
+	// ScheduleAppointment adds a Pending task for an appointment booked with the
+	// doctor, from startTime to endTime at place, to the doctor's schedule. The task
+	// gets the appointment's ID, so scheduling the same appointment again does nothing.
+	ScheduleAppointment(ctx context.Context, appointmentID, doctorID uuid.UUID, startTime, endTime time.Time, place string) error
+
 	// TrackWorkload retrieves the current workload (tasks) for a staff member.
 	TrackWorkload(ctx context.Context, staffID uuid.UUID) ([]*entity.Task, error)
 
-	// ListStaff retrieves a list of staff members, optionally filtering by role and/or status.
-	ListStaff(ctx context.Context, req *pb.ListStaffRequest) ([]*entity.Staff, error)
+	// ListStaff retrieves staff members matching opts with pagination, optionally
+	// filtering by the role and/or status of req.
+	ListStaff(ctx context.Context, req *pb.ListStaffRequest, opts coreTypes.FilterOptions) (*coreTypes.PaginationResult[entity.Staff], error)
+
+	// SearchStaff finds staff members by partial name, phone or address, ranked by relevance.
+	SearchStaff(ctx context.Context, opts coreTypes.SearchOptions) (*coreTypes.SearchResult[entity.Staff], error)
+
+	// ExportStaff calls fn for every staff member matching opts, reading them page by page.
+	ExportStaff(ctx context.Context, opts coreTypes.FilterOptions, fn func(*entity.Staff) error) error
+
+	// ListTasks retrieves tasks matching opts with pagination, optionally
+	// filtering by the status of req.
+	ListTasks(ctx context.Context, req *pb.ListTasksRequest, opts coreTypes.FilterOptions) (*coreTypes.PaginationResult[entity.Task], error)
+
+	// === Soft-delete Lifecycle ===
+
+	// DeleteStaff soft-deletes a staff member, or permanently removes it when hardDelete is set.
+	DeleteStaff(ctx context.Context, staffID uuid.UUID, hardDelete bool) error
+
+	// RestoreStaff undeletes a soft-deleted staff member.
+	RestoreStaff(ctx context.Context, staffID uuid.UUID) (*entity.Staff, error)
+
+	// ListDeletedStaff retrieves soft-deleted staff members with pagination.
+	ListDeletedStaff(ctx context.Context, opts coreTypes.FilterOptions) (*coreTypes.PaginationResult[entity.Staff], error)
 
-	// ListTasks retrieves a list of all tasks, optionally filtered, ordered by creation time descending.
-	ListTasks(ctx context.Context, req *pb.ListTasksRequest) ([]*entity.Task, error)
+	// PurgeDeletedStaff permanently removes staff soft-deleted more than olderThanDays days ago.
+	PurgeDeletedStaff(ctx context.Context, olderThanDays int) (int64, error)
 
 	// === Lookup Table Management ===
 
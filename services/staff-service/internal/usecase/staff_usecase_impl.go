@@ -8,12 +8,14 @@ import (
 
 	pb "golang-microservices-boilerplate/proto/staff-service"
 
+	coreDatabase "golang-microservices-boilerplate/pkg/core/database"
+	coreDTO "golang-microservices-boilerplate/pkg/core/dto"
+	coreEvents "golang-microservices-boilerplate/pkg/core/events"
 	coreLogger "golang-microservices-boilerplate/pkg/core/logger"
+	coreRepository "golang-microservices-boilerplate/pkg/core/repository"
 	coreTypes "golang-microservices-boilerplate/pkg/core/types"
 	coreUseCase "golang-microservices-boilerplate/pkg/core/usecase"
 
-	// coreDTO "golang-microservices-boilerplate/pkg/core/dto"
-
 	"golang-microservices-boilerplate/services/staff-service/internal/entity"
 	"golang-microservices-boilerplate/services/staff-service/internal/repository"
 
@@ -31,6 +33,7 @@ type staffUseCaseImpl struct {
 	staffRoleRepo   repository.StaffRoleRepository
 	staffStatusRepo repository.StaffStatusRepository
 	taskStatusRepo  repository.TaskStatusRepository
+	uow             coreDatabase.UnitOfWork
 	logger          coreLogger.Logger
 }
 
@@ -41,6 +44,7 @@ func NewStaffUseCase(
 	staffRoleRepo repository.StaffRoleRepository,
 	staffStatusRepo repository.StaffStatusRepository,
 	taskStatusRepo repository.TaskStatusRepository,
+	uow coreDatabase.UnitOfWork,
 	logger coreLogger.Logger,
 ) StaffUseCase {
 	return &staffUseCaseImpl{
@@ -49,6 +53,7 @@ func NewStaffUseCase(
 		staffRoleRepo:   staffRoleRepo,
 		staffStatusRepo: staffStatusRepo,
 		taskStatusRepo:  taskStatusRepo,
+		uow:             uow,
 		logger:          logger,
 	}
 }
@@ -57,7 +62,7 @@ func NewStaffUseCase(
 
 // AddStaff handles creating a new staff member.
 func (uc *staffUseCaseImpl) AddStaff(ctx context.Context, firstName, lastName string, dob *time.Time, phone, address, roleID, statusID, specialization, nurseType string) (*entity.Staff, error) {
-	uc.logger.Info("Adding new staff", "firstName", firstName, "lastName", lastName, "roleID", roleID, "statusID", statusID)
+	uc.logger.WithContext(ctx).Info("Adding new staff", "firstName", firstName, "lastName", lastName, "roleID", roleID, "statusID", statusID)
 
 	// Validation
 	if firstName == "" || lastName == "" || phone == "" || roleID == "" || statusID == "" || dob == nil || dob.IsZero() {
@@ -67,12 +72,12 @@ func (uc *staffUseCaseImpl) AddStaff(ctx context.Context, firstName, lastName st
 	// Validate existence of roleID and statusID
 	_, err := uc.staffRoleRepo.FindByName(ctx, roleID)
 	if err != nil {
-		uc.logger.Warn("Invalid RoleID provided", "roleID", roleID, "error", err)
+		uc.logger.WithContext(ctx).Warn("Invalid RoleID provided", "roleID", roleID, "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, fmt.Sprintf("invalid role ID: %s", roleID))
 	}
 	_, err = uc.staffStatusRepo.FindByName(ctx, statusID)
 	if err != nil {
-		uc.logger.Warn("Invalid StatusID provided", "statusID", statusID, "error", err)
+		uc.logger.WithContext(ctx).Warn("Invalid StatusID provided", "statusID", statusID, "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, fmt.Sprintf("invalid status ID: %s", statusID))
 	}
 
@@ -80,20 +85,25 @@ func (uc *staffUseCaseImpl) AddStaff(ctx context.Context, firstName, lastName st
 	staff := entity.NewStaff(firstName, lastName, phone, address, *dob, roleID, statusID, specialization, nurseType)
 
 	// Use the specific repository's Create method
+	ctx = coreEvents.Record(ctx, entity.StaffAdded{Staff: staff})
 	err = uc.staffRepo.Create(ctx, staff) // GormBaseRepository provides Create
 	if err != nil {
-		uc.logger.Error("Failed to save staff", "error", err)
+		if ucErr := coreUseCase.TranslateRepositoryError(err); ucErr != nil {
+			uc.logger.WithContext(ctx).Warn("Staff creation rejected", "error", err)
+			return nil, ucErr
+		}
+		uc.logger.WithContext(ctx).Error("Failed to save staff", "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to add staff member")
 	}
 
-	uc.logger.Info("Staff added successfully", "staffID", staff.ID.String())
+	uc.logger.WithContext(ctx).Info("Staff added successfully", "staffID", staff.ID.String())
 	// Refetch to preload relations for the response
-	return uc.staffRepo.FindByID(ctx, staff.ID)
+	return uc.staffRepo.FindByID(coreDatabase.WithPrimary(ctx), staff.ID)
 }
 
 // GetStaffDetails retrieves staff details by ID.
 func (uc *staffUseCaseImpl) GetStaffDetails(ctx context.Context, staffID uuid.UUID) (*entity.Staff, error) {
-	uc.logger.Info("Getting staff details", "staffID", staffID.String())
+	uc.logger.WithContext(ctx).Info("Getting staff details", "staffID", staffID.String())
 	if staffID == uuid.Nil {
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid staff ID")
 	}
@@ -101,56 +111,90 @@ func (uc *staffUseCaseImpl) GetStaffDetails(ctx context.Context, staffID uuid.UU
 	// Use the specific repository's FindByID which handles preloading
 	staff, err := uc.staffRepo.FindByID(ctx, staffID)
 	if err != nil {
-		if errors.Is(err, errors.New("staff not found")) {
-			uc.logger.Warn("Staff not found", "staffID", staffID.String())
+		if errors.Is(err, coreRepository.ErrNotFound) {
+			uc.logger.WithContext(ctx).Warn("Staff not found", "staffID", staffID.String())
 			return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrNotFound, "staff not found")
 		}
-		uc.logger.Error("Failed to get staff details", "staffID", staffID.String(), "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to get staff details", "staffID", staffID.String(), "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to get staff details")
 	}
 	return staff, nil
 }
 
+// staffDetails holds the staff fields an update mask can name, under their
+// pb.UpdateStaffDetailsRequest field names.
+type staffDetails struct {
+	FirstName      string    `json:"first_name"`
+	LastName       string    `json:"last_name"`
+	DateOfBirth    time.Time `json:"date_of_birth"`
+	PhoneNumber    string    `json:"phone_number"`
+	Address        string    `json:"address"`
+	Specialization string    `json:"specialization"`
+	NurseType      string    `json:"nurse_type"`
+}
+
 // UpdateStaffDetails updates existing staff details.
-func (uc *staffUseCaseImpl) UpdateStaffDetails(ctx context.Context, staffID uuid.UUID, firstName, lastName string, dob *time.Time, phone, address, specialization, nurseType string) (*entity.Staff, error) {
-	uc.logger.Info("Updating staff details", "staffID", staffID.String())
+func (uc *staffUseCaseImpl) UpdateStaffDetails(ctx context.Context, staffID uuid.UUID, firstName, lastName string, dob *time.Time, phone, address, specialization, nurseType string, expectedVersion *int64, updateMask []string) (*entity.Staff, error) {
+	uc.logger.WithContext(ctx).Info("Updating staff details", "staffID", staffID.String())
 	if staffID == uuid.Nil {
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid staff ID")
 	}
 
-	// Fetch existing staff - Use the base FindByID without preloads for the update target
-	staff, err := uc.staffRepo.FindByID(ctx, staffID) // staffRepo embeds the base repo, call FindByID directly
+	// Fetch existing staff
+	staff, err := uc.staffRepo.FindByID(coreDatabase.WithPrimary(ctx), staffID) // Read from the primary: the version must be current
 	if err != nil {
-		if errors.Is(err, errors.New("staff not found")) {
-			uc.logger.Warn("Staff not found for update", "staffID", staffID.String())
+		if errors.Is(err, coreRepository.ErrNotFound) {
+			uc.logger.WithContext(ctx).Warn("Staff not found for update", "staffID", staffID.String())
 			return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrNotFound, "staff not found")
 		}
-		uc.logger.Error("Failed to find staff for update", "staffID", staffID.String(), "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to find staff for update", "staffID", staffID.String(), "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to find staff for update")
 	}
 
-	// Apply updates using entity method
+	// Apply updates: exactly the masked fields, which may clear them, or the
+	// non-empty ones through the entity method
 	var dobValue time.Time
 	if dob != nil {
 		dobValue = *dob
 	}
-	staff.UpdateDetails(firstName, lastName, phone, address, dobValue, specialization, nurseType)
+	if len(updateMask) > 0 {
+		details := staffDetails{
+			FirstName: firstName, LastName: lastName, DateOfBirth: dobValue, PhoneNumber: phone,
+			Address: address, Specialization: specialization, NurseType: nurseType,
+		}
+		if err := coreDTO.ApplyMask(details, staff, updateMask); err != nil {
+			if ucErr := coreUseCase.TranslateMaskError(err); ucErr != nil {
+				return nil, ucErr
+			}
+			uc.logger.WithContext(ctx).Error("Failed to apply staff update mask", "staffID", staffID.String(), "error", err)
+			return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to update staff details")
+		}
+	} else {
+		staff.UpdateDetails(firstName, lastName, phone, address, dobValue, specialization, nurseType)
+	}
+	if expectedVersion != nil {
+		staff.Version = *expectedVersion // Repository rejects the write if the client's copy is stale
+	}
 
 	// Use repository's Update method
 	err = uc.staffRepo.Update(ctx, staff)
 	if err != nil {
-		uc.logger.Error("Failed to update staff", "staffID", staffID.String(), "error", err)
+		if errors.Is(err, coreRepository.ErrVersionConflict) {
+			uc.logger.WithContext(ctx).Warn("Stale staff update rejected", "staffID", staffID.String(), "error", err)
+			return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrConflict, "staff was modified by another request; reload and retry")
+		}
+		uc.logger.WithContext(ctx).Error("Failed to update staff", "staffID", staffID.String(), "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to update staff details")
 	}
 
-	uc.logger.Info("Staff details updated successfully", "staffID", staffID.String())
+	uc.logger.WithContext(ctx).Info("Staff details updated successfully", "staffID", staffID.String())
 	// Refetch using the specific FindByID to get preloaded relations for the response
-	return uc.staffRepo.FindByID(ctx, staffID)
+	return uc.staffRepo.FindByID(coreDatabase.WithPrimary(ctx), staffID)
 }
 
 // UpdateStaffSchedule creates new tasks and links them.
 func (uc *staffUseCaseImpl) UpdateStaffSchedule(ctx context.Context, staffID uuid.UUID, tasksToSchedule []*pb.TaskProto) error {
-	uc.logger.Info("Updating staff schedule by adding tasks", "staffID", staffID.String(), "taskCount", len(tasksToSchedule))
+	uc.logger.WithContext(ctx).Info("Updating staff schedule by adding tasks", "staffID", staffID.String(), "taskCount", len(tasksToSchedule))
 	if staffID == uuid.Nil {
 		return coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid staff ID")
 	}
@@ -159,13 +203,13 @@ func (uc *staffUseCaseImpl) UpdateStaffSchedule(ctx context.Context, staffID uui
 	tasks := make([]*entity.Task, 0, len(tasksToSchedule))
 	for _, pt := range tasksToSchedule {
 		if pt.StartTime == nil || pt.EndTime == nil || pt.StartTime.AsTime().After(pt.EndTime.AsTime()) || pt.StatusId == "" {
-			uc.logger.Warn("Invalid task data in schedule update", "staffID", staffID.String(), "taskTitle", pt.Title)
+			uc.logger.WithContext(ctx).Warn("Invalid task data in schedule update", "staffID", staffID.String(), "taskTitle", pt.Title)
 			return coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, fmt.Sprintf("invalid data for task '%s' (time range or status ID)", pt.Title))
 		}
 		// Validate existence of StatusId
 		_, err := uc.taskStatusRepo.FindByName(ctx, pt.StatusId)
 		if err != nil {
-			uc.logger.Warn("Invalid TaskStatusID provided for task", "taskTitle", pt.Title, "statusID", pt.StatusId, "error", err)
+			uc.logger.WithContext(ctx).Warn("Invalid TaskStatusID provided for task", "taskTitle", pt.Title, "statusID", pt.StatusId, "error", err)
 			return coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, fmt.Sprintf("invalid status ID '%s' for task '%s'", pt.StatusId, pt.Title))
 		}
 
@@ -181,36 +225,36 @@ func (uc *staffUseCaseImpl) UpdateStaffSchedule(ctx context.Context, staffID uui
 
 	err := uc.staffRepo.AddScheduleEntries(ctx, staffID, tasks)
 	if err != nil {
-		uc.logger.Error("Failed to add schedule entries in repo", "staffID", staffID.String(), "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to add schedule entries in repo", "staffID", staffID.String(), "error", err)
 		return coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to update staff schedule")
 	}
 
-	uc.logger.Info("Staff schedule updated successfully by adding tasks", "staffID", staffID.String())
+	uc.logger.WithContext(ctx).Info("Staff schedule updated successfully by adding tasks", "staffID", staffID.String())
 	return nil
 }
 
 // SetStaffStatus updates the staff's status.
 func (uc *staffUseCaseImpl) SetStaffStatus(ctx context.Context, staffID uuid.UUID, statusID string) error {
-	uc.logger.Info("Setting staff status", "staffID", staffID.String(), "statusID", statusID)
+	uc.logger.WithContext(ctx).Info("Setting staff status", "staffID", staffID.String(), "statusID", statusID)
 	if staffID == uuid.Nil || statusID == "" {
 		return coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid staff ID or status ID")
 	}
 	// Validate existence of statusID
 	_, err := uc.staffStatusRepo.FindByName(ctx, statusID)
 	if err != nil {
-		uc.logger.Warn("Invalid StatusID provided for update", "statusID", statusID, "error", err)
+		uc.logger.WithContext(ctx).Warn("Invalid StatusID provided for update", "statusID", statusID, "error", err)
 		return coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, fmt.Sprintf("invalid status ID: %s", statusID))
 	}
 
 	err = uc.staffRepo.UpdateStatus(ctx, staffID, statusID)
 	if err != nil {
-		uc.logger.Error("Failed to set staff status in repo", "staffID", staffID.String(), "error", err)
-		if errors.Is(err, errors.New("staff not found or status not changed")) {
+		uc.logger.WithContext(ctx).Error("Failed to set staff status in repo", "staffID", staffID.String(), "error", err)
+		if errors.Is(err, coreRepository.ErrNotFound) {
 			return coreUseCase.NewUseCaseError(coreUseCase.ErrNotFound, "staff not found")
 		}
 		return coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to set staff status")
 	}
-	uc.logger.Info("Staff status set successfully", "staffID", staffID.String())
+	uc.logger.WithContext(ctx).Info("Staff status set successfully", "staffID", staffID.String())
 	return nil
 }
 
@@ -221,7 +265,7 @@ func (uc *staffUseCaseImpl) GetDoctorAvailability(ctx context.Context, doctorID
 	if doctorID != nil {
 		logFields = append(logFields, "doctorID", doctorID.String())
 	}
-	uc.logger.Info("Getting doctor availability (placeholder implementation)", logFields...)
+	uc.logger.WithContext(ctx).Info("Getting doctor availability (placeholder implementation)", logFields...)
 
 	if doctorID != nil && *doctorID == uuid.Nil {
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid doctor ID provided")
@@ -234,7 +278,7 @@ func (uc *staffUseCaseImpl) GetDoctorAvailability(ctx context.Context, doctorID
 	// For now, this gets active doctors but doesn't calculate free slots.
 	doctors, err := uc.staffRepo.FindAvailableDoctors(ctx, startTime, endTime)
 	if err != nil {
-		uc.logger.Error("Failed to find active doctors", "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to find active doctors", "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to retrieve potential doctor availability")
 	}
 
@@ -254,36 +298,24 @@ func (uc *staffUseCaseImpl) GetDoctorAvailability(ctx context.Context, doctorID
 	// The actual logic should calculate free slots based on each doctor's tasks.
 	availableSlots := []*pb.GetDoctorAvailabilityResponse_TimeSlot{}
 	if len(doctors) > 0 {
-		uc.logger.Warn("GetDoctorAvailability returning placeholder availability - full range for first found doctor")
+		uc.logger.WithContext(ctx).Warn("GetDoctorAvailability returning placeholder availability - full range for first found doctor")
 		availableSlots = append(availableSlots, &pb.GetDoctorAvailabilityResponse_TimeSlot{
 			StartTime: timestamppb.New(startTime),
 			EndTime:   timestamppb.New(endTime),
 		})
 	}
 
-	uc.logger.Info("Finished getting doctor availability (placeholder)", "slotCount", len(availableSlots))
+	uc.logger.WithContext(ctx).Info("Finished getting doctor availability (placeholder)", "slotCount", len(availableSlots))
 	return availableSlots, nil
 }
 
 // AssignTask assigns a task.
 func (uc *staffUseCaseImpl) AssignTask(ctx context.Context, staffID uuid.UUID, title, description string, priority int32, startTime, endTime *time.Time, statusID string) (*entity.Task, error) {
-	uc.logger.Info("Assigning task", "staffID", staffID.String(), "title", title)
+	uc.logger.WithContext(ctx).Info("Assigning task", "staffID", staffID.String(), "title", title)
 	if staffID == uuid.Nil || title == "" || statusID == "" || startTime == nil || endTime == nil || startTime.IsZero() || endTime.IsZero() || startTime.After(*endTime) {
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid input for assigning task")
 	}
 
-	// Validate existence of staffID and statusID
-	_, err := uc.staffRepo.FindByID(ctx, staffID)
-	if err != nil {
-		uc.logger.Warn("Invalid StaffID provided for task assignment", "staffID", staffID, "error", err)
-		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, fmt.Sprintf("invalid staff ID: %s", staffID))
-	}
-	_, err = uc.taskStatusRepo.FindByName(ctx, statusID)
-	if err != nil {
-		uc.logger.Warn("Invalid TaskStatusID provided for task assignment", "statusID", statusID, "error", err)
-		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, fmt.Sprintf("invalid task status ID: %s", statusID))
-	}
-
 	task := &entity.Task{
 		Title:       title,
 		Description: description,
@@ -293,41 +325,115 @@ func (uc *staffUseCaseImpl) AssignTask(ctx context.Context, staffID uuid.UUID, t
 		StatusID:    statusID,
 	}
 
-	err = uc.staffRepo.AssignTaskToStaff(ctx, staffID, task)
+	// Validate the staff member and status and assign the task in one unit of work
+	err := uc.uow.Do(ctx, func(ctx context.Context) error {
+		if _, err := uc.staffRepo.FindByID(ctx, staffID); err != nil {
+			uc.logger.WithContext(ctx).Warn("Invalid StaffID provided for task assignment", "staffID", staffID, "error", err)
+			return coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, fmt.Sprintf("invalid staff ID: %s", staffID))
+		}
+		if _, err := uc.taskStatusRepo.FindByName(ctx, statusID); err != nil {
+			uc.logger.WithContext(ctx).Warn("Invalid TaskStatusID provided for task assignment", "statusID", statusID, "error", err)
+			return coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, fmt.Sprintf("invalid task status ID: %s", statusID))
+		}
+
+		ctx = coreEvents.Record(ctx, entity.TaskAssigned{StaffID: staffID, Task: task})
+		if err := uc.staffRepo.AssignTaskToStaff(ctx, staffID, task); err != nil {
+			uc.logger.WithContext(ctx).Error("Failed to assign task in repo", "staffID", staffID.String(), "error", err)
+			return coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to assign task")
+		}
+		return nil
+	})
 	if err != nil {
-		uc.logger.Error("Failed to assign task in repo", "staffID", staffID.String(), "error", err)
+		var ucErr *coreUseCase.UseCaseError
+		if errors.As(err, &ucErr) {
+			return nil, err
+		}
+		uc.logger.WithContext(ctx).Error("Task assignment transaction failed", "staffID", staffID.String(), "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to assign task")
 	}
 
-	uc.logger.Info("Task assigned successfully", "staffID", staffID.String(), "taskID", task.ID.String())
+	uc.logger.WithContext(ctx).Info("Task assigned successfully", "staffID", staffID.String(), "taskID", task.ID.String())
 	// Fetch status relation for the response
 	taskStatus, findErr := uc.taskStatusRepo.FindByName(ctx, task.StatusID)
 	if findErr == nil && taskStatus != nil {
 		task.Status = *taskStatus // Dereference the pointer here
 	} else if findErr != nil {
 		// Log error if finding the status failed, but don't fail the whole operation
-		uc.logger.Error("Failed to find task status after assignment", "statusID", task.StatusID, "error", findErr)
+		uc.logger.WithContext(ctx).Error("Failed to find task status after assignment", "statusID", task.StatusID, "error", findErr)
 	}
 	return task, nil
 }
 
+// appointmentTaskStatus is the (seeded) status of tasks scheduling appointments.
+const appointmentTaskStatus = "Pending"
+
+// ScheduleAppointment adds a task for an appointment to the doctor's schedule.
+func (uc *staffUseCaseImpl) ScheduleAppointment(ctx context.Context, appointmentID, doctorID uuid.UUID, startTime, endTime time.Time, place string) error {
+	if appointmentID == uuid.Nil || doctorID == uuid.Nil || startTime.IsZero() || endTime.Before(startTime) {
+		return coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid appointment to schedule")
+	}
+
+	// The task has the ID of the appointment, so a redelivered event is a no-op
+	if _, err := uc.taskRepo.FindByID(ctx, appointmentID); err == nil {
+		uc.logger.WithContext(ctx).Debug("Appointment already scheduled", "appointmentID", appointmentID.String())
+		return nil
+	} else if !errors.Is(err, coreRepository.ErrNotFound) {
+		uc.logger.WithContext(ctx).Error("Failed to look up appointment task", "appointmentID", appointmentID.String(), "error", err)
+		return coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to schedule appointment")
+	}
+
+	task := &entity.Task{
+		Title:       "Appointment",
+		Description: place,
+		StartTime:   startTime,
+		EndTime:     endTime,
+		StatusID:    appointmentTaskStatus,
+	}
+	task.ID = appointmentID
+
+	err := uc.uow.Do(ctx, func(ctx context.Context) error {
+		if _, err := uc.staffRepo.FindByID(ctx, doctorID); err != nil {
+			if errors.Is(err, coreRepository.ErrNotFound) {
+				return coreUseCase.NewUseCaseError(coreUseCase.ErrNotFound, "staff not found")
+			}
+			return err
+		}
+		ctx = coreEvents.Record(ctx, entity.TaskAssigned{StaffID: doctorID, Task: task})
+		return uc.staffRepo.AssignTaskToStaff(ctx, doctorID, task)
+	})
+	switch {
+	case err == nil:
+		uc.logger.WithContext(ctx).Info("Appointment scheduled", "appointmentID", appointmentID.String(), "doctorID", doctorID.String())
+		return nil
+	case errors.Is(err, coreRepository.ErrUniqueViolation):
+		return nil // Scheduled by a concurrent delivery of the same event
+	}
+	var ucErr *coreUseCase.UseCaseError
+	if errors.As(err, &ucErr) {
+		return err
+	}
+	uc.logger.WithContext(ctx).Error("Failed to schedule appointment", "appointmentID", appointmentID.String(), "doctorID", doctorID.String(), "error", err)
+	return coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to schedule appointment")
+}
+
 // TrackWorkload retrieves tasks for a staff member.
 func (uc *staffUseCaseImpl) TrackWorkload(ctx context.Context, staffID uuid.UUID) ([]*entity.Task, error) {
-	uc.logger.Info("Tracking workload", "staffID", staffID.String())
+	uc.logger.WithContext(ctx).Info("Tracking workload", "staffID", staffID.String())
 	if staffID == uuid.Nil {
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid staff ID")
 	}
 
 	tasks, err := uc.staffRepo.FindTasksByStaffID(ctx, staffID)
 	if err != nil {
-		uc.logger.Error("Failed to track workload in repo", "staffID", staffID.String(), "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to track workload in repo", "staffID", staffID.String(), "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to track workload")
 	}
 	return tasks, nil
 }
 
-// ListStaff retrieves a list of staff members, optionally filtered.
-func (uc *staffUseCaseImpl) ListStaff(ctx context.Context, req *pb.ListStaffRequest) ([]*entity.Staff, error) {
+// ListStaff retrieves staff members matching opts with pagination, optionally
+// filtered by the role and status of req.
+func (uc *staffUseCaseImpl) ListStaff(ctx context.Context, req *pb.ListStaffRequest, opts coreTypes.FilterOptions) (*coreTypes.PaginationResult[entity.Staff], error) {
 	logFields := []interface{}{}
 	filter := make(map[string]interface{})
 
@@ -340,30 +446,23 @@ func (uc *staffUseCaseImpl) ListStaff(ctx context.Context, req *pb.ListStaffRequ
 		logFields = append(logFields, "filter_status_id", req.StatusId)
 	}
 
-	uc.logger.Info("Listing staff", logFields...)
+	uc.logger.WithContext(ctx).Info("Listing staff", logFields...)
 
-	// Assuming coreTypes and FilterOptions are available in scope.
-	// Import "golang-microservices-boilerplate/pkg/core/types" if needed.
-	// Assuming staffRepo embeds coreRepository.BaseRepository[entity.Staff]
-	// which has FindWithFilter(ctx, filter, opts).
-	filterOpts := coreTypes.FilterOptions{} // Use default pagination/sorting for now
-
-	paginationResult, err := uc.staffRepo.FindWithFilter(ctx, filter, filterOpts)
+	// The role and status of req override the same keys of opts.Filters
+	result, err := uc.staffRepo.FindWithFilter(ctx, filter, opts)
 	if err != nil {
-		uc.logger.Error("Failed to list staff from repository", "error", err)
+		if ucErr := coreUseCase.TranslateRepositoryError(err); ucErr != nil {
+			return nil, ucErr
+		}
+		uc.logger.WithContext(ctx).Error("Failed to list staff from repository", "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to retrieve staff list")
 	}
-
-	// Assuming paginationResult has an 'Items' field containing []*entity.Staff.
-	if paginationResult == nil || paginationResult.Items == nil {
-		return []*entity.Staff{}, nil // Return empty slice, not nil
-	}
-
-	return paginationResult.Items, nil
+	return result, nil
 }
 
-// ListTasks retrieves a list of all tasks, optionally filtered, ordered by creation time.
-func (uc *staffUseCaseImpl) ListTasks(ctx context.Context, req *pb.ListTasksRequest) ([]*entity.Task, error) {
+// ListTasks retrieves tasks matching opts with pagination, optionally filtered
+// by the status of req.
+func (uc *staffUseCaseImpl) ListTasks(ctx context.Context, req *pb.ListTasksRequest, opts coreTypes.FilterOptions) (*coreTypes.PaginationResult[entity.Task], error) {
 	logFields := []interface{}{}
 	filter := make(map[string]interface{})
 
@@ -372,39 +471,142 @@ func (uc *staffUseCaseImpl) ListTasks(ctx context.Context, req *pb.ListTasksRequ
 		logFields = append(logFields, "filter_status_id", req.StatusId)
 	}
 
-	uc.logger.Info("Listing tasks", logFields...)
+	uc.logger.WithContext(ctx).Info("Listing tasks", logFields...)
+
+	// The status of req overrides the same key of opts.Filters
+	result, err := uc.taskRepo.FindWithFilter(ctx, filter, opts)
+	if err != nil {
+		if ucErr := coreUseCase.TranslateRepositoryError(err); ucErr != nil {
+			return nil, ucErr
+		}
+		uc.logger.WithContext(ctx).Error("Failed to list tasks from repository", "error", err)
+		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to retrieve task list")
+	}
+	return result, nil
+}
+
+// --- Soft-delete Lifecycle Implementations ---
+
+// DeleteStaff soft-deletes a staff member, or permanently removes it when hardDelete is set.
+func (uc *staffUseCaseImpl) DeleteStaff(ctx context.Context, staffID uuid.UUID, hardDelete bool) error {
+	uc.logger.WithContext(ctx).Info("Deleting staff", "staffID", staffID.String(), "hardDelete", hardDelete)
+	if staffID == uuid.Nil {
+		return coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid staff ID")
+	}
+
+	if err := uc.staffRepo.Delete(ctx, staffID, hardDelete); err != nil {
+		if errors.Is(err, coreRepository.ErrNotFound) {
+			return coreUseCase.NewUseCaseError(coreUseCase.ErrNotFound, "staff not found")
+		}
+		uc.logger.WithContext(ctx).Error("Failed to delete staff", "staffID", staffID.String(), "error", err)
+		return coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to delete staff")
+	}
+	return nil
+}
 
-	// Set sorting options for FindWithFilter
-	filterOpts := coreTypes.DefaultFilterOptions()
+// RestoreStaff undeletes a soft-deleted staff member and returns it with relations preloaded.
+func (uc *staffUseCaseImpl) RestoreStaff(ctx context.Context, staffID uuid.UUID) (*entity.Staff, error) {
+	uc.logger.WithContext(ctx).Info("Restoring staff", "staffID", staffID.String())
+	if staffID == uuid.Nil {
+		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "invalid staff ID")
+	}
 
-	filterOpts.SortBy = "created_at"
-	filterOpts.SortDesc = true
+	if err := uc.staffRepo.Restore(ctx, staffID); err != nil {
+		if errors.Is(err, coreRepository.ErrNotFound) {
+			return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrNotFound, "deleted staff not found")
+		}
+		uc.logger.WithContext(ctx).Error("Failed to restore staff", "staffID", staffID.String(), "error", err)
+		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to restore staff")
+	}
 
-	paginationResult, err := uc.taskRepo.FindWithFilter(ctx, filter, filterOpts)
+	staff, err := uc.staffRepo.FindByID(coreDatabase.WithPrimary(ctx), staffID) // Replicas may not have seen the restore yet
 	if err != nil {
-		uc.logger.Error("Failed to list tasks from repository", "error", err)
-		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to retrieve task list")
+		if ucErr := coreUseCase.TranslateRepositoryError(err); ucErr != nil {
+			return nil, ucErr
+		}
+		uc.logger.WithContext(ctx).Error("Failed to get restored staff", "staffID", staffID.String(), "error", err)
+		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to retrieve restored staff")
+	}
+	return staff, nil
+}
+
+// SearchStaff finds staff members by partial name, phone or address, ranked by relevance.
+func (uc *staffUseCaseImpl) SearchStaff(ctx context.Context, opts coreTypes.SearchOptions) (*coreTypes.SearchResult[entity.Staff], error) {
+	uc.logger.WithContext(ctx).Info("Searching staff", "query", opts.Query)
+
+	result, err := uc.staffRepo.Search(ctx, opts)
+	if err != nil {
+		if ucErr := coreUseCase.TranslateRepositoryError(err); ucErr != nil {
+			return nil, ucErr
+		}
+		uc.logger.WithContext(ctx).Error("Failed to search staff", "error", err)
+		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to search staff")
+	}
+	return result, nil
+}
+
+// ExportStaff streams every staff member matching opts. An error of fn ends the
+// export and is returned unchanged.
+func (uc *staffUseCaseImpl) ExportStaff(ctx context.Context, opts coreTypes.FilterOptions, fn func(*entity.Staff) error) error {
+	uc.logger.WithContext(ctx).Info("Exporting staff")
+
+	var fnErr error
+	err := coreRepository.ForEach(ctx, uc.staffRepo, opts, func(staff *entity.Staff) error {
+		fnErr = fn(staff)
+		return fnErr
+	})
+	if err == nil || fnErr != nil {
+		return err
 	}
+	if ucErr := coreUseCase.TranslateRepositoryError(err); ucErr != nil {
+		return ucErr
+	}
+	uc.logger.WithContext(ctx).Error("Failed to export staff", "error", err)
+	return coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to export staff")
+}
+
+// ListDeletedStaff retrieves soft-deleted staff members with pagination.
+func (uc *staffUseCaseImpl) ListDeletedStaff(ctx context.Context, opts coreTypes.FilterOptions) (*coreTypes.PaginationResult[entity.Staff], error) {
+	uc.logger.WithContext(ctx).Info("Listing deleted staff")
+
+	result, err := uc.staffRepo.ListDeleted(ctx, opts)
+	if err != nil {
+		if ucErr := coreUseCase.TranslateRepositoryError(err); ucErr != nil {
+			return nil, ucErr
+		}
+		uc.logger.WithContext(ctx).Error("Failed to list deleted staff", "error", err)
+		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to retrieve deleted staff list")
+	}
+	return result, nil
+}
 
-	if paginationResult == nil || paginationResult.Items == nil {
-		return []*entity.Task{}, nil
+// PurgeDeletedStaff permanently removes staff soft-deleted more than olderThanDays days ago.
+func (uc *staffUseCaseImpl) PurgeDeletedStaff(ctx context.Context, olderThanDays int) (int64, error) {
+	if olderThanDays < 0 {
+		return 0, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "older_than_days must not be negative")
 	}
 
-	return paginationResult.Items, nil
+	purged, err := uc.staffRepo.PurgeDeleted(ctx, time.Duration(olderThanDays)*24*time.Hour)
+	if err != nil {
+		uc.logger.WithContext(ctx).Error("Failed to purge deleted staff", "olderThanDays", olderThanDays, "error", err)
+		return 0, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to purge deleted staff")
+	}
+	uc.logger.WithContext(ctx).Info("Purged deleted staff", "olderThanDays", olderThanDays, "count", purged)
+	return purged, nil
 }
 
 // --- Lookup Table Management Implementations ---
 
 // Staff Roles
 func (uc *staffUseCaseImpl) AddStaffRole(ctx context.Context, name, description string) (*entity.StaffRole, error) {
-	uc.logger.Info("Adding staff role", "name", name)
+	uc.logger.WithContext(ctx).Info("Adding staff role", "name", name)
 	if name == "" {
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "role name cannot be empty")
 	}
 	role := &entity.StaffRole{Name: name, Description: description}
 	err := uc.staffRoleRepo.Create(ctx, role)
 	if err != nil {
-		uc.logger.Error("Failed to add staff role", "name", name, "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to add staff role", "name", name, "error", err)
 		// TODO: Handle unique constraint violation error specifically
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to add staff role")
 	}
@@ -412,10 +614,10 @@ func (uc *staffUseCaseImpl) AddStaffRole(ctx context.Context, name, description
 }
 
 func (uc *staffUseCaseImpl) ListStaffRoles(ctx context.Context) ([]*entity.StaffRole, error) {
-	uc.logger.Info("Listing staff roles")
+	uc.logger.WithContext(ctx).Info("Listing staff roles")
 	roles, err := uc.staffRoleRepo.ListAll(ctx)
 	if err != nil {
-		uc.logger.Error("Failed to list staff roles", "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to list staff roles", "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to list staff roles")
 	}
 	return roles, nil
@@ -423,24 +625,24 @@ func (uc *staffUseCaseImpl) ListStaffRoles(ctx context.Context) ([]*entity.Staff
 
 // Staff Statuses
 func (uc *staffUseCaseImpl) AddStaffStatus(ctx context.Context, name, description string) (*entity.StaffStatus, error) {
-	uc.logger.Info("Adding staff status", "name", name)
+	uc.logger.WithContext(ctx).Info("Adding staff status", "name", name)
 	if name == "" {
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "status name cannot be empty")
 	}
 	status := &entity.StaffStatus{Name: name, Description: description}
 	err := uc.staffStatusRepo.Create(ctx, status)
 	if err != nil {
-		uc.logger.Error("Failed to add staff status", "name", name, "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to add staff status", "name", name, "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to add staff status")
 	}
 	return status, nil
 }
 
 func (uc *staffUseCaseImpl) ListStaffStatuses(ctx context.Context) ([]*entity.StaffStatus, error) {
-	uc.logger.Info("Listing staff statuses")
+	uc.logger.WithContext(ctx).Info("Listing staff statuses")
 	statuses, err := uc.staffStatusRepo.ListAll(ctx)
 	if err != nil {
-		uc.logger.Error("Failed to list staff statuses", "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to list staff statuses", "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to list staff statuses")
 	}
 	return statuses, nil
@@ -448,24 +650,24 @@ func (uc *staffUseCaseImpl) ListStaffStatuses(ctx context.Context) ([]*entity.St
 
 // Task Statuses
 func (uc *staffUseCaseImpl) AddTaskStatus(ctx context.Context, name, description string) (*entity.TaskStatus, error) {
-	uc.logger.Info("Adding task status", "name", name)
+	uc.logger.WithContext(ctx).Info("Adding task status", "name", name)
 	if name == "" {
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInvalidInput, "task status name cannot be empty")
 	}
 	status := &entity.TaskStatus{Name: name, Description: description}
 	err := uc.taskStatusRepo.Create(ctx, status)
 	if err != nil {
-		uc.logger.Error("Failed to add task status", "name", name, "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to add task status", "name", name, "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to add task status")
 	}
 	return status, nil
 }
 
 func (uc *staffUseCaseImpl) ListTaskStatuses(ctx context.Context) ([]*entity.TaskStatus, error) {
-	uc.logger.Info("Listing task statuses")
+	uc.logger.WithContext(ctx).Info("Listing task statuses")
 	statuses, err := uc.taskStatusRepo.ListAll(ctx)
 	if err != nil {
-		uc.logger.Error("Failed to list task statuses", "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to list task statuses", "error", err)
 		return nil, coreUseCase.NewUseCaseError(coreUseCase.ErrInternal, "failed to list task statuses")
 	}
 	return statuses, nil
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "staff_schedule_entries";
+DROP TABLE IF EXISTS "staff_tasks";
+DROP TABLE IF EXISTS "staff";
+DROP TABLE IF EXISTS "task_statuses";
+DROP TABLE IF EXISTS "staff_statuses";
+DROP TABLE IF EXISTS "staff_roles";
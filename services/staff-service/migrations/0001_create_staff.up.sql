@@ -0,0 +1,72 @@
+CREATE TABLE IF NOT EXISTS "staff_roles" (
+    "name" text NOT NULL,
+    "description" text,
+    PRIMARY KEY ("name")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_staff_roles_name" ON "staff_roles" ("name");
+
+CREATE TABLE IF NOT EXISTS "staff_statuses" (
+    "name" text NOT NULL,
+    "description" text,
+    PRIMARY KEY ("name")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_staff_statuses_name" ON "staff_statuses" ("name");
+
+CREATE TABLE IF NOT EXISTS "task_statuses" (
+    "name" text NOT NULL,
+    "description" text,
+    PRIMARY KEY ("name")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_task_statuses_name" ON "task_statuses" ("name");
+
+CREATE TABLE IF NOT EXISTS "staff" (
+    "id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 1,
+    "first_name" text NOT NULL,
+    "last_name" text NOT NULL,
+    "date_of_birth" date,
+    "phone_number" text,
+    "address" text,
+    "role_id" text NOT NULL,
+    "status_id" text NOT NULL,
+    "specialization" text,
+    "nurse_type" text,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_staff_role" FOREIGN KEY ("role_id") REFERENCES "staff_roles" ("name"),
+    CONSTRAINT "fk_staff_status" FOREIGN KEY ("status_id") REFERENCES "staff_statuses" ("name")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_staff_phone_number" ON "staff" ("phone_number");
+CREATE INDEX IF NOT EXISTS "idx_staff_role_id" ON "staff" ("role_id");
+CREATE INDEX IF NOT EXISTS "idx_staff_status_id" ON "staff" ("status_id");
+CREATE INDEX IF NOT EXISTS "idx_staff_deleted_at" ON "staff" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "staff_tasks" (
+    "id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 1,
+    "title" text NOT NULL,
+    "description" text,
+    "priority" bigint,
+    "start_time" timestamptz NOT NULL,
+    "end_time" timestamptz NOT NULL,
+    "status_id" text NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_staff_tasks_status" FOREIGN KEY ("status_id") REFERENCES "task_statuses" ("name")
+);
+CREATE INDEX IF NOT EXISTS "idx_staff_tasks_status_id" ON "staff_tasks" ("status_id");
+CREATE INDEX IF NOT EXISTS "idx_staff_tasks_deleted_at" ON "staff_tasks" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "staff_schedule_entries" (
+    "staff_id" uuid NOT NULL,
+    "task_id" uuid NOT NULL,
+    PRIMARY KEY ("staff_id", "task_id"),
+    CONSTRAINT "fk_staff_schedule" FOREIGN KEY ("staff_id") REFERENCES "staff" ("id") ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT "fk_staff_schedule_entries_task" FOREIGN KEY ("task_id") REFERENCES "staff_tasks" ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_staff_schedule_entries_staff_id" ON "staff_schedule_entries" ("staff_id");
+CREATE INDEX IF NOT EXISTS "idx_staff_schedule_entries_task_id" ON "staff_schedule_entries" ("task_id");
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "idx_staff_phone_number";
+CREATE UNIQUE INDEX "idx_staff_phone_number" ON "staff" ("phone_number");
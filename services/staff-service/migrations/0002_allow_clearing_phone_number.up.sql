@@ -0,0 +1,3 @@
+-- Phone numbers can be cleared through update masks; several cleared numbers must not collide
+DROP INDEX IF EXISTS "idx_staff_phone_number";
+CREATE UNIQUE INDEX "idx_staff_phone_number" ON "staff" ("phone_number") WHERE "phone_number" <> '';
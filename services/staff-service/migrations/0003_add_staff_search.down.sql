@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "idx_staff_search_text";
+DROP INDEX IF EXISTS "idx_staff_search_vector";
+ALTER TABLE "staff" DROP COLUMN IF EXISTS "search_text", DROP COLUMN IF EXISTS "search_vector";
@@ -0,0 +1,39 @@
+// Package seeds holds the reference data of the staff-service tables.
+package seeds
+
+import (
+	"embed"
+	"fmt"
+
+	"golang-microservices-boilerplate/pkg/core/database"
+)
+
+//go:embed *.yaml
+var files embed.FS
+
+// Source is the seed source of the staff-service.
+var Source = database.SeedSource{Name: "staff-service", FS: files}
+
+// Rows decodes the seeded rows of table into models, so tests can load the
+// same reference data into in-memory repositories:
+//
+//	roles, err := seeds.Rows[entity.StaffRole]("staff_roles")
+func Rows[T any](table string) ([]T, error) {
+	seedFiles, err := Source.Files()
+	if err != nil {
+		return nil, err
+	}
+	var rows []T
+	for _, f := range seedFiles {
+		if f.Table != table {
+			continue
+		}
+		if err := f.Decode(&rows); err != nil {
+			return nil, err
+		}
+	}
+	if rows == nil {
+		return nil, fmt.Errorf("no seed data for table %s", table)
+	}
+	return rows, nil
+}
@@ -1,33 +1,74 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"golang-microservices-boilerplate/pkg/core/audit"
 	"golang-microservices-boilerplate/pkg/core/database"
+	"golang-microservices-boilerplate/pkg/core/events"
 	"golang-microservices-boilerplate/pkg/core/grpc"
 	"golang-microservices-boilerplate/pkg/core/logger"
+	coreMigrations "golang-microservices-boilerplate/pkg/core/migrations"
+	"golang-microservices-boilerplate/pkg/core/ratelimit"
+	"golang-microservices-boilerplate/pkg/core/tracing"
+	"golang-microservices-boilerplate/pkg/middleware"
 	"golang-microservices-boilerplate/pkg/utils"
 	pb "golang-microservices-boilerplate/proto/user-service" // Import generated proto package
 	controller "golang-microservices-boilerplate/services/user-service/internal/controller"
-	entity "golang-microservices-boilerplate/services/user-service/internal/model/entity"
+	"golang-microservices-boilerplate/services/user-service/internal/model/entity"
 	"golang-microservices-boilerplate/services/user-service/internal/repository"
 	"golang-microservices-boilerplate/services/user-service/internal/usecase"
+	"golang-microservices-boilerplate/services/user-service/migrations"
 )
 
-// Placeholder TokenGenerator implementation
-type simpleTokenGenerator struct{}
+// jwtTokenGenerator issues HS256 token pairs in the format the gRPC auth
+// interceptor and the gateway middleware validate.
+type jwtTokenGenerator struct {
+	accessSecret  string
+	refreshSecret string
+}
+
+func (tg *jwtTokenGenerator) GenerateTokenPair(customClaims map[string]interface{}, accessDuration, refreshDuration time.Duration) (accessToken, refreshToken string, expiresAt int64, err error) {
+	return middleware.GenerateTokenPair(customClaims, accessDuration, refreshDuration, tg.accessSecret, tg.refreshSecret)
+}
 
-func (tg *simpleTokenGenerator) GenerateTokenPair(customClaims map[string]interface{}, accessDuration, refreshDuration time.Duration) (accessToken, refreshToken string, expiresAt int64, err error) {
-	// In a real implementation, this would generate actual JWTs
-	log.Printf("Generating placeholder tokens with claims: %v, accessDur: %v, refreshDur: %v", customClaims, accessDuration, refreshDuration)
-	accessToken = utils.GetEnv("ACCESS_TOKEN_SECRET", "access_token_secret_wqim")
-	refreshToken = utils.GetEnv("REFRESH_TOKEN_SECRET", "refresh_token_secret_KMT")
-	expiresAt = time.Now().Add(accessDuration).Unix()
-	return
+// authPolicy allow-lists signing in and restricts managing users to admins;
+// any signed-in user may look users up. The first admin is created from
+// ADMIN_EMAIL and ADMIN_PASSWORD on start.
+var authPolicy = &grpc.AuthPolicy{
+	Public: []string{
+		pb.UserService_Login_FullMethodName,
+		pb.UserService_Refresh_FullMethodName,
+	},
+	Roles: map[string][]string{
+		pb.UserService_Create_FullMethodName:          {string(entity.RoleAdmin)},
+		pb.UserService_Update_FullMethodName:          {string(entity.RoleAdmin)},
+		pb.UserService_Delete_FullMethodName:          {string(entity.RoleAdmin)},
+		pb.UserService_CreateMany_FullMethodName:      {string(entity.RoleAdmin)},
+		pb.UserService_UpdateMany_FullMethodName:      {string(entity.RoleAdmin)},
+		pb.UserService_DeleteMany_FullMethodName:      {string(entity.RoleAdmin)},
+		pb.UserService_Restore_FullMethodName:         {string(entity.RoleAdmin)},
+		pb.UserService_ListDeleted_FullMethodName:     {string(entity.RoleAdmin)},
+		pb.UserService_PurgeDeleted_FullMethodName:    {string(entity.RoleAdmin)},
+		pb.UserService_ListAuditEvents_FullMethodName: {string(entity.RoleAdmin)},
+	},
+}
+
+// rateLimitRules throttle signing in per client IP and the bulk methods per
+// user, like the gateway's, also for callers reaching the service directly.
+// The client IP forwarded by the gateway is only believed from the proxies
+// of TRUSTED_PROXIES. RATE_LIMIT_STORE=postgres shares the buckets between
+// replicas.
+var rateLimitRules = []ratelimit.Rule{
+	{Pattern: pb.UserService_Login_FullMethodName, Key: ratelimit.KeyIP, Limit: ratelimit.Limit{Requests: 5, Period: time.Minute}},
+	{Pattern: pb.UserService_CreateMany_FullMethodName, Key: ratelimit.KeySubject, Limit: ratelimit.Limit{Requests: 10, Period: time.Minute}},
+	{Pattern: pb.UserService_UpdateMany_FullMethodName, Key: ratelimit.KeySubject, Limit: ratelimit.Limit{Requests: 10, Period: time.Minute}},
+	{Pattern: pb.UserService_DeleteMany_FullMethodName, Key: ratelimit.KeySubject, Limit: ratelimit.Limit{Requests: 10, Period: time.Minute}},
 }
 
 func main() {
@@ -53,16 +94,92 @@ func main() {
 	}
 	logger.Info("Connected to database")
 
-	// Auto migrate models (using entity package)
-	if err := db.MigrateModels(&entity.User{}); err != nil {
-		logger.Fatal("Failed to auto-migrate models", "error", err)
+	// Versioned migrations of the user tables and the shared core tables
+	migrator := database.NewMigrator(db.DB, migrations.Source, coreMigrations.Source)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		err := database.RunMigrateCommand(context.Background(), migrator, os.Args[2:], os.Stdout)
+		_ = db.Close()
+		if err != nil {
+			logger.Fatal("Migration command failed", "error", err)
+		}
+		return
+	}
+
+	// The user-service has no reference tables yet; give it a seeds package like
+	// the staff-service's when it gets one
+	seeder := database.NewSeeder(db.DB)
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		err := database.RunSeedCommand(context.Background(), seeder, os.Args[2:], os.Stdout)
+		_ = db.Close()
+		if err != nil {
+			logger.Fatal("Seed command failed", "error", err)
+		}
+		return
+	}
+
+	// Trace calls and queries with the exporter selected by TRACING_EXPORTER
+	tracer, err := tracing.Setup(context.Background(), tracing.ConfigFromEnv("user-service"))
+	if err != nil {
+		logger.Fatal("Failed to set up tracing", "error", err)
+	}
+	defer func() {
+		if err := tracer.Shutdown(context.Background()); err != nil {
+			logger.Error("Error flushing traces", "error", err)
+		}
+	}()
+
+	// Record an audit trail of every entity change
+	if err := audit.Register(db.DB); err != nil {
+		logger.Fatal("Failed to register audit callbacks", "error", err)
+	}
+
+	// Write recorded domain events to the outbox in the transaction of each change
+	if err := events.Register(db.DB); err != nil {
+		logger.Fatal("Failed to register outbox callbacks", "error", err)
+	}
+
+	// Apply pending migrations unless deployments run "migrate up" themselves
+	if db.Config.MigrateOnStart {
+		applied, err := migrator.Up(context.Background())
+		if err != nil {
+			logger.Fatal("Failed to migrate database schema", "error", err)
+		}
+		logger.Info("Database schema migrated", "applied", len(applied))
+	}
+
+	// Upsert reference data such as lookup tables unless deployments run "seed" themselves
+	if db.Config.SeedOnStart {
+		results, err := seeder.Seed(context.Background())
+		if err != nil {
+			logger.Fatal("Failed to seed reference data", "error", err)
+		}
+		logger.Info("Reference data seeded", "files", len(results))
+	}
+
+	// Dispatch the outbox until shutdown
+	eventsCtx, stopEvents := context.WithCancel(context.Background())
+	defer stopEvents()
+	if err := events.Start(eventsCtx, db.DB, db.Config.URI, events.NewBus(), logger, events.DefaultConfig()); err != nil {
+		logger.Fatal("Failed to start event dispatcher", "error", err)
 	}
 
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(db.DB)
 
 	// Initialize Token Generator and Durations
-	tokenGen := &simpleTokenGenerator{}
+	grpcConfig := grpc.DefaultGrpcServerConfig()
+	grpcConfig.AuthPolicy = authPolicy
+	rateLimitStore, err := ratelimit.NewStore(ratelimit.DefaultConfig(), db.DB)
+	if err != nil {
+		logger.Fatal("Failed to create rate limit store", "error", err)
+	}
+	if grpcConfig.RateLimits, err = ratelimit.NewLimiter(rateLimitStore, rateLimitRules...); err != nil {
+		logger.Fatal("Invalid rate limit rules", "error", err)
+	}
+	tokenGen := &jwtTokenGenerator{
+		accessSecret:  grpcConfig.AccessTokenSecret,
+		refreshSecret: utils.GetEnv("REFRESH_TOKEN_SECRET", ""),
+	}
 	// TODO: Load durations from config/env
 	accessTokenDuration := 7 * 24 * time.Hour   // Example: 7 days
 	refreshTokenDuration := 30 * 24 * time.Hour // Example: 30 days
@@ -70,11 +187,19 @@ func main() {
 	// Initialize use cases with all required arguments
 	userUseCase := usecase.NewUserUseCase(userRepo, logger, tokenGen, &accessTokenDuration, &refreshTokenDuration)
 
+	// Create the first admin, without whom nobody can create users
+	if email := utils.GetEnv("ADMIN_EMAIL", ""); email != "" {
+		if _, err := userUseCase.EnsureAdmin(context.Background(), email, utils.GetEnv("ADMIN_PASSWORD", "")); err != nil {
+			logger.Fatal("Failed to create the admin user", "error", err)
+		}
+	}
+
 	// Initialize gRPC server with interceptors
-	grpcServer := grpc.NewBaseGrpcServer(logger)
+	grpcServer := grpc.NewBaseGrpcServerWithConfig(logger, grpcConfig)
+	grpcServer.AddHealthCheck("database", db.Ping) // NOT_SERVING while the database is unreachable
 
 	// Initialize gRPC service implementation (the controller)
-	userServer := controller.NewUserServer(userUseCase) // Controller now acts as the server implementation
+	userServer := controller.NewUserServer(userUseCase, audit.NewStore(db.DB)) // Controller now acts as the server implementation
 
 	// Register the service implementation with the gRPC server
 	pb.RegisterUserServiceServer(grpcServer.Server(), userServer) // Use generated registration function
@@ -8,12 +8,17 @@ import (
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"golang-microservices-boilerplate/pkg/core/audit"
+	coreGrpc "golang-microservices-boilerplate/pkg/core/grpc"
 	coreTypes "golang-microservices-boilerplate/pkg/core/types"
-	"golang-microservices-boilerplate/pkg/core/usecase"
+	core_usecase "golang-microservices-boilerplate/pkg/core/usecase"
 	core_pb "golang-microservices-boilerplate/proto/core"
 	pb "golang-microservices-boilerplate/proto/user-service"
 	"golang-microservices-boilerplate/services/user-service/internal/model/entity"
@@ -30,8 +35,8 @@ func (m *UserMapper) EntityToProto(user *entity.User) (*pb.User, error) {
 		return nil, errors.New("cannot map nil entity to proto")
 	}
 	var deletedAt *timestamppb.Timestamp
-	if user.DeletedAt != nil {
-		deletedAt = timestamppb.New(*user.DeletedAt)
+	if user.DeletedAt.Valid {
+		deletedAt = timestamppb.New(user.DeletedAt.Time)
 	}
 	var lastLoginAt *timestamppb.Timestamp
 	if user.LastLoginAt != nil {
@@ -54,6 +59,7 @@ func (m *UserMapper) EntityToProto(user *entity.User) (*pb.User, error) {
 		Address:     user.Address,
 		Age:         user.Age,
 		ProfilePic:  user.ProfilePic,
+		Version:     user.Version,
 	}, nil
 }
 
@@ -87,6 +93,7 @@ func (m *UserMapper) UserDTOToProto(dto *userschema.UserResponseDTO) (*pb.User,
 		Address:     dto.Address,
 		Age:         int32(dto.Age),
 		ProfilePic:  dto.ProfilePic,
+		Version:     dto.Version,
 	}, nil
 }
 
@@ -133,11 +140,7 @@ func (m *UserMapper) ProtoUpdateToDTO(req *pb.UpdateUserRequest) (userschema.Use
 		dto.LastName = req.LastName.Value
 	}
 	if req.Role != nil {
-		role := entity.Role(req.Role.Value)
-		if !role.IsValid() {
-		} else {
-			dto.Role = role
-		}
+		dto.Role = entity.Role(req.Role.Value) // Validation rejects unknown roles
 	}
 	if req.IsActive != nil {
 		dto.IsActive = req.IsActive.Value
@@ -154,9 +157,37 @@ func (m *UserMapper) ProtoUpdateToDTO(req *pb.UpdateUserRequest) (userschema.Use
 	if req.ProfilePic != nil {
 		dto.ProfilePic = req.ProfilePic.Value
 	}
+	dto.Version = req.Version
+	mask, err := userUpdateMask(req, req.GetUpdateMask())
+	if err != nil {
+		return userschema.UserUpdateDTO{}, err
+	}
+	dto.UpdateMask = mask
 	return dto, nil
 }
 
+// userUpdateMask returns the fields an update request applies: the paths of its
+// update mask, or the fields present in the request when it has none. Absent
+// masked fields are mapped to their zero value, so they are cleared.
+func userUpdateMask(req proto.Message, mask *fieldmaskpb.FieldMask) ([]string, error) {
+	if len(mask.GetPaths()) > 0 {
+		if !mask.IsValid(req) {
+			return nil, fmt.Errorf("invalid update mask %v", mask.GetPaths())
+		}
+		return mask.GetPaths(), nil
+	}
+	paths := []string{}
+	req.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		switch fd.Name() {
+		case "id", "version", "update_mask":
+		default:
+			paths = append(paths, string(fd.Name()))
+		}
+		return true
+	})
+	return paths, nil
+}
+
 // ProtoLoginToSchema converts proto.LoginRequest to userschema.LoginCredentials.
 func (m *UserMapper) ProtoLoginToSchema(req *pb.LoginRequest) (userschema.LoginCredentials, error) {
 	if req == nil {
@@ -250,6 +281,12 @@ func (m *UserMapper) ProtoListRequestToFilterOptions(req *pb.ListUsersRequest) c
 	if req.Options.IncludeDeleted != nil {
 		opts.IncludeDeleted = *req.Options.IncludeDeleted
 	}
+	if req.Options.Cursor != nil {
+		opts.Cursor = *req.Options.Cursor
+	}
+	if req.Options.SkipCount != nil {
+		opts.SkipCount = *req.Options.SkipCount
+	}
 
 	if len(req.Options.Filters) > 0 {
 		opts.Filters = make(map[string]interface{}, len(req.Options.Filters))
@@ -282,6 +319,7 @@ func (m *UserMapper) PaginationResultToProtoList(result *coreTypes.PaginationRes
 		TotalItems: result.TotalItems,
 		Limit:      int32(result.Limit),
 		Offset:     int32(result.Offset),
+		NextCursor: result.NextCursor,
 	}
 
 	return &pb.ListUsersResponse{
@@ -311,10 +349,7 @@ func (m *UserMapper) ProtoUpdateItemToDTO(item *pb.UpdateUserItem) (userschema.U
 		dto.LastName = item.LastName.Value
 	}
 	if item.Role != nil {
-		role := entity.Role(item.Role.Value)
-		if role.IsValid() {
-			dto.Role = role
-		} // else: Ignore invalid role update
+		dto.Role = entity.Role(item.Role.Value) // Validation rejects unknown roles
 	}
 	if item.IsActive != nil {
 		dto.IsActive = item.IsActive.Value
@@ -331,6 +366,12 @@ func (m *UserMapper) ProtoUpdateItemToDTO(item *pb.UpdateUserItem) (userschema.U
 	if item.ProfilePic != nil {
 		dto.ProfilePic = item.ProfilePic.Value
 	}
+	dto.Version = item.Version
+	mask, err := userUpdateMask(item, item.GetUpdateMask())
+	if err != nil {
+		return userschema.UserUpdateDTO{}, err
+	}
+	dto.UpdateMask = mask
 	return dto, nil
 }
 
@@ -341,11 +382,12 @@ type userServer struct {
 	pb.UnimplementedUserServiceServer
 	uc     userservice_usecase.UserUsecase
 	mapper UserMapper
+	audit  *coreGrpc.AuditEventServer
 }
 
 // NewUserServer creates a new gRPC server instance.
-func NewUserServer(uc userservice_usecase.UserUsecase) pb.UserServiceServer {
-	return &userServer{uc: uc, mapper: UserMapper{}}
+func NewUserServer(uc userservice_usecase.UserUsecase, auditEvents audit.Reader) pb.UserServiceServer {
+	return &userServer{uc: uc, mapper: UserMapper{}, audit: coreGrpc.NewAuditEventServer(auditEvents)}
 }
 
 // Create implements proto.UserServiceServer.
@@ -357,7 +399,7 @@ func (s *userServer) Create(ctx context.Context, req *pb.CreateUserRequest) (*pb
 
 	createdUser, err := s.uc.Create(ctx, dto)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	userProto, err := s.mapper.EntityToProto(createdUser)
@@ -377,7 +419,7 @@ func (s *userServer) GetByID(ctx context.Context, req *pb.GetUserByIDRequest) (*
 
 	user, err := s.uc.GetByID(ctx, id)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	userProto, err := s.mapper.EntityToProto(user)
@@ -394,7 +436,7 @@ func (s *userServer) List(ctx context.Context, req *pb.ListUsersRequest) (*pb.Li
 
 	result, err := s.uc.List(ctx, opts)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	response, err := s.mapper.PaginationResultToProtoList(result)
@@ -419,7 +461,7 @@ func (s *userServer) Update(ctx context.Context, req *pb.UpdateUserRequest) (*pb
 
 	updatedUser, err := s.uc.Update(ctx, id, dto)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	userProto, err := s.mapper.EntityToProto(updatedUser)
@@ -441,12 +483,62 @@ func (s *userServer) Delete(ctx context.Context, req *pb.DeleteUserRequest) (*em
 
 	// Call the consolidated use case method
 	if err := s.uc.Delete(ctx, id, hardDelete); err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
+// Restore implements proto.UserServiceServer.
+func (s *userServer) Restore(ctx context.Context, req *pb.RestoreUserRequest) (*pb.RestoreUserResponse, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user ID format: %v", err)
+	}
+
+	restoredUser, err := s.uc.Restore(ctx, id)
+	if err != nil {
+		return nil, coreGrpc.ErrorToStatus(err)
+	}
+
+	userProto, err := s.mapper.EntityToProto(restoredUser)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to map result: %v", err)
+	}
+
+	return &pb.RestoreUserResponse{User: userProto}, nil
+}
+
+// ListDeleted implements proto.UserServiceServer.
+func (s *userServer) ListDeleted(ctx context.Context, req *pb.ListDeletedUsersRequest) (*pb.ListDeletedUsersResponse, error) {
+	opts := s.mapper.ProtoListRequestToFilterOptions(&pb.ListUsersRequest{Options: req.GetOptions()})
+
+	result, err := s.uc.ListDeleted(ctx, opts)
+	if err != nil {
+		return nil, coreGrpc.ErrorToStatus(err)
+	}
+
+	response, err := s.mapper.PaginationResultToProtoList(result)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to map result list: %v", err)
+	}
+
+	return &pb.ListDeletedUsersResponse{
+		Users:          response.Users,
+		PaginationInfo: response.PaginationInfo,
+	}, nil
+}
+
+// PurgeDeleted implements proto.UserServiceServer.
+func (s *userServer) PurgeDeleted(ctx context.Context, req *pb.PurgeDeletedUsersRequest) (*pb.PurgeDeletedUsersResponse, error) {
+	purged, err := s.uc.PurgeDeleted(ctx, int(req.GetOlderThanDays()))
+	if err != nil {
+		return nil, coreGrpc.ErrorToStatus(err)
+	}
+
+	return &pb.PurgeDeletedUsersResponse{PurgedCount: purged}, nil
+}
+
 // FindWithFilter implements proto.UserServiceServer.
 func (s *userServer) FindWithFilter(ctx context.Context, req *pb.FindUsersWithFilterRequest) (*pb.FindUsersWithFilterResponse, error) {
 	// Map the options from the request, which now contains the filters map internally
@@ -458,7 +550,7 @@ func (s *userServer) FindWithFilter(ctx context.Context, req *pb.FindUsersWithFi
 	// Pass opts.Filters directly to the use case
 	result, err := s.uc.FindWithFilter(ctx, opts.Filters, opts)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	// Need to map PaginationResult[entity.User] to FindUsersWithFilterResponse
@@ -475,6 +567,7 @@ func (s *userServer) FindWithFilter(ctx context.Context, req *pb.FindUsersWithFi
 		TotalItems: result.TotalItems,
 		Limit:      int32(result.Limit),
 		Offset:     int32(result.Offset),
+		NextCursor: result.NextCursor,
 	}
 
 	return &pb.FindUsersWithFilterResponse{
@@ -486,7 +579,10 @@ func (s *userServer) FindWithFilter(ctx context.Context, req *pb.FindUsersWithFi
 // CreateMany implements proto.UserServiceServer.
 func (s *userServer) CreateMany(ctx context.Context, req *pb.CreateUsersRequest) (*pb.CreateUsersResponse, error) {
 	if req == nil || len(req.Users) == 0 {
-		return &pb.CreateUsersResponse{Users: []*pb.User{}}, nil
+		return &pb.CreateUsersResponse{Users: []*pb.User{}, Results: []*core_pb.BulkItemResult{}}, nil
+	}
+	if req.GetPartialSuccess() {
+		return s.createManyPartial(ctx, req)
 	}
 
 	dtos := make([]userschema.UserCreateDTO, 0, len(req.Users))
@@ -500,29 +596,67 @@ func (s *userServer) CreateMany(ctx context.Context, req *pb.CreateUsersRequest)
 
 	createdUsers, err := s.uc.CreateMany(ctx, dtos)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	usersProto := make([]*pb.User, 0, len(createdUsers))
-	for _, userEntity := range createdUsers {
+	results := make([]*core_pb.BulkItemResult, 0, len(createdUsers))
+	for i, userEntity := range createdUsers {
 		userProto, mapErr := s.mapper.EntityToProto(userEntity)
 		if mapErr != nil {
 			return nil, status.Errorf(codes.Internal, "failed to map created user %s: %v", userEntity.ID, mapErr)
 		}
 		usersProto = append(usersProto, userProto)
+		results = append(results, coreGrpc.BulkItemResultToProto(i, userEntity.ID, nil))
 	}
 
-	return &pb.CreateUsersResponse{Users: usersProto}, nil
+	return &pb.CreateUsersResponse{Users: usersProto, Results: results}, nil
+}
+
+// createManyPartial creates the users of req independently and reports the
+// outcome of every item.
+func (s *userServer) createManyPartial(ctx context.Context, req *pb.CreateUsersRequest) (*pb.CreateUsersResponse, error) {
+	results := make([]*core_pb.BulkItemResult, len(req.Users))
+	dtos := make([]userschema.UserCreateDTO, 0, len(req.Users))
+	positions := make([]int, 0, len(req.Users)) // Request index of each DTO
+	for i, createReq := range req.Users {
+		dto, err := s.mapper.ProtoCreateToDTO(createReq)
+		if err != nil {
+			results[i] = coreGrpc.BulkItemResultToProto(i, uuid.Nil, status.Errorf(codes.InvalidArgument, "failed to map user: %v", err))
+			continue
+		}
+		dtos = append(dtos, dto)
+		positions = append(positions, i)
+	}
+
+	usersProto := make([]*pb.User, 0, len(dtos))
+	for _, r := range s.uc.CreateManyPartial(ctx, dtos) {
+		results[positions[r.Index]] = coreGrpc.BulkItemResultToProto(positions[r.Index], r.ID, r.Err)
+		if r.Entity == nil {
+			continue
+		}
+		userProto, mapErr := s.mapper.EntityToProto(r.Entity)
+		if mapErr != nil {
+			return nil, status.Errorf(codes.Internal, "failed to map created user %s: %v", r.ID, mapErr)
+		}
+		usersProto = append(usersProto, userProto)
+	}
+
+	return &pb.CreateUsersResponse{Users: usersProto, Results: results}, nil
 }
 
 // UpdateMany implements proto.UserServiceServer.
-func (s *userServer) UpdateMany(ctx context.Context, req *pb.UpdateUsersRequest) (*emptypb.Empty, error) {
+func (s *userServer) UpdateMany(ctx context.Context, req *pb.UpdateUsersRequest) (*pb.UpdateUsersResponse, error) {
 	if req == nil || len(req.Items) == 0 {
-		return &emptypb.Empty{}, nil // Nothing to update
+		return &pb.UpdateUsersResponse{Results: []*core_pb.BulkItemResult{}}, nil // Nothing to update
+	}
+	if req.GetPartialSuccess() {
+		return s.updateManyPartial(ctx, req)
 	}
 
 	// Map proto request items to the map expected by the use case
 	updatesMap := make(map[uuid.UUID]userschema.UserUpdateDTO)
+	ids := make([]uuid.UUID, 0, len(req.Items))
 	for i, item := range req.Items {
 		id, err := uuid.Parse(item.GetId())
 		if err != nil {
@@ -534,21 +668,55 @@ func (s *userServer) UpdateMany(ctx context.Context, req *pb.UpdateUsersRequest)
 			return nil, status.Errorf(codes.InvalidArgument, "failed to map update item %d (ID: %s): %v", i, id, err)
 		}
 		updatesMap[id] = dto
+		ids = append(ids, id)
 	}
 
 	// Call the use case
 	if err := s.uc.UpdateMany(ctx, updatesMap); err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
-	// Return empty response on success
-	return &emptypb.Empty{}, nil
+	results := make([]*core_pb.BulkItemResult, len(ids))
+	for i, id := range ids {
+		results[i] = coreGrpc.BulkItemResultToProto(i, id, nil)
+	}
+	return &pb.UpdateUsersResponse{Results: results}, nil
+}
+
+// updateManyPartial applies the updates of req independently and reports the
+// outcome of every item.
+func (s *userServer) updateManyPartial(ctx context.Context, req *pb.UpdateUsersRequest) (*pb.UpdateUsersResponse, error) {
+	results := make([]*core_pb.BulkItemResult, len(req.Items))
+	updates := make([]core_usecase.BulkUpdate[userschema.UserUpdateDTO], 0, len(req.Items))
+	positions := make([]int, 0, len(req.Items)) // Request index of each update
+	for i, item := range req.Items {
+		id, err := uuid.Parse(item.GetId())
+		if err != nil {
+			results[i] = coreGrpc.BulkItemResultToProto(i, uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid user ID format: %v", err))
+			continue
+		}
+		dto, err := s.mapper.ProtoUpdateItemToDTO(item)
+		if err != nil {
+			results[i] = coreGrpc.BulkItemResultToProto(i, id, status.Errorf(codes.InvalidArgument, "failed to map update item: %v", err))
+			continue
+		}
+		updates = append(updates, core_usecase.BulkUpdate[userschema.UserUpdateDTO]{ID: id, DTO: dto})
+		positions = append(positions, i)
+	}
+
+	for _, r := range s.uc.UpdateManyPartial(ctx, updates) {
+		results[positions[r.Index]] = coreGrpc.BulkItemResultToProto(positions[r.Index], r.ID, r.Err)
+	}
+	return &pb.UpdateUsersResponse{Results: results}, nil
 }
 
 // DeleteMany implements proto.UserServiceServer (handles soft and hard delete).
-func (s *userServer) DeleteMany(ctx context.Context, req *pb.DeleteUsersRequest) (*emptypb.Empty, error) {
+func (s *userServer) DeleteMany(ctx context.Context, req *pb.DeleteUsersRequest) (*pb.DeleteUsersResponse, error) {
 	if req == nil || len(req.Ids) == 0 {
-		return &emptypb.Empty{}, nil // Nothing to delete
+		return &pb.DeleteUsersResponse{Results: []*core_pb.BulkItemResult{}}, nil // Nothing to delete
+	}
+	if req.GetPartialSuccess() {
+		return s.deleteManyPartial(ctx, req)
 	}
 
 	hardDelete := req.GetHardDelete()
@@ -564,10 +732,36 @@ func (s *userServer) DeleteMany(ctx context.Context, req *pb.DeleteUsersRequest)
 
 	// Call the consolidated use case method
 	if err := s.uc.DeleteMany(ctx, uuidSlice, hardDelete); err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
-	return &emptypb.Empty{}, nil
+	results := make([]*core_pb.BulkItemResult, len(uuidSlice))
+	for i, id := range uuidSlice {
+		results[i] = coreGrpc.BulkItemResultToProto(i, id, nil)
+	}
+	return &pb.DeleteUsersResponse{Results: results}, nil
+}
+
+// deleteManyPartial deletes the users of req independently and reports the
+// outcome of every item.
+func (s *userServer) deleteManyPartial(ctx context.Context, req *pb.DeleteUsersRequest) (*pb.DeleteUsersResponse, error) {
+	results := make([]*core_pb.BulkItemResult, len(req.Ids))
+	ids := make([]uuid.UUID, 0, len(req.Ids))
+	positions := make([]int, 0, len(req.Ids)) // Request index of each ID
+	for i, idStr := range req.Ids {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			results[i] = coreGrpc.BulkItemResultToProto(i, uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid user ID format: %v", err))
+			continue
+		}
+		ids = append(ids, id)
+		positions = append(positions, i)
+	}
+
+	for _, r := range s.uc.DeleteManyPartial(ctx, ids, req.GetHardDelete()) {
+		results[positions[r.Index]] = coreGrpc.BulkItemResultToProto(positions[r.Index], r.ID, r.Err)
+	}
+	return &pb.DeleteUsersResponse{Results: results}, nil
 }
 
 // Login implements proto.UserServiceServer.
@@ -579,7 +773,7 @@ func (s *userServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.Login
 
 	loginResult, err := s.uc.Login(ctx, creds)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	response, err := s.mapper.SchemaLoginResultToProto(loginResult)
@@ -599,7 +793,7 @@ func (s *userServer) Refresh(ctx context.Context, req *pb.RefreshRequest) (*pb.R
 
 	refreshResult, err := s.uc.Refresh(ctx, refreshToken)
 	if err != nil {
-		return nil, mapUseCaseErrorToGrpcStatus(err)
+		return nil, coreGrpc.ErrorToStatus(err)
 	}
 
 	response, err := s.mapper.SchemaRefreshResultToProto(refreshResult)
@@ -610,29 +804,7 @@ func (s *userServer) Refresh(ctx context.Context, req *pb.RefreshRequest) (*pb.R
 	return response, nil
 }
 
-// mapUseCaseErrorToGrpcStatus converts use case errors to gRPC status errors.
-func mapUseCaseErrorToGrpcStatus(err error) error {
-	var ucErr *usecase.UseCaseError
-	if errors.As(err, &ucErr) {
-		switch ucErr.Type {
-		case usecase.ErrNotFound:
-			return status.Error(codes.NotFound, ucErr.Message)
-		case usecase.ErrInvalidInput:
-			return status.Error(codes.InvalidArgument, ucErr.Message)
-		case usecase.ErrUnauthorized:
-			return status.Error(codes.Unauthenticated, ucErr.Message)
-		case usecase.ErrForbidden:
-			return status.Error(codes.PermissionDenied, ucErr.Message)
-		case usecase.ErrConflict:
-			return status.Error(codes.AlreadyExists, ucErr.Message)
-		case usecase.ErrInternal:
-			return status.Error(codes.Internal, ucErr.Message)
-		default:
-			return status.Error(codes.Unknown, fmt.Sprintf("unknown use case error: %v", err))
-		}
-	}
-	if errors.Is(err, context.DeadlineExceeded) {
-		return status.Error(codes.DeadlineExceeded, err.Error())
-	}
-	return status.Error(codes.Internal, fmt.Sprintf("an unexpected internal error occurred: %v", err))
+// ListAuditEvents implements proto.UserServiceServer.
+func (s *userServer) ListAuditEvents(ctx context.Context, req *core_pb.ListAuditEventsRequest) (*core_pb.ListAuditEventsResponse, error) {
+	return s.audit.ListAuditEvents(ctx, req)
 }
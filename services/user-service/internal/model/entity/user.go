@@ -37,7 +37,7 @@ type User struct {
 	entity.BaseEntity        // Embed core base entity
 	Username          string `json:"username" gorm:"uniqueIndex;not null"`
 	Email             string `json:"email" gorm:"uniqueIndex;not null"`
-	Password          string `json:"-" gorm:"not null"` // Password is never exposed
+	Password          string `json:"-" gorm:"not null" audit:"redact"` // Password is never exposed, not even in the audit trail
 	FirstName         string `json:"first_name" gorm:"size:50;not null"`
 	LastName          string `json:"last_name" gorm:"size:50;not null"`
 	// Use string for Role, restricted to known values.
@@ -58,6 +58,18 @@ func (User) TableName() string {
 	return "users"
 }
 
+// FilterableColumns lists the columns clients may filter users by (never the password hash)
+func (u User) FilterableColumns() []string {
+	return append(u.BaseEntity.FilterableColumns(),
+		"username", "email", "first_name", "last_name", "role", "is_active", "last_login_at", "phone", "age")
+}
+
+// SortableColumns lists the columns clients may sort users by
+func (u User) SortableColumns() []string {
+	return append(u.BaseEntity.SortableColumns(),
+		"username", "email", "first_name", "last_name", "role", "last_login_at", "age")
+}
+
 // Add required methods for core.Entity interface with value receivers
 func (u User) GetID() uuid.UUID {
 	return u.ID
@@ -83,7 +95,7 @@ func (u User) GetUpdatedAt() time.Time {
 
 // GetDeletedAt returns the deletion timestamp (value receiver for Entity interface)
 func (u User) GetDeletedAt() *time.Time {
-	return u.DeletedAt // Access embedded field
+	return u.BaseEntity.GetDeletedAt() // Delegate to embedded BaseEntity
 }
 
 // BeforeCreate hook to validate and prepare data before saving to database
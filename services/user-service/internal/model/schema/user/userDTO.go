@@ -39,6 +39,17 @@ type UserUpdateDTO struct {
 	Address    string `json:"address,omitempty" validate:"omitempty,max=255"`
 	Age        int    `json:"age,omitempty" validate:"omitempty,min=10,max=100"`
 	ProfilePic string `json:"profile_pic,omitempty" validate:"omitempty,url"`
+
+	// Version is the expected current version for optimistic locking (nil skips the check)
+	Version *int64 `json:"version,omitempty"`
+
+	// UpdateMask lists the fields to apply by JSON name, including empty ones (nil applies every field)
+	UpdateMask []string `json:"-"`
+}
+
+// FieldMask implements dto.Masker.
+func (d UserUpdateDTO) FieldMask() []string {
+	return d.UpdateMask
 }
 
 type UserResponseDTO struct {
@@ -2,10 +2,12 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	core_entity "golang-microservices-boilerplate/pkg/core/entity"
 	core_logger "golang-microservices-boilerplate/pkg/core/logger"
+	core_repository "golang-microservices-boilerplate/pkg/core/repository"
 	core_usecase "golang-microservices-boilerplate/pkg/core/usecase"
 	"golang-microservices-boilerplate/services/user-service/internal/model/entity"
 	schema "golang-microservices-boilerplate/services/user-service/internal/model/schema/user"
@@ -14,9 +16,6 @@ import (
 	"github.com/google/uuid"
 )
 
-// Specific error messages to check against repository errors
-const errUserNotFoundMsg = "entity not found"
-
 // Define JWT expiration durations (can be configured externally)
 const (
 	defaultAccessTokenDuration  = 7 * 24 * time.Hour  // 7 days
@@ -40,6 +39,11 @@ type UserUsecase interface {
 	core_usecase.BaseUseCase[entity.User, schema.UserCreateDTO, schema.UserUpdateDTO]      // Use schema DTOs
 	Login(ctx context.Context, creds schema.LoginCredentials) (*schema.LoginResult, error) // Use schema types
 	Refresh(ctx context.Context, refreshToken string) (*schema.RefreshResult, error)       // Use schema type
+
+	// EnsureAdmin creates an active admin with email and password unless a user
+	// with that email exists, and reports whether it did. Only admins can create
+	// users, so this is how a fresh deployment gets its first one.
+	EnsureAdmin(ctx context.Context, email, password string) (bool, error)
 }
 
 // userUseCaseImpl implements the UserUsecase interface.
@@ -85,24 +89,24 @@ func NewUserUseCase(
 // Login implements UserUsecase.
 // It now accepts and returns types from the schema package.
 func (uc *userUseCaseImpl) Login(ctx context.Context, creds schema.LoginCredentials) (*schema.LoginResult, error) {
-	uc.logger.Info("Attempting login", "email", creds.Email)
+	uc.logger.WithContext(ctx).Info("Attempting login", "email", creds.Email)
 
 	// 1. Find user by email, check active, check password
 	user, err := uc.userRepo.FindByEmail(ctx, creds.Email)
 	if err != nil {
-		if err.Error() == errUserNotFoundMsg {
-			uc.logger.Warn("Login failed: user not found", "email", creds.Email)
+		if errors.Is(err, core_repository.ErrNotFound) {
+			uc.logger.WithContext(ctx).Warn("Login failed: user not found", "email", creds.Email)
 			return nil, core_usecase.NewUseCaseError(core_usecase.ErrNotFound, "user not found")
 		}
-		uc.logger.Error("Failed to find user by email during login", "email", creds.Email, "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to find user by email during login", "email", creds.Email, "error", err)
 		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to retrieve user data")
 	}
 	if !user.IsActive {
-		uc.logger.Warn("Login failed: user is inactive", "email", creds.Email, "user_id", user.ID)
+		uc.logger.WithContext(ctx).Warn("Login failed: user is inactive", "email", creds.Email, "user_id", user.ID)
 		return nil, core_usecase.NewUseCaseError(core_usecase.ErrUnauthorized, "user account is inactive")
 	}
 	if !user.CheckPassword(creds.Password) {
-		uc.logger.Warn("Login failed: invalid password", "email", creds.Email, "user_id", user.ID)
+		uc.logger.WithContext(ctx).Warn("Login failed: invalid password", "email", creds.Email, "user_id", user.ID)
 		return nil, core_usecase.NewUseCaseError(core_usecase.ErrUnauthorized, "invalid credentials")
 	}
 
@@ -120,18 +124,19 @@ func (uc *userUseCaseImpl) Login(ctx context.Context, creds schema.LoginCredenti
 		uc.refreshTokenDuration,
 	)
 	if err != nil {
-		uc.logger.Error("Failed to generate token pair", "user_id", user.ID, "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to generate token pair", "user_id", user.ID, "error", err)
 		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to generate authentication tokens")
 	}
 
-	uc.logger.Info("Login successful", "email", creds.Email, "user_id", user.ID)
+	uc.logger.WithContext(ctx).Info("Login successful", "email", creds.Email, "user_id", user.ID)
 
 	userDTO := schema.UserResponseDTO{
 		BaseEntityDTO: core_entity.BaseEntityDTO{
 			ID:        user.ID,
 			CreatedAt: user.CreatedAt,
 			UpdatedAt: user.UpdatedAt,
-			DeletedAt: user.DeletedAt,
+			DeletedAt: user.GetDeletedAt(),
+			Version:   user.Version,
 		},
 		Username:    user.Username,
 		Email:       user.Email,
@@ -158,7 +163,7 @@ func (uc *userUseCaseImpl) Login(ctx context.Context, creds schema.LoginCredenti
 // Refresh implements UserUsecase.
 // It now returns the result type from the schema package.
 func (uc *userUseCaseImpl) Refresh(ctx context.Context, refreshToken string) (*schema.RefreshResult, error) {
-	uc.logger.Info("Attempting token refresh")
+	uc.logger.WithContext(ctx).Info("Attempting token refresh")
 
 	// 1. Validate refresh token & extract claims
 	// TODO: Implement proper refresh token validation
@@ -178,7 +183,7 @@ func (uc *userUseCaseImpl) Refresh(ctx context.Context, refreshToken string) (*s
 	// 2. Load user from DB
 	user, err := uc.BaseUseCaseImpl.GetByID(ctx, userID)
 	if err != nil || !user.IsActive {
-		uc.logger.Warn("User for refresh token not found or inactive", "user_id", userID)
+		uc.logger.WithContext(ctx).Warn("User for refresh token not found or inactive", "user_id", userID)
 		if err != nil {
 			return nil, err
 		}
@@ -199,11 +204,11 @@ func (uc *userUseCaseImpl) Refresh(ctx context.Context, refreshToken string) (*s
 		0, // No new refresh token needed
 	)
 	if err != nil {
-		uc.logger.Error("Failed to generate new access token during refresh", "user_id", user.ID, "error", err)
+		uc.logger.WithContext(ctx).Error("Failed to generate new access token during refresh", "user_id", user.ID, "error", err)
 		return nil, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to refresh access token")
 	}
 
-	uc.logger.Info("Token refresh successful", "user_id", user.ID)
+	uc.logger.WithContext(ctx).Info("Token refresh successful", "user_id", user.ID)
 
 	// 5. Return RefreshResult (using schema type)
 	return &schema.RefreshResult{
@@ -213,10 +218,44 @@ func (uc *userUseCaseImpl) Refresh(ctx context.Context, refreshToken string) (*s
 	}, nil
 }
 
+// EnsureAdmin implements UserUsecase.
+func (uc *userUseCaseImpl) EnsureAdmin(ctx context.Context, email, password string) (bool, error) {
+	existing, err := uc.userRepo.FindByEmail(ctx, email)
+	if err == nil {
+		if existing.Role != entity.RoleAdmin || !existing.IsActive {
+			uc.logger.WithContext(ctx).Warn("Bootstrap admin email belongs to a user that is not an active admin", "email", email, "user_id", existing.ID)
+		}
+		return false, nil
+	}
+	if !errors.Is(err, core_repository.ErrNotFound) {
+		uc.logger.WithContext(ctx).Error("Failed to look up bootstrap admin", "email", email, "error", err)
+		return false, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to retrieve user data")
+	}
+
+	admin := &entity.User{
+		Email:    email,
+		Password: password,
+		Role:     entity.RoleAdmin,
+		IsActive: true,
+	}
+	if err := admin.SetPassword(password); err != nil {
+		return false, core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "admin "+err.Error())
+	}
+	if err := uc.userRepo.Create(ctx, admin); err != nil {
+		if ucErr := core_usecase.TranslateRepositoryError(err); ucErr != nil {
+			return false, ucErr
+		}
+		uc.logger.WithContext(ctx).Error("Failed to create bootstrap admin", "email", email, "error", err)
+		return false, core_usecase.NewUseCaseError(core_usecase.ErrInternal, "failed to create admin")
+	}
+	uc.logger.WithContext(ctx).Info("Bootstrap admin created", "email", email, "user_id", admin.ID)
+	return true, nil
+}
+
 /*
 // Example implementation for a custom method PromoteUser
 func (uc *userUseCaseImpl) PromoteUser(ctx context.Context, userID uuid.UUID, newRole entity.Role) error {
-	uc.logger.Info("Promoting user", "user_id", userID, "new_role", newRole)
+	uc.logger.WithContext(ctx).Info("Promoting user", "user_id", userID, "new_role", newRole)
 	if !newRole.IsValid() {
 		return core_usecase.NewUseCaseError(core_usecase.ErrInvalidInput, "invalid role specified")
 	}
@@ -237,7 +276,7 @@ func (uc *userUseCaseImpl) PromoteUser(ctx context.Context, userID uuid.UUID, ne
 		return err
 	}
 
-	uc.logger.Info("User promotion successful", "user_id", userID, "new_role", newRole)
+	uc.logger.WithContext(ctx).Info("User promotion successful", "user_id", userID, "new_role", newRole)
 	return nil
 }
 */
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" uuid,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 1,
+    "username" text NOT NULL,
+    "email" text NOT NULL,
+    "password" text NOT NULL,
+    "first_name" varchar(50) NOT NULL,
+    "last_name" varchar(50) NOT NULL,
+    "role" varchar(10) NOT NULL,
+    "is_active" boolean DEFAULT false,
+    "last_login_at" timestamptz DEFAULT null,
+    "phone" varchar(20),
+    "address" text,
+    "age" integer,
+    "profile_pic" varchar(255),
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_username" ON "users" ("username");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_email" ON "users" ("email");
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");